@@ -1,19 +1,41 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/TemirB/rest-api-marketplace/internal/analytics"
+	"github.com/TemirB/rest-api-marketplace/internal/auction"
 	auth "github.com/TemirB/rest-api-marketplace/internal/auth"
+	"github.com/TemirB/rest-api-marketplace/internal/block"
+	"github.com/TemirB/rest-api-marketplace/internal/cart"
+	"github.com/TemirB/rest-api-marketplace/internal/chat"
 	"github.com/TemirB/rest-api-marketplace/internal/config"
+	"github.com/TemirB/rest-api-marketplace/internal/content"
 	"github.com/TemirB/rest-api-marketplace/internal/database"
+	"github.com/TemirB/rest-api-marketplace/internal/exchange"
+	"github.com/TemirB/rest-api-marketplace/internal/favorite"
+	"github.com/TemirB/rest-api-marketplace/internal/follow"
 	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+	"github.com/TemirB/rest-api-marketplace/internal/moderation"
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/internal/offer"
+	"github.com/TemirB/rest-api-marketplace/internal/order"
 	post "github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/internal/promotion"
+	"github.com/TemirB/rest-api-marketplace/internal/quota"
+	"github.com/TemirB/rest-api-marketplace/internal/ratelimit"
+	"github.com/TemirB/rest-api-marketplace/internal/review"
+	"github.com/TemirB/rest-api-marketplace/internal/search"
+	"github.com/TemirB/rest-api-marketplace/internal/stream"
 	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
 )
 
 func main() {
@@ -45,35 +67,352 @@ func main() {
 	}
 	defer dbRepo.Close()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	// Initialize storages
 	userDB := auth.NewStorage(dbRepo, logger)
-	postDB := post.NewStorage(dbRepo, logger)
+	// В конфиге позиции считаются с единицы, в ленте — с нуля
+	feedSlots := make([]int, 0, len(cfg.Promotion.FeedSlots))
+	for _, slot := range cfg.Promotion.FeedSlots {
+		if slot > 0 {
+			feedSlots = append(feedSlots, slot-1)
+		}
+	}
+	postDB := post.NewStorage(dbRepo, logger, post.WithFeedSlots(feedSlots))
+	favoriteDB := favorite.NewStorage(dbRepo, logger)
+	notificationDB := notify.NewStorage(dbRepo, logger)
+	searchDB := search.NewStorage(dbRepo, logger)
+	chatDB := chat.NewStorage(dbRepo, logger)
+	offerDB := offer.NewStorage(dbRepo, logger)
+	auctionDB := auction.NewStorage(dbRepo, logger)
+	orderDB := order.NewStorage(dbRepo, logger)
+	cartDB := cart.NewStorage(dbRepo, logger)
+	reviewDB := review.NewStorage(dbRepo, logger)
+	followDB := follow.NewStorage(dbRepo, logger)
+	blockDB := block.NewStorage(dbRepo, logger)
+	moderationDB := moderation.NewStorage(dbRepo, logger)
+	contentDB := content.NewStorage(dbRepo, logger)
+	promotionDB := promotion.NewStorage(dbRepo, logger)
+	quotaDB := quota.NewStorage(dbRepo, logger)
+	analyticsDB := analytics.NewStorage(dbRepo, logger)
+
+	// Exchange rates
+	baseCurrency, err := money.ParseCurrency(cfg.Exchange.BaseCurrency)
+	if err != nil {
+		logger.Fatal(
+			"Invalid base currency",
+			zap.Error(err),
+		)
+	}
+	var ratesSource exchange.Source = exchange.NewStorage(dbRepo, baseCurrency, logger)
+	if cfg.Exchange.RatesFile != "" {
+		ratesSource = exchange.NewFileSource(cfg.Exchange.RatesFile)
+	}
+	rates := exchange.NewCache(ratesSource, logger)
+	go rates.Run(ctx, time.Duration(cfg.Exchange.Refresh)*time.Minute)
+
+	// Content filter
+	dictionaries, err := content.LoadDictionaries(cfg.Content.DictionariesDir)
+	if err != nil {
+		logger.Fatal(
+			"Failed to load content dictionaries",
+			zap.Error(err),
+		)
+	}
+	contentFilter := content.NewPipeline(logger)
+	for _, check := range []struct {
+		checker content.Checker
+		action  string
+	}{
+		{content.NewBannedWords(dictionaries), cfg.Content.BannedWords},
+		{content.NewContacts(), cfg.Content.Contacts},
+		{content.NewSpam(), cfg.Content.Spam},
+		{content.NewDuplicateText(contentDB, time.Duration(cfg.Content.DuplicateWindow)*24*time.Hour), cfg.Content.DuplicateText},
+	} {
+		action, err := content.ParseAction(check.action)
+		if err != nil {
+			logger.Fatal(
+				"Invalid content filter action",
+				zap.String("check", check.checker.Name()),
+				zap.Error(err),
+			)
+		}
+		contentFilter.Add(check.checker, action)
+	}
+
+	switch cfg.Duplicates.Mode {
+	case post.DuplicatesOff, post.DuplicatesMark, post.DuplicatesReject:
+	default:
+		logger.Fatal(
+			"Invalid duplicate check mode",
+			zap.String("mode", cfg.Duplicates.Mode),
+		)
+	}
+
+	// Real-time events
+	hub := stream.NewHub(stream.Limits{
+		MaxPerUser: cfg.Stream.MaxPerUser,
+		Buffer:     cfg.Stream.Buffer,
+	}, logger)
+	defer hub.Close()
+	var events stream.Publisher = hub
+	if cfg.Stream.Backend == "postgres" {
+		bridge := stream.NewPGBridge(dbRepo, database.DSN(cfg), "marketplace_events", hub, logger)
+		go func() {
+			if err := bridge.Run(ctx); err != nil {
+				logger.Error("Stream listener stopped", zap.Error(err))
+			}
+		}()
+		events = bridge
+	}
+	publish := func(typ string, data any, recipients ...string) {
+		e, err := stream.NewEvent(typ, data, recipients...)
+		if err == nil {
+			err = events.Publish(e)
+		}
+		if err != nil {
+			logger.Warn("Failed to publish stream event", zap.String("type", typ), zap.Error(err))
+		}
+	}
+
+	// Notifications
+	dispatcher := notify.NewDispatcher(logger)
+	dispatcher.Register(notify.ChannelInbox, notify.NewInboxChannel(notificationDB,
+		notify.WithOnInsert(func(n *notify.Notification) { publish(stream.TypeNotification, n, n.Recipient) }),
+	))
+	dispatcher.Register(notify.ChannelWebhook, notify.NewWebhookChannel(
+		cfg.Notify.WebhookSecret,
+		time.Duration(cfg.Notify.WebhookTimeout)*time.Second,
+	))
+	go dispatcher.Run(ctx, time.Duration(cfg.Notify.DigestInterval)*time.Minute)
 
 	// Initialize services
-	expiration := time.Now().Add(time.Duration(cfg.JWT.Expiration) * time.Minute)
+	expiration := time.Duration(cfg.JWT.Expiration) * time.Minute
 	tokemManager := jwt.New(cfg.JWT.Secret, expiration)
 	authService := auth.NewService(userDB, tokemManager, logger)
-	postService := post.NewService(postDB, logger)
+	quotaLimits := func(l config.QuotaLimits) quota.Limits {
+		return quota.Limits{Active: l.Active, Hourly: l.Hourly, Daily: l.Daily}
+	}
+	quotaService := quota.NewService(quotaDB, quota.Tiers{
+		NewAccountAge: time.Duration(cfg.Quota.NewAccountDays) * 24 * time.Hour,
+		New:           quotaLimits(cfg.Quota.New),
+		Regular:       quotaLimits(cfg.Quota.Regular),
+	}, logger)
+	go quotaService.Run(ctx, time.Hour)
+	blockService := block.NewService(blockDB, logger)
+	var (
+		searchService     *search.Service
+		favoriteService   *favorite.Service
+		moderationService *moderation.Service
+	)
+	collector := analytics.NewCollector(analyticsDB, time.Duration(cfg.Analytics.ViewWindow)*time.Minute,
+		cfg.Analytics.Buffer, logger,
+	)
+	go collector.Run(ctx, time.Duration(cfg.Analytics.FlushInterval)*time.Second)
+	promotionService := promotion.NewService(promotionDB, postDB, logger, promotion.WithNotifier(dispatcher))
+	go promotionService.Run(ctx, time.Minute)
+	postService := post.NewService(postDB, logger,
+		post.WithRates(rates),
+		post.WithContentFilter(contentFilter),
+		post.WithDuplicateCheck(post.DuplicateCheck{
+			Mode:      cfg.Duplicates.Mode,
+			Threshold: float64(cfg.Duplicates.Similarity) / 100,
+			Window:    time.Duration(cfg.Duplicates.Window) * 24 * time.Hour,
+		}),
+		post.WithQuotas(quotaService),
+		post.WithBlocks(blockService),
+		post.WithSimilar(post.SimilarParams{
+			Limit:     cfg.Similar.Limit,
+			PriceBand: float64(cfg.Similar.PriceBand) / 100,
+			CacheTTL:  time.Duration(cfg.Similar.CacheTTL) * time.Minute,
+		}),
+		post.WithOnFlag(func(p *post.Post) { moderationService.OnPostFlagged(p) }),
+		post.WithOnCreate(func(p *post.Post) { searchService.OnPostCreated(p) }),
+		post.WithOnCreate(func(p *post.Post) {
+			// Замечания фильтра контента видит только владелец
+			event := *p
+			event.ContentFlags = nil
+			publish(stream.TypePostCreated, &event)
+		}),
+		post.WithOnPriceChange(func(p *post.Post, c *post.PriceChange) { favoriteService.OnPriceChange(p, c) }),
+		post.WithOnFeed(promotionService.OnFeed),
+		post.WithOnView(promotionService.OnView),
+		post.WithOnFeed(collector.OnFeed),
+	)
+	searchService = search.NewService(searchDB, postService, dispatcher, logger, search.WithRates(rates))
+	favoriteService = favorite.NewService(favoriteDB, postService, logger,
+		favorite.WithPriceDropAlerts(dispatcher, float64(cfg.Notify.PriceDropThreshold)),
+		favorite.WithOnAdd(func(_ string, postID uint) { collector.RecordFavorite(postID) }),
+	)
+	notificationService := notify.NewService(notificationDB, logger)
+	chatService := chat.NewService(chatDB, postService, logger,
+		chat.WithNotifier(dispatcher),
+		chat.WithBlocks(blockService),
+		chat.WithOnMessage(func(c *chat.Conversation, m *chat.Message) {
+			publish(stream.TypeMessageCreated, m, c.Buyer, c.Seller)
+		}),
+		chat.WithOnOpen(func(c *chat.Conversation) { collector.RecordMessage(c.PostID) }),
+	)
+	offerService := offer.NewService(offerDB, postService, time.Duration(cfg.Offers.TTL)*time.Hour, logger,
+		offer.WithNotifier(dispatcher),
+		offer.WithBlocks(blockService),
+	)
+	go offerService.Run(ctx, time.Minute)
+	auctionService := auction.NewService(auctionDB, postService, auction.AntiSniping{
+		Window:    time.Duration(cfg.Auction.SnipeWindow) * time.Second,
+		Extension: time.Duration(cfg.Auction.Extension) * time.Second,
+	}, logger, auction.WithNotifier(dispatcher), auction.WithBlocks(blockService))
+	go auctionService.Run(ctx, 10*time.Second)
+	payments := order.NewFakeProvider(cfg.Payments.WebhookSecret, cfg.Payments.CallbackURL, logger)
+	orderService := order.NewService(orderDB, payments, logger,
+		order.WithNotifier(dispatcher),
+		order.WithBlocks(blockService),
+		order.WithPaymentTTL(time.Duration(cfg.Payments.TTL)*time.Minute),
+	)
+	go orderService.Run(ctx, time.Minute)
+	cartService := cart.NewService(cartDB, postDB, orderService, logger)
+	reviewService := review.NewService(reviewDB, userDB, logger,
+		review.WithNotifier(dispatcher),
+		review.WithBlocks(blockService),
+	)
+	followService := follow.NewService(followDB, logger)
+	moderationService = moderation.NewService(moderationDB, postDB, userDB, cfg.Moderation.AutoHideReports, logger,
+		moderation.WithNotifier(dispatcher),
+	)
+
+	analyticsService := analytics.NewService(analyticsDB, logger)
 
 	// Initialize handlers
+	clientIP := middleware.KeyByIP(cfg.RateLimit.TrustedProxies)
+	apiKeys := middleware.NewAPIKeys(cfg.RateLimit.APIKeys)
+	// Зритель — логин, а для анонимов IP: повторные просмотры одного зрителя не считаются
+	viewer := middleware.KeyByLogin(clientIP)
 	authHandler := auth.NewHandler(authService, logger)
-	postHandler := post.NewHandler(postService, logger)
+	postHandler := post.NewHandler(postService, logger,
+		post.WithOnDetailView(func(p *post.Post, r *http.Request) { collector.RecordView(p.ID, viewer(r)) }),
+	)
+	favoriteHandler := favorite.NewHandler(favoriteService, logger)
+	searchHandler := search.NewHandler(searchService, logger)
+	notificationHandler := notify.NewHandler(notificationService, logger)
+	chatHandler := chat.NewHandler(chatService, logger)
+	offerHandler := offer.NewHandler(offerService, logger)
+	auctionHandler := auction.NewHandler(auctionService, logger)
+	orderHandler := order.NewHandler(orderService, logger)
+	cartHandler := cart.NewHandler(cartService, logger)
+	reviewHandler := review.NewHandler(reviewService, logger)
+	followHandler := follow.NewHandler(followService, logger)
+	blockHandler := block.NewHandler(blockService, logger)
+	moderationHandler := moderation.NewHandler(moderationService, logger)
+	quotaHandler := quota.NewHandler(quotaService, logger)
+	promotionHandler := promotion.NewHandler(promotionService, logger)
+	analyticsHandler := analytics.NewHandler(analyticsService, logger)
+	streamHandler := stream.NewHandler(hub, time.Duration(cfg.Stream.Heartbeat)*time.Second, logger,
+		stream.WithRates(rates),
+		stream.WithBlocks(blockService),
+	)
+
+	// Rate limiting
+	var limits ratelimit.Store
+	switch cfg.RateLimit.Backend {
+	case "memory":
+		store := ratelimit.NewMemory()
+		go store.Run(ctx, time.Minute)
+		limits = store
+	case "postgres":
+		store := ratelimit.NewPGStore(dbRepo, logger)
+		go store.Run(ctx, time.Minute)
+		limits = store
+	default:
+		logger.Fatal(
+			"Invalid rate limit backend",
+			zap.String("backend", cfg.RateLimit.Backend),
+		)
+	}
+	rateLimit := func(group string, g config.RateLimitGroup) func(http.Handler) http.Handler {
+		if g.Rate <= 0 {
+			return func(next http.Handler) http.Handler { return next }
+		}
+		var key middleware.KeyFunc
+		switch g.Key {
+		case "ip":
+			key = clientIP
+		case "login":
+			key = middleware.KeyByLogin(clientIP)
+		case "api_key":
+			key = middleware.KeyByAPIKey(apiKeys, clientIP)
+		default:
+			logger.Fatal(
+				"Invalid rate limit key",
+				zap.String("group", group),
+				zap.String("key", g.Key),
+			)
+		}
+		return middleware.RateLimit(limits, group, ratelimit.Limit{Rate: g.Rate, Period: time.Minute, Burst: g.Burst}, key)
+	}
+	authLimit := rateLimit("auth", cfg.RateLimit.Auth)
+	userLimit := rateLimit("user", cfg.RateLimit.User)
+	// Лимит ставится после авторизации, чтобы различать клиентов по логину
+	requireAuth := middleware.Chain(middleware.JWTAuthMiddleware(authService), userLimit)
+	optionalAuth := middleware.Chain(middleware.OptionalAuthMiddleware(authService), rateLimit("public", cfg.RateLimit.Public))
+	queryAuth := middleware.Chain(middleware.JWTQueryAuthMiddleware(authService), userLimit)
 
 	// Set up HTTP server and routes
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("/register", authHandler.Register)
-	mux.HandleFunc("/login", authHandler.Login)
+	mux.Handle("/register", authLimit(http.HandlerFunc(authHandler.Register)))
+	mux.Handle("/login", authLimit(http.HandlerFunc(authHandler.Login)))
 
-	mux.Handle("/posts", middleware.JWTAuthMiddleware(authService)(
+	mux.Handle("/posts", requireAuth(
 		http.HandlerFunc(postHandler.CreatePost),
 	))
-	mux.Handle("/posts/feed", middleware.OptionalAuthMiddleware(authService)(
+	mux.Handle("/posts/feed", optionalAuth(
 		http.HandlerFunc(postHandler.GetPosts),
 	))
 
-	mux.Handle("/posts/", middleware.OptionalAuthMiddleware(authService)(
+	mux.Handle("/posts/", optionalAuth(
 		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/favorite") {
+				if r.Context().Value(middleware.CtxUser) == nil {
+					http.Error(w, "Unauthorized", http.StatusUnauthorized)
+					return
+				}
+				favoriteHandler.Favorite(w, r)
+				return
+			}
+			if strings.HasSuffix(r.URL.Path, "/offers") {
+				if r.Context().Value(middleware.CtxUser) == nil {
+					http.Error(w, "Unauthorized", http.StatusUnauthorized)
+					return
+				}
+				offerHandler.PostOffers(w, r)
+				return
+			}
+			if strings.HasSuffix(r.URL.Path, "/auction") {
+				auctionHandler.Auction(w, r)
+				return
+			}
+			if strings.HasSuffix(r.URL.Path, "/bids") {
+				auctionHandler.Bids(w, r)
+				return
+			}
+			if strings.HasSuffix(r.URL.Path, "/report") {
+				moderationHandler.Report(w, r)
+				return
+			}
+			if strings.HasSuffix(r.URL.Path, "/promote") {
+				promotionHandler.Promote(w, r)
+				return
+			}
+			if strings.HasSuffix(r.URL.Path, "/price-history") {
+				postHandler.GetPriceHistory(w, r)
+				return
+			}
+			if strings.HasSuffix(r.URL.Path, "/similar") {
+				postHandler.GetSimilar(w, r)
+				return
+			}
+
 			switch r.Method {
 			case http.MethodGet:
 				postHandler.GetPostByID(w, r)
@@ -86,6 +425,13 @@ func main() {
 				}
 				postHandler.UpdatePost(w, r)
 
+			case http.MethodPatch:
+				if r.Context().Value(middleware.CtxUser) == nil {
+					http.Error(w, "Unauthorized", http.StatusUnauthorized)
+					return
+				}
+				postHandler.PatchPost(w, r)
+
 			case http.MethodDelete:
 				if r.Context().Value(middleware.CtxUser) == nil {
 					http.Error(w, "Unauthorized", http.StatusUnauthorized)
@@ -99,6 +445,108 @@ func main() {
 		}),
 	))
 
+	mux.Handle("/me/favorites", requireAuth(
+		http.HandlerFunc(favoriteHandler.ListFavorites),
+	))
+
+	mux.Handle("/me/searches", requireAuth(
+		http.HandlerFunc(searchHandler.Searches),
+	))
+	mux.Handle("/me/searches/", requireAuth(
+		http.HandlerFunc(searchHandler.Search),
+	))
+	mux.Handle("/me/notifications", requireAuth(
+		http.HandlerFunc(notificationHandler.ListNotifications),
+	))
+	mux.Handle("/me/notifications/", requireAuth(
+		http.HandlerFunc(notificationHandler.MarkRead),
+	))
+
+	mux.Handle("/conversations", requireAuth(
+		http.HandlerFunc(chatHandler.OpenConversation),
+	))
+	mux.Handle("/conversations/", requireAuth(
+		http.HandlerFunc(chatHandler.Conversation),
+	))
+	mux.Handle("/me/conversations", requireAuth(
+		http.HandlerFunc(chatHandler.ListConversations),
+	))
+	mux.Handle("/me/blocks", requireAuth(
+		http.HandlerFunc(blockHandler.Blocks),
+	))
+	mux.Handle("/me/blocks/", requireAuth(
+		http.HandlerFunc(blockHandler.Blocks),
+	))
+
+	mux.Handle("/offers/", requireAuth(
+		http.HandlerFunc(offerHandler.Offer),
+	))
+	mux.Handle("/me/offers", requireAuth(
+		http.HandlerFunc(offerHandler.ListOffers),
+	))
+
+	mux.Handle("/orders", requireAuth(
+		http.HandlerFunc(orderHandler.CreateOrder),
+	))
+	mux.Handle("/orders/", requireAuth(
+		http.HandlerFunc(orderHandler.Order),
+	))
+	mux.Handle("/me/orders", requireAuth(
+		http.HandlerFunc(orderHandler.ListOrders),
+	))
+	mux.Handle("/me/cart/items", requireAuth(
+		http.HandlerFunc(cartHandler.Items),
+	))
+	mux.Handle("/me/cart/items/", requireAuth(
+		http.HandlerFunc(cartHandler.Item),
+	))
+	mux.Handle("/me/cart/checkout", requireAuth(
+		http.HandlerFunc(cartHandler.Checkout),
+	))
+	mux.Handle("/me/quotas", requireAuth(
+		http.HandlerFunc(quotaHandler.Quotas),
+	))
+	mux.Handle("/me/promotions", requireAuth(
+		http.HandlerFunc(promotionHandler.Promotions),
+	))
+	mux.Handle("/me/analytics", requireAuth(
+		http.HandlerFunc(analyticsHandler.Analytics),
+	))
+	mux.Handle("/users/", optionalAuth(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/follow") {
+				followHandler.Follow(w, r)
+				return
+			}
+			reviewHandler.User(w, r)
+		}),
+	))
+	mux.Handle("/me/following", requireAuth(
+		http.HandlerFunc(followHandler.Following),
+	))
+	mux.Handle("/reviews/", requireAuth(
+		http.HandlerFunc(reviewHandler.Review),
+	))
+
+	mux.Handle("/moderation/cases", requireAuth(
+		http.HandlerFunc(moderationHandler.Cases),
+	))
+	mux.Handle("/moderation/cases/", requireAuth(
+		http.HandlerFunc(moderationHandler.Case),
+	))
+	mux.Handle("/moderation/audit", requireAuth(
+		http.HandlerFunc(moderationHandler.Audit),
+	))
+	// Вебхук вызывает провайдер: подлинность проверяется подписью, а не JWT, и частота не ограничивается
+	mux.HandleFunc("/payments/webhook", orderHandler.PaymentWebhook)
+
+	mux.Handle("/stream", queryAuth(
+		http.HandlerFunc(streamHandler.SSE),
+	))
+	mux.Handle("/stream/ws", queryAuth(
+		http.HandlerFunc(streamHandler.WebSocket),
+	))
+
 	ServerAddress := ":" + strconv.Itoa(cfg.AppPort)
 	log.Printf("Server started at %s\n", ServerAddress)
 	log.Fatal(http.ListenAndServe(ServerAddress, mux))
@@ -14,7 +14,10 @@ require (
 	golang.org/x/crypto v0.37.0
 )
 
-require github.com/testcontainers/testcontainers-go v0.38.0
+require (
+	github.com/gorilla/websocket v1.5.3
+	github.com/testcontainers/testcontainers-go v0.38.0
+)
 
 require (
 	dario.cat/mergo v1.0.1 // indirect
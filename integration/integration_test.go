@@ -24,6 +24,7 @@ import (
 	"github.com/TemirB/rest-api-marketplace/internal/middleware"
 	"github.com/TemirB/rest-api-marketplace/internal/post"
 	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
 )
 
 var (
@@ -125,7 +126,7 @@ func TestFullScenario(t *testing.T) {
 	json.NewDecoder(resp.Body).Decode(&p1)
 	resp.Body.Close()
 	assert.Equal(t, "First post", p1.Title)
-	assert.Equal(t, 50.0, p1.Price)
+	assert.Equal(t, money.MustParse("50", "RUB"), p1.Price)
 	assert.Equal(t, login, p1.Owner)
 	assert.NotZero(t, p1.ID)
 
@@ -167,7 +168,7 @@ func TestFullScenario(t *testing.T) {
 	json.NewDecoder(resp.Body).Decode(&priceF)
 	resp.Body.Close()
 	assert.Len(t, priceF, 1)
-	assert.Equal(t, 150.0, priceF[0].Price)
+	assert.Equal(t, money.MustParse("150", "RUB"), priceF[0].Price)
 
 	// 8. Фильтрация по владельцу
 	resp, err = client.Get(base + "/posts/feed?owner=" + login)
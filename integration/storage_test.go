@@ -0,0 +1,72 @@
+package integration
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/auction"
+	"github.com/TemirB/rest-api-marketplace/internal/database"
+)
+
+func TestAuctionStorage_CloseDue_NoWinner(t *testing.T) {
+	rnd := time.Now().UnixNano() % 1_000_000_000
+	seller := fmt.Sprintf("seller%d", rnd)
+	bidder := fmt.Sprintf("bidder%d", rnd)
+	for _, login := range []string{seller, bidder} {
+		_, err := db.Exec(`INSERT INTO users (login, password) VALUES ($1, 'x')`, login)
+		assert.NoError(t, err)
+	}
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM users WHERE login IN ($1, $2)`, seller, bidder)
+	})
+
+	// Пост без ставок и пост, где ставка не дотянула до резервной цены
+	var noBids, reserveNotMet uint
+	query := `
+		INSERT INTO posts (title, description, price, image_url, owner, listing_type)
+		VALUES ('Lot', 'Lot', 100, 'http://example.com/lot.png', $1, 'auction')
+		RETURNING id
+	`
+	assert.NoError(t, db.QueryRow(query, seller).Scan(&noBids))
+	assert.NoError(t, db.QueryRow(query, seller).Scan(&reserveNotMet))
+
+	_, err := db.Exec(`
+		INSERT INTO auctions (post_id, seller, currency, start_price, reserve_price, min_increment, ends_at)
+		VALUES ($1, $3, 'RUB', 100, NULL, 10, NOW() - INTERVAL '1 minute'),
+		       ($2, $3, 'RUB', 100, 500, 10, NOW() - INTERVAL '1 minute')
+	`, noBids, reserveNotMet, seller)
+	assert.NoError(t, err)
+	_, err = db.Exec(`UPDATE auctions SET current_price = 150, leader = $2, bid_count = 1 WHERE post_id = $1`, reserveNotMet, bidder)
+	assert.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO bids (post_id, bidder, amount) VALUES ($1, $2, 150)`, reserveNotMet, bidder)
+	assert.NoError(t, err)
+
+	storage := auction.NewStorage(&database.Repository{DB: db, Logger: zap.NewNop()}, zap.NewNop())
+	closed, err := storage.CloseDue()
+	assert.NoError(t, err)
+
+	losers := map[uint][]string{}
+	for _, c := range closed {
+		assert.Empty(t, c.Auction.Winner)
+		losers[c.Auction.PostID] = c.Losers
+	}
+	assert.Contains(t, losers, noBids)
+	assert.Equal(t, []string{bidder}, losers[reserveNotMet])
+
+	for _, id := range []uint{noBids, reserveNotMet} {
+		var listingType, status string
+		assert.NoError(t, db.QueryRow(`SELECT listing_type, status FROM posts WHERE id = $1`, id).Scan(&listingType, &status))
+		assert.Equal(t, "fixed", listingType)
+		assert.Equal(t, "active", status)
+
+		var auctions, bids int
+		assert.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM auctions WHERE post_id = $1`, id).Scan(&auctions))
+		assert.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM bids WHERE post_id = $1`, id).Scan(&bids))
+		assert.Zero(t, auctions)
+		assert.Zero(t, bids)
+	}
+}
@@ -0,0 +1,68 @@
+package analytics
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	defaultDays = 30
+	maxDays     = 90
+)
+
+// dateLayout — формат дня в отчёте.
+const dateLayout = "2006-01-02"
+
+var ErrInvalidDays = errors.New("days must be between 1 and 90")
+
+// Counters — счётчики поста за период.
+type Counters struct {
+	// Views — просмотры страницы поста; один зритель засчитывается раз в окно дедупликации
+	Views int `json:"views"`
+	// Impressions — показы поста в ленте
+	Impressions int `json:"impressions"`
+	// Favorites — добавления в избранное
+	Favorites int `json:"favorites"`
+	// Messages — начатые переписки с продавцом
+	Messages int `json:"messages"`
+}
+
+func (c *Counters) add(o Counters) {
+	c.Views += o.Views
+	c.Impressions += o.Impressions
+	c.Favorites += o.Favorites
+	c.Messages += o.Messages
+}
+
+// Row — счётчики поста за один день (UTC).
+type Row struct {
+	PostID uint
+	Day    time.Time
+	Counters
+}
+
+// Day — точка временного ряда.
+type Day struct {
+	Date string `json:"date"`
+	Counters
+}
+
+// PostStats — статистика одного поста: итоги за период и ряд по дням без пропусков.
+type PostStats struct {
+	PostID uint     `json:"post_id"`
+	Title  string   `json:"title"`
+	Totals Counters `json:"totals"`
+	Daily  []Day    `json:"daily"`
+}
+
+// Report — ответ GET /me/analytics.
+type Report struct {
+	From  string       `json:"from"`
+	To    string       `json:"to"`
+	Posts []*PostStats `json:"posts"`
+}
+
+// day возвращает начало дня t в UTC.
+func day(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,161 @@
+package analytics
+
+// mockgen  -source=collector.go -destination=collector_mock_test.go -package=analytics
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+type writer interface {
+	Flush(rows []Row) error
+}
+
+type event struct {
+	postIDs []uint
+	delta   Counters
+	// viewer — кто смотрит пост; задан только для просмотров
+	viewer string
+	at     time.Time
+}
+
+type key struct {
+	postID uint
+	day    time.Time
+}
+
+// Collector копит события в памяти и пишет их в базу пачками, чтобы запросы,
+// которые их порождают, не ждали записи. Записывать события безопасно из любых горутин;
+// если очередь переполнена, событие теряется — статистика не важнее ответа клиенту.
+type Collector struct {
+	repository writer
+	events     chan event
+	// window — в течение какого времени повторные просмотры одного зрителя не считаются
+	window time.Duration
+	now    func() time.Time
+	logger *zap.Logger
+
+	// Состояние ниже принадлежит горутине Run
+	seen    map[string]time.Time
+	pending map[key]*Counters
+}
+
+func NewCollector(repository writer, window time.Duration, buffer int, logger *zap.Logger) *Collector {
+	return &Collector{
+		repository: repository,
+		events:     make(chan event, buffer),
+		window:     window,
+		now:        time.Now,
+		logger:     logger,
+		seen:       make(map[string]time.Time),
+		pending:    make(map[key]*Counters),
+	}
+}
+
+func (c *Collector) record(e event) {
+	e.at = c.now()
+	select {
+	case c.events <- e:
+	default:
+		c.logger.Debug("Analytics queue is full, event dropped")
+	}
+}
+
+// RecordView засчитывает просмотр страницы поста зрителем viewer (логин или адрес).
+func (c *Collector) RecordView(postID uint, viewer string) {
+	c.record(event{postIDs: []uint{postID}, delta: Counters{Views: 1}, viewer: viewer})
+}
+
+// RecordImpressions засчитывает показ в ленте каждому посту из postIDs.
+func (c *Collector) RecordImpressions(postIDs []uint) {
+	if len(postIDs) == 0 {
+		return
+	}
+	c.record(event{postIDs: postIDs, delta: Counters{Impressions: 1}})
+}
+
+func (c *Collector) RecordFavorite(postID uint) {
+	c.record(event{postIDs: []uint{postID}, delta: Counters{Favorites: 1}})
+}
+
+func (c *Collector) RecordMessage(postID uint) {
+	c.record(event{postIDs: []uint{postID}, delta: Counters{Messages: 1}})
+}
+
+// OnFeed — обработчик для post.WithOnFeed.
+func (c *Collector) OnFeed(feed []*post.Post) {
+	ids := make([]uint, 0, len(feed))
+	for _, p := range feed {
+		ids = append(ids, p.ID)
+	}
+	c.RecordImpressions(ids)
+}
+
+// Run принимает события и раз в interval записывает накопленное, пока не отменён ctx.
+// Перед выходом записывает то, что успел накопить.
+func (c *Collector) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.flush()
+			return
+		case e := <-c.events:
+			c.add(e)
+		case <-ticker.C:
+			c.flush()
+		}
+	}
+}
+
+func (c *Collector) add(e event) {
+	if e.viewer != "" {
+		seenKey := strconv.FormatUint(uint64(e.postIDs[0]), 10) + "|" + e.viewer
+		if last, ok := c.seen[seenKey]; ok && e.at.Sub(last) < c.window {
+			return
+		}
+		c.seen[seenKey] = e.at
+	}
+
+	d := day(e.at)
+	for _, id := range e.postIDs {
+		k := key{postID: id, day: d}
+		counters, ok := c.pending[k]
+		if !ok {
+			counters = &Counters{}
+			c.pending[k] = counters
+		}
+		counters.add(e.delta)
+	}
+}
+
+// flush записывает накопленные счётчики. При ошибке они теряются, чтобы память не росла,
+// пока база недоступна.
+func (c *Collector) flush() {
+	now := c.now()
+	for k, at := range c.seen {
+		if now.Sub(at) >= c.window {
+			delete(c.seen, k)
+		}
+	}
+
+	if len(c.pending) == 0 {
+		return
+	}
+	rows := make([]Row, 0, len(c.pending))
+	for k, counters := range c.pending {
+		rows = append(rows, Row{PostID: k.postID, Day: k.day, Counters: *counters})
+	}
+	c.pending = make(map[key]*Counters)
+
+	if err := c.repository.Flush(rows); err != nil {
+		c.logger.Warn("Failed to flush analytics", zap.Int("rows", len(rows)), zap.Error(err))
+	}
+}
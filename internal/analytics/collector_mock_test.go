@@ -0,0 +1,48 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: collector.go
+
+// Package analytics is a generated GoMock package.
+package analytics
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockwriter is a mock of writer interface.
+type Mockwriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockwriterMockRecorder
+}
+
+// MockwriterMockRecorder is the mock recorder for Mockwriter.
+type MockwriterMockRecorder struct {
+	mock *Mockwriter
+}
+
+// NewMockwriter creates a new mock instance.
+func NewMockwriter(ctrl *gomock.Controller) *Mockwriter {
+	mock := &Mockwriter{ctrl: ctrl}
+	mock.recorder = &MockwriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockwriter) EXPECT() *MockwriterMockRecorder {
+	return m.recorder
+}
+
+// Flush mocks base method.
+func (m *Mockwriter) Flush(rows []Row) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", rows)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockwriterMockRecorder) Flush(rows interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*Mockwriter)(nil).Flush), rows)
+}
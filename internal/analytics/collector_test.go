@@ -0,0 +1,85 @@
+package analytics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+// drain передаёт накопленные в очереди события в add, как это делает Run.
+func drain(c *Collector) {
+	for {
+		select {
+		case e := <-c.events:
+			c.add(e)
+		default:
+			return
+		}
+	}
+}
+
+func TestCollector_Flush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 3, 1, 23, 50, 0, 0, time.UTC)
+	today := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	repo := NewMockwriter(ctrl)
+	c := NewCollector(repo, 30*time.Minute, 100, zap.NewNop())
+	c.now = func() time.Time { return now }
+
+	c.RecordView(7, "login:bob")
+	c.RecordView(7, "login:bob")
+	c.RecordView(7, "ip:10.0.0.1")
+	c.RecordView(8, "login:bob")
+	c.OnFeed([]*post.Post{{ID: 7}, {ID: 8}})
+	c.RecordFavorite(7)
+	c.RecordMessage(8)
+	drain(c)
+
+	repo.EXPECT().Flush(gomock.Any()).DoAndReturn(func(rows []Row) error {
+		assert.ElementsMatch(t, []Row{
+			{PostID: 7, Day: today, Counters: Counters{Views: 2, Impressions: 1, Favorites: 1}},
+			{PostID: 8, Day: today, Counters: Counters{Views: 1, Impressions: 1, Messages: 1}},
+		}, rows)
+		return nil
+	})
+	c.flush()
+
+	// Через 20 минут — уже следующий день, но окно дедупликации не истекло
+	now = now.Add(20 * time.Minute)
+	c.RecordView(7, "login:bob")
+	c.RecordView(7, "login:carol")
+	drain(c)
+
+	repo.EXPECT().Flush([]Row{
+		{PostID: 7, Day: today.AddDate(0, 0, 1), Counters: Counters{Views: 1}},
+	}).Return(errors.New("db down"))
+	c.flush()
+
+	// Ошибка записи не копит счётчики
+	assert.Empty(t, c.pending)
+	c.flush()
+
+	now = now.Add(30 * time.Minute)
+	c.flush()
+	assert.Empty(t, c.seen)
+}
+
+func TestCollector_QueueFull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := NewCollector(NewMockwriter(ctrl), time.Minute, 1, zap.NewNop())
+	c.RecordFavorite(1)
+	c.RecordFavorite(2)
+
+	assert.Len(t, c.events, 1)
+}
@@ -0,0 +1,64 @@
+package analytics
+
+// mockgen  -source=handler.go -destination=handler_mock_test.go -package=analytics
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+)
+
+type service interface {
+	GetAnalytics(owner string, days int) (*Report, error)
+}
+
+type Handler struct {
+	service service
+	logger  *zap.Logger
+}
+
+func NewHandler(service service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Analytics обрабатывает GET /me/analytics?days=30.
+func (h *Handler) Analytics(w http.ResponseWriter, r *http.Request) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var days int
+	if v := r.URL.Query().Get("days"); v != "" {
+		if days, err = strconv.Atoi(v); err != nil {
+			http.Error(w, "Bad Request: invalid days", http.StatusBadRequest)
+			return
+		}
+	}
+
+	report, err := h.service.GetAnalytics(login, days)
+	if errors.Is(err, ErrInvalidDays) {
+		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to get analytics", zap.String("owner", login), zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
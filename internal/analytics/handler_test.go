@@ -0,0 +1,96 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+)
+
+func TestHandler_Analytics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		url        string
+		user       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name: "1. Default_Period",
+			url:  "/me/analytics",
+			user: "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetAnalytics("alice", 0).Return(&Report{Posts: []*PostStats{}}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "2. Custom_Period",
+			url:  "/me/analytics?days=7",
+			user: "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetAnalytics("alice", 7).Return(&Report{Posts: []*PostStats{}}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "3. Invalid_Days",
+			url:          "/me/analytics?days=week",
+			user:         "alice",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "4. Too_Long",
+			url:  "/me/analytics?days=365",
+			user: "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetAnalytics("alice", 365).Return(nil, ErrInvalidDays)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "5. Unauthorized",
+			url:          "/me/analytics",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "6. Storage_Error",
+			url:  "/me/analytics",
+			user: "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetAnalytics("alice", 0).Return(nil, errors.New("db down"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewMockservice(ctrl)
+			tc.setupMocks(service)
+
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			if tc.user != "" {
+				req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, tc.user))
+			}
+			rec := httptest.NewRecorder()
+			NewHandler(service, zap.NewNop()).Analytics(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
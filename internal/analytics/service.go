@@ -0,0 +1,81 @@
+package analytics
+
+// mockgen  -source=service.go -destination=service_mock_test.go -package=analytics
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type storage interface {
+	Series(owner string, from, to time.Time) ([]StatsRow, error)
+}
+
+type Service struct {
+	repository storage
+	now        func() time.Time
+	logger     *zap.Logger
+}
+
+func NewService(repository storage, logger *zap.Logger) *Service {
+	return &Service{
+		repository: repository,
+		now:        time.Now,
+		logger:     logger,
+	}
+}
+
+// GetAnalytics возвращает статистику постов owner за последние days дней, включая сегодня (UTC).
+// 0 — за 30 дней.
+func (s *Service) GetAnalytics(owner string, days int) (*Report, error) {
+	if days == 0 {
+		days = defaultDays
+	}
+	if days < 1 || days > maxDays {
+		return nil, ErrInvalidDays
+	}
+	to := day(s.now())
+	from := to.AddDate(0, 0, -(days - 1))
+
+	rows, err := s.repository.Series(owner, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		From:  from.Format(dateLayout),
+		To:    to.Format(dateLayout),
+		Posts: []*PostStats{},
+	}
+	var (
+		current *PostStats
+		index   map[string]int
+	)
+	for _, row := range rows {
+		if current == nil || current.PostID != row.PostID {
+			current, index = newPostStats(row.PostID, row.Title, from, days)
+			report.Posts = append(report.Posts, current)
+		}
+		if row.Day.IsZero() {
+			continue
+		}
+		if i, ok := index[row.Day.Format(dateLayout)]; ok {
+			current.Daily[i].add(row.Counters)
+			current.Totals.add(row.Counters)
+		}
+	}
+	return report, nil
+}
+
+// newPostStats заводит статистику поста с нулями за каждый день периода.
+func newPostStats(postID uint, title string, from time.Time, days int) (*PostStats, map[string]int) {
+	stats := &PostStats{PostID: postID, Title: title, Daily: make([]Day, days)}
+	index := make(map[string]int, days)
+	for i := range stats.Daily {
+		date := from.AddDate(0, 0, i).Format(dateLayout)
+		stats.Daily[i].Date = date
+		index[date] = i
+	}
+	return stats, index
+}
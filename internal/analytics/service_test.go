@@ -0,0 +1,59 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestService_GetAnalytics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 3, 3, 15, 0, 0, 0, time.UTC)
+	mar1 := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	mar3 := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	repo := NewMockstorage(ctrl)
+	repo.EXPECT().Series("alice", mar1, mar3).Return([]StatsRow{
+		{Title: "Bike", Row: Row{PostID: 8, Day: mar1, Counters: Counters{Views: 3, Impressions: 40}}},
+		{Title: "Bike", Row: Row{PostID: 8, Day: mar3, Counters: Counters{Views: 1, Favorites: 1}}},
+		{Title: "Lamp", Row: Row{PostID: 5}},
+	}, nil)
+
+	service := NewService(repo, zap.NewNop())
+	service.now = func() time.Time { return now }
+
+	report, err := service.GetAnalytics("alice", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-03-01", report.From)
+	assert.Equal(t, "2025-03-03", report.To)
+	assert.Len(t, report.Posts, 2)
+
+	bike := report.Posts[0]
+	assert.Equal(t, uint(8), bike.PostID)
+	assert.Equal(t, Counters{Views: 4, Impressions: 40, Favorites: 1}, bike.Totals)
+	assert.Equal(t, []Day{
+		{Date: "2025-03-01", Counters: Counters{Views: 3, Impressions: 40}},
+		{Date: "2025-03-02"},
+		{Date: "2025-03-03", Counters: Counters{Views: 1, Favorites: 1}},
+	}, bike.Daily)
+
+	lamp := report.Posts[1]
+	assert.Equal(t, Counters{}, lamp.Totals)
+	assert.Len(t, lamp.Daily, 3)
+}
+
+func TestService_GetAnalytics_InvalidDays(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewService(NewMockstorage(ctrl), zap.NewNop())
+	_, err := service.GetAnalytics("alice", 91)
+	assert.ErrorIs(t, err, ErrInvalidDays)
+	_, err = service.GetAnalytics("alice", -1)
+	assert.ErrorIs(t, err, ErrInvalidDays)
+}
@@ -0,0 +1,114 @@
+package analytics
+
+// mockgen  -source=storage.go -destination=storage_mock_test.go -package=analytics
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// flushQuery прибавляет пачку счётчиков к дневной статистике. Счётчики удалённых
+// за это время постов отбрасываются.
+const flushQuery = `
+	INSERT INTO post_stats (post_id, day, views, impressions, favorites, messages)
+	SELECT u.post_id, u.day, u.views, u.impressions, u.favorites, u.messages
+	FROM unnest($1::int[], $2::date[], $3::int[], $4::int[], $5::int[], $6::int[])
+		AS u(post_id, day, views, impressions, favorites, messages)
+	WHERE EXISTS (SELECT 1 FROM posts p WHERE p.id = u.post_id)
+	ON CONFLICT (post_id, day) DO UPDATE SET
+		views = post_stats.views + EXCLUDED.views,
+		impressions = post_stats.impressions + EXCLUDED.impressions,
+		favorites = post_stats.favorites + EXCLUDED.favorites,
+		messages = post_stats.messages + EXCLUDED.messages
+`
+
+type Repository interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+type Storage struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+func NewStorage(repository Repository, logger *zap.Logger) *Storage {
+	return &Storage{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// Flush прибавляет счётчики rows к дневной статистике одним запросом.
+func (r *Storage) Flush(rows []Row) error {
+	n := len(rows)
+	ids, days := make([]int64, 0, n), make([]string, 0, n)
+	views, impressions := make([]int64, 0, n), make([]int64, 0, n)
+	favorites, messages := make([]int64, 0, n), make([]int64, 0, n)
+	for _, row := range rows {
+		ids = append(ids, int64(row.PostID))
+		days = append(days, row.Day.Format(dateLayout))
+		views = append(views, int64(row.Views))
+		impressions = append(impressions, int64(row.Impressions))
+		favorites = append(favorites, int64(row.Favorites))
+		messages = append(messages, int64(row.Messages))
+	}
+
+	_, err := r.repository.Exec(flushQuery,
+		pq.Array(ids), pq.Array(days), pq.Array(views), pq.Array(impressions), pq.Array(favorites), pq.Array(messages),
+	)
+	if err != nil {
+		r.logger.Error("Failed to flush analytics", zap.Int("rows", len(rows)), zap.Error(err))
+		return errors.Errorf("failed to flush analytics: %v", err)
+	}
+	return nil
+}
+
+// StatsRow — строка статистики поста владельца; у постов без статистики за период Day нулевой.
+type StatsRow struct {
+	Title string
+	Row
+}
+
+// Series возвращает дневную статистику всех постов owner за дни from..to включительно,
+// новые посты первыми.
+func (r *Storage) Series(owner string, from, to time.Time) ([]StatsRow, error) {
+	query := `
+		SELECT p.id, p.title, s.day, s.views, s.impressions, s.favorites, s.messages
+		FROM posts p
+		LEFT JOIN post_stats s ON s.post_id = p.id AND s.day BETWEEN $2 AND $3
+		WHERE p.owner = $1
+		ORDER BY p.id DESC, s.day
+	`
+	rows, err := r.repository.Query(query, owner, from.Format(dateLayout), to.Format(dateLayout))
+	if err != nil {
+		r.logger.Error("Failed to get analytics", zap.String("owner", owner), zap.Error(err))
+		return nil, errors.Errorf("failed to get analytics: %v", err)
+	}
+	defer rows.Close()
+
+	var res []StatsRow
+	for rows.Next() {
+		var (
+			s                                       StatsRow
+			d                                       sql.NullTime
+			views, impressions, favorites, messages sql.NullInt64
+		)
+		if err := rows.Scan(&s.PostID, &s.Title, &d, &views, &impressions, &favorites, &messages); err != nil {
+			return nil, errors.Errorf("failed to scan analytics: %v", err)
+		}
+		s.Day = d.Time
+		s.Counters = Counters{
+			Views:       int(views.Int64),
+			Impressions: int(impressions.Int64),
+			Favorites:   int(favorites.Int64),
+			Messages:    int(messages.Int64),
+		}
+		res = append(res, s)
+	}
+	return res, rows.Err()
+}
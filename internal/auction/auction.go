@@ -0,0 +1,184 @@
+package auction
+
+import (
+	"errors"
+	"time"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+// Состояния аукциона.
+const (
+	StatusOpen   = "open"
+	StatusClosed = "closed"
+)
+
+// Виды уведомлений аукциона.
+const (
+	KindOutbid       = "outbid"
+	KindAuctionWon   = "auction_won"
+	KindAuctionLost  = "auction_lost"
+	KindAuctionEnded = "auction_ended"
+)
+
+const (
+	minAuctionLength = 10 * time.Minute
+	maxAuctionLength = 30 * 24 * time.Hour
+)
+
+var (
+	ErrAuctionNotFound  = errors.New("auction not found")
+	ErrAuctionExists    = errors.New("post is already an auction")
+	ErrAuctionClosed    = errors.New("auction is closed")
+	ErrNotOwner         = errors.New("only the post owner can start an auction")
+	ErrOwnAuction       = errors.New("cannot bid on your own auction")
+	ErrAlreadyLeading   = errors.New("you already have the highest bid")
+	ErrBidTooLow        = errors.New("bid is below the minimum")
+	ErrPostNotAvailable = errors.New("post is not available for an auction")
+	ErrOpenOffers       = errors.New("post has open offers")
+	ErrInvalidAuction   = errors.New("start price and increment must be positive, reserve not below start, end between 10 minutes and 30 days from now")
+)
+
+// Auction — аукцион по посту: пост становится аукционным и продаётся тому, кто
+// предложит больше всех к EndsAt, если ставка не ниже резервной цены.
+type Auction struct {
+	PostID       uint         `json:"post_id"`
+	Seller       string       `json:"seller"`
+	StartPrice   money.Money  `json:"start_price"`
+	ReservePrice *money.Money `json:"reserve_price,omitempty"`
+	MinIncrement money.Money  `json:"min_increment"`
+
+	CurrentPrice *money.Money `json:"current_price,omitempty"`
+	Leader       string       `json:"leader,omitempty"`
+	BidCount     int          `json:"bid_count"`
+	ReserveMet   bool         `json:"reserve_met"`
+
+	EndsAt    time.Time  `json:"ends_at"`
+	Status    string     `json:"status"`
+	Winner    string     `json:"winner,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+}
+
+type Bid struct {
+	ID        uint        `json:"id"`
+	PostID    uint        `json:"post_id"`
+	Bidder    string      `json:"bidder"`
+	Amount    money.Money `json:"amount"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// AntiSniping продлевает аукцион: ставка, сделанная меньше чем за Window до конца,
+// отодвигает конец на Extension от момента ставки.
+type AntiSniping struct {
+	Window    time.Duration
+	Extension time.Duration
+}
+
+// CreateRequest — параметры нового аукциона. Валюта по умолчанию — валюта поста.
+type CreateRequest struct {
+	StartPrice   money.Money  `json:"start_price"`
+	ReservePrice *money.Money `json:"reserve_price"`
+	MinIncrement money.Money  `json:"min_increment"`
+	EndsAt       time.Time    `json:"ends_at"`
+}
+
+// MinBid возвращает минимальную допустимую ставку.
+func (a *Auction) MinBid() (money.Money, error) {
+	if a.CurrentPrice == nil {
+		return a.StartPrice, nil
+	}
+	return a.CurrentPrice.Add(a.MinIncrement)
+}
+
+// refreshReserve пересчитывает ReserveMet по текущей ставке.
+func (a *Auction) refreshReserve() {
+	a.ReserveMet = false
+	if a.CurrentPrice == nil {
+		return
+	}
+	if a.ReservePrice == nil {
+		a.ReserveMet = true
+		return
+	}
+	cmp, err := a.CurrentPrice.Cmp(*a.ReservePrice)
+	a.ReserveMet = err == nil && cmp >= 0
+}
+
+// Place проверяет ставку и применяет её к аукциону: обновляет текущую цену, лидера
+// и, если ставка пришла в последние секунды, продлевает аукцион.
+func (a *Auction) Place(bidder string, amount money.Money, now time.Time, snipe AntiSniping) (*Bid, error) {
+	if a.Status != StatusOpen || !now.Before(a.EndsAt) {
+		return nil, ErrAuctionClosed
+	}
+	if bidder == a.Seller {
+		return nil, ErrOwnAuction
+	}
+	if bidder == a.Leader {
+		return nil, ErrAlreadyLeading
+	}
+
+	if !amount.HasCurrency() {
+		amount = amount.WithCurrency(a.StartPrice.Currency())
+	}
+	if err := amount.Validate(); err != nil {
+		return nil, ErrBidTooLow
+	}
+	minBid, err := a.MinBid()
+	if err != nil {
+		return nil, err
+	}
+	if cmp, err := amount.Cmp(minBid); err != nil || cmp < 0 {
+		return nil, ErrBidTooLow
+	}
+
+	a.CurrentPrice = &amount
+	a.Leader = bidder
+	a.BidCount++
+	a.refreshReserve()
+	if snipe.Window > 0 && a.EndsAt.Sub(now) < snipe.Window {
+		if extended := now.Add(snipe.Extension); extended.After(a.EndsAt) {
+			a.EndsAt = extended
+		}
+	}
+
+	return &Bid{PostID: a.PostID, Bidder: bidder, Amount: amount, CreatedAt: now}, nil
+}
+
+// newAuction проверяет параметры и собирает аукцион по посту в валюте asking.
+func newAuction(postID uint, seller string, req CreateRequest, asking money.Currency, now time.Time) (*Auction, error) {
+	withCurrency := func(m money.Money) money.Money {
+		if !m.HasCurrency() {
+			return m.WithCurrency(asking)
+		}
+		return m
+	}
+
+	a := &Auction{
+		PostID:       postID,
+		Seller:       seller,
+		StartPrice:   withCurrency(req.StartPrice),
+		MinIncrement: withCurrency(req.MinIncrement),
+		EndsAt:       req.EndsAt,
+		Status:       StatusOpen,
+	}
+	if req.ReservePrice != nil {
+		reserve := withCurrency(*req.ReservePrice)
+		a.ReservePrice = &reserve
+	}
+
+	for _, m := range []money.Money{a.StartPrice, a.MinIncrement} {
+		if !m.IsPositive() || m.Currency() != asking || m.Validate() != nil {
+			return nil, ErrInvalidAuction
+		}
+	}
+	if a.ReservePrice != nil {
+		if cmp, err := a.ReservePrice.Cmp(a.StartPrice); err != nil || cmp < 0 || a.ReservePrice.Validate() != nil {
+			return nil, ErrInvalidAuction
+		}
+	}
+	if length := a.EndsAt.Sub(now); length < minAuctionLength || length > maxAuctionLength {
+		return nil, ErrInvalidAuction
+	}
+	return a, nil
+}
@@ -0,0 +1,201 @@
+package auction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+var testNow = time.Date(2025, 7, 21, 12, 0, 0, 0, time.UTC)
+
+func openAuction() *Auction {
+	reserve := money.MustParse("1500", "RUB")
+	return &Auction{
+		PostID:       1,
+		Seller:       "bob",
+		StartPrice:   money.MustParse("1000", "RUB"),
+		ReservePrice: &reserve,
+		MinIncrement: money.MustParse("100", "RUB"),
+		EndsAt:       testNow.Add(time.Hour),
+		Status:       StatusOpen,
+	}
+}
+
+func TestAuction_Place(t *testing.T) {
+	snipe := AntiSniping{Window: 2 * time.Minute, Extension: 2 * time.Minute}
+	current := money.MustParse("1200", "RUB")
+
+	testCases := []struct {
+		name string
+
+		setup  func(a *Auction)
+		bidder string
+		amount money.Money
+		now    time.Time
+
+		expectedErr        error
+		expectedEndsAt     time.Time
+		expectedReserveMet bool
+	}{
+		{
+			name:           "1. First_Bid_At_Start_Price",
+			bidder:         "alice",
+			amount:         money.New(money.NewDecimal(1000, 0), ""),
+			now:            testNow,
+			expectedEndsAt: testNow.Add(time.Hour),
+		},
+		{
+			name:        "2. First_Bid_Below_Start",
+			bidder:      "alice",
+			amount:      money.MustParse("900", "RUB"),
+			now:         testNow,
+			expectedErr: ErrBidTooLow,
+		},
+		{
+			name: "3. Increment_Not_Reached",
+			setup: func(a *Auction) {
+				a.CurrentPrice, a.Leader, a.BidCount = &current, "carol", 1
+			},
+			bidder:      "alice",
+			amount:      money.MustParse("1250", "RUB"),
+			now:         testNow,
+			expectedErr: ErrBidTooLow,
+		},
+		{
+			name: "4. Reserve_Met",
+			setup: func(a *Auction) {
+				a.CurrentPrice, a.Leader, a.BidCount = &current, "carol", 1
+			},
+			bidder:             "alice",
+			amount:             money.MustParse("1500", "RUB"),
+			now:                testNow,
+			expectedEndsAt:     testNow.Add(time.Hour),
+			expectedReserveMet: true,
+		},
+		{
+			name:           "5. Late_Bid_Extends_Auction",
+			bidder:         "alice",
+			amount:         money.MustParse("1000", "RUB"),
+			now:            testNow.Add(time.Hour - 30*time.Second),
+			expectedEndsAt: testNow.Add(time.Hour + 90*time.Second),
+		},
+		{
+			name:        "6. After_End",
+			bidder:      "alice",
+			amount:      money.MustParse("1000", "RUB"),
+			now:         testNow.Add(time.Hour),
+			expectedErr: ErrAuctionClosed,
+		},
+		{
+			name:        "7. Seller_Bids",
+			bidder:      "bob",
+			amount:      money.MustParse("1000", "RUB"),
+			now:         testNow,
+			expectedErr: ErrOwnAuction,
+		},
+		{
+			name: "8. Leader_Bids_Again",
+			setup: func(a *Auction) {
+				a.CurrentPrice, a.Leader, a.BidCount = &current, "alice", 1
+			},
+			bidder:      "alice",
+			amount:      money.MustParse("2000", "RUB"),
+			now:         testNow,
+			expectedErr: ErrAlreadyLeading,
+		},
+		{
+			name:        "9. Other_Currency",
+			bidder:      "alice",
+			amount:      money.MustParse("1000", "USD"),
+			now:         testNow,
+			expectedErr: ErrBidTooLow,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := openAuction()
+			if tc.setup != nil {
+				tc.setup(a)
+			}
+			bids := a.BidCount
+
+			bid, err := a.Place(tc.bidder, tc.amount, tc.now, snipe)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.bidder, a.Leader)
+			assert.Equal(t, bid.Amount, *a.CurrentPrice)
+			assert.Equal(t, bids+1, a.BidCount)
+			assert.Equal(t, tc.expectedEndsAt, a.EndsAt)
+			assert.Equal(t, tc.expectedReserveMet, a.ReserveMet)
+		})
+	}
+}
+
+func TestNewAuction(t *testing.T) {
+	reserveBelowStart := money.MustParse("500", "RUB")
+
+	testCases := []struct {
+		name string
+
+		req CreateRequest
+
+		wantErr bool
+	}{
+		{
+			name: "1. Valid_Default_Currency",
+			req: CreateRequest{
+				StartPrice:   money.New(money.NewDecimal(1000, 0), ""),
+				MinIncrement: money.New(money.NewDecimal(50, 0), ""),
+				EndsAt:       testNow.Add(24 * time.Hour),
+			},
+		},
+		{
+			name: "2. Reserve_Below_Start",
+			req: CreateRequest{
+				StartPrice:   money.MustParse("1000", "RUB"),
+				ReservePrice: &reserveBelowStart,
+				MinIncrement: money.MustParse("50", "RUB"),
+				EndsAt:       testNow.Add(24 * time.Hour),
+			},
+			wantErr: true,
+		},
+		{
+			name: "3. Too_Short",
+			req: CreateRequest{
+				StartPrice:   money.MustParse("1000", "RUB"),
+				MinIncrement: money.MustParse("50", "RUB"),
+				EndsAt:       testNow.Add(time.Minute),
+			},
+			wantErr: true,
+		},
+		{
+			name: "4. Zero_Increment",
+			req: CreateRequest{
+				StartPrice:   money.MustParse("1000", "RUB"),
+				MinIncrement: money.MustParse("0", "RUB"),
+				EndsAt:       testNow.Add(24 * time.Hour),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := newAuction(1, "bob", tc.req, "RUB", testNow)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidAuction)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, money.Currency("RUB"), a.StartPrice.Currency())
+			assert.Equal(t, StatusOpen, a.Status)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package auction
+
+// mockgen  -source=handler.go -destination=handler_mock_test.go -package=auction
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/block"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+type service interface {
+	CreateAuction(postID uint, login string, req CreateRequest) (*Auction, error)
+	GetAuction(postID uint, viewer string) (*Auction, error)
+	PlaceBid(postID uint, bidder string, amount money.Money) (*Auction, *Bid, error)
+	ListBids(postID uint, limit int) ([]*Bid, error)
+}
+
+type Handler struct {
+	service service
+	logger  *zap.Logger
+}
+
+func NewHandler(service service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+type bidRequest struct {
+	Amount money.Money `json:"amount"`
+}
+
+type bidResponse struct {
+	Bid     *Bid     `json:"bid"`
+	Auction *Auction `json:"auction"`
+}
+
+// writeError переводит ошибки аукциона в HTTP-статусы.
+func (h *Handler) writeError(w http.ResponseWriter, err error, msg string, fields ...zap.Field) {
+	switch {
+	case errors.Is(err, ErrAuctionNotFound), errors.Is(err, post.ErrPostNotFound):
+		http.Error(w, "Not Found", http.StatusNotFound)
+	case errors.Is(err, ErrNotOwner), errors.Is(err, block.ErrBlocked):
+		http.Error(w, "Forbidden: "+err.Error(), http.StatusForbidden)
+	case errors.Is(err, ErrAuctionClosed), errors.Is(err, ErrAuctionExists),
+		errors.Is(err, ErrPostNotAvailable), errors.Is(err, ErrOpenOffers):
+		http.Error(w, "Conflict: "+err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrBidTooLow), errors.Is(err, ErrOwnAuction), errors.Is(err, ErrAlreadyLeading),
+		errors.Is(err, ErrInvalidAuction):
+		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+	default:
+		h.logger.Error(msg, append(fields, zap.Error(err))...)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// postID разбирает /posts/{id}/{suffix}.
+func postID(path, suffix string) (uint, bool, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 4 || parts[1] != "posts" || parts[3] != suffix {
+		return 0, false, nil
+	}
+	id, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return 0, true, err
+	}
+	return uint(id), true, nil
+}
+
+// Auction обрабатывает /posts/{id}/auction: GET — состояние аукциона, POST — сделать пост аукционом.
+func (h *Handler) Auction(w http.ResponseWriter, r *http.Request) {
+	id, ok, err := postID(r.URL.Path, "auction")
+	if !ok {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Bad Request: invalid id", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		viewer, _ := jwt.GetLogin(r)
+		a, err := h.service.GetAuction(id, viewer)
+		if err != nil {
+			h.writeError(w, err, "Failed to get auction", zap.Uint("post_id", id))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(a)
+
+	case http.MethodPost:
+		login, err := jwt.GetLogin(r)
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var req CreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Bad Request: invalid JSON", http.StatusBadRequest)
+			return
+		}
+		a, err := h.service.CreateAuction(id, login, req)
+		if err != nil {
+			h.writeError(w, err, "Failed to create auction", zap.Uint("post_id", id))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(a)
+
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Bids обрабатывает /posts/{id}/bids: GET — история ставок, POST — сделать ставку.
+func (h *Handler) Bids(w http.ResponseWriter, r *http.Request) {
+	id, ok, err := postID(r.URL.Path, "bids")
+	if !ok {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Bad Request: invalid id", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		bids, err := h.service.ListBids(id, limit)
+		if err != nil {
+			h.writeError(w, err, "Failed to list bids", zap.Uint("post_id", id))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(bids)
+
+	case http.MethodPost:
+		login, err := jwt.GetLogin(r)
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var req bidRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Bad Request: invalid JSON", http.StatusBadRequest)
+			return
+		}
+		a, bid, err := h.service.PlaceBid(id, login, req.Amount)
+		if err != nil {
+			h.writeError(w, err, "Failed to place bid", zap.Uint("post_id", id), zap.String("bidder", login))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(bidResponse{Bid: bid, Auction: a})
+
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+
+// Package auction is a generated GoMock package.
+package auction
+
+import (
+	reflect "reflect"
+
+	money "github.com/TemirB/rest-api-marketplace/pkg/money"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockservice is a mock of service interface.
+type Mockservice struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceMockRecorder
+}
+
+// MockserviceMockRecorder is the mock recorder for Mockservice.
+type MockserviceMockRecorder struct {
+	mock *Mockservice
+}
+
+// NewMockservice creates a new mock instance.
+func NewMockservice(ctrl *gomock.Controller) *Mockservice {
+	mock := &Mockservice{ctrl: ctrl}
+	mock.recorder = &MockserviceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockservice) EXPECT() *MockserviceMockRecorder {
+	return m.recorder
+}
+
+// CreateAuction mocks base method.
+func (m *Mockservice) CreateAuction(postID uint, login string, req CreateRequest) (*Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuction", postID, login, req)
+	ret0, _ := ret[0].(*Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuction indicates an expected call of CreateAuction.
+func (mr *MockserviceMockRecorder) CreateAuction(postID, login, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuction", reflect.TypeOf((*Mockservice)(nil).CreateAuction), postID, login, req)
+}
+
+// GetAuction mocks base method.
+func (m *Mockservice) GetAuction(postID uint, viewer string) (*Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuction", postID, viewer)
+	ret0, _ := ret[0].(*Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuction indicates an expected call of GetAuction.
+func (mr *MockserviceMockRecorder) GetAuction(postID, viewer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuction", reflect.TypeOf((*Mockservice)(nil).GetAuction), postID, viewer)
+}
+
+// ListBids mocks base method.
+func (m *Mockservice) ListBids(postID uint, limit int) ([]*Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBids", postID, limit)
+	ret0, _ := ret[0].([]*Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBids indicates an expected call of ListBids.
+func (mr *MockserviceMockRecorder) ListBids(postID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBids", reflect.TypeOf((*Mockservice)(nil).ListBids), postID, limit)
+}
+
+// PlaceBid mocks base method.
+func (m *Mockservice) PlaceBid(postID uint, bidder string, amount money.Money) (*Auction, *Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceBid", postID, bidder, amount)
+	ret0, _ := ret[0].(*Auction)
+	ret1, _ := ret[1].(*Bid)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PlaceBid indicates an expected call of PlaceBid.
+func (mr *MockserviceMockRecorder) PlaceBid(postID, bidder, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceBid", reflect.TypeOf((*Mockservice)(nil).PlaceBid), postID, bidder, amount)
+}
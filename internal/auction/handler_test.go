@@ -0,0 +1,179 @@
+package auction
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+func newRequest(method, path, body, user string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if user != "" {
+		req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, user))
+	}
+	return req
+}
+
+func TestHandler_Bids(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		method     string
+		path       string
+		body       string
+		user       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name:   "1. Placed",
+			method: http.MethodPost,
+			path:   "/posts/1/bids",
+			body:   `{"amount":{"amount":"1300","currency":"RUB"}}`,
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().PlaceBid(uint(1), "alice", money.MustParse("1300", "RUB")).
+					Return(&Auction{PostID: 1}, &Bid{ID: 9}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "2. Too_Low",
+			method: http.MethodPost,
+			path:   "/posts/1/bids",
+			body:   `{"amount":10}`,
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().PlaceBid(uint(1), "alice", gomock.Any()).Return(nil, nil, ErrBidTooLow)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "3. Closed",
+			method: http.MethodPost,
+			path:   "/posts/1/bids",
+			body:   `{"amount":2000}`,
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().PlaceBid(uint(1), "alice", gomock.Any()).Return(nil, nil, ErrAuctionClosed)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "4. Unauthorized",
+			method:       http.MethodPost,
+			path:         "/posts/1/bids",
+			body:         `{"amount":2000}`,
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "5. History",
+			method: http.MethodGet,
+			path:   "/posts/1/bids?limit=10",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().ListBids(uint(1), 10).Return([]*Bid{{ID: 9}}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "6. Invalid_ID",
+			method:       http.MethodGet,
+			path:         "/posts/abc/bids",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+			rr := httptest.NewRecorder()
+
+			handler.Bids(rr, newRequest(tc.method, tc.path, tc.body, tc.user))
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestHandler_Auction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		method     string
+		body       string
+		user       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name:   "1. Get_Anonymous",
+			method: http.MethodGet,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetAuction(uint(1), "").Return(&Auction{PostID: 1}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "2. Create",
+			method: http.MethodPost,
+			body:   `{"start_price":1000,"min_increment":100,"ends_at":"2030-01-01T00:00:00Z"}`,
+			user:   "bob",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().CreateAuction(uint(1), "bob", gomock.Any()).Return(&Auction{PostID: 1}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "3. Create_Not_Owner",
+			method: http.MethodPost,
+			body:   `{"start_price":1000,"min_increment":100,"ends_at":"2030-01-01T00:00:00Z"}`,
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().CreateAuction(uint(1), "alice", gomock.Any()).Return(nil, ErrNotOwner)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:   "4. Not_Found",
+			method: http.MethodGet,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetAuction(uint(1), "").Return(nil, ErrAuctionNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+			rr := httptest.NewRecorder()
+
+			handler.Auction(rr, newRequest(tc.method, "/posts/1/auction", tc.body, tc.user))
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package auction
+
+// mockgen  -source=service.go -destination=service_mock_test.go -package=auction
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+const (
+	defaultBidLimit = 50
+	maxBidLimit     = 200
+)
+
+type storage interface {
+	Create(a *Auction) error
+	Get(postID uint) (*Auction, error)
+	PlaceBid(postID uint, place func(a *Auction) (*Bid, error)) (*Auction, *Bid, error)
+	ListBids(postID uint, limit int) ([]*Bid, error)
+	CloseDue() ([]*Closed, error)
+}
+
+type posts interface {
+	GetPostByID(id uint) (*post.Post, error)
+}
+
+type notifier interface {
+	Notify(msg notify.Message) error
+}
+
+// blocks запрещает ставки, если продавец и участник заблокировали друг друга.
+type blocks interface {
+	Check(a, b string) error
+}
+
+type Service struct {
+	repository storage
+	posts      posts
+	snipe      AntiSniping
+	notifier   notifier
+	blocks     blocks
+	now        func() time.Time
+	logger     *zap.Logger
+}
+
+type Option func(*Service)
+
+// WithNotifier включает уведомления о перебитых ставках и итогах аукциона.
+func WithNotifier(notifier notifier) Option {
+	return func(s *Service) {
+		s.notifier = notifier
+	}
+}
+
+// WithBlocks запрещает делать ставки на аукционы тех, с кем есть блокировка.
+func WithBlocks(blocks blocks) Option {
+	return func(s *Service) {
+		s.blocks = blocks
+	}
+}
+
+func NewService(repository storage, posts posts, snipe AntiSniping, logger *zap.Logger, opts ...Option) *Service {
+	s := &Service{
+		repository: repository,
+		posts:      posts,
+		snipe:      snipe,
+		now:        time.Now,
+		logger:     logger,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// CreateAuction превращает пост владельца в аукцион.
+func (s *Service) CreateAuction(postID uint, login string, req CreateRequest) (*Auction, error) {
+	p, err := s.posts.GetPostByID(postID)
+	if err != nil {
+		return nil, err
+	}
+	if p.Owner != login {
+		return nil, ErrNotOwner
+	}
+	if p.ListingType == post.ListingAuction {
+		return nil, ErrAuctionExists
+	}
+	if p.Hidden || p.Status != post.StatusActive {
+		return nil, ErrPostNotAvailable
+	}
+
+	a, err := newAuction(postID, login, req, p.Price.Currency(), s.now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repository.Create(a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// GetAuction возвращает аукцион; резервную цену видит только продавец.
+func (s *Service) GetAuction(postID uint, viewer string) (*Auction, error) {
+	a, err := s.repository.Get(postID)
+	if err != nil {
+		return nil, err
+	}
+	if viewer != a.Seller {
+		a.ReservePrice = nil
+	}
+	return a, nil
+}
+
+// PlaceBid делает ставку и уведомляет предыдущего лидера, что его ставку перебили.
+func (s *Service) PlaceBid(postID uint, bidder string, amount money.Money) (*Auction, *Bid, error) {
+	var outbid string
+	a, bid, err := s.repository.PlaceBid(postID, func(a *Auction) (*Bid, error) {
+		if s.blocks != nil {
+			if err := s.blocks.Check(bidder, a.Seller); err != nil {
+				return nil, err
+			}
+		}
+		outbid = a.Leader
+		return a.Place(bidder, amount, s.now(), s.snipe)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Ставить может только не продавец, поэтому резервную цену скрываем
+	a.ReservePrice = nil
+	if outbid != "" {
+		s.notify(outbid, KindOutbid, fmt.Sprintf("You have been outbid: %s", bid.Amount), a)
+	}
+	return a, bid, nil
+}
+
+func (s *Service) ListBids(postID uint, limit int) ([]*Bid, error) {
+	if limit <= 0 {
+		limit = defaultBidLimit
+	}
+	if limit > maxBidLimit {
+		limit = maxBidLimit
+	}
+	return s.repository.ListBids(postID, limit)
+}
+
+// CloseAuctions закрывает завершившиеся аукционы и рассылает итоги продавцу, победителю и остальным участникам.
+func (s *Service) CloseAuctions() error {
+	closed, err := s.repository.CloseDue()
+	if err != nil {
+		return err
+	}
+	for _, c := range closed {
+		a := c.Auction
+		switch {
+		case a.Winner != "":
+			s.notify(a.Winner, KindAuctionWon, fmt.Sprintf("You won the auction: %s", a.CurrentPrice), a)
+			s.notify(a.Seller, KindAuctionEnded, fmt.Sprintf("Auction ended: sold to %s for %s", a.Winner, a.CurrentPrice), a)
+		case a.BidCount > 0:
+			s.notify(a.Seller, KindAuctionEnded, "Auction ended: reserve price not met", a)
+		default:
+			s.notify(a.Seller, KindAuctionEnded, "Auction ended with no bids", a)
+		}
+
+		// Резервную цену участникам не раскрываем
+		public := *a
+		public.ReservePrice = nil
+		for _, login := range c.Losers {
+			s.notify(login, KindAuctionLost, "Auction ended: your bid did not win", &public)
+		}
+	}
+	return nil
+}
+
+// Run периодически закрывает завершившиеся аукционы, пока не отменён ctx.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.CloseAuctions(); err != nil {
+				s.logger.Warn("Failed to close auctions", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (s *Service) notify(recipient, kind, title string, a *Auction) {
+	if s.notifier == nil {
+		return
+	}
+	payload, err := json.Marshal(a)
+	if err != nil {
+		return
+	}
+	err = s.notifier.Notify(notify.Message{
+		Recipient: recipient,
+		Kind:      kind,
+		Title:     title,
+		Payload:   payload,
+		Channel:   notify.ChannelInbox,
+	})
+	if err != nil {
+		s.logger.Warn(
+			"Failed to queue auction notification",
+			zap.Uint("post_id", a.PostID),
+			zap.String("kind", kind),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,223 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package auction is a generated GoMock package.
+package auction
+
+import (
+	reflect "reflect"
+
+	notify "github.com/TemirB/rest-api-marketplace/internal/notify"
+	post "github.com/TemirB/rest-api-marketplace/internal/post"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockstorage is a mock of storage interface.
+type Mockstorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockstorageMockRecorder
+}
+
+// MockstorageMockRecorder is the mock recorder for Mockstorage.
+type MockstorageMockRecorder struct {
+	mock *Mockstorage
+}
+
+// NewMockstorage creates a new mock instance.
+func NewMockstorage(ctrl *gomock.Controller) *Mockstorage {
+	mock := &Mockstorage{ctrl: ctrl}
+	mock.recorder = &MockstorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstorage) EXPECT() *MockstorageMockRecorder {
+	return m.recorder
+}
+
+// CloseDue mocks base method.
+func (m *Mockstorage) CloseDue() ([]*Closed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseDue")
+	ret0, _ := ret[0].([]*Closed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseDue indicates an expected call of CloseDue.
+func (mr *MockstorageMockRecorder) CloseDue() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseDue", reflect.TypeOf((*Mockstorage)(nil).CloseDue))
+}
+
+// Create mocks base method.
+func (m *Mockstorage) Create(a *Auction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", a)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockstorageMockRecorder) Create(a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*Mockstorage)(nil).Create), a)
+}
+
+// Get mocks base method.
+func (m *Mockstorage) Get(postID uint) (*Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", postID)
+	ret0, _ := ret[0].(*Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockstorageMockRecorder) Get(postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mockstorage)(nil).Get), postID)
+}
+
+// ListBids mocks base method.
+func (m *Mockstorage) ListBids(postID uint, limit int) ([]*Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBids", postID, limit)
+	ret0, _ := ret[0].([]*Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBids indicates an expected call of ListBids.
+func (mr *MockstorageMockRecorder) ListBids(postID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBids", reflect.TypeOf((*Mockstorage)(nil).ListBids), postID, limit)
+}
+
+// PlaceBid mocks base method.
+func (m *Mockstorage) PlaceBid(postID uint, place func(*Auction) (*Bid, error)) (*Auction, *Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceBid", postID, place)
+	ret0, _ := ret[0].(*Auction)
+	ret1, _ := ret[1].(*Bid)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PlaceBid indicates an expected call of PlaceBid.
+func (mr *MockstorageMockRecorder) PlaceBid(postID, place interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceBid", reflect.TypeOf((*Mockstorage)(nil).PlaceBid), postID, place)
+}
+
+// Mockposts is a mock of posts interface.
+type Mockposts struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostsMockRecorder
+}
+
+// MockpostsMockRecorder is the mock recorder for Mockposts.
+type MockpostsMockRecorder struct {
+	mock *Mockposts
+}
+
+// NewMockposts creates a new mock instance.
+func NewMockposts(ctrl *gomock.Controller) *Mockposts {
+	mock := &Mockposts{ctrl: ctrl}
+	mock.recorder = &MockpostsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockposts) EXPECT() *MockpostsMockRecorder {
+	return m.recorder
+}
+
+// GetPostByID mocks base method.
+func (m *Mockposts) GetPostByID(id uint) (*post.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostByID", id)
+	ret0, _ := ret[0].(*post.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostByID indicates an expected call of GetPostByID.
+func (mr *MockpostsMockRecorder) GetPostByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostByID", reflect.TypeOf((*Mockposts)(nil).GetPostByID), id)
+}
+
+// Mocknotifier is a mock of notifier interface.
+type Mocknotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MocknotifierMockRecorder
+}
+
+// MocknotifierMockRecorder is the mock recorder for Mocknotifier.
+type MocknotifierMockRecorder struct {
+	mock *Mocknotifier
+}
+
+// NewMocknotifier creates a new mock instance.
+func NewMocknotifier(ctrl *gomock.Controller) *Mocknotifier {
+	mock := &Mocknotifier{ctrl: ctrl}
+	mock.recorder = &MocknotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocknotifier) EXPECT() *MocknotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *Mocknotifier) Notify(msg notify.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MocknotifierMockRecorder) Notify(msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*Mocknotifier)(nil).Notify), msg)
+}
+
+// Mockblocks is a mock of blocks interface.
+type Mockblocks struct {
+	ctrl     *gomock.Controller
+	recorder *MockblocksMockRecorder
+}
+
+// MockblocksMockRecorder is the mock recorder for Mockblocks.
+type MockblocksMockRecorder struct {
+	mock *Mockblocks
+}
+
+// NewMockblocks creates a new mock instance.
+func NewMockblocks(ctrl *gomock.Controller) *Mockblocks {
+	mock := &Mockblocks{ctrl: ctrl}
+	mock.recorder = &MockblocksMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockblocks) EXPECT() *MockblocksMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *Mockblocks) Check(a, b string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", a, b)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockblocksMockRecorder) Check(a, b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*Mockblocks)(nil).Check), a, b)
+}
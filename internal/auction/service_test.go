@@ -0,0 +1,204 @@
+package auction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/block"
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+func newTestService(repo storage, posts posts, n notifier) *Service {
+	s := NewService(repo, posts, AntiSniping{Window: 2 * time.Minute, Extension: 2 * time.Minute}, zap.NewNop(), WithNotifier(n))
+	s.now = func() time.Time { return testNow }
+	return s
+}
+
+func TestService_CreateAuction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := CreateRequest{
+		StartPrice:   money.MustParse("1000", "RUB"),
+		MinIncrement: money.MustParse("100", "RUB"),
+		EndsAt:       testNow.Add(24 * time.Hour),
+	}
+	fixed := func() *post.Post {
+		return &post.Post{ID: 1, Owner: "bob", Price: money.MustParse("2000", "RUB"), Status: post.StatusActive, ListingType: post.ListingFixed}
+	}
+
+	testCases := []struct {
+		name string
+
+		login      string
+		setupMocks func(repo *Mockstorage, posts *Mockposts)
+
+		expectedErr error
+	}{
+		{
+			name:  "1. Created",
+			login: "bob",
+			setupMocks: func(repo *Mockstorage, posts *Mockposts) {
+				posts.EXPECT().GetPostByID(uint(1)).Return(fixed(), nil)
+				repo.EXPECT().Create(gomock.Any()).DoAndReturn(func(a *Auction) error {
+					assert.Equal(t, "bob", a.Seller)
+					assert.Equal(t, testNow.Add(24*time.Hour), a.EndsAt)
+					return nil
+				})
+			},
+		},
+		{
+			name:  "2. Not_Owner",
+			login: "alice",
+			setupMocks: func(repo *Mockstorage, posts *Mockposts) {
+				posts.EXPECT().GetPostByID(uint(1)).Return(fixed(), nil)
+			},
+			expectedErr: ErrNotOwner,
+		},
+		{
+			name:  "3. Already_Auction",
+			login: "bob",
+			setupMocks: func(repo *Mockstorage, posts *Mockposts) {
+				p := fixed()
+				p.ListingType = post.ListingAuction
+				posts.EXPECT().GetPostByID(uint(1)).Return(p, nil)
+			},
+			expectedErr: ErrAuctionExists,
+		},
+		{
+			name:  "4. Open_Offers",
+			login: "bob",
+			setupMocks: func(repo *Mockstorage, posts *Mockposts) {
+				posts.EXPECT().GetPostByID(uint(1)).Return(fixed(), nil)
+				repo.EXPECT().Create(gomock.Any()).Return(ErrOpenOffers)
+			},
+			expectedErr: ErrOpenOffers,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			posts := NewMockposts(ctrl)
+			tc.setupMocks(repo, posts)
+			service := newTestService(repo, posts, NewMocknotifier(ctrl))
+
+			_, err := service.CreateAuction(1, tc.login, req)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// lockedAuction имитирует Storage.PlaceBid: вызывает place на заблокированном аукционе.
+func lockedAuction(a *Auction) func(postID uint, place func(a *Auction) (*Bid, error)) (*Auction, *Bid, error) {
+	return func(postID uint, place func(a *Auction) (*Bid, error)) (*Auction, *Bid, error) {
+		bid, err := place(a)
+		if err != nil {
+			return nil, nil, err
+		}
+		return a, bid, nil
+	}
+}
+
+func TestService_PlaceBid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("1. Outbid_Notification", func(t *testing.T) {
+		repo := NewMockstorage(ctrl)
+		n := NewMocknotifier(ctrl)
+		service := newTestService(repo, NewMockposts(ctrl), n)
+
+		a := openAuction()
+		current := money.MustParse("1200", "RUB")
+		a.CurrentPrice, a.Leader, a.BidCount = &current, "carol", 1
+		repo.EXPECT().PlaceBid(uint(1), gomock.Any()).DoAndReturn(lockedAuction(a))
+		n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+			assert.Equal(t, "carol", msg.Recipient)
+			assert.Equal(t, KindOutbid, msg.Kind)
+			assert.NotContains(t, string(msg.Payload), "reserve_price")
+			return nil
+		})
+
+		got, bid, err := service.PlaceBid(1, "alice", money.MustParse("1300", "RUB"))
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", got.Leader)
+		assert.Equal(t, money.MustParse("1300", "RUB"), bid.Amount)
+		assert.Nil(t, got.ReservePrice)
+	})
+
+	t.Run("2. First_Bid_Without_Notification", func(t *testing.T) {
+		repo := NewMockstorage(ctrl)
+		service := newTestService(repo, NewMockposts(ctrl), NewMocknotifier(ctrl))
+		repo.EXPECT().PlaceBid(uint(1), gomock.Any()).DoAndReturn(lockedAuction(openAuction()))
+
+		_, _, err := service.PlaceBid(1, "alice", money.MustParse("1000", "RUB"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("3. Too_Low", func(t *testing.T) {
+		repo := NewMockstorage(ctrl)
+		service := newTestService(repo, NewMockposts(ctrl), NewMocknotifier(ctrl))
+		repo.EXPECT().PlaceBid(uint(1), gomock.Any()).DoAndReturn(lockedAuction(openAuction()))
+
+		_, _, err := service.PlaceBid(1, "alice", money.MustParse("10", "RUB"))
+		assert.ErrorIs(t, err, ErrBidTooLow)
+	})
+
+	t.Run("4. Blocked", func(t *testing.T) {
+		repo := NewMockstorage(ctrl)
+		blocks := NewMockblocks(ctrl)
+		service := newTestService(repo, NewMockposts(ctrl), NewMocknotifier(ctrl))
+		WithBlocks(blocks)(service)
+
+		a := openAuction()
+		repo.EXPECT().PlaceBid(uint(1), gomock.Any()).DoAndReturn(lockedAuction(a))
+		blocks.EXPECT().Check("alice", a.Seller).Return(block.ErrBlocked)
+
+		_, _, err := service.PlaceBid(1, "alice", money.MustParse("1000", "RUB"))
+		assert.ErrorIs(t, err, block.ErrBlocked)
+		assert.Zero(t, a.BidCount)
+	})
+}
+
+func TestService_CloseAuctions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	n := NewMocknotifier(ctrl)
+	service := newTestService(repo, NewMockposts(ctrl), n)
+
+	sold := openAuction()
+	price := money.MustParse("1600", "RUB")
+	sold.Status, sold.Winner, sold.CurrentPrice, sold.BidCount = StatusClosed, "alice", &price, 3
+	unsold := openAuction()
+	unsold.PostID, unsold.Seller, unsold.Status = 2, "dave", StatusClosed
+
+	repo.EXPECT().CloseDue().Return([]*Closed{
+		{Auction: sold, Losers: []string{"carol"}},
+		{Auction: unsold},
+	}, nil)
+
+	got := map[string]string{}
+	n.EXPECT().Notify(gomock.Any()).Times(4).DoAndReturn(func(msg notify.Message) error {
+		got[msg.Recipient+"/"+msg.Kind] = msg.Title
+		return nil
+	})
+
+	assert.NoError(t, service.CloseAuctions())
+	assert.Contains(t, got, "alice/"+KindAuctionWon)
+	assert.Contains(t, got, "carol/"+KindAuctionLost)
+	assert.Equal(t, "Auction ended: sold to alice for 1600.00 RUB", got["bob/"+KindAuctionEnded])
+	assert.Equal(t, "Auction ended with no bids", got["dave/"+KindAuctionEnded])
+}
@@ -0,0 +1,361 @@
+package auction
+
+// mockgen  -source=storage.go -destination=storage_mock_test.go -package=auction
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+const auctionColumns = "post_id, seller, currency, start_price, reserve_price, min_increment, current_price," +
+	" leader, bid_count, ends_at, status, winner, created_at, closed_at"
+
+type Repository interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+	Query(query string, args ...any) (*sql.Rows, error)
+	Begin() (*sql.Tx, error)
+}
+
+type Storage struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+func NewStorage(repository Repository, logger *zap.Logger) *Storage {
+	return &Storage{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanAuction читает строку в порядке auctionColumns. Суммы хранятся в NUMERIC, поэтому сканируем их строками.
+func scanAuction(row rowScanner, a *Auction) error {
+	var (
+		currency, start, increment string
+		reserve, current           sql.NullString
+		leader, winner             sql.NullString
+		closedAt                   sql.NullTime
+	)
+	err := row.Scan(
+		&a.PostID, &a.Seller, &currency, &start, &reserve, &increment, &current,
+		&leader, &a.BidCount, &a.EndsAt, &a.Status, &winner, &a.CreatedAt, &closedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	parse := func(s string) (money.Money, error) {
+		d, err := money.ParseDecimal(s)
+		if err != nil {
+			return money.Money{}, errors.Wrap(err, "failed to parse auction amount")
+		}
+		return money.New(d, money.Currency(currency)), nil
+	}
+	parseNull := func(s sql.NullString) (*money.Money, error) {
+		if !s.Valid {
+			return nil, nil
+		}
+		m, err := parse(s.String)
+		return &m, err
+	}
+
+	if a.StartPrice, err = parse(start); err != nil {
+		return err
+	}
+	if a.MinIncrement, err = parse(increment); err != nil {
+		return err
+	}
+	if a.ReservePrice, err = parseNull(reserve); err != nil {
+		return err
+	}
+	if a.CurrentPrice, err = parseNull(current); err != nil {
+		return err
+	}
+	a.Leader = leader.String
+	a.Winner = winner.String
+	a.ClosedAt = nil
+	if closedAt.Valid {
+		a.ClosedAt = &closedAt.Time
+	}
+	a.refreshReserve()
+	return nil
+}
+
+func nullAmount(m *money.Money) any {
+	if m == nil {
+		return nil
+	}
+	return m.Amount().String()
+}
+
+func nullString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// Create в одной транзакции делает пост аукционным и создаёт аукцион. Пост должен быть
+// активным объявлением с фиксированной ценой без открытых предложений, и в продаже должна
+// оставаться ровно одна единица товара: аукцион продаёт один лот.
+// Строка поста блокируется до проверки предложений: offer.Storage.Create берёт ту же блокировку,
+// поэтому предложение не может появиться между проверкой и переводом поста в аукцион.
+func (r *Storage) Create(a *Auction) error {
+	tx, err := r.repository.Begin()
+	if err != nil {
+		return errors.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	var id uint
+	query := `SELECT id FROM posts WHERE id = $1 FOR UPDATE`
+	if err := tx.QueryRow(query, a.PostID).Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return ErrPostNotAvailable
+		}
+		return errors.Errorf("failed to lock post: %v", err)
+	}
+
+	var hasOffers bool
+	query = `SELECT EXISTS(SELECT 1 FROM offers WHERE post_id = $1 AND status IN ('pending', 'countered'))`
+	if err := tx.QueryRow(query, a.PostID).Scan(&hasOffers); err != nil {
+		return errors.Errorf("failed to check open offers: %v", err)
+	}
+	if hasOffers {
+		return ErrOpenOffers
+	}
+
+	query = `
+		UPDATE posts SET listing_type = 'auction'
+		WHERE id = $1 AND owner = $2 AND status = 'active' AND listing_type = 'fixed'
+			AND quantity - quantity_sold = 1
+	`
+	res, err := tx.Exec(query, a.PostID, a.Seller)
+	if err != nil {
+		r.logger.Error("Failed to mark post as auction", zap.Uint("post_id", a.PostID), zap.Error(err))
+		return errors.Errorf("failed to mark post as auction: %v", err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return ErrPostNotAvailable
+	}
+
+	query = `
+		INSERT INTO auctions (post_id, seller, currency, start_price, reserve_price, min_increment, ends_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING status, created_at
+	`
+	err = tx.QueryRow(
+		query,
+		a.PostID,
+		a.Seller,
+		a.StartPrice.Currency(),
+		a.StartPrice.Amount().String(),
+		nullAmount(a.ReservePrice),
+		a.MinIncrement.Amount().String(),
+		a.EndsAt,
+	).Scan(&a.Status, &a.CreatedAt)
+	if err != nil {
+		r.logger.Error("Failed to create auction", zap.Uint("post_id", a.PostID), zap.Error(err))
+		return errors.Errorf("failed to create auction: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Errorf("failed to commit auction: %v", err)
+	}
+	return nil
+}
+
+func (r *Storage) Get(postID uint) (*Auction, error) {
+	query := `SELECT ` + auctionColumns + ` FROM auctions WHERE post_id = $1`
+
+	var a Auction
+	if err := scanAuction(r.repository.QueryRow(query, postID), &a); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAuctionNotFound
+		}
+		r.logger.Error("Failed to get auction", zap.Uint("post_id", postID), zap.Error(err))
+		return nil, errors.Errorf("failed to get auction: %v", err)
+	}
+	return &a, nil
+}
+
+// PlaceBid блокирует строку аукциона (SELECT ... FOR UPDATE), поэтому одновременные ставки
+// выполняются по очереди: place видит актуальную цену и применяет к аукциону ставку,
+// которую затем сохраняем вместе с новым состоянием аукциона.
+func (r *Storage) PlaceBid(postID uint, place func(a *Auction) (*Bid, error)) (*Auction, *Bid, error) {
+	tx, err := r.repository.Begin()
+	if err != nil {
+		return nil, nil, errors.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	// Пока пост скрыт модерацией, ставки не принимаются
+	var a Auction
+	query := `
+		SELECT ` + auctionColumns + ` FROM auctions
+		WHERE post_id = $1 AND NOT EXISTS (SELECT 1 FROM posts WHERE id = $1 AND hidden)
+		FOR UPDATE
+	`
+	if err := scanAuction(tx.QueryRow(query, postID), &a); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, ErrAuctionNotFound
+		}
+		r.logger.Error("Failed to lock auction", zap.Uint("post_id", postID), zap.Error(err))
+		return nil, nil, errors.Errorf("failed to lock auction: %v", err)
+	}
+
+	bid, err := place(&a)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query = `INSERT INTO bids (post_id, bidder, amount, created_at) VALUES ($1, $2, $3, $4) RETURNING id`
+	if err := tx.QueryRow(query, postID, bid.Bidder, bid.Amount.Amount().String(), bid.CreatedAt).Scan(&bid.ID); err != nil {
+		r.logger.Error("Failed to insert bid", zap.Uint("post_id", postID), zap.Error(err))
+		return nil, nil, errors.Errorf("failed to insert bid: %v", err)
+	}
+
+	query = `UPDATE auctions SET current_price = $2, leader = $3, bid_count = $4, ends_at = $5 WHERE post_id = $1`
+	_, err = tx.Exec(query, postID, nullAmount(a.CurrentPrice), nullString(a.Leader), a.BidCount, a.EndsAt)
+	if err != nil {
+		r.logger.Error("Failed to update auction", zap.Uint("post_id", postID), zap.Error(err))
+		return nil, nil, errors.Errorf("failed to update auction: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, errors.Errorf("failed to commit bid: %v", err)
+	}
+	return &a, bid, nil
+}
+
+// ListBids возвращает последние limit ставок, новые сначала.
+func (r *Storage) ListBids(postID uint, limit int) ([]*Bid, error) {
+	query := `
+		SELECT b.id, b.post_id, b.bidder, b.amount, a.currency, b.created_at
+		FROM bids b JOIN auctions a ON a.post_id = b.post_id
+		WHERE b.post_id = $1
+		ORDER BY b.id DESC
+		LIMIT $2
+	`
+	rows, err := r.repository.Query(query, postID, limit)
+	if err != nil {
+		r.logger.Error("Failed to list bids", zap.Uint("post_id", postID), zap.Error(err))
+		return nil, errors.Errorf("failed to list bids: %v", err)
+	}
+	defer rows.Close()
+
+	bids := []*Bid{}
+	for rows.Next() {
+		var (
+			b                Bid
+			amount, currency string
+		)
+		if err := rows.Scan(&b.ID, &b.PostID, &b.Bidder, &amount, &currency, &b.CreatedAt); err != nil {
+			return nil, errors.Errorf("failed to scan bid: %v", err)
+		}
+		d, err := money.ParseDecimal(amount)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse bid amount")
+		}
+		b.Amount = money.New(d, money.Currency(currency))
+		bids = append(bids, &b)
+	}
+	return bids, rows.Err()
+}
+
+// Closed — закрытый аукцион и участники, которые не выиграли.
+type Closed struct {
+	Auction *Auction
+	Losers  []string
+}
+
+// CloseDue закрывает аукционы, у которых вышло время. Победитель — лидер, если резервная цена
+// достигнута; его пост резервируется. Без победителя пост возвращается к фиксированной цене,
+// а аукцион со ставками удаляется, чтобы пост можно было купить или выставить на аукцион заново.
+// Строки, которые сейчас заблокированы ставкой, пропускаются до следующего прохода.
+func (r *Storage) CloseDue() ([]*Closed, error) {
+	tx, err := r.repository.Begin()
+	if err != nil {
+		return nil, errors.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE auctions
+		SET status = 'closed',
+		    closed_at = NOW(),
+		    winner = CASE
+		        WHEN leader IS NOT NULL AND (reserve_price IS NULL OR current_price >= reserve_price) THEN leader
+		    END
+		WHERE post_id IN (
+		    SELECT post_id FROM auctions
+		    WHERE status = 'open' AND ends_at <= NOW()
+		    FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + auctionColumns
+	rows, err := tx.Query(query)
+	if err != nil {
+		r.logger.Error("Failed to close auctions", zap.Error(err))
+		return nil, errors.Errorf("failed to close auctions: %v", err)
+	}
+	var closed []*Closed
+	for rows.Next() {
+		var a Auction
+		if err := scanAuction(rows, &a); err != nil {
+			rows.Close()
+			return nil, errors.Errorf("failed to scan auction: %v", err)
+		}
+		closed = append(closed, &Closed{Auction: &a})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to close auctions: %v", err)
+	}
+
+	for _, c := range closed {
+		if c.Auction.Winner != "" {
+			query := `UPDATE posts SET status = 'reserved', quantity_sold = quantity_sold + 1 WHERE id = $1`
+			if _, err := tx.Exec(query, c.Auction.PostID); err != nil {
+				return nil, errors.Errorf("failed to reserve post: %v", err)
+			}
+		}
+
+		query := `SELECT DISTINCT bidder FROM bids WHERE post_id = $1 AND bidder <> $2`
+		rows, err := tx.Query(query, c.Auction.PostID, c.Auction.Winner)
+		if err != nil {
+			return nil, errors.Errorf("failed to list bidders: %v", err)
+		}
+		for rows.Next() {
+			var login string
+			if err := rows.Scan(&login); err != nil {
+				rows.Close()
+				return nil, errors.Errorf("failed to scan bidder: %v", err)
+			}
+			c.Losers = append(c.Losers, login)
+		}
+		rows.Close()
+
+		if c.Auction.Winner == "" {
+			if _, err := tx.Exec(`UPDATE posts SET listing_type = 'fixed' WHERE id = $1`, c.Auction.PostID); err != nil {
+				return nil, errors.Errorf("failed to return post to fixed price: %v", err)
+			}
+			if _, err := tx.Exec(`DELETE FROM auctions WHERE post_id = $1`, c.Auction.PostID); err != nil {
+				return nil, errors.Errorf("failed to delete closed auction: %v", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Errorf("failed to commit closed auctions: %v", err)
+	}
+	return closed, nil
+}
@@ -104,6 +104,10 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 			zap.String("login", user.Login),
 			zap.Error(err),
 		)
+		if errors.Is(err, ErrBanned) {
+			http.Error(w, "Forbidden: "+err.Error(), http.StatusForbidden)
+			return
+		}
 		http.Error(w, "Unauthorized: "+err.Error(), http.StatusUnauthorized)
 		return
 	}
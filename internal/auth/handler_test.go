@@ -215,6 +215,22 @@ func TestHandler_Login(t *testing.T) {
 
 			expectedStatus: http.StatusUnauthorized,
 		},
+		{
+			name: "Login_Error_Banned",
+
+			method:  http.MethodPost,
+			url:     "/login",
+			reqBody: []byte(`{"login": "testUser", "password": "testPassword"}`),
+
+			setupMocks: func(ctrl *gomock.Controller) *Handler {
+				mockService := NewMockservice(ctrl)
+				mockService.EXPECT().Login("testUser", "testPassword").Return("", ErrBanned)
+
+				return NewHandler(mockService, zap.NewNop())
+			},
+
+			expectedStatus: http.StatusForbidden,
+		},
 	}
 
 	for _, tc := range testCases {
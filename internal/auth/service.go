@@ -19,12 +19,15 @@ var (
 	ErrWrongPassword      = errors.New("wrong password")
 
 	ErrUserExists = errors.New("user already exists")
+
+	ErrBanned = errors.New("user is banned")
 )
 
 type storage interface {
 	Create(user *User) error
 	GetByLogin(login string) (*User, error)
 	Exists(login string) (bool, error)
+	IsBanned(login string) (bool, error)
 }
 type manager interface {
 	GenerateToken(login string) (string, error)
@@ -118,10 +121,25 @@ func (s *Service) Login(login, password string) (string, error) {
 		)
 		return "", ErrWrongPassword
 	}
+	if user.Banned {
+		return "", ErrBanned
+	}
 
 	return s.manager.GenerateToken(login)
 }
 
+// ValidateToken проверяет токен и то, что пользователя не заблокировали после входа.
 func (s *Service) ValidateToken(tokenString string) (string, error) {
-	return s.manager.ValidateToken(tokenString)
+	login, err := s.manager.ValidateToken(tokenString)
+	if err != nil {
+		return "", err
+	}
+	banned, err := s.storage.IsBanned(login)
+	if err != nil {
+		return "", err
+	}
+	if banned {
+		return "", ErrBanned
+	}
+	return login, nil
 }
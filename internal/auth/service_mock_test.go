@@ -77,6 +77,21 @@ func (mr *MockstorageMockRecorder) GetByLogin(login interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByLogin", reflect.TypeOf((*Mockstorage)(nil).GetByLogin), login)
 }
 
+// IsBanned mocks base method.
+func (m *Mockstorage) IsBanned(login string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBanned", login)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBanned indicates an expected call of IsBanned.
+func (mr *MockstorageMockRecorder) IsBanned(login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBanned", reflect.TypeOf((*Mockstorage)(nil).IsBanned), login)
+}
+
 // Mockmanager is a mock of manager interface.
 type Mockmanager struct {
 	ctrl     *gomock.Controller
@@ -206,6 +206,22 @@ func TestService_Login(t *testing.T) {
 
 			expectedError: ErrInvalidCredentials,
 		},
+		{
+			name: "Login_banned",
+
+			login:    "testuser",
+			password: securePWD,
+
+			setupMocks: func(ctrl *gomock.Controller) *Service {
+				storage := NewMockstorage(ctrl)
+				storage.EXPECT().GetByLogin("testuser").Return(&User{Login: "testuser", Password: securePassword, Banned: true}, nil)
+				service := NewService(storage, nil, zap.NewNop())
+
+				return service
+			},
+
+			expectedError: ErrBanned,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -226,3 +242,38 @@ func TestService_Login(t *testing.T) {
 		})
 	}
 }
+
+func TestService_ValidateToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		banned bool
+
+		expectedError error
+	}{
+		{name: "Valid_Token"},
+		{name: "Banned_After_Login", banned: true, expectedError: ErrBanned},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storage := NewMockstorage(ctrl)
+			manager := NewMockmanager(ctrl)
+			service := NewService(storage, manager, zap.NewNop())
+
+			manager.EXPECT().ValidateToken("testToken").Return("testuser", nil)
+			storage.EXPECT().IsBanned("testuser").Return(tc.banned, nil)
+
+			login, err := service.ValidateToken("testToken")
+			if err != tc.expectedError {
+				t.Fatalf("Expected error: %v, but got: %v", tc.expectedError, err)
+			}
+			if err == nil && login != "testuser" {
+				t.Fatalf("Expected login 'testuser', but got: %s", login)
+			}
+		})
+	}
+}
@@ -37,11 +37,11 @@ func (r *Storage) Create(user *User) error {
 }
 
 func (r *Storage) GetByLogin(login string) (*User, error) {
-	query := `SELECT login, password FROM users WHERE login = $1`
+	query := `SELECT login, password, role, banned_at IS NOT NULL FROM users WHERE login = $1`
 	row := r.repository.QueryRow(query, login)
 
 	var user User
-	err := row.Scan(&user.Login, &user.Password)
+	err := row.Scan(&user.Login, &user.Password, &user.Role, &user.Banned)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			r.logger.Debug("User not found", zap.String("login", login))
@@ -64,6 +64,30 @@ func (r *Storage) Exists(login string) (bool, error) {
 	return exists, nil
 }
 
+// GetRole возвращает роль пользователя.
+func (r *Storage) GetRole(login string) (string, error) {
+	var role string
+	err := r.repository.QueryRow(`SELECT role FROM users WHERE login = $1`, login).Scan(&role)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errors.New("user not found")
+		}
+		r.logger.Error("Failed to get user role", zap.String("login", login), zap.Error(err))
+		return "", errors.Errorf("failed to get user role: %v", err)
+	}
+	return role, nil
+}
+
+// IsBanned сообщает, заблокирован ли пользователь.
+func (r *Storage) IsBanned(login string) (bool, error) {
+	var banned bool
+	query := `SELECT EXISTS(SELECT 1 FROM users WHERE login = $1 AND banned_at IS NOT NULL)`
+	if err := r.repository.QueryRow(query, login).Scan(&banned); err != nil {
+		return false, errors.Errorf("failed to check if user is banned: %v", err)
+	}
+	return banned, nil
+}
+
 func (r *Storage) Delete(login string) error {
 	query := `DELETE FROM users WHERE login = $1`
 	_, err := r.repository.Exec(query, login)
@@ -1,8 +1,17 @@
 package auth
 
+// Роли пользователей. Модератор может скрывать чужой контент; роль выдаётся в БД.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+)
+
 type User struct {
 	Login    string
 	Password string
+	Role     string `json:"-"`
+	// Banned — пользователь заблокирован модератором и не может войти
+	Banned bool `json:"-"`
 }
 
 func NewUser(login, password string) *User {
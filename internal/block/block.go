@@ -0,0 +1,11 @@
+package block
+
+import (
+	"errors"
+)
+
+var (
+	ErrBlocked      = errors.New("user is blocked")
+	ErrSelfBlock    = errors.New("cannot block yourself")
+	ErrUserNotFound = errors.New("user not found")
+)
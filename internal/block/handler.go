@@ -0,0 +1,90 @@
+package block
+
+// mockgen  -source=handler.go -destination=handler_mock_test.go -package=block
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+)
+
+type service interface {
+	Block(blocker, blocked string) error
+	Unblock(blocker, blocked string) error
+	ListBlocked(blocker string) ([]string, error)
+}
+
+type Handler struct {
+	service service
+	logger  *zap.Logger
+}
+
+func NewHandler(service service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error, msg string, fields ...zap.Field) {
+	switch {
+	case errors.Is(err, ErrUserNotFound):
+		http.Error(w, "Not Found", http.StatusNotFound)
+	case errors.Is(err, ErrSelfBlock):
+		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+	default:
+		h.logger.Error(msg, append(fields, zap.Error(err))...)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// Blocks обрабатывает GET /me/blocks и PUT/DELETE /me/blocks/{login}.
+func (h *Handler) Blocks(w http.ResponseWriter, r *http.Request) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || len(parts) > 4 || parts[1] != "me" || parts[2] != "blocks" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	if len(parts) == 3 {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		blocked, err := h.service.ListBlocked(login)
+		if err != nil {
+			h.writeError(w, err, "Failed to list blocked users", zap.String("login", login))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(blocked)
+		return
+	}
+
+	target := parts[3]
+	switch r.Method {
+	case http.MethodPut:
+		err = h.service.Block(login, target)
+	case http.MethodDelete:
+		err = h.service.Unblock(login, target)
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		h.writeError(w, err, "Failed to update block list", zap.String("login", login), zap.String("target", target))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
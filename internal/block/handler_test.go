@@ -0,0 +1,60 @@
+package block
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+)
+
+func newRequest(method, path, user string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	if user != "" {
+		req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, user))
+	}
+	return req
+}
+
+func TestHandler_Blocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := NewMockservice(ctrl)
+	handler := NewHandler(mockService, zap.NewNop())
+
+	mockService.EXPECT().Block("alice", "spammer").Return(nil)
+	mockService.EXPECT().Unblock("alice", "spammer").Return(nil)
+	mockService.EXPECT().ListBlocked("alice").Return([]string{"spammer"}, nil)
+	mockService.EXPECT().Block("alice", "ghost").Return(ErrUserNotFound)
+	mockService.EXPECT().Block("alice", "alice").Return(ErrSelfBlock)
+
+	rr := httptest.NewRecorder()
+	handler.Blocks(rr, newRequest(http.MethodPut, "/me/blocks/spammer", "alice"))
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.Blocks(rr, newRequest(http.MethodDelete, "/me/blocks/spammer", "alice"))
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.Blocks(rr, newRequest(http.MethodGet, "/me/blocks", "alice"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `["spammer"]`, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	handler.Blocks(rr, newRequest(http.MethodPut, "/me/blocks/ghost", "alice"))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.Blocks(rr, newRequest(http.MethodPut, "/me/blocks/alice", "alice"))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.Blocks(rr, newRequest(http.MethodGet, "/me/blocks", ""))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
@@ -0,0 +1,57 @@
+package block
+
+// mockgen  -source=service.go -destination=service_mock_test.go -package=block
+
+import (
+	"go.uber.org/zap"
+)
+
+type storage interface {
+	Block(blocker, blocked string) error
+	Unblock(blocker, blocked string) error
+	ListBlocked(blocker string) ([]string, error)
+	IsBlocked(a, b string) (bool, error)
+}
+
+// Service — список блокировок пользователей. Заблокированный не может писать блокирующему,
+// предлагать цену, делать ставки и оставлять отзывы на его посты, а блокирующий не видит
+// его постов и отзывов. Блокировка действует в обе стороны: тот, кто заблокировал, тоже не
+// может начать переписку или сделать предложение.
+type Service struct {
+	repository storage
+	logger     *zap.Logger
+}
+
+func NewService(repository storage, logger *zap.Logger) *Service {
+	return &Service{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+func (s *Service) Block(blocker, blocked string) error {
+	if blocker == blocked {
+		return ErrSelfBlock
+	}
+	return s.repository.Block(blocker, blocked)
+}
+
+func (s *Service) Unblock(blocker, blocked string) error {
+	return s.repository.Unblock(blocker, blocked)
+}
+
+func (s *Service) ListBlocked(blocker string) ([]string, error) {
+	return s.repository.ListBlocked(blocker)
+}
+
+// Check возвращает ErrBlocked, если кто-то из двух пользователей заблокировал другого.
+func (s *Service) Check(a, b string) error {
+	blocked, err := s.repository.IsBlocked(a, b)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrBlocked
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package block
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestService_Block_Self(t *testing.T) {
+	service := NewService(nil, zap.NewNop())
+	assert.ErrorIs(t, service.Block("alice", "alice"), ErrSelfBlock)
+}
+
+func TestService_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	repo.EXPECT().IsBlocked("alice", "bob").Return(false, nil)
+	repo.EXPECT().IsBlocked("alice", "spammer").Return(true, nil)
+	repo.EXPECT().IsBlocked("alice", "carol").Return(false, errors.New("db down"))
+	service := NewService(repo, zap.NewNop())
+
+	assert.NoError(t, service.Check("alice", "bob"))
+	assert.ErrorIs(t, service.Check("alice", "spammer"), ErrBlocked)
+	assert.Error(t, service.Check("alice", "carol"))
+}
@@ -0,0 +1,108 @@
+package block
+
+// mockgen  -source=storage.go -destination=storage_mock_test.go -package=block
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// pgForeignKeyViolation — код ошибки Postgres при нарушении внешнего ключа.
+const pgForeignKeyViolation = "23503"
+
+type Repository interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+type Storage struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+func NewStorage(repository Repository, logger *zap.Logger) *Storage {
+	return &Storage{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+func (r *Storage) Block(blocker, blocked string) error {
+	query := `INSERT INTO user_blocks (blocker, blocked) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	if _, err := r.repository.Exec(query, blocker, blocked); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgForeignKeyViolation {
+			return ErrUserNotFound
+		}
+		r.logger.Error(
+			"Failed to block user",
+			zap.String("blocker", blocker),
+			zap.String("blocked", blocked),
+			zap.Error(err),
+		)
+		return errors.Errorf("failed to block user: %v", err)
+	}
+	return nil
+}
+
+func (r *Storage) Unblock(blocker, blocked string) error {
+	query := `DELETE FROM user_blocks WHERE blocker = $1 AND blocked = $2`
+	if _, err := r.repository.Exec(query, blocker, blocked); err != nil {
+		r.logger.Error(
+			"Failed to unblock user",
+			zap.String("blocker", blocker),
+			zap.String("blocked", blocked),
+			zap.Error(err),
+		)
+		return errors.Errorf("failed to unblock user: %v", err)
+	}
+	return nil
+}
+
+func (r *Storage) ListBlocked(blocker string) ([]string, error) {
+	rows, err := r.repository.Query(`SELECT blocked FROM user_blocks WHERE blocker = $1 ORDER BY created_at`, blocker)
+	if err != nil {
+		r.logger.Error(
+			"Failed to list blocked users",
+			zap.String("blocker", blocker),
+			zap.Error(err),
+		)
+		return nil, errors.Wrap(err, "failed to list blocked users")
+	}
+	defer rows.Close()
+
+	blocked := []string{}
+	for rows.Next() {
+		var login string
+		if err := rows.Scan(&login); err != nil {
+			return nil, errors.Wrap(err, "failed to scan blocked user")
+		}
+		blocked = append(blocked, login)
+	}
+	return blocked, rows.Err()
+}
+
+// IsBlocked сообщает, заблокировал ли кто-то из двух пользователей другого.
+func (r *Storage) IsBlocked(a, b string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM user_blocks
+			WHERE (blocker = $1 AND blocked = $2) OR (blocker = $2 AND blocked = $1)
+		)
+	`
+	var blocked bool
+	if err := r.repository.QueryRow(query, a, b).Scan(&blocked); err != nil {
+		r.logger.Error(
+			"Failed to check block",
+			zap.String("a", a),
+			zap.String("b", b),
+			zap.Error(err),
+		)
+		return false, errors.Errorf("failed to check block: %v", err)
+	}
+	return blocked, nil
+}
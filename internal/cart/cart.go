@@ -0,0 +1,74 @@
+package cart
+
+import (
+	"errors"
+	"time"
+
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+// Коды предупреждений о позициях, изменившихся с момента добавления в корзину.
+const (
+	WarningPriceChanged         = "price_changed"
+	WarningUnavailable          = "unavailable"
+	WarningInsufficientQuantity = "insufficient_quantity"
+)
+
+var (
+	ErrInvalidQuantity      = errors.New("quantity must be at least 1")
+	ErrOwnPost              = errors.New("cannot add your own post to the cart")
+	ErrPostNotAvailable     = errors.New("post is not available for purchase")
+	ErrInsufficientQuantity = errors.New("not enough items in stock")
+	ErrEmptyCart            = errors.New("cart is empty")
+	ErrCartChanged          = errors.New("cart has changed, review it before checkout")
+)
+
+// Item — позиция корзины. Price — цена поста в момент добавления (или последней проверки корзины).
+type Item struct {
+	PostID   uint        `json:"post_id"`
+	Quantity int         `json:"quantity"`
+	Price    money.Money `json:"price"`
+	AddedAt  time.Time   `json:"added_at"`
+
+	Post *post.Post `json:"post,omitempty"`
+}
+
+// Warning сообщает, что позиция изменилась с момента добавления в корзину.
+type Warning struct {
+	PostID  uint   `json:"post_id"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+
+	// Price — текущая цена поста при price_changed
+	Price *money.Money `json:"price,omitempty"`
+	// Available — сколько единиц осталось при insufficient_quantity
+	Available int `json:"available,omitempty"`
+}
+
+// Cart — корзина покупателя. Totals — суммы по доступным позициям в текущих ценах, по одной на валюту.
+type Cart struct {
+	Items    []*Item       `json:"items"`
+	Totals   []money.Money `json:"totals"`
+	Warnings []*Warning    `json:"warnings"`
+}
+
+// check сверяет позицию с текущим состоянием поста; nil — позицию можно купить как есть.
+func check(item *Item, p *post.Post) *Warning {
+	if p == nil || p.Hidden || p.Status != post.StatusActive || p.ListingType != post.ListingFixed {
+		return &Warning{PostID: item.PostID, Code: WarningUnavailable, Message: "post is no longer available"}
+	}
+	if available := p.Available(); available < item.Quantity {
+		return &Warning{
+			PostID:    item.PostID,
+			Code:      WarningInsufficientQuantity,
+			Message:   "only some of the items are left in stock",
+			Available: available,
+		}
+	}
+	if !p.Price.Equal(item.Price) {
+		price := p.Price
+		return &Warning{PostID: item.PostID, Code: WarningPriceChanged, Message: "price has changed", Price: &price}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package cart
+
+// mockgen  -source=handler.go -destination=handler_mock_test.go -package=cart
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/block"
+	"github.com/TemirB/rest-api-marketplace/internal/order"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+)
+
+type service interface {
+	GetCart(login string) (*Cart, error)
+	AddItem(login string, postID uint, quantity int) (*Item, error)
+	RemoveItem(login string, postID uint) error
+	Clear(login string) error
+	Checkout(login string) ([]*order.Order, *Cart, error)
+}
+
+type Handler struct {
+	service service
+	logger  *zap.Logger
+}
+
+func NewHandler(service service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+type addRequest struct {
+	PostID   uint `json:"post_id"`
+	Quantity *int `json:"quantity"`
+}
+
+// writeError переводит ошибки корзины в HTTP-статусы.
+func (h *Handler) writeError(w http.ResponseWriter, err error, msg string, fields ...zap.Field) {
+	switch {
+	case errors.Is(err, post.ErrPostNotFound):
+		http.Error(w, "Not Found", http.StatusNotFound)
+	case errors.Is(err, block.ErrBlocked):
+		http.Error(w, "Forbidden: "+err.Error(), http.StatusForbidden)
+	case errors.Is(err, ErrPostNotAvailable), errors.Is(err, ErrInsufficientQuantity):
+		http.Error(w, "Conflict: "+err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrOwnPost), errors.Is(err, ErrInvalidQuantity), errors.Is(err, ErrEmptyCart):
+		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+	default:
+		h.logger.Error(msg, append(fields, zap.Error(err))...)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// Items обрабатывает /me/cart/items: GET — корзина с предупреждениями, POST — добавить
+// пост (повторное добавление заменяет количество), DELETE — очистить корзину.
+func (h *Handler) Items(w http.ResponseWriter, r *http.Request) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		cart, err := h.service.GetCart(login)
+		if err != nil {
+			h.writeError(w, err, "Failed to get cart", zap.String("login", login))
+			return
+		}
+		writeJSON(w, http.StatusOK, cart)
+	case http.MethodPost:
+		var req addRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.PostID == 0 {
+			http.Error(w, "Bad Request: invalid JSON or post_id", http.StatusBadRequest)
+			return
+		}
+		quantity := 1
+		if req.Quantity != nil {
+			quantity = *req.Quantity
+		}
+
+		item, err := h.service.AddItem(login, req.PostID, quantity)
+		if err != nil {
+			h.writeError(w, err, "Failed to add cart item", zap.String("login", login), zap.Uint("post_id", req.PostID))
+			return
+		}
+		writeJSON(w, http.StatusCreated, item)
+	case http.MethodDelete:
+		if err := h.service.Clear(login); err != nil {
+			h.writeError(w, err, "Failed to clear cart", zap.String("login", login))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Item — DELETE /me/cart/items/{post_id}.
+func (h *Handler) Item(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id64, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/me/cart/items/"), 10, 64)
+	if err != nil {
+		http.Error(w, "Bad Request: invalid post id", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.RemoveItem(login, uint(id64)); err != nil {
+		h.writeError(w, err, "Failed to remove cart item", zap.String("login", login), zap.Uint64("post_id", id64))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Checkout — POST /me/cart/checkout. Возвращает 201 и заказы (по одному на продавца)
+// или 409 и обновлённую корзину, если цены или остатки изменились.
+func (h *Handler) Checkout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	orders, cart, err := h.service.Checkout(login)
+	if errors.Is(err, ErrCartChanged) {
+		writeJSON(w, http.StatusConflict, cart)
+		return
+	}
+	if err != nil {
+		h.writeError(w, err, "Failed to check out cart", zap.String("login", login))
+		return
+	}
+	writeJSON(w, http.StatusCreated, orders)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+
+// Package cart is a generated GoMock package.
+package cart
+
+import (
+	reflect "reflect"
+
+	order "github.com/TemirB/rest-api-marketplace/internal/order"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockservice is a mock of service interface.
+type Mockservice struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceMockRecorder
+}
+
+// MockserviceMockRecorder is the mock recorder for Mockservice.
+type MockserviceMockRecorder struct {
+	mock *Mockservice
+}
+
+// NewMockservice creates a new mock instance.
+func NewMockservice(ctrl *gomock.Controller) *Mockservice {
+	mock := &Mockservice{ctrl: ctrl}
+	mock.recorder = &MockserviceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockservice) EXPECT() *MockserviceMockRecorder {
+	return m.recorder
+}
+
+// AddItem mocks base method.
+func (m *Mockservice) AddItem(login string, postID uint, quantity int) (*Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", login, postID, quantity)
+	ret0, _ := ret[0].(*Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddItem indicates an expected call of AddItem.
+func (mr *MockserviceMockRecorder) AddItem(login, postID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*Mockservice)(nil).AddItem), login, postID, quantity)
+}
+
+// Checkout mocks base method.
+func (m *Mockservice) Checkout(login string) ([]*order.Order, *Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", login)
+	ret0, _ := ret[0].([]*order.Order)
+	ret1, _ := ret[1].(*Cart)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockserviceMockRecorder) Checkout(login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*Mockservice)(nil).Checkout), login)
+}
+
+// Clear mocks base method.
+func (m *Mockservice) Clear(login string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", login)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockserviceMockRecorder) Clear(login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*Mockservice)(nil).Clear), login)
+}
+
+// GetCart mocks base method.
+func (m *Mockservice) GetCart(login string) (*Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCart", login)
+	ret0, _ := ret[0].(*Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCart indicates an expected call of GetCart.
+func (mr *MockserviceMockRecorder) GetCart(login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCart", reflect.TypeOf((*Mockservice)(nil).GetCart), login)
+}
+
+// RemoveItem mocks base method.
+func (m *Mockservice) RemoveItem(login string, postID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveItem", login, postID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveItem indicates an expected call of RemoveItem.
+func (mr *MockserviceMockRecorder) RemoveItem(login, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveItem", reflect.TypeOf((*Mockservice)(nil).RemoveItem), login, postID)
+}
@@ -0,0 +1,188 @@
+package cart
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+	"github.com/TemirB/rest-api-marketplace/internal/order"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+func newRequest(method, path, body, user string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if user != "" {
+		req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, user))
+	}
+	return req
+}
+
+func TestHandler_Items(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		method     string
+		body       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name:   "1. Get",
+			method: http.MethodGet,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetCart("alice").Return(&Cart{}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "2. Add",
+			method: http.MethodPost,
+			body:   `{"post_id":1,"quantity":2}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().AddItem("alice", uint(1), 2).Return(&Item{PostID: 1, Quantity: 2}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "3. Add_Default_Quantity",
+			method: http.MethodPost,
+			body:   `{"post_id":1}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().AddItem("alice", uint(1), 1).Return(&Item{PostID: 1, Quantity: 1}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "4. Add_Sold_Out",
+			method: http.MethodPost,
+			body:   `{"post_id":1}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().AddItem("alice", uint(1), 1).Return(nil, ErrPostNotAvailable)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:   "5. Add_Not_Found",
+			method: http.MethodPost,
+			body:   `{"post_id":1}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().AddItem("alice", uint(1), 1).Return(nil, post.ErrPostNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "6. Add_Missing_Post_ID",
+			method:       http.MethodPost,
+			body:         `{}`,
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "7. Clear",
+			method: http.MethodDelete,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Clear("alice").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:         "8. Wrong_Method",
+			method:       http.MethodPut,
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+			rr := httptest.NewRecorder()
+
+			handler.Items(rr, newRequest(tc.method, "/me/cart/items", tc.body, "alice"))
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestHandler_Item(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := NewMockservice(ctrl)
+	handler := NewHandler(mockService, zap.NewNop())
+
+	mockService.EXPECT().RemoveItem("alice", uint(7)).Return(nil)
+	rr := httptest.NewRecorder()
+	handler.Item(rr, newRequest(http.MethodDelete, "/me/cart/items/7", "", "alice"))
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.Item(rr, newRequest(http.MethodDelete, "/me/cart/items/abc", "", "alice"))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestHandler_Checkout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "1. Created",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Checkout("alice").Return([]*order.Order{{ID: 1}, {ID: 2}}, nil, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "2. Cart_Changed",
+			setupMocks: func(s *Mockservice) {
+				cart := &Cart{Warnings: []*Warning{{PostID: 1, Code: WarningPriceChanged}}}
+				s.EXPECT().Checkout("alice").Return(nil, cart, ErrCartChanged)
+			},
+			expectedCode: http.StatusConflict,
+			expectedBody: `"code":"price_changed"`,
+		},
+		{
+			name: "3. Empty",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Checkout("alice").Return(nil, nil, ErrEmptyCart)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+			rr := httptest.NewRecorder()
+
+			handler.Checkout(rr, newRequest(http.MethodPost, "/me/cart/checkout", "", "alice"))
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			assert.Contains(t, rr.Body.String(), tc.expectedBody)
+		})
+	}
+}
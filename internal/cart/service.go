@@ -0,0 +1,197 @@
+package cart
+
+// mockgen  -source=service.go -destination=service_mock_test.go -package=cart
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/order"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+type storage interface {
+	List(login string) ([]*Item, error)
+	Put(login string, item *Item) error
+	Remove(login string, postID uint) error
+	Clear(login string) error
+}
+
+// posts — источник актуальных цен и остатков; корзина сверяется с ним при каждом чтении.
+type posts interface {
+	GetByID(id uint) (*post.Post, error)
+}
+
+type orders interface {
+	Checkout(buyer string, items []*order.Item) ([]*order.Order, error)
+}
+
+type Service struct {
+	repository storage
+	posts      posts
+	orders     orders
+	logger     *zap.Logger
+}
+
+func NewService(repository storage, posts posts, orders orders, logger *zap.Logger) *Service {
+	return &Service{
+		repository: repository,
+		posts:      posts,
+		orders:     orders,
+		logger:     logger,
+	}
+}
+
+// GetCart возвращает корзину с актуальными постами и предупреждениями об изменившихся позициях.
+func (s *Service) GetCart(login string) (*Cart, error) {
+	items, err := s.repository.List(login)
+	if err != nil {
+		return nil, err
+	}
+
+	cart := &Cart{Items: items, Totals: []money.Money{}, Warnings: []*Warning{}}
+	totals := map[money.Currency]int{}
+	for _, item := range items {
+		p, err := s.posts.GetByID(item.PostID)
+		if err != nil && !errors.Is(err, post.ErrPostNotFound) {
+			return nil, err
+		}
+		item.Post = p
+
+		warning := check(item, p)
+		if warning != nil {
+			cart.Warnings = append(cart.Warnings, warning)
+		}
+		if warning != nil && warning.Code != WarningPriceChanged {
+			continue
+		}
+
+		subtotal, err := p.Price.Times(item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		i, ok := totals[subtotal.Currency()]
+		if !ok {
+			totals[subtotal.Currency()] = len(cart.Totals)
+			cart.Totals = append(cart.Totals, subtotal)
+			continue
+		}
+		if cart.Totals[i], err = cart.Totals[i].Add(subtotal); err != nil {
+			return nil, err
+		}
+	}
+	return cart, nil
+}
+
+// AddItem кладёт в корзину quantity единиц поста по текущей цене. Если пост уже в корзине,
+// количество заменяется.
+func (s *Service) AddItem(login string, postID uint, quantity int) (*Item, error) {
+	if quantity < 1 {
+		return nil, ErrInvalidQuantity
+	}
+	p, err := s.posts.GetByID(postID)
+	if err != nil {
+		return nil, err
+	}
+	if p.Owner == login {
+		return nil, ErrOwnPost
+	}
+	if p.Hidden || p.Status != post.StatusActive || p.ListingType != post.ListingFixed {
+		return nil, ErrPostNotAvailable
+	}
+	if p.Available() < quantity {
+		return nil, ErrInsufficientQuantity
+	}
+
+	item := &Item{PostID: postID, Quantity: quantity, Price: p.Price}
+	if err := s.repository.Put(login, item); err != nil {
+		return nil, err
+	}
+	item.Post = p
+	return item, nil
+}
+
+func (s *Service) RemoveItem(login string, postID uint) error {
+	return s.repository.Remove(login, postID)
+}
+
+func (s *Service) Clear(login string) error {
+	return s.repository.Clear(login)
+}
+
+// Checkout покупает содержимое корзины: по заказу на каждого продавца. Если с момента
+// добавления что-то изменилось, заказы не создаются: корзина обновляется до актуальных
+// цен и остатков и возвращается вместе с ErrCartChanged, чтобы покупатель её проверил.
+func (s *Service) Checkout(login string) ([]*order.Order, *Cart, error) {
+	cart, err := s.GetCart(login)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(cart.Items) == 0 {
+		return nil, nil, ErrEmptyCart
+	}
+	if len(cart.Warnings) > 0 {
+		return nil, s.refresh(login, cart), ErrCartChanged
+	}
+
+	items := make([]*order.Item, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		expected := item.Price
+		items = append(items, &order.Item{PostID: item.PostID, Quantity: item.Quantity, Expected: &expected})
+	}
+
+	orders, err := s.orders.Checkout(login, items)
+	switch {
+	case errors.Is(err, order.ErrPriceChanged), errors.Is(err, order.ErrInsufficientQuantity),
+		errors.Is(err, order.ErrPostNotAvailable), errors.Is(err, post.ErrPostNotFound):
+		// Пост изменился между проверкой корзины и покупкой
+		cart, err := s.GetCart(login)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, s.refresh(login, cart), ErrCartChanged
+	case err != nil:
+		return nil, nil, err
+	}
+
+	if err := s.repository.Clear(login); err != nil {
+		s.logger.Warn("Failed to clear cart after checkout", zap.String("login", login), zap.Error(err))
+	}
+	return orders, nil, nil
+}
+
+// refresh запоминает в корзине текущие цены и урезает количество до остатка, чтобы повторное
+// оформление прошло, если покупатель согласен с изменениями. Недоступные позиции остаются
+// в корзине с предупреждением, пока покупатель их не уберёт.
+func (s *Service) refresh(login string, cart *Cart) *Cart {
+	for _, w := range cart.Warnings {
+		for _, item := range cart.Items {
+			if item.PostID != w.PostID {
+				continue
+			}
+			switch w.Code {
+			case WarningPriceChanged:
+				item.Price = *w.Price
+			case WarningInsufficientQuantity:
+				if w.Available < 1 {
+					continue
+				}
+				item.Quantity = w.Available
+				item.Price = item.Post.Price
+			default:
+				continue
+			}
+			if err := s.repository.Put(login, item); err != nil {
+				s.logger.Warn(
+					"Failed to refresh cart item",
+					zap.String("login", login),
+					zap.Uint("post_id", item.PostID),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+	return cart
+}
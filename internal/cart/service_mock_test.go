@@ -0,0 +1,169 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package cart is a generated GoMock package.
+package cart
+
+import (
+	reflect "reflect"
+
+	order "github.com/TemirB/rest-api-marketplace/internal/order"
+	post "github.com/TemirB/rest-api-marketplace/internal/post"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockstorage is a mock of storage interface.
+type Mockstorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockstorageMockRecorder
+}
+
+// MockstorageMockRecorder is the mock recorder for Mockstorage.
+type MockstorageMockRecorder struct {
+	mock *Mockstorage
+}
+
+// NewMockstorage creates a new mock instance.
+func NewMockstorage(ctrl *gomock.Controller) *Mockstorage {
+	mock := &Mockstorage{ctrl: ctrl}
+	mock.recorder = &MockstorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstorage) EXPECT() *MockstorageMockRecorder {
+	return m.recorder
+}
+
+// Clear mocks base method.
+func (m *Mockstorage) Clear(login string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear", login)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockstorageMockRecorder) Clear(login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*Mockstorage)(nil).Clear), login)
+}
+
+// List mocks base method.
+func (m *Mockstorage) List(login string) ([]*Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", login)
+	ret0, _ := ret[0].([]*Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockstorageMockRecorder) List(login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*Mockstorage)(nil).List), login)
+}
+
+// Put mocks base method.
+func (m *Mockstorage) Put(login string, item *Item) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", login, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockstorageMockRecorder) Put(login, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*Mockstorage)(nil).Put), login, item)
+}
+
+// Remove mocks base method.
+func (m *Mockstorage) Remove(login string, postID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", login, postID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockstorageMockRecorder) Remove(login, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*Mockstorage)(nil).Remove), login, postID)
+}
+
+// Mockposts is a mock of posts interface.
+type Mockposts struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostsMockRecorder
+}
+
+// MockpostsMockRecorder is the mock recorder for Mockposts.
+type MockpostsMockRecorder struct {
+	mock *Mockposts
+}
+
+// NewMockposts creates a new mock instance.
+func NewMockposts(ctrl *gomock.Controller) *Mockposts {
+	mock := &Mockposts{ctrl: ctrl}
+	mock.recorder = &MockpostsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockposts) EXPECT() *MockpostsMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *Mockposts) GetByID(id uint) (*post.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*post.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockpostsMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*Mockposts)(nil).GetByID), id)
+}
+
+// Mockorders is a mock of orders interface.
+type Mockorders struct {
+	ctrl     *gomock.Controller
+	recorder *MockordersMockRecorder
+}
+
+// MockordersMockRecorder is the mock recorder for Mockorders.
+type MockordersMockRecorder struct {
+	mock *Mockorders
+}
+
+// NewMockorders creates a new mock instance.
+func NewMockorders(ctrl *gomock.Controller) *Mockorders {
+	mock := &Mockorders{ctrl: ctrl}
+	mock.recorder = &MockordersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockorders) EXPECT() *MockordersMockRecorder {
+	return m.recorder
+}
+
+// Checkout mocks base method.
+func (m *Mockorders) Checkout(buyer string, items []*order.Item) ([]*order.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Checkout", buyer, items)
+	ret0, _ := ret[0].([]*order.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Checkout indicates an expected call of Checkout.
+func (mr *MockordersMockRecorder) Checkout(buyer, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Checkout", reflect.TypeOf((*Mockorders)(nil).Checkout), buyer, items)
+}
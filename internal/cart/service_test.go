@@ -0,0 +1,257 @@
+package cart
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/order"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+func testPost(id uint, owner, price string, quantity int) *post.Post {
+	return &post.Post{
+		ID:          id,
+		Owner:       owner,
+		Price:       money.MustParse(price, "RUB"),
+		Status:      post.StatusActive,
+		ListingType: post.ListingFixed,
+		Quantity:    quantity,
+	}
+}
+
+func TestService_GetCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	posts := NewMockposts(ctrl)
+	service := NewService(repo, posts, NewMockorders(ctrl), zap.NewNop())
+
+	sold := testPost(3, "carol", "50", 1)
+	sold.Status = post.StatusSold
+
+	repo.EXPECT().List("alice").Return([]*Item{
+		{PostID: 1, Quantity: 2, Price: money.MustParse("100", "RUB")},
+		{PostID: 2, Quantity: 1, Price: money.MustParse("300", "RUB")},
+		{PostID: 3, Quantity: 1, Price: money.MustParse("50", "RUB")},
+		{PostID: 4, Quantity: 5, Price: money.MustParse("10", "RUB")},
+		{PostID: 5, Quantity: 1, Price: money.MustParse("10", "RUB")},
+	}, nil)
+	posts.EXPECT().GetByID(uint(1)).Return(testPost(1, "bob", "100", 5), nil)
+	posts.EXPECT().GetByID(uint(2)).Return(testPost(2, "carol", "250", 1), nil)
+	posts.EXPECT().GetByID(uint(3)).Return(sold, nil)
+	posts.EXPECT().GetByID(uint(4)).Return(testPost(4, "bob", "10", 3), nil)
+	posts.EXPECT().GetByID(uint(5)).Return(nil, post.ErrPostNotFound)
+
+	cart, err := service.GetCart("alice")
+	assert.NoError(t, err)
+	assert.Len(t, cart.Items, 5)
+
+	codes := map[uint]string{}
+	for _, w := range cart.Warnings {
+		codes[w.PostID] = w.Code
+	}
+	assert.Equal(t, map[uint]string{
+		2: WarningPriceChanged,
+		3: WarningUnavailable,
+		4: WarningInsufficientQuantity,
+		5: WarningUnavailable,
+	}, codes)
+	// 2 × 100 + 250 по текущей цене; недоступные позиции не считаются
+	assert.Equal(t, 1, len(cart.Totals))
+	assert.True(t, money.MustParse("450", "RUB").Equal(cart.Totals[0]))
+}
+
+func TestService_AddItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reserved := testPost(1, "bob", "100", 1)
+	reserved.Status = post.StatusReserved
+
+	testCases := []struct {
+		name string
+
+		quantity   int
+		setupMocks func(repo *Mockstorage, posts *Mockposts)
+
+		expectedErr error
+	}{
+		{
+			name:     "1. Added",
+			quantity: 2,
+			setupMocks: func(repo *Mockstorage, posts *Mockposts) {
+				posts.EXPECT().GetByID(uint(1)).Return(testPost(1, "bob", "100", 3), nil)
+				repo.EXPECT().Put("alice", &Item{PostID: 1, Quantity: 2, Price: money.MustParse("100", "RUB")}).Return(nil)
+			},
+		},
+		{
+			name:     "2. Own_Post",
+			quantity: 1,
+			setupMocks: func(repo *Mockstorage, posts *Mockposts) {
+				posts.EXPECT().GetByID(uint(1)).Return(testPost(1, "alice", "100", 3), nil)
+			},
+			expectedErr: ErrOwnPost,
+		},
+		{
+			name:     "3. Not_Available",
+			quantity: 1,
+			setupMocks: func(repo *Mockstorage, posts *Mockposts) {
+				posts.EXPECT().GetByID(uint(1)).Return(reserved, nil)
+			},
+			expectedErr: ErrPostNotAvailable,
+		},
+		{
+			name:     "4. Not_Enough_In_Stock",
+			quantity: 4,
+			setupMocks: func(repo *Mockstorage, posts *Mockposts) {
+				posts.EXPECT().GetByID(uint(1)).Return(testPost(1, "bob", "100", 3), nil)
+			},
+			expectedErr: ErrInsufficientQuantity,
+		},
+		{
+			name:        "5. Zero_Quantity",
+			quantity:    0,
+			setupMocks:  func(repo *Mockstorage, posts *Mockposts) {},
+			expectedErr: ErrInvalidQuantity,
+		},
+		{
+			name:     "6. Post_Not_Found",
+			quantity: 1,
+			setupMocks: func(repo *Mockstorage, posts *Mockposts) {
+				posts.EXPECT().GetByID(uint(1)).Return(nil, post.ErrPostNotFound)
+			},
+			expectedErr: post.ErrPostNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			posts := NewMockposts(ctrl)
+			tc.setupMocks(repo, posts)
+			service := NewService(repo, posts, NewMockorders(ctrl), zap.NewNop())
+
+			item, err := service.AddItem("alice", 1, tc.quantity)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.quantity, item.Quantity)
+		})
+	}
+}
+
+func TestService_Checkout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	items := func() []*Item {
+		return []*Item{
+			{PostID: 1, Quantity: 2, Price: money.MustParse("100", "RUB")},
+			{PostID: 2, Quantity: 1, Price: money.MustParse("300", "RUB")},
+		}
+	}
+
+	testCases := []struct {
+		name string
+
+		setupMocks func(repo *Mockstorage, posts *Mockposts, orders *Mockorders)
+
+		expectedOrders int
+		expectedErr    error
+	}{
+		{
+			name: "1. Split_By_Seller",
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, orders *Mockorders) {
+				repo.EXPECT().List("alice").Return(items(), nil)
+				posts.EXPECT().GetByID(uint(1)).Return(testPost(1, "bob", "100", 5), nil)
+				posts.EXPECT().GetByID(uint(2)).Return(testPost(2, "carol", "300", 1), nil)
+				orders.EXPECT().Checkout("alice", gomock.Any()).DoAndReturn(func(buyer string, items []*order.Item) ([]*order.Order, error) {
+					assert.Len(t, items, 2)
+					assert.True(t, money.MustParse("100", "RUB").Equal(*items[0].Expected))
+					return []*order.Order{{ID: 1, Seller: "bob"}, {ID: 2, Seller: "carol"}}, nil
+				})
+				repo.EXPECT().Clear("alice").Return(nil)
+			},
+			expectedOrders: 2,
+		},
+		{
+			name: "2. Price_Changed_Refreshes_Cart",
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, orders *Mockorders) {
+				repo.EXPECT().List("alice").Return(items(), nil)
+				posts.EXPECT().GetByID(uint(1)).Return(testPost(1, "bob", "100", 5), nil)
+				posts.EXPECT().GetByID(uint(2)).Return(testPost(2, "carol", "250", 1), nil)
+				repo.EXPECT().Put("alice", gomock.Any()).DoAndReturn(func(login string, item *Item) error {
+					assert.Equal(t, uint(2), item.PostID)
+					assert.True(t, money.MustParse("250", "RUB").Equal(item.Price))
+					return nil
+				})
+			},
+			expectedErr: ErrCartChanged,
+		},
+		{
+			name: "3. Stock_Reduced_Clamps_Quantity",
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, orders *Mockorders) {
+				repo.EXPECT().List("alice").Return(items(), nil)
+				posts.EXPECT().GetByID(uint(1)).Return(testPost(1, "bob", "100", 1), nil)
+				posts.EXPECT().GetByID(uint(2)).Return(testPost(2, "carol", "300", 1), nil)
+				repo.EXPECT().Put("alice", gomock.Any()).DoAndReturn(func(login string, item *Item) error {
+					assert.Equal(t, uint(1), item.PostID)
+					assert.Equal(t, 1, item.Quantity)
+					return nil
+				})
+			},
+			expectedErr: ErrCartChanged,
+		},
+		{
+			name: "4. Sold_During_Checkout",
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, orders *Mockorders) {
+				sold := testPost(2, "carol", "300", 1)
+				sold.Status = post.StatusSold
+
+				repo.EXPECT().List("alice").Return(items(), nil).Times(2)
+				posts.EXPECT().GetByID(uint(1)).Return(testPost(1, "bob", "100", 5), nil).Times(2)
+				gomock.InOrder(
+					posts.EXPECT().GetByID(uint(2)).Return(testPost(2, "carol", "300", 1), nil),
+					posts.EXPECT().GetByID(uint(2)).Return(sold, nil),
+				)
+				orders.EXPECT().Checkout("alice", gomock.Any()).Return(nil, order.ErrInsufficientQuantity)
+			},
+			expectedErr: ErrCartChanged,
+		},
+		{
+			name: "5. Empty",
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, orders *Mockorders) {
+				repo.EXPECT().List("alice").Return([]*Item{}, nil)
+			},
+			expectedErr: ErrEmptyCart,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			posts := NewMockposts(ctrl)
+			orders := NewMockorders(ctrl)
+			tc.setupMocks(repo, posts, orders)
+			service := NewService(repo, posts, orders, zap.NewNop())
+
+			created, cart, err := service.Checkout("alice")
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				if tc.expectedErr == ErrCartChanged {
+					assert.NotEmpty(t, cart.Warnings)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, created, tc.expectedOrders)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package cart
+
+// mockgen  -source=storage.go -destination=storage_mock_test.go -package=cart
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+// pgForeignKeyViolation — код ошибки Postgres при нарушении внешнего ключа.
+const pgForeignKeyViolation = "23503"
+
+type Repository interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+type Storage struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+func NewStorage(repository Repository, logger *zap.Logger) *Storage {
+	return &Storage{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// List возвращает позиции корзины в порядке добавления.
+func (r *Storage) List(login string) ([]*Item, error) {
+	query := `
+		SELECT post_id, quantity, price, currency, added_at
+		FROM cart_items WHERE login = $1
+		ORDER BY added_at, post_id
+	`
+	rows, err := r.repository.Query(query, login)
+	if err != nil {
+		r.logger.Error("Failed to list cart", zap.String("login", login), zap.Error(err))
+		return nil, errors.Wrap(err, "failed to list cart")
+	}
+	defer rows.Close()
+
+	items := []*Item{}
+	for rows.Next() {
+		var (
+			item            Item
+			price, currency string
+		)
+		if err := rows.Scan(&item.PostID, &item.Quantity, &price, &currency, &item.AddedAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan cart item")
+		}
+		amount, err := money.ParseDecimal(price)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse cart item price")
+		}
+		item.Price = money.New(amount, money.Currency(currency))
+		items = append(items, &item)
+	}
+	return items, rows.Err()
+}
+
+// Put добавляет позицию или заменяет количество и цену уже лежащей в корзине.
+func (r *Storage) Put(login string, item *Item) error {
+	query := `
+		INSERT INTO cart_items (login, post_id, quantity, price, currency)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (login, post_id) DO UPDATE
+		SET quantity = EXCLUDED.quantity, price = EXCLUDED.price, currency = EXCLUDED.currency
+	`
+	_, err := r.repository.Exec(
+		query,
+		login,
+		item.PostID,
+		item.Quantity,
+		item.Price.Amount().String(),
+		item.Price.Currency(),
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgForeignKeyViolation {
+			return post.ErrPostNotFound
+		}
+		r.logger.Error(
+			"Failed to put cart item",
+			zap.String("login", login),
+			zap.Uint("post_id", item.PostID),
+			zap.Error(err),
+		)
+		return errors.Errorf("failed to put cart item: %v", err)
+	}
+	return nil
+}
+
+// Remove убирает позицию из корзины. Удаление отсутствующей позиции не считается ошибкой.
+func (r *Storage) Remove(login string, postID uint) error {
+	query := `DELETE FROM cart_items WHERE login = $1 AND post_id = $2`
+	if _, err := r.repository.Exec(query, login, postID); err != nil {
+		r.logger.Error(
+			"Failed to remove cart item",
+			zap.String("login", login),
+			zap.Uint("post_id", postID),
+			zap.Error(err),
+		)
+		return errors.Errorf("failed to remove cart item: %v", err)
+	}
+	return nil
+}
+
+// Clear очищает корзину.
+func (r *Storage) Clear(login string) error {
+	if _, err := r.repository.Exec(`DELETE FROM cart_items WHERE login = $1`, login); err != nil {
+		r.logger.Error("Failed to clear cart", zap.String("login", login), zap.Error(err))
+		return errors.Errorf("failed to clear cart: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package chat
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// KindNewMessage — уведомление о новом сообщении в переписке.
+	KindNewMessage = "new_message"
+
+	maxBodyLength       = 2000
+	defaultMessageLimit = 50
+	maxMessageLimit     = 100
+)
+
+var (
+	ErrConversationNotFound = errors.New("conversation not found")
+	ErrNotParticipant       = errors.New("not a participant of the conversation")
+	ErrOwnPost              = errors.New("cannot start a conversation about your own post")
+	ErrInvalidBody          = errors.New("message must be between 1 and 2000 characters")
+)
+
+// Conversation — переписка покупателя с владельцем поста о конкретном посте.
+// На одну пару (пост, покупатель) приходится одна переписка.
+type Conversation struct {
+	ID            uint      `json:"id"`
+	PostID        uint      `json:"post_id"`
+	Buyer         string    `json:"buyer"`
+	Seller        string    `json:"seller"`
+	CreatedAt     time.Time `json:"created_at"`
+	LastMessageAt time.Time `json:"last_message_at"`
+
+	// UnreadCount — число непрочитанных входящих сообщений для текущего пользователя
+	UnreadCount int `json:"unread_count"`
+}
+
+// IsParticipant сообщает, может ли пользователь читать и писать в переписку.
+func (c *Conversation) IsParticipant(login string) bool {
+	return login != "" && (login == c.Buyer || login == c.Seller)
+}
+
+// Counterpart возвращает второго участника переписки.
+func (c *Conversation) Counterpart(login string) string {
+	if login == c.Buyer {
+		return c.Seller
+	}
+	return c.Buyer
+}
+
+type Message struct {
+	ID             uint       `json:"id"`
+	ConversationID uint       `json:"conversation_id"`
+	Sender         string     `json:"sender"`
+	Body           string     `json:"body"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ReadAt         *time.Time `json:"read_at,omitempty"`
+}
+
+// Page — параметры курсорной пагинации сообщений: Before — id самого старого
+// сообщения предыдущей страницы (0 — с последнего сообщения).
+type Page struct {
+	Before uint
+	Limit  int
+}
+
+func (p *Page) normalize() {
+	if p.Limit <= 0 {
+		p.Limit = defaultMessageLimit
+	}
+	if p.Limit > maxMessageLimit {
+		p.Limit = maxMessageLimit
+	}
+}
+
+func validateBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" || utf8.RuneCountInString(body) > maxBodyLength {
+		return "", ErrInvalidBody
+	}
+	return body, nil
+}
@@ -0,0 +1,198 @@
+package chat
+
+// mockgen  -source=handler.go -destination=handler_mock_test.go -package=chat
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/block"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+)
+
+const (
+	defaultConversationLimit = 20
+	maxConversationLimit     = 100
+)
+
+type service interface {
+	OpenConversation(postID uint, buyer, text string) (*Conversation, bool, error)
+	ListConversations(login string, limit, offset int) ([]*Conversation, error)
+	ListMessages(conversationID uint, login string, page Page) ([]*Message, error)
+	SendMessage(conversationID uint, sender, text string) (*Message, error)
+	MarkRead(conversationID uint, login string) error
+}
+
+type Handler struct {
+	service service
+	logger  *zap.Logger
+}
+
+func NewHandler(service service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+type openRequest struct {
+	PostID  uint   `json:"post_id"`
+	Message string `json:"message"`
+}
+
+type messageRequest struct {
+	Body string `json:"body"`
+}
+
+// writeError переводит ошибки переписки в HTTP-статусы.
+func (h *Handler) writeError(w http.ResponseWriter, err error, msg string, fields ...zap.Field) {
+	switch {
+	case errors.Is(err, ErrConversationNotFound), errors.Is(err, post.ErrPostNotFound):
+		http.Error(w, "Not Found", http.StatusNotFound)
+	case errors.Is(err, ErrNotParticipant), errors.Is(err, block.ErrBlocked):
+		http.Error(w, "Forbidden: "+err.Error(), http.StatusForbidden)
+	case errors.Is(err, ErrOwnPost), errors.Is(err, ErrInvalidBody):
+		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+	default:
+		h.logger.Error(msg, append(fields, zap.Error(err))...)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// OpenConversation — POST /conversations. Возвращает 201 для новой переписки и 200 для существующей.
+func (h *Handler) OpenConversation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req openRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.PostID == 0 {
+		http.Error(w, "Bad Request: invalid JSON or post_id", http.StatusBadRequest)
+		return
+	}
+
+	c, created, err := h.service.OpenConversation(req.PostID, login, req.Message)
+	if err != nil {
+		h.writeError(w, err, "Failed to open conversation", zap.Uint("post_id", req.PostID))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(c)
+}
+
+// ListConversations — GET /me/conversations?limit=&offset=.
+func (h *Handler) ListConversations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+	limit, err := strconv.Atoi(q.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultConversationLimit
+	}
+	if limit > maxConversationLimit {
+		limit = maxConversationLimit
+	}
+	offset, err := strconv.Atoi(q.Get("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	conversations, err := h.service.ListConversations(login, limit, offset)
+	if err != nil {
+		h.writeError(w, err, "Failed to list conversations", zap.String("login", login))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(conversations)
+}
+
+// Conversation обрабатывает /conversations/{id}/messages (GET, POST) и /conversations/{id}/read (POST).
+func (h *Handler) Conversation(w http.ResponseWriter, r *http.Request) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) != 4 || parts[1] != "conversations" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	id64, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		http.Error(w, "Bad Request: invalid id", http.StatusBadRequest)
+		return
+	}
+	id := uint(id64)
+
+	switch {
+	case parts[3] == "messages" && r.Method == http.MethodGet:
+		q := r.URL.Query()
+		before, _ := strconv.ParseUint(q.Get("before"), 10, 64)
+		limit, _ := strconv.Atoi(q.Get("limit"))
+
+		messages, err := h.service.ListMessages(id, login, Page{Before: uint(before), Limit: limit})
+		if err != nil {
+			h.writeError(w, err, "Failed to list messages", zap.Uint("conversation_id", id))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(messages)
+
+	case parts[3] == "messages" && r.Method == http.MethodPost:
+		var req messageRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Bad Request: invalid JSON", http.StatusBadRequest)
+			return
+		}
+		m, err := h.service.SendMessage(id, login, req.Body)
+		if err != nil {
+			h.writeError(w, err, "Failed to send message", zap.Uint("conversation_id", id))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(m)
+
+	case parts[3] == "read" && r.Method == http.MethodPost:
+		if err := h.service.MarkRead(id, login); err != nil {
+			h.writeError(w, err, "Failed to mark conversation as read", zap.Uint("conversation_id", id))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case parts[3] == "messages" || parts[3] == "read":
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+
+	default:
+		http.Error(w, "Not Found", http.StatusNotFound)
+	}
+}
@@ -0,0 +1,165 @@
+package chat
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/block"
+	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+)
+
+func newRequest(method, path, body, user string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if user != "" {
+		req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, user))
+	}
+	return req
+}
+
+func TestHandler_OpenConversation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		body       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name: "1. Created",
+			body: `{"post_id":1,"message":"Hi"}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().OpenConversation(uint(1), "alice", "Hi").Return(&Conversation{ID: 7}, true, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "2. Existing",
+			body: `{"post_id":1}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().OpenConversation(uint(1), "alice", "").Return(&Conversation{ID: 7}, false, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "3. Blocked",
+			body: `{"post_id":1}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().OpenConversation(uint(1), "alice", "").Return(nil, false, block.ErrBlocked)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "4. Missing_Post_ID",
+			body:         `{}`,
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+			rr := httptest.NewRecorder()
+
+			handler.OpenConversation(rr, newRequest(http.MethodPost, "/conversations", tc.body, "alice"))
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestHandler_Conversation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		method     string
+		path       string
+		body       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name:   "1. List_Messages_With_Cursor",
+			method: http.MethodGet,
+			path:   "/conversations/7/messages?before=40&limit=10",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().ListMessages(uint(7), "alice", Page{Before: 40, Limit: 10}).Return([]*Message{}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "2. Send_Message",
+			method: http.MethodPost,
+			path:   "/conversations/7/messages",
+			body:   `{"body":"Hello"}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().SendMessage(uint(7), "alice", "Hello").Return(&Message{ID: 1}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "3. Not_Participant",
+			method: http.MethodGet,
+			path:   "/conversations/7/messages",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().ListMessages(uint(7), "alice", Page{}).Return(nil, ErrNotParticipant)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:   "4. Mark_Read",
+			method: http.MethodPost,
+			path:   "/conversations/7/read",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().MarkRead(uint(7), "alice").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "5. Not_Found",
+			method: http.MethodPost,
+			path:   "/conversations/8/read",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().MarkRead(uint(8), "alice").Return(ErrConversationNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "6. Wrong_Method",
+			method:       http.MethodDelete,
+			path:         "/conversations/7/messages",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+			rr := httptest.NewRecorder()
+
+			handler.Conversation(rr, newRequest(tc.method, tc.path, tc.body, "alice"))
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package chat
+
+// mockgen  -source=service.go -destination=service_mock_test.go -package=chat
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+type storage interface {
+	CreateConversation(c *Conversation) (bool, error)
+	GetConversation(id uint) (*Conversation, error)
+	ListConversations(login string, limit, offset int) ([]*Conversation, error)
+	CreateMessage(m *Message) error
+	ListMessages(conversationID uint, page Page) ([]*Message, error)
+	MarkRead(conversationID uint, reader string) error
+}
+
+type posts interface {
+	GetPostByID(id uint) (*post.Post, error)
+}
+
+type notifier interface {
+	Notify(msg notify.Message) error
+}
+
+// blocks запрещает переписку, если кто-то из собеседников заблокировал другого.
+type blocks interface {
+	Check(a, b string) error
+}
+
+type Service struct {
+	repository storage
+	posts      posts
+	notifier   notifier
+	blocks     blocks
+	onMessage  []func(*Conversation, *Message)
+	onOpen     []func(*Conversation)
+	logger     *zap.Logger
+}
+
+type Option func(*Service)
+
+// WithNotifier включает уведомления получателю о новых сообщениях.
+func WithNotifier(notifier notifier) Option {
+	return func(s *Service) {
+		s.notifier = notifier
+	}
+}
+
+// WithBlocks запрещает писать тем, с кем есть блокировка.
+func WithBlocks(blocks blocks) Option {
+	return func(s *Service) {
+		s.blocks = blocks
+	}
+}
+
+// WithOnMessage добавляет обработчик нового сообщения, например для доставки в реальном времени.
+func WithOnMessage(fn func(*Conversation, *Message)) Option {
+	return func(s *Service) {
+		s.onMessage = append(s.onMessage, fn)
+	}
+}
+
+// WithOnOpen добавляет обработчик, который вызывается, когда покупатель впервые пишет по посту.
+func WithOnOpen(fn func(*Conversation)) Option {
+	return func(s *Service) {
+		s.onOpen = append(s.onOpen, fn)
+	}
+}
+
+func NewService(repository storage, posts posts, logger *zap.Logger, opts ...Option) *Service {
+	s := &Service{
+		repository: repository,
+		posts:      posts,
+		logger:     logger,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// OpenConversation открывает переписку покупателя с владельцем поста (или возвращает
+// существующую) и, если text не пуст, отправляет первое сообщение.
+func (s *Service) OpenConversation(postID uint, buyer, text string) (*Conversation, bool, error) {
+	p, err := s.posts.GetPostByID(postID)
+	if err != nil {
+		return nil, false, err
+	}
+	if p.Owner == buyer {
+		return nil, false, ErrOwnPost
+	}
+	if err := s.checkBlocked(buyer, p.Owner); err != nil {
+		return nil, false, err
+	}
+
+	var body string
+	if text != "" {
+		if body, err = validateBody(text); err != nil {
+			return nil, false, err
+		}
+	}
+
+	c := &Conversation{PostID: postID, Buyer: buyer, Seller: p.Owner}
+	created, err := s.repository.CreateConversation(c)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if body != "" {
+		if _, err := s.send(c, buyer, body); err != nil {
+			return nil, false, err
+		}
+	}
+	if created {
+		for _, fn := range s.onOpen {
+			fn(c)
+		}
+	}
+	return c, created, nil
+}
+
+func (s *Service) ListConversations(login string, limit, offset int) ([]*Conversation, error) {
+	return s.repository.ListConversations(login, limit, offset)
+}
+
+// conversationFor возвращает переписку, если login — её участник.
+func (s *Service) conversationFor(id uint, login string) (*Conversation, error) {
+	c, err := s.repository.GetConversation(id)
+	if err != nil {
+		return nil, err
+	}
+	if !c.IsParticipant(login) {
+		return nil, ErrNotParticipant
+	}
+	return c, nil
+}
+
+func (s *Service) ListMessages(conversationID uint, login string, page Page) ([]*Message, error) {
+	if _, err := s.conversationFor(conversationID, login); err != nil {
+		return nil, err
+	}
+	page.normalize()
+	return s.repository.ListMessages(conversationID, page)
+}
+
+func (s *Service) SendMessage(conversationID uint, sender, text string) (*Message, error) {
+	c, err := s.conversationFor(conversationID, sender)
+	if err != nil {
+		return nil, err
+	}
+	body, err := validateBody(text)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkBlocked(sender, c.Counterpart(sender)); err != nil {
+		return nil, err
+	}
+	return s.send(c, sender, body)
+}
+
+// MarkRead отмечает прочитанными входящие сообщения — это и есть отметка о прочтении для собеседника.
+func (s *Service) MarkRead(conversationID uint, login string) error {
+	if _, err := s.conversationFor(conversationID, login); err != nil {
+		return err
+	}
+	return s.repository.MarkRead(conversationID, login)
+}
+
+func (s *Service) checkBlocked(a, b string) error {
+	if s.blocks == nil {
+		return nil
+	}
+	return s.blocks.Check(a, b)
+}
+
+func (s *Service) send(c *Conversation, sender, body string) (*Message, error) {
+	m := &Message{ConversationID: c.ID, Sender: sender, Body: body}
+	if err := s.repository.CreateMessage(m); err != nil {
+		return nil, err
+	}
+	for _, fn := range s.onMessage {
+		fn(c, m)
+	}
+	s.notify(c, m)
+	return m, nil
+}
+
+func (s *Service) notify(c *Conversation, m *Message) {
+	if s.notifier == nil {
+		return
+	}
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return
+	}
+	// Сообщения копятся в дайджест, чтобы активная переписка не заваливала входящие
+	err = s.notifier.Notify(notify.Message{
+		Recipient: c.Counterpart(m.Sender),
+		Kind:      KindNewMessage,
+		Title:     fmt.Sprintf("New message from %s", m.Sender),
+		Payload:   payload,
+		Channel:   notify.ChannelInbox,
+		Digest:    true,
+	})
+	if err != nil {
+		s.logger.Warn(
+			"Failed to queue message notification",
+			zap.Uint("conversation_id", c.ID),
+			zap.Error(err),
+		)
+	}
+}
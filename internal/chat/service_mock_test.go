@@ -0,0 +1,236 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package chat is a generated GoMock package.
+package chat
+
+import (
+	reflect "reflect"
+
+	notify "github.com/TemirB/rest-api-marketplace/internal/notify"
+	post "github.com/TemirB/rest-api-marketplace/internal/post"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockstorage is a mock of storage interface.
+type Mockstorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockstorageMockRecorder
+}
+
+// MockstorageMockRecorder is the mock recorder for Mockstorage.
+type MockstorageMockRecorder struct {
+	mock *Mockstorage
+}
+
+// NewMockstorage creates a new mock instance.
+func NewMockstorage(ctrl *gomock.Controller) *Mockstorage {
+	mock := &Mockstorage{ctrl: ctrl}
+	mock.recorder = &MockstorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstorage) EXPECT() *MockstorageMockRecorder {
+	return m.recorder
+}
+
+// CreateConversation mocks base method.
+func (m *Mockstorage) CreateConversation(c *Conversation) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateConversation", c)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateConversation indicates an expected call of CreateConversation.
+func (mr *MockstorageMockRecorder) CreateConversation(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConversation", reflect.TypeOf((*Mockstorage)(nil).CreateConversation), c)
+}
+
+// CreateMessage mocks base method.
+func (m_2 *Mockstorage) CreateMessage(m *Message) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "CreateMessage", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMessage indicates an expected call of CreateMessage.
+func (mr *MockstorageMockRecorder) CreateMessage(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessage", reflect.TypeOf((*Mockstorage)(nil).CreateMessage), m)
+}
+
+// GetConversation mocks base method.
+func (m *Mockstorage) GetConversation(id uint) (*Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversation", id)
+	ret0, _ := ret[0].(*Conversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversation indicates an expected call of GetConversation.
+func (mr *MockstorageMockRecorder) GetConversation(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversation", reflect.TypeOf((*Mockstorage)(nil).GetConversation), id)
+}
+
+// ListConversations mocks base method.
+func (m *Mockstorage) ListConversations(login string, limit, offset int) ([]*Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConversations", login, limit, offset)
+	ret0, _ := ret[0].([]*Conversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConversations indicates an expected call of ListConversations.
+func (mr *MockstorageMockRecorder) ListConversations(login, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConversations", reflect.TypeOf((*Mockstorage)(nil).ListConversations), login, limit, offset)
+}
+
+// ListMessages mocks base method.
+func (m *Mockstorage) ListMessages(conversationID uint, page Page) ([]*Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMessages", conversationID, page)
+	ret0, _ := ret[0].([]*Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMessages indicates an expected call of ListMessages.
+func (mr *MockstorageMockRecorder) ListMessages(conversationID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessages", reflect.TypeOf((*Mockstorage)(nil).ListMessages), conversationID, page)
+}
+
+// MarkRead mocks base method.
+func (m *Mockstorage) MarkRead(conversationID uint, reader string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", conversationID, reader)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockstorageMockRecorder) MarkRead(conversationID, reader interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*Mockstorage)(nil).MarkRead), conversationID, reader)
+}
+
+// Mockposts is a mock of posts interface.
+type Mockposts struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostsMockRecorder
+}
+
+// MockpostsMockRecorder is the mock recorder for Mockposts.
+type MockpostsMockRecorder struct {
+	mock *Mockposts
+}
+
+// NewMockposts creates a new mock instance.
+func NewMockposts(ctrl *gomock.Controller) *Mockposts {
+	mock := &Mockposts{ctrl: ctrl}
+	mock.recorder = &MockpostsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockposts) EXPECT() *MockpostsMockRecorder {
+	return m.recorder
+}
+
+// GetPostByID mocks base method.
+func (m *Mockposts) GetPostByID(id uint) (*post.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostByID", id)
+	ret0, _ := ret[0].(*post.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostByID indicates an expected call of GetPostByID.
+func (mr *MockpostsMockRecorder) GetPostByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostByID", reflect.TypeOf((*Mockposts)(nil).GetPostByID), id)
+}
+
+// Mocknotifier is a mock of notifier interface.
+type Mocknotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MocknotifierMockRecorder
+}
+
+// MocknotifierMockRecorder is the mock recorder for Mocknotifier.
+type MocknotifierMockRecorder struct {
+	mock *Mocknotifier
+}
+
+// NewMocknotifier creates a new mock instance.
+func NewMocknotifier(ctrl *gomock.Controller) *Mocknotifier {
+	mock := &Mocknotifier{ctrl: ctrl}
+	mock.recorder = &MocknotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocknotifier) EXPECT() *MocknotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *Mocknotifier) Notify(msg notify.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MocknotifierMockRecorder) Notify(msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*Mocknotifier)(nil).Notify), msg)
+}
+
+// Mockblocks is a mock of blocks interface.
+type Mockblocks struct {
+	ctrl     *gomock.Controller
+	recorder *MockblocksMockRecorder
+}
+
+// MockblocksMockRecorder is the mock recorder for Mockblocks.
+type MockblocksMockRecorder struct {
+	mock *Mockblocks
+}
+
+// NewMockblocks creates a new mock instance.
+func NewMockblocks(ctrl *gomock.Controller) *Mockblocks {
+	mock := &Mockblocks{ctrl: ctrl}
+	mock.recorder = &MockblocksMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockblocks) EXPECT() *MockblocksMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *Mockblocks) Check(a, b string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", a, b)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockblocksMockRecorder) Check(a, b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*Mockblocks)(nil).Check), a, b)
+}
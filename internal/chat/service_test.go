@@ -0,0 +1,190 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/block"
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+func TestService_OpenConversation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		buyer      string
+		text       string
+		setupMocks func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier, b *Mockblocks)
+
+		expectedCreated bool
+		expectedErr     error
+	}{
+		{
+			name:  "1. New_Conversation_With_First_Message",
+			buyer: "alice",
+			text:  " Is it still available? ",
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier, b *Mockblocks) {
+				posts.EXPECT().GetPostByID(uint(1)).Return(&post.Post{ID: 1, Owner: "bob"}, nil)
+				b.EXPECT().Check("alice", "bob").Return(nil)
+				repo.EXPECT().CreateConversation(&Conversation{PostID: 1, Buyer: "alice", Seller: "bob"}).
+					DoAndReturn(func(c *Conversation) (bool, error) {
+						c.ID = 7
+						return true, nil
+					})
+				repo.EXPECT().CreateMessage(&Message{ConversationID: 7, Sender: "alice", Body: "Is it still available?"}).Return(nil)
+				n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+					assert.Equal(t, "bob", msg.Recipient)
+					assert.Equal(t, KindNewMessage, msg.Kind)
+					return nil
+				})
+			},
+			expectedCreated: true,
+		},
+		{
+			name:  "2. Own_Post",
+			buyer: "bob",
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier, b *Mockblocks) {
+				posts.EXPECT().GetPostByID(uint(1)).Return(&post.Post{ID: 1, Owner: "bob"}, nil)
+			},
+			expectedErr: ErrOwnPost,
+		},
+		{
+			name:  "3. Blocked",
+			buyer: "alice",
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier, b *Mockblocks) {
+				posts.EXPECT().GetPostByID(uint(1)).Return(&post.Post{ID: 1, Owner: "bob"}, nil)
+				b.EXPECT().Check("alice", "bob").Return(block.ErrBlocked)
+			},
+			expectedErr: block.ErrBlocked,
+		},
+		{
+			name:  "4. Post_Not_Found",
+			buyer: "alice",
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier, b *Mockblocks) {
+				posts.EXPECT().GetPostByID(uint(1)).Return(nil, post.ErrPostNotFound)
+			},
+			expectedErr: post.ErrPostNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			posts := NewMockposts(ctrl)
+			n := NewMocknotifier(ctrl)
+			b := NewMockblocks(ctrl)
+			tc.setupMocks(repo, posts, n, b)
+			var opened []*Conversation
+			service := NewService(repo, posts, zap.NewNop(), WithNotifier(n), WithBlocks(b),
+				WithOnOpen(func(c *Conversation) { opened = append(opened, c) }),
+			)
+
+			c, created, err := service.OpenConversation(1, tc.buyer, tc.text)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Empty(t, opened)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCreated, created)
+			assert.Equal(t, uint(7), c.ID)
+			assert.Equal(t, tc.expectedCreated, len(opened) == 1)
+		})
+	}
+}
+
+func TestService_SendMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	conversation := &Conversation{ID: 7, PostID: 1, Buyer: "alice", Seller: "bob"}
+
+	testCases := []struct {
+		name string
+
+		sender     string
+		body       string
+		setupMocks func(repo *Mockstorage, b *Mockblocks)
+
+		expectedErr error
+	}{
+		{
+			name:   "1. Seller_Replies",
+			sender: "bob",
+			body:   "Yes",
+			setupMocks: func(repo *Mockstorage, b *Mockblocks) {
+				repo.EXPECT().GetConversation(uint(7)).Return(conversation, nil)
+				b.EXPECT().Check("bob", "alice").Return(nil)
+				repo.EXPECT().CreateMessage(&Message{ConversationID: 7, Sender: "bob", Body: "Yes"}).Return(nil)
+			},
+		},
+		{
+			name:   "2. Outsider",
+			sender: "carol",
+			body:   "Hi",
+			setupMocks: func(repo *Mockstorage, b *Mockblocks) {
+				repo.EXPECT().GetConversation(uint(7)).Return(conversation, nil)
+			},
+			expectedErr: ErrNotParticipant,
+		},
+		{
+			name:   "3. Empty_Body",
+			sender: "alice",
+			body:   "   ",
+			setupMocks: func(repo *Mockstorage, b *Mockblocks) {
+				repo.EXPECT().GetConversation(uint(7)).Return(conversation, nil)
+			},
+			expectedErr: ErrInvalidBody,
+		},
+		{
+			name:   "4. Blocked_After_Start",
+			sender: "alice",
+			body:   "Hello?",
+			setupMocks: func(repo *Mockstorage, b *Mockblocks) {
+				repo.EXPECT().GetConversation(uint(7)).Return(conversation, nil)
+				b.EXPECT().Check("alice", "bob").Return(block.ErrBlocked)
+			},
+			expectedErr: block.ErrBlocked,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			b := NewMockblocks(ctrl)
+			tc.setupMocks(repo, b)
+			service := NewService(repo, nil, zap.NewNop(), WithBlocks(b))
+
+			_, err := service.SendMessage(7, tc.sender, tc.body)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_ListMessages_NormalizesPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	service := NewService(repo, nil, zap.NewNop())
+
+	repo.EXPECT().GetConversation(uint(7)).Return(&Conversation{ID: 7, Buyer: "alice", Seller: "bob"}, nil).Times(2)
+	repo.EXPECT().ListMessages(uint(7), Page{Before: 0, Limit: defaultMessageLimit}).Return([]*Message{}, nil)
+	repo.EXPECT().ListMessages(uint(7), Page{Before: 40, Limit: maxMessageLimit}).Return([]*Message{}, nil)
+
+	_, err := service.ListMessages(7, "alice", Page{})
+	assert.NoError(t, err)
+	_, err = service.ListMessages(7, "bob", Page{Before: 40, Limit: 1000})
+	assert.NoError(t, err)
+}
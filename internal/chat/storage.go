@@ -0,0 +1,198 @@
+package chat
+
+// mockgen  -source=storage.go -destination=storage_mock_test.go -package=chat
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const conversationColumns = "c.id, c.post_id, c.buyer, c.seller, c.created_at, c.last_message_at"
+
+type Repository interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+type Storage struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+func NewStorage(repository Repository, logger *zap.Logger) *Storage {
+	return &Storage{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanConversation(row rowScanner, c *Conversation, extra ...any) error {
+	dest := []any{&c.ID, &c.PostID, &c.Buyer, &c.Seller, &c.CreatedAt, &c.LastMessageAt}
+	return row.Scan(append(dest, extra...)...)
+}
+
+// CreateConversation создаёт переписку или, если она уже есть, заполняет c существующей.
+// Возвращает true, если переписка создана.
+func (r *Storage) CreateConversation(c *Conversation) (bool, error) {
+	query := `
+		INSERT INTO conversations (post_id, buyer, seller)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (post_id, buyer) DO NOTHING
+		RETURNING id, created_at, last_message_at
+	`
+	err := r.repository.QueryRow(query, c.PostID, c.Buyer, c.Seller).Scan(&c.ID, &c.CreatedAt, &c.LastMessageAt)
+	if err == nil {
+		return true, nil
+	}
+	if err != sql.ErrNoRows {
+		r.logger.Error(
+			"Failed to create conversation",
+			zap.Uint("post_id", c.PostID),
+			zap.String("buyer", c.Buyer),
+			zap.Error(err),
+		)
+		return false, errors.Errorf("failed to create conversation: %v", err)
+	}
+
+	query = `SELECT ` + conversationColumns + ` FROM conversations c WHERE c.post_id = $1 AND c.buyer = $2`
+	if err := scanConversation(r.repository.QueryRow(query, c.PostID, c.Buyer), c); err != nil {
+		return false, errors.Errorf("failed to get conversation: %v", err)
+	}
+	return false, nil
+}
+
+func (r *Storage) GetConversation(id uint) (*Conversation, error) {
+	query := `SELECT ` + conversationColumns + ` FROM conversations c WHERE c.id = $1`
+
+	var c Conversation
+	if err := scanConversation(r.repository.QueryRow(query, id), &c); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrConversationNotFound
+		}
+		r.logger.Error(
+			"Failed to get conversation",
+			zap.Uint("id", id),
+			zap.Error(err),
+		)
+		return nil, errors.Errorf("failed to get conversation: %v", err)
+	}
+	return &c, nil
+}
+
+// ListConversations возвращает переписки пользователя, сначала с последними сообщениями.
+func (r *Storage) ListConversations(login string, limit, offset int) ([]*Conversation, error) {
+	query := `
+		SELECT ` + conversationColumns + `,
+			(SELECT COUNT(*) FROM messages m
+			 WHERE m.conversation_id = c.id AND m.sender <> $1 AND m.read_at IS NULL) AS unread_count
+		FROM conversations c
+		WHERE c.buyer = $1 OR c.seller = $1
+		ORDER BY c.last_message_at DESC, c.id DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.repository.Query(query, login, limit, offset)
+	if err != nil {
+		r.logger.Error(
+			"Failed to list conversations",
+			zap.String("login", login),
+			zap.Error(err),
+		)
+		return nil, errors.Wrap(err, "failed to list conversations")
+	}
+	defer rows.Close()
+
+	conversations := []*Conversation{}
+	for rows.Next() {
+		var c Conversation
+		if err := scanConversation(rows, &c, &c.UnreadCount); err != nil {
+			return nil, errors.Wrap(err, "failed to scan conversation")
+		}
+		conversations = append(conversations, &c)
+	}
+	return conversations, rows.Err()
+}
+
+// CreateMessage сохраняет сообщение и сдвигает last_message_at переписки.
+func (r *Storage) CreateMessage(m *Message) error {
+	query := `
+		WITH msg AS (
+			INSERT INTO messages (conversation_id, sender, body)
+			VALUES ($1, $2, $3)
+			RETURNING id, created_at
+		), upd AS (
+			UPDATE conversations SET last_message_at = (SELECT created_at FROM msg) WHERE id = $1
+		)
+		SELECT id, created_at FROM msg
+	`
+	err := r.repository.QueryRow(query, m.ConversationID, m.Sender, m.Body).Scan(&m.ID, &m.CreatedAt)
+	if err != nil {
+		r.logger.Error(
+			"Failed to create message",
+			zap.Uint("conversation_id", m.ConversationID),
+			zap.Error(err),
+		)
+		return errors.Errorf("failed to create message: %v", err)
+	}
+	return nil
+}
+
+// ListMessages возвращает страницу сообщений, новые первыми.
+func (r *Storage) ListMessages(conversationID uint, page Page) ([]*Message, error) {
+	query := `
+		SELECT id, conversation_id, sender, body, created_at, read_at
+		FROM messages
+		WHERE conversation_id = $1 AND ($2 = 0 OR id < $2)
+		ORDER BY id DESC
+		LIMIT $3
+	`
+	rows, err := r.repository.Query(query, conversationID, page.Before, page.Limit)
+	if err != nil {
+		r.logger.Error(
+			"Failed to list messages",
+			zap.Uint("conversation_id", conversationID),
+			zap.Error(err),
+		)
+		return nil, errors.Wrap(err, "failed to list messages")
+	}
+	defer rows.Close()
+
+	messages := []*Message{}
+	for rows.Next() {
+		var (
+			m      Message
+			readAt sql.NullTime
+		)
+		if err := rows.Scan(&m.ID, &m.ConversationID, &m.Sender, &m.Body, &m.CreatedAt, &readAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan message")
+		}
+		if readAt.Valid {
+			m.ReadAt = &readAt.Time
+		}
+		messages = append(messages, &m)
+	}
+	return messages, rows.Err()
+}
+
+// MarkRead отмечает прочитанными все входящие для reader сообщения переписки.
+func (r *Storage) MarkRead(conversationID uint, reader string) error {
+	query := `
+		UPDATE messages SET read_at = NOW()
+		WHERE conversation_id = $1 AND sender <> $2 AND read_at IS NULL
+	`
+	if _, err := r.repository.Exec(query, conversationID, reader); err != nil {
+		r.logger.Error(
+			"Failed to mark messages as read",
+			zap.Uint("conversation_id", conversationID),
+			zap.Error(err),
+		)
+		return errors.Errorf("failed to mark messages as read: %v", err)
+	}
+	return nil
+}
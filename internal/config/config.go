@@ -1,8 +1,10 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -17,7 +19,21 @@ type Config struct {
 	DBPassword string
 	DBName     string
 
-	JWT JWTConfig
+	JWT        JWTConfig
+	Exchange   ExchangeConfig
+	Notify     NotifyConfig
+	Stream     StreamConfig
+	Offers     OffersConfig
+	Auction    AuctionConfig
+	Payments   PaymentsConfig
+	Moderation ModerationConfig
+	Content    ContentConfig
+	Duplicates DuplicatesConfig
+	Quota      QuotaConfig
+	RateLimit  RateLimitConfig
+	Promotion  PromotionConfig
+	Analytics  AnalyticsConfig
+	Similar    SimilarConfig
 }
 
 type JWTConfig struct {
@@ -25,6 +41,110 @@ type JWTConfig struct {
 	Expiration int
 }
 
+type ExchangeConfig struct {
+	BaseCurrency string
+	RatesFile    string // если пусто, курсы читаются из таблицы exchange_rates
+	Refresh      int    // интервал обновления курсов в минутах
+}
+
+type NotifyConfig struct {
+	DigestInterval int    // интервал отправки дайджестов в минутах
+	WebhookSecret  string // ключ HMAC-подписи вебхуков; пустой — без подписи
+	WebhookTimeout int    // таймаут запроса вебхука в секундах
+
+	PriceDropThreshold int // снижение цены в процентах, больше которого отправляется уведомление
+}
+
+type StreamConfig struct {
+	Backend    string // memory — один процесс, postgres — рассылка между инстансами через LISTEN/NOTIFY
+	Heartbeat  int    // интервал heartbeat в секундах
+	MaxPerUser int    // одновременных подключений на пользователя
+	Buffer     int    // событий в очереди подключения
+}
+
+type OffersConfig struct {
+	TTL int // сколько часов предложение ждёт ответа
+}
+
+type AuctionConfig struct {
+	SnipeWindow int // ставка за столько секунд до конца продлевает аукцион
+	Extension   int // на сколько секунд от момента ставки продлевается аукцион
+}
+
+type PaymentsConfig struct {
+	WebhookSecret string // ключ HMAC-подписи вебхуков платёжного провайдера, обязателен
+	CallbackURL   string // куда фейковый провайдер присылает вебхуки; пустой — не присылает
+	TTL           int    // сколько минут неоплаченный заказ держит товар; 0 — бессрочно
+}
+
+type ModerationConfig struct {
+	AutoHideReports int // после стольких жалоб пост скрывается до решения модератора
+}
+
+// ContentConfig задаёт действие каждой проверки фильтра контента: reject, flag или allow.
+type ContentConfig struct {
+	BannedWords     string
+	Contacts        string
+	Spam            string
+	DuplicateText   string
+	DictionariesDir string // каталог с дополнительными словарями <язык>.txt; пустой — только встроенные
+	DuplicateWindow int    // за сколько дней искать посты с таким же описанием
+}
+
+type DuplicatesConfig struct {
+	Mode       string // off, mark — сохранить с пометкой duplicate_of, reject — отклонить
+	Similarity int    // с какого сходства текстов в процентах пост считается дублем
+	Window     int    // с постами за сколько последних дней сравнивается новый
+}
+
+// QuotaConfig задаёт лимиты на создание объявлений; 0 — без ограничения.
+// Модераторы не ограничены.
+type QuotaConfig struct {
+	NewAccountDays int // сколько дней после регистрации действуют лимиты New
+	New            QuotaLimits
+	Regular        QuotaLimits
+}
+
+type QuotaLimits struct {
+	Active int // сколько непроданных объявлений может быть одновременно
+	Hourly int // сколько объявлений можно создать за час
+	Daily  int // сколько объявлений можно создать за сутки
+}
+
+type RateLimitConfig struct {
+	Backend string // memory — лимиты одного процесса, postgres — общие для всех инстансов
+	// число своих прокси перед сервером: адрес клиента — запись X-Forwarded-For, добавленная самым
+	// дальним из них; 0 — заголовкам не доверять
+	TrustedProxies int
+	APIKeys        map[string]string // имя клиента → выданный ему API-ключ для ключа лимита api_key
+	Auth           RateLimitGroup
+	Public         RateLimitGroup
+	User           RateLimitGroup
+}
+
+// RateLimitGroup — лимит группы маршрутов.
+type RateLimitGroup struct {
+	Rate  int    // запросов в минуту; 0 — без ограничения
+	Burst int    // сколько запросов можно сделать подряд; 0 — Rate
+	Key   string // ip, login или api_key — по чему различать клиентов
+}
+
+type PromotionConfig struct {
+	FeedSlots []int // позиции ленты (с единицы) для продвигаемых постов; 0 — без рекламных позиций
+}
+
+type AnalyticsConfig struct {
+	ViewWindow    int // в течение скольких минут повторные просмотры одного зрителя не считаются
+	FlushInterval int // как часто счётчики записываются в базу, в секундах
+	Buffer        int // событий в очереди до записи; лишние отбрасываются
+}
+
+type SimilarConfig struct {
+	Limit     int // сколько похожих постов возвращать
+	PriceBand int // на сколько процентов цена похожего поста может отличаться от цены исходного
+	CacheTTL  int // сколько минут хранится выдача для поста; 0 — без кэша
+}
+
 func Load() (*Config, error) {
 	err := godotenv.Load()
 	if err != nil {
@@ -46,6 +166,199 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	ratesRefresh, err := getEnvInt("EXCHANGE_RATES_REFRESH", 60)
+	if err != nil {
+		return nil, err
+	}
+
+	digestInterval, err := getEnvInt("NOTIFY_DIGEST_INTERVAL", 60)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookTimeout, err := getEnvInt("NOTIFY_WEBHOOK_TIMEOUT", 5)
+	if err != nil {
+		return nil, err
+	}
+
+	priceDropThreshold, err := getEnvInt("PRICE_DROP_THRESHOLD", 10)
+	if err != nil {
+		return nil, err
+	}
+
+	streamHeartbeat, err := getEnvInt("STREAM_HEARTBEAT", 25)
+	if err != nil {
+		return nil, err
+	}
+	if streamHeartbeat <= 0 {
+		return nil, errors.New("STREAM_HEARTBEAT must be positive")
+	}
+
+	streamMaxPerUser, err := getEnvInt("STREAM_MAX_PER_USER", 5)
+	if err != nil {
+		return nil, err
+	}
+
+	streamBuffer, err := getEnvInt("STREAM_BUFFER", 64)
+	if err != nil {
+		return nil, err
+	}
+
+	offerTTL, err := getEnvInt("OFFER_TTL", 48)
+	if err != nil {
+		return nil, err
+	}
+
+	auctionSnipeWindow, err := getEnvInt("AUCTION_SNIPE_WINDOW", 120)
+	if err != nil {
+		return nil, err
+	}
+
+	auctionExtension, err := getEnvInt("AUCTION_EXTENSION", 120)
+	if err != nil {
+		return nil, err
+	}
+
+	autoHideReports, err := getEnvInt("MODERATION_AUTO_HIDE_REPORTS", 3)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicateWindow, err := getEnvInt("CONTENT_DUPLICATE_WINDOW", 30)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicatesSimilarity, err := getEnvInt("POST_DUPLICATE_SIMILARITY", 70)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicatesWindow, err := getEnvInt("POST_DUPLICATE_WINDOW", 30)
+	if err != nil {
+		return nil, err
+	}
+
+	quotaNewAccountDays, err := getEnvInt("QUOTA_NEW_ACCOUNT_DAYS", 7)
+	if err != nil {
+		return nil, err
+	}
+
+	quotaNewActive, err := getEnvInt("QUOTA_NEW_ACTIVE", 5)
+	if err != nil {
+		return nil, err
+	}
+
+	quotaNewHourly, err := getEnvInt("QUOTA_NEW_HOURLY", 2)
+	if err != nil {
+		return nil, err
+	}
+
+	quotaNewDaily, err := getEnvInt("QUOTA_NEW_DAILY", 5)
+	if err != nil {
+		return nil, err
+	}
+
+	quotaActive, err := getEnvInt("QUOTA_ACTIVE", 50)
+	if err != nil {
+		return nil, err
+	}
+
+	quotaHourly, err := getEnvInt("QUOTA_HOURLY", 10)
+	if err != nil {
+		return nil, err
+	}
+
+	quotaDaily, err := getEnvInt("QUOTA_DAILY", 30)
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimitTrustedProxies, err := getEnvInt("RATE_LIMIT_TRUSTED_PROXIES", 0)
+	if err != nil {
+		return nil, err
+	}
+	if rateLimitTrustedProxies < 0 {
+		return nil, errors.New("RATE_LIMIT_TRUSTED_PROXIES must not be negative")
+	}
+
+	rateLimitAPIKeys, err := getEnvPairs("RATE_LIMIT_API_KEYS")
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimitAuth, err := getEnvInt("RATE_LIMIT_AUTH", 10)
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimitAuthBurst, err := getEnvInt("RATE_LIMIT_AUTH_BURST", 5)
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimitPublic, err := getEnvInt("RATE_LIMIT_PUBLIC", 300)
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimitPublicBurst, err := getEnvInt("RATE_LIMIT_PUBLIC_BURST", 60)
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimitUser, err := getEnvInt("RATE_LIMIT_USER", 120)
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimitUserBurst, err := getEnvInt("RATE_LIMIT_USER_BURST", 30)
+	if err != nil {
+		return nil, err
+	}
+
+	promotionSlots, err := getEnvInts("PROMOTION_FEED_SLOTS", "1,6,11")
+	if err != nil {
+		return nil, err
+	}
+
+	analyticsWindow, err := getEnvInt("ANALYTICS_VIEW_WINDOW", 30)
+	if err != nil {
+		return nil, err
+	}
+	analyticsFlush, err := getEnvInt("ANALYTICS_FLUSH_INTERVAL", 10)
+	if err != nil {
+		return nil, err
+	}
+	analyticsBuffer, err := getEnvInt("ANALYTICS_BUFFER", 10000)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentTTL, err := getEnvInt("PAYMENT_TTL", 30)
+	if err != nil {
+		return nil, err
+	}
+
+	// Без ключа вебхук об оплате мог бы прислать кто угодно
+	paymentSecret := os.Getenv("PAYMENT_WEBHOOK_SECRET")
+	if paymentSecret == "" {
+		return nil, errors.New("PAYMENT_WEBHOOK_SECRET is required")
+	}
+
+	similarLimit, err := getEnvInt("SIMILAR_LIMIT", 12)
+	if err != nil {
+		return nil, err
+	}
+	similarPriceBand, err := getEnvInt("SIMILAR_PRICE_BAND", 50)
+	if err != nil {
+		return nil, err
+	}
+	similarCacheTTL, err := getEnvInt("SIMILAR_CACHE_TTL", 10)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		AppName:    os.Getenv("APP_NAME"),
 		AppPort:    appPort,
@@ -58,5 +371,151 @@ func Load() (*Config, error) {
 			Secret:     os.Getenv("JWT_SECRET"),
 			Expiration: jwtExpiration,
 		},
+		Exchange: ExchangeConfig{
+			BaseCurrency: getEnv("EXCHANGE_BASE_CURRENCY", "RUB"),
+			RatesFile:    os.Getenv("EXCHANGE_RATES_FILE"),
+			Refresh:      ratesRefresh,
+		},
+		Notify: NotifyConfig{
+			DigestInterval: digestInterval,
+			WebhookSecret:  os.Getenv("NOTIFY_WEBHOOK_SECRET"),
+			WebhookTimeout: webhookTimeout,
+
+			PriceDropThreshold: priceDropThreshold,
+		},
+		Stream: StreamConfig{
+			Backend:    getEnv("STREAM_BACKEND", "memory"),
+			Heartbeat:  streamHeartbeat,
+			MaxPerUser: streamMaxPerUser,
+			Buffer:     streamBuffer,
+		},
+		Offers: OffersConfig{
+			TTL: offerTTL,
+		},
+		Auction: AuctionConfig{
+			SnipeWindow: auctionSnipeWindow,
+			Extension:   auctionExtension,
+		},
+		Payments: PaymentsConfig{
+			WebhookSecret: paymentSecret,
+			CallbackURL:   os.Getenv("PAYMENT_CALLBACK_URL"),
+			TTL:           paymentTTL,
+		},
+		Moderation: ModerationConfig{
+			AutoHideReports: autoHideReports,
+		},
+		Content: ContentConfig{
+			BannedWords:     getEnv("CONTENT_BANNED_WORDS", "reject"),
+			Contacts:        getEnv("CONTENT_CONTACTS", "flag"),
+			Spam:            getEnv("CONTENT_SPAM", "flag"),
+			DuplicateText:   getEnv("CONTENT_DUPLICATE_TEXT", "flag"),
+			DictionariesDir: os.Getenv("CONTENT_DICTIONARIES_DIR"),
+			DuplicateWindow: duplicateWindow,
+		},
+		Duplicates: DuplicatesConfig{
+			Mode:       getEnv("POST_DUPLICATES", "mark"),
+			Similarity: duplicatesSimilarity,
+			Window:     duplicatesWindow,
+		},
+		Quota: QuotaConfig{
+			NewAccountDays: quotaNewAccountDays,
+			New: QuotaLimits{
+				Active: quotaNewActive,
+				Hourly: quotaNewHourly,
+				Daily:  quotaNewDaily,
+			},
+			Regular: QuotaLimits{
+				Active: quotaActive,
+				Hourly: quotaHourly,
+				Daily:  quotaDaily,
+			},
+		},
+		RateLimit: RateLimitConfig{
+			Backend:        getEnv("RATE_LIMIT_BACKEND", "memory"),
+			TrustedProxies: rateLimitTrustedProxies,
+			APIKeys:        rateLimitAPIKeys,
+			Auth: RateLimitGroup{
+				Rate:  rateLimitAuth,
+				Burst: rateLimitAuthBurst,
+				Key:   getEnv("RATE_LIMIT_AUTH_KEY", "ip"),
+			},
+			Public: RateLimitGroup{
+				Rate:  rateLimitPublic,
+				Burst: rateLimitPublicBurst,
+				Key:   getEnv("RATE_LIMIT_PUBLIC_KEY", "login"),
+			},
+			User: RateLimitGroup{
+				Rate:  rateLimitUser,
+				Burst: rateLimitUserBurst,
+				Key:   getEnv("RATE_LIMIT_USER_KEY", "login"),
+			},
+		},
+		Promotion: PromotionConfig{
+			FeedSlots: promotionSlots,
+		},
+		Analytics: AnalyticsConfig{
+			ViewWindow:    analyticsWindow,
+			FlushInterval: analyticsFlush,
+			Buffer:        analyticsBuffer,
+		},
+		Similar: SimilarConfig{
+			Limit:     similarLimit,
+			PriceBand: similarPriceBand,
+			CacheTTL:  similarCacheTTL,
+		},
 	}, nil
 }
+
+// getEnv возвращает значение переменной окружения или def, если она не задана.
+func getEnv(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+	return def
+}
+
+// getEnvInt — то же, что getEnv, но для целых чисел.
+func getEnvInt(key string, def int) (int, error) {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def, nil
+	}
+	return strconv.Atoi(v)
+}
+
+// getEnvInts — то же, что getEnvInt, но для списка целых через запятую.
+func getEnvInts(key, def string) ([]int, error) {
+	v := getEnv(key, def)
+	var res []int
+	for _, part := range strings.Split(v, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, n)
+	}
+	return res, nil
+}
+
+// getEnvPairs читает список пар имя=значение через запятую.
+func getEnvPairs(key string) (map[string]string, error) {
+	res := map[string]string{}
+	for _, part := range strings.Split(getEnv(key, ""), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" || value == "" {
+			// Значение не выводим: это может быть секрет
+			return nil, errors.New(key + " must be a comma-separated list of name=value pairs")
+		}
+		res[name] = value
+	}
+	return res, nil
+}
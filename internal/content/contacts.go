@@ -0,0 +1,56 @@
+package content
+
+import (
+	"regexp"
+	"unicode"
+)
+
+var (
+	urlPattern   = regexp.MustCompile(`(?i)(?:https?://|www\.)\S+|\bt\.me/\S+|\b[a-z0-9][a-z0-9-]*\.(?:ru|su|com|net|org|info|biz|io|me)\b`)
+	emailPattern = regexp.MustCompile(`(?i)\b[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}\b`)
+	// phonePattern находит кандидатов; номером считается кандидат из 10–15 цифр
+	phonePattern = regexp.MustCompile(`\+?\d[\d\s\-().]{8,}\d`)
+)
+
+const (
+	minPhoneDigits = 10
+	maxPhoneDigits = 15
+)
+
+// Contacts ищет в описании ссылки, почту и номера телефонов: продавцы уводят покупателей
+// с площадки, а для связи есть переписка.
+type Contacts struct{}
+
+func NewContacts() *Contacts {
+	return &Contacts{}
+}
+
+func (c *Contacts) Name() string {
+	return "contacts"
+}
+
+func (c *Contacts) Check(doc *Document) ([]Reason, error) {
+	text := doc.Title + "\n" + doc.Description
+
+	var reasons []Reason
+	if emailPattern.MatchString(text) {
+		reasons = append(reasons, Reason{Code: "email", Message: "email addresses are not allowed, use messages instead"})
+		text = emailPattern.ReplaceAllString(text, " ")
+	}
+	if urlPattern.MatchString(text) {
+		reasons = append(reasons, Reason{Code: "url", Message: "links are not allowed in posts"})
+	}
+	for _, candidate := range phonePattern.FindAllString(text, -1) {
+		digits := 0
+		for _, r := range candidate {
+			if unicode.IsDigit(r) {
+				digits++
+			}
+		}
+		if digits >= minPhoneDigits && digits <= maxPhoneDigits {
+			reasons = append(reasons, Reason{Code: "phone", Message: "phone numbers are not allowed, use messages instead"})
+			break
+		}
+	}
+	return reasons, nil
+}
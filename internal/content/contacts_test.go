@@ -0,0 +1,38 @@
+package content
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContacts_Check(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		description string
+
+		expectedCodes []string
+	}{
+		{name: "1. Clean", description: "Продаю велосипед, 21 скорость, рама 19 дюймов"},
+		{name: "2. Link", description: "Больше фото на https://example.com/bike", expectedCodes: []string{"url"}},
+		{name: "3. Bare_Domain", description: "Смотрите на avito.ru", expectedCodes: []string{"url"}},
+		{name: "4. Telegram", description: "Пишите в t.me/seller", expectedCodes: []string{"url"}},
+		{name: "5. Email", description: "Почта seller@example.com", expectedCodes: []string{"email"}},
+		{name: "6. Phone", description: "Звоните +7 (999) 123-45-67", expectedCodes: []string{"phone"}},
+		{name: "7. Price_Is_Not_Phone", description: "Цена 15 000 руб., торг от 12 500"},
+	}
+
+	checker := NewContacts()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reasons, err := checker.Check(&Document{Title: "Велосипед", Description: tc.description})
+			assert.NoError(t, err)
+			var codes []string
+			for _, r := range reasons {
+				codes = append(codes, r.Code)
+			}
+			assert.Equal(t, tc.expectedCodes, codes)
+		})
+	}
+}
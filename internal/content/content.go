@@ -0,0 +1,77 @@
+package content
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Действия при срабатывании проверки.
+const (
+	// ActionReject — пост не сохраняется, клиент получает причины
+	ActionReject = "reject"
+	// ActionFlag — пост сохраняется и попадает в очередь модерации
+	ActionFlag = "flag"
+	// ActionAllow — срабатывание только логируется
+	ActionAllow = "allow"
+)
+
+// ParseAction разбирает действие из конфигурации.
+func ParseAction(s string) (string, error) {
+	switch s {
+	case ActionReject, ActionFlag, ActionAllow:
+		return s, nil
+	}
+	return "", fmt.Errorf("unknown content filter action %q", s)
+}
+
+// Document — проверяемый текст поста.
+type Document struct {
+	// PostID — 0 для нового поста
+	PostID      uint
+	Owner       string
+	Title       string
+	Description string
+}
+
+// Reason — почему сработала проверка.
+type Reason struct {
+	Check   string `json:"check"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Action  string `json:"action"`
+}
+
+// Checker — одна проверка цепочки. Пустой список причин — текст прошёл проверку.
+type Checker interface {
+	Name() string
+	Check(doc *Document) ([]Reason, error)
+}
+
+// Verdict — итог цепочки: самое строгое из действий сработавших проверок.
+type Verdict struct {
+	Action  string   `json:"action"`
+	Reasons []Reason `json:"reasons"`
+}
+
+// normalize приводит текст к нижнему регистру, заменяет ё на е и оставляет только буквы и цифры,
+// разделённые одиночными пробелами.
+func normalize(s string) string {
+	var sb strings.Builder
+	space := true
+	for _, r := range strings.ToLower(s) {
+		if r == 'ё' {
+			r = 'е'
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+			space = false
+			continue
+		}
+		if !space {
+			sb.WriteByte(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
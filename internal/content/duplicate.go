@@ -0,0 +1,55 @@
+package content
+
+// mockgen  -source=duplicate.go -destination=duplicate_mock_test.go -package=content
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// duplicateMinLength — короткие описания («Торг», «Самовывоз») совпадают у всех и не проверяются.
+const duplicateMinLength = 40
+
+type texts interface {
+	FindDescription(description, excludeOwner string, excludePost uint, since time.Time) (uint, error)
+}
+
+// DuplicateText ищет описание, дословно (без учёта регистра и пунктуации) совпадающее
+// с описанием недавнего поста другого продавца: так выглядят мошеннические рассылки
+// и объявления с чужим текстом.
+type DuplicateText struct {
+	texts  texts
+	window time.Duration
+	now    func() time.Time
+}
+
+func NewDuplicateText(texts texts, window time.Duration) *DuplicateText {
+	return &DuplicateText{
+		texts:  texts,
+		window: window,
+		now:    time.Now,
+	}
+}
+
+func (c *DuplicateText) Name() string {
+	return "duplicate_text"
+}
+
+func (c *DuplicateText) Check(doc *Document) ([]Reason, error) {
+	if utf8.RuneCountInString(normalize(doc.Description)) < duplicateMinLength {
+		return nil, nil
+	}
+
+	postID, err := c.texts.FindDescription(doc.Description, doc.Owner, doc.PostID, c.now().Add(-c.window))
+	if err != nil {
+		return nil, err
+	}
+	if postID == 0 {
+		return nil, nil
+	}
+	return []Reason{{
+		Code:    "duplicate_text",
+		Message: fmt.Sprintf("description copies post #%d of another seller", postID),
+	}}, nil
+}
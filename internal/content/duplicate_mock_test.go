@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: duplicate.go
+
+// Package content is a generated GoMock package.
+package content
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mocktexts is a mock of texts interface.
+type Mocktexts struct {
+	ctrl     *gomock.Controller
+	recorder *MocktextsMockRecorder
+}
+
+// MocktextsMockRecorder is the mock recorder for Mocktexts.
+type MocktextsMockRecorder struct {
+	mock *Mocktexts
+}
+
+// NewMocktexts creates a new mock instance.
+func NewMocktexts(ctrl *gomock.Controller) *Mocktexts {
+	mock := &Mocktexts{ctrl: ctrl}
+	mock.recorder = &MocktextsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocktexts) EXPECT() *MocktextsMockRecorder {
+	return m.recorder
+}
+
+// FindDescription mocks base method.
+func (m *Mocktexts) FindDescription(description, excludeOwner string, excludePost uint, since time.Time) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDescription", description, excludeOwner, excludePost, since)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDescription indicates an expected call of FindDescription.
+func (mr *MocktextsMockRecorder) FindDescription(description, excludeOwner, excludePost, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDescription", reflect.TypeOf((*Mocktexts)(nil).FindDescription), description, excludeOwner, excludePost, since)
+}
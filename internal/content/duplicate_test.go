@@ -0,0 +1,78 @@
+package content
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicateText_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 7, 21, 12, 0, 0, 0, time.UTC)
+	scam := "Отдам iPhone 15 Pro бесплатно, оплатите только доставку курьером!"
+
+	testCases := []struct {
+		name string
+
+		doc        *Document
+		setupMocks func(texts *Mocktexts)
+
+		expectedCodes []string
+		expectedErr   error
+	}{
+		{
+			name: "1. Copied_Text",
+			doc:  &Document{Owner: "alice", Title: "iPhone", Description: "ОТДАМ iphone 15 pro бесплатно — оплатите только доставку курьером"},
+			setupMocks: func(texts *Mocktexts) {
+				texts.EXPECT().FindDescription(gomock.Any(), "alice", uint(0), now.Add(-24*time.Hour)).Return(uint(4), nil)
+			},
+			expectedCodes: []string{"duplicate_text"},
+		},
+		{
+			name: "2. No_Duplicate",
+			doc:  &Document{PostID: 4, Owner: "alice", Title: "iPhone", Description: scam},
+			setupMocks: func(texts *Mocktexts) {
+				texts.EXPECT().FindDescription(scam, "alice", uint(4), gomock.Any()).Return(uint(0), nil)
+			},
+		},
+		{
+			name:       "3. Short_Text_Not_Checked",
+			doc:        &Document{Owner: "alice", Title: "Шкаф", Description: "Самовывоз"},
+			setupMocks: func(texts *Mocktexts) {},
+		},
+		{
+			name: "4. Storage_Error",
+			doc:  &Document{Owner: "alice", Title: "iPhone", Description: scam},
+			setupMocks: func(texts *Mocktexts) {
+				texts.EXPECT().FindDescription(scam, "alice", uint(0), gomock.Any()).Return(uint(0), errors.New("db is down"))
+			},
+			expectedErr: errors.New("db is down"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			texts := NewMocktexts(ctrl)
+			tc.setupMocks(texts)
+			checker := NewDuplicateText(texts, 24*time.Hour)
+			checker.now = func() time.Time { return now }
+
+			reasons, err := checker.Check(tc.doc)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			var codes []string
+			for _, r := range reasons {
+				codes = append(codes, r.Code)
+			}
+			assert.Equal(t, tc.expectedCodes, codes)
+		})
+	}
+}
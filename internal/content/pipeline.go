@@ -0,0 +1,67 @@
+package content
+
+import (
+	"go.uber.org/zap"
+)
+
+type step struct {
+	checker Checker
+	action  string
+}
+
+// Pipeline прогоняет текст через цепочку проверок. Проверки не зависят друг от друга:
+// выполняются все, даже если одна уже отклонила пост, чтобы клиент увидел все причины сразу.
+type Pipeline struct {
+	steps  []step
+	logger *zap.Logger
+}
+
+func NewPipeline(logger *zap.Logger) *Pipeline {
+	return &Pipeline{logger: logger}
+}
+
+// Add подключает проверку с действием action (reject, flag или allow).
+func (p *Pipeline) Add(checker Checker, action string) *Pipeline {
+	p.steps = append(p.steps, step{checker: checker, action: action})
+	return p
+}
+
+// Check возвращает вердикт по документу. Сломавшаяся проверка пропускается: из-за недоступной
+// базы посты не должны перестать публиковаться.
+func (p *Pipeline) Check(doc *Document) *Verdict {
+	verdict := &Verdict{Action: ActionAllow, Reasons: []Reason{}}
+	for _, s := range p.steps {
+		reasons, err := s.checker.Check(doc)
+		if err != nil {
+			p.logger.Warn(
+				"Content check failed",
+				zap.String("check", s.checker.Name()),
+				zap.Uint("post_id", doc.PostID),
+				zap.Error(err),
+			)
+			continue
+		}
+		if len(reasons) == 0 {
+			continue
+		}
+		if s.action == ActionAllow {
+			p.logger.Info(
+				"Content check matched",
+				zap.String("check", s.checker.Name()),
+				zap.String("owner", doc.Owner),
+				zap.Any("reasons", reasons),
+			)
+			continue
+		}
+
+		for _, r := range reasons {
+			r.Check = s.checker.Name()
+			r.Action = s.action
+			verdict.Reasons = append(verdict.Reasons, r)
+		}
+		if s.action == ActionReject || verdict.Action == ActionAllow {
+			verdict.Action = s.action
+		}
+	}
+	return verdict
+}
@@ -0,0 +1,95 @@
+package content
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type stubChecker struct {
+	name    string
+	reasons []Reason
+	err     error
+}
+
+func (c *stubChecker) Name() string {
+	return c.name
+}
+
+func (c *stubChecker) Check(doc *Document) ([]Reason, error) {
+	return c.reasons, c.err
+}
+
+func TestPipeline_Check(t *testing.T) {
+	matched := func(name, code string) *stubChecker {
+		return &stubChecker{name: name, reasons: []Reason{{Code: code, Message: code}}}
+	}
+	clean := &stubChecker{name: "clean"}
+	broken := &stubChecker{name: "broken", err: errors.New("db is down")}
+
+	testCases := []struct {
+		name string
+
+		setup func(p *Pipeline)
+
+		expectedAction string
+		expectedCodes  []string
+	}{
+		{
+			name:           "1. Nothing_Matched",
+			setup:          func(p *Pipeline) { p.Add(clean, ActionReject) },
+			expectedAction: ActionAllow,
+			expectedCodes:  []string{},
+		},
+		{
+			name: "2. Flag",
+			setup: func(p *Pipeline) {
+				p.Add(clean, ActionReject).Add(matched("spam", "caps"), ActionFlag)
+			},
+			expectedAction: ActionFlag,
+			expectedCodes:  []string{"spam/caps"},
+		},
+		{
+			name: "3. Reject_Wins_And_Keeps_All_Reasons",
+			setup: func(p *Pipeline) {
+				p.Add(matched("spam", "caps"), ActionFlag).
+					Add(matched("banned_words", "banned_word"), ActionReject).
+					Add(matched("contacts", "url"), ActionFlag)
+			},
+			expectedAction: ActionReject,
+			expectedCodes:  []string{"spam/caps", "banned_words/banned_word", "contacts/url"},
+		},
+		{
+			name:           "4. Allow_Only_Logs",
+			setup:          func(p *Pipeline) { p.Add(matched("contacts", "phone"), ActionAllow) },
+			expectedAction: ActionAllow,
+			expectedCodes:  []string{},
+		},
+		{
+			name: "5. Broken_Check_Skipped",
+			setup: func(p *Pipeline) {
+				p.Add(broken, ActionReject).Add(matched("spam", "repetition"), ActionFlag)
+			},
+			expectedAction: ActionFlag,
+			expectedCodes:  []string{"spam/repetition"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewPipeline(zap.NewNop())
+			tc.setup(p)
+
+			verdict := p.Check(&Document{Owner: "alice", Title: "Bike"})
+
+			assert.Equal(t, tc.expectedAction, verdict.Action)
+			codes := []string{}
+			for _, r := range verdict.Reasons {
+				codes = append(codes, r.Check+"/"+r.Code)
+			}
+			assert.Equal(t, tc.expectedCodes, codes)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package content
+
+// Similarity возвращает коэффициент Жаккара по триграммам символов нормализованных текстов:
+// 1 — тексты совпадают с точностью до регистра и пунктуации, 0 — общих триграмм нет.
+// Триграммы устойчивы к мелким правкам вроде опечаток и перестановки слов.
+func Similarity(a, b string) float64 {
+	ta, tb := trigrams(normalize(a)), trigrams(normalize(b))
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	common := 0
+	for t := range ta {
+		if _, ok := tb[t]; ok {
+			common++
+		}
+	}
+	return float64(common) / float64(len(ta)+len(tb)-common)
+}
+
+// trigrams разбивает текст на триграммы символов; края слов дополняются пробелами,
+// чтобы короткие слова тоже давали триграммы.
+func trigrams(s string) map[string]struct{} {
+	set := map[string]struct{}{}
+	if s == "" {
+		return set
+	}
+	runes := []rune(" " + s + " ")
+	for i := 0; i+3 <= len(runes); i++ {
+		set[string(runes[i:i+3])] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,30 @@
+package content
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarity(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		a, b string
+
+		min, max float64
+	}{
+		{name: "1. Same_Text_Different_Case", a: "iPhone 13, 128 ГБ!", b: "IPHONE 13 128 гб", min: 1, max: 1},
+		{name: "2. Small_Edit", a: "Продаю iPhone 13 128 ГБ, отличное состояние", b: "Продаю iPhone 13 128 ГБ в отличном состоянии", min: 0.6, max: 0.99},
+		{name: "3. Different", a: "Велосипед горный", b: "Диван угловой", min: 0, max: 0.1},
+		{name: "4. Empty", a: "", b: "Диван", min: 0, max: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := Similarity(tc.a, tc.b)
+			assert.GreaterOrEqual(t, s, tc.min)
+			assert.LessOrEqual(t, s, tc.max)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package content
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// capsMinLetters — короче этого текст на капс не проверяется: «NIKE AIR» — нормальный заголовок
+	capsMinLetters = 20
+	capsMaxShare   = 0.7
+
+	// repeatRun — столько одинаковых символов подряд («!!!!!!», «ооооочень») считается спамом
+	repeatRun = 6
+
+	// stuffingMinWords и stuffingMaxShare — одно слово не может занимать больше трети длинного текста
+	stuffingMinWords = 12
+	stuffingMaxShare = 0.33
+)
+
+// Spam — эвристики спама: текст капсом, длинные повторы символов и набивка ключевыми словами.
+type Spam struct{}
+
+func NewSpam() *Spam {
+	return &Spam{}
+}
+
+func (c *Spam) Name() string {
+	return "spam"
+}
+
+func (c *Spam) Check(doc *Document) ([]Reason, error) {
+	text := doc.Title + " " + doc.Description
+
+	var reasons []Reason
+	if excessiveCaps(text) {
+		reasons = append(reasons, Reason{Code: "caps", Message: "too much text in capital letters"})
+	}
+	if repeatedRun(text) {
+		reasons = append(reasons, Reason{Code: "repetition", Message: "too many repeated characters"})
+	}
+	if keywordStuffing(text) {
+		reasons = append(reasons, Reason{Code: "keyword_stuffing", Message: "the same word is repeated too many times"})
+	}
+	return reasons, nil
+}
+
+func excessiveCaps(text string) bool {
+	letters, upper := 0, 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.IsUpper(r) {
+			upper++
+		}
+	}
+	return letters >= capsMinLetters && float64(upper)/float64(letters) > capsMaxShare
+}
+
+func repeatedRun(text string) bool {
+	var (
+		prev rune
+		run  int
+	)
+	for _, r := range strings.ToLower(text) {
+		if r == prev && !unicode.IsSpace(r) && !unicode.IsDigit(r) {
+			run++
+			if run >= repeatRun {
+				return true
+			}
+			continue
+		}
+		prev, run = r, 1
+	}
+	return false
+}
+
+func keywordStuffing(text string) bool {
+	words := strings.Fields(normalize(text))
+	if len(words) < stuffingMinWords {
+		return false
+	}
+	counts := map[string]int{}
+	for _, w := range words {
+		// Предлоги и союзы повторяются в любом тексте
+		if len([]rune(w)) < 3 {
+			continue
+		}
+		counts[w]++
+		if float64(counts[w])/float64(len(words)) > stuffingMaxShare {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package content
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpam_Check(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		title       string
+		description string
+
+		expectedCodes []string
+	}{
+		{name: "1. Clean", title: "NIKE Air Max", description: "Размер 42, носил один сезон"},
+		{name: "2. Caps", title: "СРОЧНО ПРОДАМ", description: "ОТДАМ ЗА КОПЕЙКИ ТОЛЬКО СЕГОДНЯ", expectedCodes: []string{"caps"}},
+		{name: "3. Repetition", title: "Дёшево", description: "Лучшая цена!!!!!!!", expectedCodes: []string{"repetition"}},
+		{
+			name:          "4. Keyword_Stuffing",
+			title:         "iphone",
+			description:   "iphone iphone iphone apple iphone купить iphone дешево iphone телефон iphone смартфон",
+			expectedCodes: []string{"keyword_stuffing"},
+		},
+		{name: "5. Long_Number_Is_Fine", title: "Купюра", description: "Номер 10000000 серии АА"},
+	}
+
+	checker := NewSpam()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reasons, err := checker.Check(&Document{Title: tc.title, Description: tc.description})
+			assert.NoError(t, err)
+			var codes []string
+			for _, r := range reasons {
+				codes = append(codes, r.Code)
+			}
+			assert.Equal(t, tc.expectedCodes, codes)
+		})
+	}
+}
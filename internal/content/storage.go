@@ -0,0 +1,50 @@
+package content
+
+// mockgen  -source=storage.go -destination=storage_mock_test.go -package=content
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type Repository interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+type Storage struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+func NewStorage(repository Repository, logger *zap.Logger) *Storage {
+	return &Storage{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// FindDescription возвращает id самого нового видимого поста другого продавца, созданного после since,
+// с тем же нормализованным описанием, или 0, если такого нет. Описания сравниваются по хешу
+// description_hash (см. normalize_text в migrations/init.sql), поэтому поиск идёт по индексу.
+func (r *Storage) FindDescription(description, excludeOwner string, excludePost uint, since time.Time) (uint, error) {
+	query := `
+		SELECT id FROM posts
+		WHERE description_hash = md5(normalize_text($1))
+			AND owner <> $2 AND id <> $3 AND created_at >= $4 AND NOT hidden
+		ORDER BY id DESC
+		LIMIT 1
+	`
+	var id uint
+	err := r.repository.QueryRow(query, description, excludeOwner, excludePost, since).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		r.logger.Error("Failed to find duplicate description", zap.Error(err))
+		return 0, errors.Errorf("failed to find duplicate description: %v", err)
+	}
+	return id, nil
+}
@@ -0,0 +1,146 @@
+package content
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+//go:embed dictionaries/*.txt
+var builtinDictionaries embed.FS
+
+// Dictionary — запрещённые слова одного языка. Слово со звёздочкой на конце совпадает
+// со всеми словами, которые с него начинаются.
+type Dictionary struct {
+	Lang     string
+	words    map[string]bool
+	prefixes []string
+}
+
+// ParseDictionary читает словарь: одно слово на строку, строки с # — комментарии.
+func ParseDictionary(lang string, r io.Reader) (*Dictionary, error) {
+	d := &Dictionary{Lang: lang, words: map[string]bool{}}
+	if err := d.read(r); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *Dictionary) read(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if prefix, ok := strings.CutSuffix(line, "*"); ok {
+			if prefix = normalize(prefix); prefix != "" {
+				d.prefixes = append(d.prefixes, prefix)
+			}
+			continue
+		}
+		if word := normalize(line); word != "" {
+			d.words[word] = true
+		}
+	}
+	return scanner.Err()
+}
+
+// match сообщает, запрещено ли слово.
+func (d *Dictionary) match(word string) bool {
+	if d.words[word] {
+		return true
+	}
+	for _, p := range d.prefixes {
+		if strings.HasPrefix(word, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadDictionaries возвращает встроенные словари, дополненные файлами <язык>.txt из dir.
+// Пустой dir — только встроенные словари.
+func LoadDictionaries(dir string) ([]*Dictionary, error) {
+	byLang := map[string]*Dictionary{}
+	load := func(fsys fs.FS, root string) error {
+		files, err := fs.Glob(fsys, path.Join(root, "*.txt"))
+		if err != nil {
+			return err
+		}
+		for _, name := range files {
+			f, err := fsys.Open(name)
+			if err != nil {
+				return err
+			}
+			lang := strings.TrimSuffix(path.Base(name), ".txt")
+			d, ok := byLang[lang]
+			if !ok {
+				d = &Dictionary{Lang: lang, words: map[string]bool{}}
+				byLang[lang] = d
+			}
+			err = d.read(f)
+			f.Close()
+			if err != nil {
+				return fmt.Errorf("failed to read dictionary %s: %w", name, err)
+			}
+		}
+		return nil
+	}
+
+	if err := load(builtinDictionaries, "dictionaries"); err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		if err := load(os.DirFS(dir), "."); err != nil {
+			return nil, err
+		}
+	}
+
+	dicts := make([]*Dictionary, 0, len(byLang))
+	for _, d := range byLang {
+		dicts = append(dicts, d)
+	}
+	sort.Slice(dicts, func(i, j int) bool { return dicts[i].Lang < dicts[j].Lang })
+	return dicts, nil
+}
+
+// BannedWords ищет в заголовке и описании слова из словарей запрещённых товаров.
+type BannedWords struct {
+	dicts []*Dictionary
+}
+
+func NewBannedWords(dicts []*Dictionary) *BannedWords {
+	return &BannedWords{dicts: dicts}
+}
+
+func (c *BannedWords) Name() string {
+	return "banned_words"
+}
+
+func (c *BannedWords) Check(doc *Document) ([]Reason, error) {
+	var reasons []Reason
+	seen := map[string]bool{}
+	for _, word := range strings.Fields(normalize(doc.Title + " " + doc.Description)) {
+		if seen[word] {
+			continue
+		}
+		for _, d := range c.dicts {
+			if d.match(word) {
+				seen[word] = true
+				reasons = append(reasons, Reason{
+					Code:    "banned_word",
+					Message: fmt.Sprintf("%q is not allowed (%s)", word, d.Lang),
+				})
+				break
+			}
+		}
+	}
+	return reasons, nil
+}
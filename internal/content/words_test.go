@@ -0,0 +1,78 @@
+package content
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBannedWords_Check(t *testing.T) {
+	ru, err := ParseDictionary("ru", strings.NewReader("# комментарий\nнаркотик*\nспайс\n"))
+	require.NoError(t, err)
+	en, err := ParseDictionary("en", strings.NewReader("cocaine\n"))
+	require.NoError(t, err)
+	checker := NewBannedWords([]*Dictionary{en, ru})
+
+	testCases := []struct {
+		name string
+
+		doc *Document
+
+		expectedMessages []string
+	}{
+		{
+			name: "1. Clean",
+			doc:  &Document{Title: "Велосипед", Description: "Городской, почти новый"},
+		},
+		{
+			name:             "2. Prefix_Match_Any_Case",
+			doc:              &Document{Title: "Продам", Description: "НАРКОТИКИ недорого"},
+			expectedMessages: []string{`"наркотики" is not allowed (ru)`},
+		},
+		{
+			name:             "3. Word_Reported_Once",
+			doc:              &Document{Title: "Cocaine", Description: "cocaine, Спайс"},
+			expectedMessages: []string{`"cocaine" is not allowed (en)`, `"спайс" is not allowed (ru)`},
+		},
+		{
+			name: "4. Exact_Word_Not_Prefix",
+			doc:  &Document{Title: "Спайсы", Description: "Специи"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reasons, err := checker.Check(tc.doc)
+			assert.NoError(t, err)
+			var messages []string
+			for _, r := range reasons {
+				messages = append(messages, r.Message)
+			}
+			assert.Equal(t, tc.expectedMessages, messages)
+		})
+	}
+}
+
+func TestLoadDictionaries(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ru.txt"), []byte("самогон\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "de.txt"), []byte("Waffe*\n"), 0o644))
+
+	dicts, err := LoadDictionaries(dir)
+	require.NoError(t, err)
+
+	byLang := map[string]*Dictionary{}
+	for _, d := range dicts {
+		byLang[d.Lang] = d
+	}
+	assert.Len(t, byLang, 3)
+	// Файл из каталога дополняет встроенный словарь, а не заменяет его
+	assert.True(t, byLang["ru"].match("самогон"))
+	assert.True(t, byLang["ru"].match("героин"))
+	assert.True(t, byLang["de"].match("waffen"))
+	assert.True(t, byLang["en"].match("cocaine"))
+}
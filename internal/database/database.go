@@ -15,13 +15,16 @@ type Repository struct {
 	Logger *zap.Logger
 }
 
-func NewPostgresDB(cfg *config.Config, logger *zap.Logger) (*Repository, error) {
-	connStr := fmt.Sprintf(
+// DSN возвращает строку подключения к PostgreSQL из конфигурации.
+func DSN(cfg *config.Config) string {
+	return fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
 		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName,
 	)
+}
 
-	db, err := sql.Open("postgres", connStr)
+func NewPostgresDB(cfg *config.Config, logger *zap.Logger) (*Repository, error) {
+	db, err := sql.Open("postgres", DSN(cfg))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open db connection: %w", err)
 	}
@@ -66,3 +69,8 @@ func (r *Repository) QueryRow(query string, args ...any) *sql.Row {
 func (r *Repository) Query(query string, args ...any) (*sql.Rows, error) {
 	return r.DB.Query(query, args...)
 }
+
+// Begin открывает транзакцию для операций, которые меняют несколько таблиц разом.
+func (r *Repository) Begin() (*sql.Tx, error) {
+	return r.DB.Begin()
+}
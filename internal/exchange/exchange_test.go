@@ -0,0 +1,94 @@
+package exchange
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+func TestFileSource_Load(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		content string
+
+		wantErr bool
+	}{
+		{
+			name:    "1. Valid_File",
+			content: `{"base":"rub","rates":{"USD":"0.0125","eur":"0.01"}}`,
+		},
+		{
+			name:    "2. Unknown_Currency",
+			content: `{"base":"RUB","rates":{"ABC":"1"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "3. Non_Positive_Rate",
+			content: `{"base":"RUB","rates":{"USD":"0"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "4. Invalid_JSON",
+			content: `{"base":`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rates.json")
+			assert.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
+
+			rates, err := NewFileSource(path).Load()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, money.RUB, rates.Base)
+			assert.Equal(t, "0.0125", rates.Rates[money.USD].String())
+			assert.Equal(t, "0.01", rates.Rates[money.EUR].String())
+			assert.False(t, rates.UpdatedAt.IsZero())
+		})
+	}
+}
+
+type stubSource struct {
+	rates *money.Rates
+	err   error
+}
+
+func (s *stubSource) Load() (*money.Rates, error) {
+	return s.rates, s.err
+}
+
+func TestCache_Refresh(t *testing.T) {
+	source := &stubSource{}
+	cache := NewCache(source, zap.NewNop())
+
+	_, err := cache.Rates()
+	assert.ErrorIs(t, err, ErrNoRates)
+
+	var refreshed *money.Rates
+	cache.OnRefresh(func(r *money.Rates) { refreshed = r })
+
+	first := &money.Rates{Base: money.RUB}
+	source.rates = first
+	assert.NoError(t, cache.Refresh())
+	assert.Same(t, first, refreshed)
+
+	// Ошибка источника не затирает последние успешные курсы
+	source.rates, source.err = nil, errors.New("source is down")
+	assert.Error(t, cache.Refresh())
+
+	rates, err := cache.Rates()
+	assert.NoError(t, err)
+	assert.Same(t, first, rates)
+}
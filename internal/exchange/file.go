@@ -0,0 +1,68 @@
+package exchange
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+// FileSource читает курсы из JSON-файла вида
+//
+//	{"base": "RUB", "updated_at": "2025-07-21T00:00:00Z", "rates": {"USD": "0.0125", "EUR": "0.0108"}}
+//
+// где rates — количество единиц валюты за 1 единицу base.
+type FileSource struct {
+	path string
+}
+
+func NewFileSource(path string) *FileSource {
+	return &FileSource{path: path}
+}
+
+type ratesFile struct {
+	Base      string            `json:"base"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	Rates     map[string]string `json:"rates"`
+}
+
+func (s *FileSource) Load() (*money.Rates, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rates file: %w", err)
+	}
+
+	var f ratesFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse rates file: %w", err)
+	}
+
+	base, err := money.ParseCurrency(f.Base)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base currency: %w", err)
+	}
+	rates := &money.Rates{
+		Base:      base,
+		Rates:     make(map[money.Currency]money.Decimal, len(f.Rates)),
+		UpdatedAt: f.UpdatedAt,
+	}
+	for code, value := range f.Rates {
+		c, err := money.ParseCurrency(code)
+		if err != nil {
+			return nil, err
+		}
+		rate, err := money.ParseDecimal(value)
+		if err != nil || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid rate for %s: %q", c, value)
+		}
+		rates.Rates[c] = rate
+	}
+	if rates.UpdatedAt.IsZero() {
+		if info, err := os.Stat(s.path); err == nil {
+			rates.UpdatedAt = info.ModTime()
+		}
+	}
+	return rates, nil
+}
@@ -0,0 +1,102 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+var ErrNoRates = errors.New("exchange rates are not loaded")
+
+// Provider отдаёт актуальные курсы валют.
+type Provider interface {
+	Rates() (*money.Rates, error)
+}
+
+// Source загружает курсы из внешнего хранилища (файл, БД).
+type Source interface {
+	Load() (*money.Rates, error)
+}
+
+// Cache держит последние загруженные курсы в памяти и периодически обновляет их из Source.
+type Cache struct {
+	source Source
+	logger *zap.Logger
+
+	mu    sync.RWMutex
+	rates *money.Rates
+	hooks []func(*money.Rates)
+}
+
+func NewCache(source Source, logger *zap.Logger) *Cache {
+	return &Cache{
+		source: source,
+		logger: logger,
+	}
+}
+
+func (c *Cache) Rates() (*money.Rates, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.rates == nil {
+		return nil, ErrNoRates
+	}
+	return c.rates, nil
+}
+
+// OnRefresh регистрирует хук, который вызывается после каждого успешного обновления курсов.
+func (c *Cache) OnRefresh(hook func(*money.Rates)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.hooks = append(c.hooks, hook)
+}
+
+// Refresh перечитывает курсы из источника. При ошибке остаются предыдущие курсы.
+func (c *Cache) Refresh() error {
+	rates, err := c.source.Load()
+	if err != nil {
+		c.logger.Error(
+			"Failed to refresh exchange rates",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	c.mu.Lock()
+	c.rates = rates
+	hooks := append([]func(*money.Rates){}, c.hooks...)
+	c.mu.Unlock()
+
+	c.logger.Info(
+		"Exchange rates refreshed",
+		zap.String("base", rates.Base.String()),
+		zap.Int("currencies", len(rates.Rates)),
+	)
+	for _, hook := range hooks {
+		hook(rates)
+	}
+	return nil
+}
+
+// Run обновляет курсы сразу и затем каждые interval, пока не отменён ctx.
+func (c *Cache) Run(ctx context.Context, interval time.Duration) {
+	_ = c.Refresh()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = c.Refresh()
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package exchange
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+type Repository interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// Storage читает курсы из таблицы exchange_rates. Курсы заданы относительно base.
+type Storage struct {
+	repository Repository
+	base       money.Currency
+	logger     *zap.Logger
+}
+
+func NewStorage(repository Repository, base money.Currency, logger *zap.Logger) *Storage {
+	return &Storage{
+		repository: repository,
+		base:       base,
+		logger:     logger,
+	}
+}
+
+func (s *Storage) Load() (*money.Rates, error) {
+	rows, err := s.repository.Query(`SELECT currency, rate, updated_at FROM exchange_rates`)
+	if err != nil {
+		s.logger.Error("Failed to load exchange rates", zap.Error(err))
+		return nil, errors.Wrap(err, "failed to load exchange rates")
+	}
+	defer rows.Close()
+
+	rates := &money.Rates{
+		Base:  s.base,
+		Rates: make(map[money.Currency]money.Decimal),
+	}
+	for rows.Next() {
+		var (
+			code, value string
+			updatedAt   time.Time
+		)
+		if err := rows.Scan(&code, &value, &updatedAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan exchange rate")
+		}
+		rate, err := money.ParseDecimal(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid rate for %s", code)
+		}
+		rates.Rates[money.Currency(code)] = rate
+		if updatedAt.After(rates.UpdatedAt) {
+			rates.UpdatedAt = updatedAt
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "error iterating over exchange rates")
+	}
+	return rates, nil
+}
@@ -0,0 +1,131 @@
+package favorite
+
+// mockgen  -source=handler.go -destination=handler_mock_test.go -package=favorite
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+)
+
+type service interface {
+	AddFavorite(login string, postID uint) error
+	RemoveFavorite(login string, postID uint) error
+	ListFavorites(login string, sort *post.SortParams, filter *post.FilterParams) ([]*post.Post, error)
+}
+
+type Handler struct {
+	service service
+	logger  *zap.Logger
+}
+
+func NewHandler(service service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// parsePostID достаёт id из пути /posts/{id}/favorite.
+func parsePostID(path string) (uint, bool, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 4 || parts[1] != "posts" || parts[3] != "favorite" {
+		return 0, false, nil
+	}
+	id64, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return 0, true, err
+	}
+	return uint(id64), true, nil
+}
+
+// Favorite обрабатывает PUT (добавить) и DELETE (убрать) на /posts/{id}/favorite.
+// Обе операции идемпотентны и возвращают 204.
+func (h *Handler) Favorite(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut && r.Method != http.MethodDelete {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, ok, err := parsePostID(r.URL.Path)
+	if !ok {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Bad Request: invalid id", http.StatusBadRequest)
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		err = h.service.AddFavorite(login, id)
+	} else {
+		err = h.service.RemoveFavorite(login, id)
+	}
+	if err != nil {
+		if errors.Is(err, post.ErrPostNotFound) {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error(
+			"Failed to update favorites",
+			zap.String("login", login),
+			zap.Uint("post_id", id),
+			zap.String("method", r.Method),
+			zap.Error(err),
+		)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListFavorites — GET /me/favorites. Принимает те же параметры, что и /posts/feed.
+func (h *Handler) ListFavorites(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	sort, filter := post.ParseFeedQuery(r.URL.Query(), login)
+	posts, err := h.service.ListFavorites(login, sort, filter)
+	if err != nil {
+		switch {
+		case errors.Is(err, post.ErrNoRateForCurrency):
+			http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+		case errors.Is(err, post.ErrRatesUnavailable):
+			http.Error(w, "Service Unavailable: "+err.Error(), http.StatusServiceUnavailable)
+		default:
+			h.logger.Error(
+				"Failed to list favorites",
+				zap.String("login", login),
+				zap.Error(err),
+			)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(posts)
+}
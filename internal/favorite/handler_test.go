@@ -0,0 +1,135 @@
+package favorite
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+func TestHandler_Favorite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		method string
+		path   string
+		user   string
+
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name:   "1. Add",
+			method: http.MethodPut,
+			path:   "/posts/7/favorite",
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().AddFavorite("alice", uint(7)).Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "2. Remove",
+			method: http.MethodDelete,
+			path:   "/posts/7/favorite",
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().RemoveFavorite("alice", uint(7)).Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "3. Post_Not_Found",
+			method: http.MethodPut,
+			path:   "/posts/99/favorite",
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().AddFavorite("alice", uint(99)).Return(post.ErrPostNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "4. Unauthorized",
+			method:       http.MethodPut,
+			path:         "/posts/7/favorite",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "5. Invalid_ID",
+			method:       http.MethodPut,
+			path:         "/posts/abc/favorite",
+			user:         "alice",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "6. Wrong_Method",
+			method:       http.MethodPost,
+			path:         "/posts/7/favorite",
+			user:         "alice",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "7. Service_Error",
+			method: http.MethodDelete,
+			path:   "/posts/7/favorite",
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().RemoveFavorite("alice", uint(7)).Return(errors.New("db error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.user != "" {
+				req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, tc.user))
+			}
+			rr := httptest.NewRecorder()
+
+			handler.Favorite(rr, req)
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestHandler_ListFavorites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := NewMockservice(ctrl)
+	handler := NewHandler(mockService, zap.NewNop())
+
+	mockService.EXPECT().
+		ListFavorites("alice", &post.SortParams{Field: "price", Direction: "ASC"}, gomock.Any()).
+		Return([]*post.Post{{ID: 1, Owner: "bob", IsFavorite: true}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/me/favorites?sort_by=price&order=asc", nil)
+	req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, "alice"))
+	rr := httptest.NewRecorder()
+
+	handler.ListFavorites(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"is_favorite":true`)
+}
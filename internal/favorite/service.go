@@ -0,0 +1,166 @@
+package favorite
+
+// mockgen  -source=service.go -destination=service_mock_test.go -package=favorite
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+// KindPriceDrop — уведомление о снижении цены избранного поста.
+const KindPriceDrop = "price_drop"
+
+type storage interface {
+	Add(login string, postID uint) (bool, error)
+	Remove(login string, postID uint) error
+	ListLogins(postID uint) ([]string, error)
+}
+
+// feed — лента постов; избранное отдаётся через неё, чтобы сортировка,
+// фильтры и конвертация цен работали так же, как в /posts/feed.
+type feed interface {
+	GetPosts(sort *post.SortParams, filter *post.FilterParams) ([]*post.Post, error)
+}
+
+type notifier interface {
+	Notify(msg notify.Message) error
+}
+
+type Service struct {
+	repository storage
+	posts      feed
+	logger     *zap.Logger
+
+	notifier      notifier
+	dropThreshold float64
+	onAdd         []func(login string, postID uint)
+}
+
+type Option func(*Service)
+
+// WithPriceDropAlerts включает уведомления о снижении цены избранных постов
+// больше чем на thresholdPercent процентов.
+func WithPriceDropAlerts(notifier notifier, thresholdPercent float64) Option {
+	return func(s *Service) {
+		s.notifier = notifier
+		s.dropThreshold = thresholdPercent
+	}
+}
+
+// WithOnAdd добавляет обработчик, который вызывается, когда пост впервые добавлен в избранное.
+// Обработчик не должен блокировать.
+func WithOnAdd(fn func(login string, postID uint)) Option {
+	return func(s *Service) {
+		s.onAdd = append(s.onAdd, fn)
+	}
+}
+
+func NewService(repository storage, posts feed, logger *zap.Logger, opts ...Option) *Service {
+	s := &Service{
+		repository: repository,
+		posts:      posts,
+		logger:     logger,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *Service) AddFavorite(login string, postID uint) error {
+	added, err := s.repository.Add(login, postID)
+	if err != nil {
+		return err
+	}
+	if added {
+		for _, fn := range s.onAdd {
+			fn(login, postID)
+		}
+	}
+	return nil
+}
+
+func (s *Service) RemoveFavorite(login string, postID uint) error {
+	return s.repository.Remove(login, postID)
+}
+
+// ListFavorites возвращает избранные посты пользователя с учётом параметров ленты.
+func (s *Service) ListFavorites(login string, sort *post.SortParams, filter *post.FilterParams) ([]*post.Post, error) {
+	filter.Owner = login
+	filter.FavoritedBy = login
+	return s.posts.GetPosts(sort, filter)
+}
+
+// OnPriceChange — обработчик для post.WithOnPriceChange.
+func (s *Service) OnPriceChange(p *post.Post, change *post.PriceChange) {
+	if s.notifier == nil {
+		return
+	}
+	if drop := change.DropPercent(); drop == 0 || drop <= s.dropThreshold {
+		return
+	}
+	updated := *p
+	go func() {
+		if err := s.AlertPriceDrop(&updated, change); err != nil {
+			s.logger.Error(
+				"Failed to send price drop alerts",
+				zap.Uint("post_id", updated.ID),
+				zap.Error(err),
+			)
+		}
+	}()
+}
+
+type priceDropPayload struct {
+	PostID      uint        `json:"post_id"`
+	PostTitle   string      `json:"post_title"`
+	OldPrice    money.Money `json:"old_price"`
+	NewPrice    money.Money `json:"new_price"`
+	DropPercent float64     `json:"drop_percent"`
+}
+
+// AlertPriceDrop уведомляет всех, кто добавил пост в избранное, кроме владельца.
+func (s *Service) AlertPriceDrop(p *post.Post, change *post.PriceChange) error {
+	logins, err := s.repository.ListLogins(p.ID)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(priceDropPayload{
+		PostID:      p.ID,
+		PostTitle:   p.Title,
+		OldPrice:    change.OldPrice,
+		NewPrice:    change.NewPrice,
+		DropPercent: change.DropPercent(),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, login := range logins {
+		if login == p.Owner {
+			continue
+		}
+		err := s.notifier.Notify(notify.Message{
+			Recipient: login,
+			Kind:      KindPriceDrop,
+			Title:     fmt.Sprintf("Price dropped: %s is now %s", p.Title, change.NewPrice),
+			Payload:   payload,
+			Channel:   notify.ChannelInbox,
+		})
+		if err != nil {
+			s.logger.Warn(
+				"Failed to queue price drop alert",
+				zap.String("login", login),
+				zap.Error(err),
+			)
+		}
+	}
+	return nil
+}
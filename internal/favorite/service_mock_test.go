@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package favorite is a generated GoMock package.
+package favorite
+
+import (
+	reflect "reflect"
+
+	notify "github.com/TemirB/rest-api-marketplace/internal/notify"
+	post "github.com/TemirB/rest-api-marketplace/internal/post"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockstorage is a mock of storage interface.
+type Mockstorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockstorageMockRecorder
+}
+
+// MockstorageMockRecorder is the mock recorder for Mockstorage.
+type MockstorageMockRecorder struct {
+	mock *Mockstorage
+}
+
+// NewMockstorage creates a new mock instance.
+func NewMockstorage(ctrl *gomock.Controller) *Mockstorage {
+	mock := &Mockstorage{ctrl: ctrl}
+	mock.recorder = &MockstorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstorage) EXPECT() *MockstorageMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *Mockstorage) Add(login string, postID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", login, postID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockstorageMockRecorder) Add(login, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*Mockstorage)(nil).Add), login, postID)
+}
+
+// ListLogins mocks base method.
+func (m *Mockstorage) ListLogins(postID uint) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLogins", postID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLogins indicates an expected call of ListLogins.
+func (mr *MockstorageMockRecorder) ListLogins(postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLogins", reflect.TypeOf((*Mockstorage)(nil).ListLogins), postID)
+}
+
+// Remove mocks base method.
+func (m *Mockstorage) Remove(login string, postID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", login, postID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockstorageMockRecorder) Remove(login, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*Mockstorage)(nil).Remove), login, postID)
+}
+
+// Mockfeed is a mock of feed interface.
+type Mockfeed struct {
+	ctrl     *gomock.Controller
+	recorder *MockfeedMockRecorder
+}
+
+// MockfeedMockRecorder is the mock recorder for Mockfeed.
+type MockfeedMockRecorder struct {
+	mock *Mockfeed
+}
+
+// NewMockfeed creates a new mock instance.
+func NewMockfeed(ctrl *gomock.Controller) *Mockfeed {
+	mock := &Mockfeed{ctrl: ctrl}
+	mock.recorder = &MockfeedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockfeed) EXPECT() *MockfeedMockRecorder {
+	return m.recorder
+}
+
+// GetPosts mocks base method.
+func (m *Mockfeed) GetPosts(sort *post.SortParams, filter *post.FilterParams) ([]*post.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPosts", sort, filter)
+	ret0, _ := ret[0].([]*post.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPosts indicates an expected call of GetPosts.
+func (mr *MockfeedMockRecorder) GetPosts(sort, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPosts", reflect.TypeOf((*Mockfeed)(nil).GetPosts), sort, filter)
+}
+
+// Mocknotifier is a mock of notifier interface.
+type Mocknotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MocknotifierMockRecorder
+}
+
+// MocknotifierMockRecorder is the mock recorder for Mocknotifier.
+type MocknotifierMockRecorder struct {
+	mock *Mocknotifier
+}
+
+// NewMocknotifier creates a new mock instance.
+func NewMocknotifier(ctrl *gomock.Controller) *Mocknotifier {
+	mock := &Mocknotifier{ctrl: ctrl}
+	mock.recorder = &MocknotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocknotifier) EXPECT() *MocknotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *Mocknotifier) Notify(msg notify.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MocknotifierMockRecorder) Notify(msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*Mocknotifier)(nil).Notify), msg)
+}
@@ -0,0 +1,91 @@
+package favorite
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+func TestService_ListFavorites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	posts := NewMockfeed(ctrl)
+	service := NewService(NewMockstorage(ctrl), posts, zap.NewNop())
+
+	sort := &post.SortParams{Field: "created_at", Direction: "DESC"}
+	expected := []*post.Post{{ID: 1, Owner: "bob", IsFavorite: true}}
+
+	posts.EXPECT().
+		GetPosts(sort, &post.FilterParams{MinPrice: 10, Owner: "alice", FavoritedBy: "alice"}).
+		Return(expected, nil)
+
+	actual, err := service.ListFavorites("alice", sort, &post.FilterParams{MinPrice: 10, Owner: "alice"})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestService_AlertPriceDrop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	notifier := NewMocknotifier(ctrl)
+	service := NewService(repo, nil, zap.NewNop(), WithPriceDropAlerts(notifier, 10))
+
+	p := &post.Post{ID: 5, Title: "Bike", Price: money.MustParse("80", "RUB"), Owner: "seller"}
+	change := &post.PriceChange{PostID: 5, OldPrice: money.MustParse("100", "RUB"), NewPrice: money.MustParse("80", "RUB")}
+
+	repo.EXPECT().ListLogins(uint(5)).Return([]string{"alice", "seller", "bob"}, nil)
+
+	var recipients []string
+	notifier.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+		assert.Equal(t, KindPriceDrop, msg.Kind)
+		assert.Equal(t, notify.ChannelInbox, msg.Channel)
+		assert.Contains(t, string(msg.Payload), `"drop_percent":20`)
+		recipients = append(recipients, msg.Recipient)
+		return nil
+	}).Times(2)
+
+	assert.NoError(t, service.AlertPriceDrop(p, change))
+	assert.Equal(t, []string{"alice", "bob"}, recipients)
+}
+
+func TestService_OnPriceChange_BelowThreshold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Без ожиданий на моках: любое обращение к хранилищу или уведомлениям провалит тест
+	service := NewService(NewMockstorage(ctrl), nil, zap.NewNop(), WithPriceDropAlerts(NewMocknotifier(ctrl), 10))
+
+	p := &post.Post{ID: 5, Price: money.MustParse("95", "RUB")}
+	service.OnPriceChange(p, &post.PriceChange{OldPrice: money.MustParse("100", "RUB"), NewPrice: money.MustParse("95", "RUB")})
+	service.OnPriceChange(p, &post.PriceChange{OldPrice: money.MustParse("90", "RUB"), NewPrice: money.MustParse("95", "RUB")})
+	// Снижение ровно на порог не уведомляет
+	service.OnPriceChange(p, &post.PriceChange{OldPrice: money.MustParse("100", "RUB"), NewPrice: money.MustParse("90", "RUB")})
+}
+
+func TestService_AddFavorite_OnAdd(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	repo.EXPECT().Add("alice", uint(7)).Return(true, nil)
+	repo.EXPECT().Add("alice", uint(7)).Return(false, nil)
+
+	var added int
+	service := NewService(repo, NewMockfeed(ctrl), zap.NewNop(), WithOnAdd(func(login string, postID uint) {
+		added++
+	}))
+
+	assert.NoError(t, service.AddFavorite("alice", 7))
+	// Повторное добавление не считается
+	assert.NoError(t, service.AddFavorite("alice", 7))
+	assert.Equal(t, 1, added)
+}
@@ -0,0 +1,97 @@
+package favorite
+
+// mockgen  -source=storage.go -destination=storage_mock_test.go -package=favorite
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+// pgForeignKeyViolation — код ошибки Postgres при нарушении внешнего ключа.
+const pgForeignKeyViolation = "23503"
+
+type Repository interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+type Storage struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+func NewStorage(repository Repository, logger *zap.Logger) *Storage {
+	return &Storage{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// Add добавляет пост в избранное и сообщает, добавлен ли он сейчас. Повторное добавление ничего не меняет.
+func (r *Storage) Add(login string, postID uint) (bool, error) {
+	query := `INSERT INTO favorites (login, post_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	res, err := r.repository.Exec(query, login, postID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgForeignKeyViolation {
+			return false, post.ErrPostNotFound
+		}
+		r.logger.Error(
+			"Failed to add favorite",
+			zap.String("login", login),
+			zap.Uint("post_id", postID),
+			zap.Error(err),
+		)
+		return false, errors.Errorf("failed to add favorite: %v", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Errorf("failed to add favorite: %v", err)
+	}
+	return n > 0, nil
+}
+
+// Remove убирает пост из избранного. Удаление отсутствующей записи не считается ошибкой.
+func (r *Storage) Remove(login string, postID uint) error {
+	query := `DELETE FROM favorites WHERE login = $1 AND post_id = $2`
+	_, err := r.repository.Exec(query, login, postID)
+	if err != nil {
+		r.logger.Error(
+			"Failed to remove favorite",
+			zap.String("login", login),
+			zap.Uint("post_id", postID),
+			zap.Error(err),
+		)
+		return errors.Errorf("failed to remove favorite: %v", err)
+	}
+	return nil
+}
+
+// ListLogins возвращает пользователей, добавивших пост в избранное.
+func (r *Storage) ListLogins(postID uint) ([]string, error) {
+	rows, err := r.repository.Query(`SELECT login FROM favorites WHERE post_id = $1`, postID)
+	if err != nil {
+		r.logger.Error(
+			"Failed to list favorites of post",
+			zap.Uint("post_id", postID),
+			zap.Error(err),
+		)
+		return nil, errors.Wrap(err, "failed to list favorites")
+	}
+	defer rows.Close()
+
+	var logins []string
+	for rows.Next() {
+		var login string
+		if err := rows.Scan(&login); err != nil {
+			return nil, errors.Wrap(err, "failed to scan favorite")
+		}
+		logins = append(logins, login)
+	}
+	return logins, rows.Err()
+}
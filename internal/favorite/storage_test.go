@@ -0,0 +1,72 @@
+package favorite
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+func TestStorage_Add(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	query := `INSERT INTO favorites (login, post_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+
+	testCases := []struct {
+		name string
+
+		execResult sql.Result
+		execErr    error
+
+		expectedAdded bool
+		expectedErr   error
+		wantErr       bool
+	}{
+		{name: "1. Added", execResult: driver.RowsAffected(1), expectedAdded: true},
+		{name: "4. Already_Favorite", execResult: driver.RowsAffected(0)},
+		{name: "2. Post_Not_Found", execErr: &pq.Error{Code: pgForeignKeyViolation}, expectedErr: post.ErrPostNotFound, wantErr: true},
+		{name: "3. DB_Error", execErr: errors.New("connection refused"), wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockRepository(ctrl)
+			repo.EXPECT().Exec(query, "alice", uint(7)).Return(tc.execResult, tc.execErr)
+			storage := NewStorage(repo, zap.NewNop())
+
+			added, err := storage.Add("alice", 7)
+			if !tc.wantErr {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedAdded, added)
+				return
+			}
+			assert.Error(t, err)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestStorage_Remove(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	query := `DELETE FROM favorites WHERE login = $1 AND post_id = $2`
+
+	repo := NewMockRepository(ctrl)
+	repo.EXPECT().Exec(query, "alice", uint(7)).Return(nil, nil)
+	repo.EXPECT().Exec(query, "alice", uint(8)).Return(nil, errors.New("connection refused"))
+	storage := NewStorage(repo, zap.NewNop())
+
+	assert.NoError(t, storage.Remove("alice", 7))
+	assert.Error(t, storage.Remove("alice", 8))
+}
@@ -0,0 +1,19 @@
+package follow
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrSelfFollow   = errors.New("cannot follow yourself")
+)
+
+// Seller — продавец, на которого подписан пользователь.
+type Seller struct {
+	Login      string    `json:"login"`
+	FollowedAt time.Time `json:"followed_at"`
+	// ActivePosts — сколько у продавца активных видимых постов
+	ActivePosts int `json:"active_posts"`
+}
@@ -0,0 +1,108 @@
+package follow
+
+// mockgen  -source=handler.go -destination=handler_mock_test.go -package=follow
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+)
+
+type service interface {
+	Follow(follower, seller string) error
+	Unfollow(follower, seller string) error
+	ListFollowing(follower string) ([]*Seller, error)
+}
+
+type Handler struct {
+	service service
+	logger  *zap.Logger
+}
+
+func NewHandler(service service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Follow обрабатывает PUT (подписаться) и DELETE (отписаться) на /users/{login}/follow.
+// Обе операции идемпотентны и возвращают 204.
+func (h *Handler) Follow(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut && r.Method != http.MethodDelete {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) != 4 || parts[1] != "users" || parts[2] == "" || parts[3] != "follow" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	seller := parts[2]
+
+	if r.Method == http.MethodPut {
+		err = h.service.Follow(login, seller)
+	} else {
+		err = h.service.Unfollow(login, seller)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUserNotFound):
+			http.Error(w, "Not Found", http.StatusNotFound)
+		case errors.Is(err, ErrSelfFollow):
+			http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+		default:
+			h.logger.Error(
+				"Failed to update follows",
+				zap.String("follower", login),
+				zap.String("seller", seller),
+				zap.String("method", r.Method),
+				zap.Error(err),
+			)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Following — GET /me/following.
+func (h *Handler) Following(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	sellers, err := h.service.ListFollowing(login)
+	if err != nil {
+		h.logger.Error(
+			"Failed to list followed sellers",
+			zap.String("follower", login),
+			zap.Error(err),
+		)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sellers)
+}
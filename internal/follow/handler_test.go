@@ -0,0 +1,147 @@
+package follow
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+)
+
+func TestHandler_Follow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		method string
+		path   string
+		user   string
+
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name:   "1. Follow",
+			method: http.MethodPut,
+			path:   "/users/bob/follow",
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Follow("alice", "bob").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "2. Unfollow",
+			method: http.MethodDelete,
+			path:   "/users/bob/follow",
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Unfollow("alice", "bob").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "3. User_Not_Found",
+			method: http.MethodPut,
+			path:   "/users/nobody/follow",
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Follow("alice", "nobody").Return(ErrUserNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "4. Self_Follow",
+			method: http.MethodPut,
+			path:   "/users/alice/follow",
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Follow("alice", "alice").Return(ErrSelfFollow)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "5. Unauthorized",
+			method:       http.MethodPut,
+			path:         "/users/bob/follow",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "6. Wrong_Method",
+			method:       http.MethodPost,
+			path:         "/users/bob/follow",
+			user:         "alice",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "7. Bad_Path",
+			method:       http.MethodPut,
+			path:         "/users//follow",
+			user:         "alice",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "8. Service_Error",
+			method: http.MethodDelete,
+			path:   "/users/bob/follow",
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Unfollow("alice", "bob").Return(errors.New("db error"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.user != "" {
+				req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, tc.user))
+			}
+			rr := httptest.NewRecorder()
+
+			handler.Follow(rr, req)
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestHandler_Following(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := NewMockservice(ctrl)
+	handler := NewHandler(mockService, zap.NewNop())
+
+	mockService.EXPECT().ListFollowing("alice").Return([]*Seller{{Login: "bob", ActivePosts: 3}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/me/following", nil)
+	req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, "alice"))
+	rr := httptest.NewRecorder()
+
+	handler.Following(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"login":"bob"`)
+	assert.Contains(t, rr.Body.String(), `"active_posts":3`)
+
+	rr = httptest.NewRecorder()
+	handler.Following(rr, httptest.NewRequest(http.MethodGet, "/me/following", nil))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
@@ -0,0 +1,41 @@
+package follow
+
+// mockgen  -source=service.go -destination=service_mock_test.go -package=follow
+
+import (
+	"go.uber.org/zap"
+)
+
+type storage interface {
+	Follow(follower, seller string) error
+	Unfollow(follower, seller string) error
+	ListFollowing(follower string) ([]*Seller, error)
+}
+
+type Service struct {
+	repository storage
+	logger     *zap.Logger
+}
+
+func NewService(repository storage, logger *zap.Logger) *Service {
+	return &Service{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// Follow подписывает follower на продавца seller. Подписаться на себя нельзя.
+func (s *Service) Follow(follower, seller string) error {
+	if follower == seller {
+		return ErrSelfFollow
+	}
+	return s.repository.Follow(follower, seller)
+}
+
+func (s *Service) Unfollow(follower, seller string) error {
+	return s.repository.Unfollow(follower, seller)
+}
+
+func (s *Service) ListFollowing(follower string) ([]*Seller, error) {
+	return s.repository.ListFollowing(follower)
+}
@@ -0,0 +1,21 @@
+package follow
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestService_Follow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	repo.EXPECT().Follow("alice", "bob").Return(nil)
+	service := NewService(repo, zap.NewNop())
+
+	assert.NoError(t, service.Follow("alice", "bob"))
+	assert.ErrorIs(t, service.Follow("alice", "alice"), ErrSelfFollow)
+}
@@ -0,0 +1,98 @@
+package follow
+
+// mockgen  -source=storage.go -destination=storage_mock_test.go -package=follow
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// pgForeignKeyViolation — код ошибки Postgres при нарушении внешнего ключа.
+const pgForeignKeyViolation = "23503"
+
+type Repository interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+type Storage struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+func NewStorage(repository Repository, logger *zap.Logger) *Storage {
+	return &Storage{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+// Follow подписывает follower на seller. Повторная подписка ничего не меняет.
+func (r *Storage) Follow(follower, seller string) error {
+	query := `INSERT INTO follows (follower, seller) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	_, err := r.repository.Exec(query, follower, seller)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgForeignKeyViolation {
+			return ErrUserNotFound
+		}
+		r.logger.Error(
+			"Failed to follow seller",
+			zap.String("follower", follower),
+			zap.String("seller", seller),
+			zap.Error(err),
+		)
+		return errors.Errorf("failed to follow seller: %v", err)
+	}
+	return nil
+}
+
+// Unfollow отписывает follower от seller. Отписка без подписки не считается ошибкой.
+func (r *Storage) Unfollow(follower, seller string) error {
+	query := `DELETE FROM follows WHERE follower = $1 AND seller = $2`
+	_, err := r.repository.Exec(query, follower, seller)
+	if err != nil {
+		r.logger.Error(
+			"Failed to unfollow seller",
+			zap.String("follower", follower),
+			zap.String("seller", seller),
+			zap.Error(err),
+		)
+		return errors.Errorf("failed to unfollow seller: %v", err)
+	}
+	return nil
+}
+
+// ListFollowing возвращает продавцов, на которых подписан follower, последние подписки первыми.
+func (r *Storage) ListFollowing(follower string) ([]*Seller, error) {
+	query := `
+		SELECT f.seller, f.created_at,
+			(SELECT COUNT(*) FROM posts p WHERE p.owner = f.seller AND p.status = 'active' AND NOT p.hidden)
+		FROM follows f
+		WHERE f.follower = $1
+		ORDER BY f.created_at DESC, f.seller
+	`
+	rows, err := r.repository.Query(query, follower)
+	if err != nil {
+		r.logger.Error(
+			"Failed to list followed sellers",
+			zap.String("follower", follower),
+			zap.Error(err),
+		)
+		return nil, errors.Errorf("failed to list followed sellers: %v", err)
+	}
+	defer rows.Close()
+
+	sellers := []*Seller{}
+	for rows.Next() {
+		var s Seller
+		if err := rows.Scan(&s.Login, &s.FollowedAt, &s.ActivePosts); err != nil {
+			return nil, errors.Errorf("failed to scan followed seller: %v", err)
+		}
+		sellers = append(sellers, &s)
+	}
+	return sellers, rows.Err()
+}
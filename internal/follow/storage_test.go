@@ -0,0 +1,64 @@
+package follow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestStorage_Follow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	query := `INSERT INTO follows (follower, seller) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+
+	testCases := []struct {
+		name string
+
+		execErr error
+
+		expectedErr error
+		wantErr     bool
+	}{
+		{name: "1. Followed"},
+		{name: "2. User_Not_Found", execErr: &pq.Error{Code: pgForeignKeyViolation}, expectedErr: ErrUserNotFound, wantErr: true},
+		{name: "3. DB_Error", execErr: errors.New("connection refused"), wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockRepository(ctrl)
+			repo.EXPECT().Exec(query, "alice", "bob").Return(nil, tc.execErr)
+			storage := NewStorage(repo, zap.NewNop())
+
+			err := storage.Follow("alice", "bob")
+			if !tc.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestStorage_Unfollow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	query := `DELETE FROM follows WHERE follower = $1 AND seller = $2`
+
+	repo := NewMockRepository(ctrl)
+	repo.EXPECT().Exec(query, "alice", "bob").Return(nil, nil)
+	repo.EXPECT().Exec(query, "alice", "carol").Return(nil, errors.New("connection refused"))
+	storage := NewStorage(repo, zap.NewNop())
+
+	assert.NoError(t, storage.Unfollow("alice", "bob"))
+	assert.Error(t, storage.Unfollow("alice", "carol"))
+}
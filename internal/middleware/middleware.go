@@ -34,6 +34,30 @@ func JWTAuthMiddleware(s authService) func(http.Handler) http.Handler {
 	}
 }
 
+// JWTQueryAuthMiddleware — как JWTAuthMiddleware, но токен можно передать и параметром
+// access_token: браузерные EventSource и WebSocket не умеют задавать заголовки.
+func JWTQueryAuthMiddleware(s authService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := r.URL.Query().Get("access_token")
+			if h := r.Header.Get("Authorization"); strings.HasPrefix(h, "Bearer ") {
+				token = strings.TrimPrefix(h, "Bearer ")
+			}
+			if token == "" {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			login, err := s.ValidateToken(token)
+			if err != nil {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			ctx := context.WithValue(r.Context(), CtxUser, login)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
 func OptionalAuthMiddleware(s authService) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
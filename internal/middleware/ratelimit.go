@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TemirB/rest-api-marketplace/internal/ratelimit"
+)
+
+// APIKeyHeader — заголовок, по которому KeyByAPIKey различает клиентов.
+const APIKeyHeader = "X-API-Key"
+
+// KeyFunc возвращает, чью корзину списывает запрос.
+type KeyFunc func(r *http.Request) string
+
+// KeyByIP различает клиентов по адресу. trustedProxies — сколько своих прокси стоит перед сервером:
+// каждый дописывает в конец X-Forwarded-For адрес, с которого к нему пришли, поэтому адрес клиента —
+// trustedProxies-я запись с конца. Записи левее задаёт сам клиент, им верить нельзя. Если записей
+// меньше, чем прокси, запрос пришёл в обход них, и берётся адрес соединения. При 0 заголовки
+// не читаются.
+func KeyByIP(trustedProxies int) KeyFunc {
+	return func(r *http.Request) string {
+		if trustedProxies > 0 {
+			var hops []string
+			for _, fwd := range r.Header.Values("X-Forwarded-For") {
+				hops = append(hops, strings.Split(fwd, ",")...)
+			}
+			if len(hops) >= trustedProxies {
+				if ip := strings.TrimSpace(hops[len(hops)-trustedProxies]); ip != "" {
+					return "ip:" + ip
+				}
+			}
+		}
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		return "ip:" + host
+	}
+}
+
+// KeyByLogin различает клиентов по логину, а анонимных — по fallback.
+// Должен стоять после middleware авторизации.
+func KeyByLogin(fallback KeyFunc) KeyFunc {
+	return func(r *http.Request) string {
+		if login, ok := r.Context().Value(CtxUser).(string); ok && login != "" {
+			return "login:" + login
+		}
+		return fallback(r)
+	}
+}
+
+// APIKeys — реестр выданных API-ключей. Ключи хранятся хешами, корзина заводится на имя клиента.
+type APIKeys struct {
+	names map[[sha256.Size]byte]string
+}
+
+// NewAPIKeys строит реестр из пар имя клиента → ключ.
+func NewAPIKeys(keys map[string]string) *APIKeys {
+	k := &APIKeys{names: make(map[[sha256.Size]byte]string, len(keys))}
+	for name, key := range keys {
+		k.names[sha256.Sum256([]byte(key))] = name
+	}
+	return k
+}
+
+// Lookup возвращает имя клиента, которому выдан key.
+func (k *APIKeys) Lookup(key string) (string, bool) {
+	name, ok := k.names[sha256.Sum256([]byte(key))]
+	return name, ok
+}
+
+// KeyByAPIKey различает клиентов по заголовку X-API-Key. Ключ должен быть в реестре keys:
+// запросы с неизвестным ключом или без него идут по fallback, иначе каждый новый выдуманный
+// ключ получал бы свою корзину.
+func KeyByAPIKey(keys *APIKeys, fallback KeyFunc) KeyFunc {
+	return func(r *http.Request) string {
+		if key := r.Header.Get(APIKeyHeader); key != "" {
+			if name, ok := keys.Lookup(key); ok {
+				return "key:" + name
+			}
+		}
+		return fallback(r)
+	}
+}
+
+// RateLimit ограничивает запросы группы маршрутов group корзиной токенов limit на каждый ключ.
+// Ответ содержит заголовки RateLimit-*, отклонённый запрос получает 429 с Retry-After.
+// Если store недоступен, запрос пропускается: лимиты не должны ронять API.
+func RateLimit(store ratelimit.Store, group string, limit ratelimit.Limit, key KeyFunc) func(http.Handler) http.Handler {
+	policy := strconv.Itoa(limit.Capacity()) + ";w=" + strconv.Itoa(int(limit.Period.Seconds()))
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			d, err := store.Take(group+":"+key(r), limit)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("RateLimit-Policy", policy)
+			h.Set("RateLimit-Limit", strconv.Itoa(d.Limit))
+			h.Set("RateLimit-Remaining", strconv.Itoa(d.Remaining))
+			h.Set("RateLimit-Reset", ceilSeconds(d.Reset))
+			if !d.Allowed {
+				h.Set("Retry-After", ceilSeconds(d.RetryAfter))
+				http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Chain объединяет middleware: первое в списке обрабатывает запрос первым.
+func Chain(mws ...func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		for i := len(mws) - 1; i >= 0; i-- {
+			next = mws[i](next)
+		}
+		return next
+	}
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
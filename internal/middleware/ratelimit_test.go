@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TemirB/rest-api-marketplace/internal/ratelimit"
+)
+
+type fakeStore struct {
+	keys     []string
+	decision ratelimit.Decision
+	err      error
+}
+
+func (s *fakeStore) Take(key string, limit ratelimit.Limit) (ratelimit.Decision, error) {
+	s.keys = append(s.keys, key)
+	return s.decision, s.err
+}
+
+func TestRateLimit(t *testing.T) {
+	limit := ratelimit.Limit{Rate: 60, Period: time.Minute, Burst: 10}
+
+	testCases := []struct {
+		name string
+
+		decision ratelimit.Decision
+		err      error
+
+		expectedCode    int
+		expectedHeaders map[string]string
+	}{
+		{
+			name:         "1. Allowed",
+			decision:     ratelimit.Decision{Allowed: true, Limit: 10, Remaining: 7, Reset: 2500 * time.Millisecond},
+			expectedCode: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"RateLimit-Policy":    "10;w=60",
+				"RateLimit-Limit":     "10",
+				"RateLimit-Remaining": "7",
+				"RateLimit-Reset":     "3",
+				"Retry-After":         "",
+			},
+		},
+		{
+			name: "2. Limited",
+			decision: ratelimit.Decision{
+				Limit: 10, Reset: 10 * time.Second, RetryAfter: 400 * time.Millisecond,
+			},
+			expectedCode: http.StatusTooManyRequests,
+			expectedHeaders: map[string]string{
+				"RateLimit-Remaining": "0",
+				"Retry-After":         "1",
+			},
+		},
+		{
+			name:         "3. Store_Unavailable",
+			err:          errors.New("db down"),
+			expectedCode: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit": "",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &fakeStore{decision: tc.decision, err: tc.err}
+			handler := RateLimit(store, "api", limit, KeyByIP(0))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			req := httptest.NewRequest(http.MethodGet, "/posts/feed", nil)
+			req.RemoteAddr = "10.0.0.1:51234"
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, []string{"api:ip:10.0.0.1"}, store.keys)
+			for header, value := range tc.expectedHeaders {
+				assert.Equal(t, value, rec.Header().Get(header), header)
+			}
+		})
+	}
+}
+
+func TestKeyFuncs(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:51234"
+	// Клиент подставил 198.51.100.1, первый прокси дописал его настоящий адрес, второй — адрес первого
+	req.Header.Set("X-Forwarded-For", "198.51.100.1, 203.0.113.7, 10.0.0.2")
+
+	assert.Equal(t, "ip:10.0.0.1", KeyByIP(0)(req))
+	assert.Equal(t, "ip:10.0.0.2", KeyByIP(1)(req))
+	assert.Equal(t, "ip:203.0.113.7", KeyByIP(2)(req))
+	// Записей меньше, чем прокси: заголовок задал клиент в обход прокси
+	assert.Equal(t, "ip:10.0.0.1", KeyByIP(4)(req))
+	assert.Equal(t, "ip:10.0.0.1", KeyByLogin(KeyByIP(0))(req))
+
+	// Прокси дописал адрес отдельным заголовком
+	split := httptest.NewRequest(http.MethodGet, "/", nil)
+	split.RemoteAddr = "10.0.0.1:51234"
+	split.Header.Add("X-Forwarded-For", "198.51.100.1")
+	split.Header.Add("X-Forwarded-For", "203.0.113.7")
+	assert.Equal(t, "ip:203.0.113.7", KeyByIP(1)(split))
+
+	authed := req.WithContext(context.WithValue(req.Context(), CtxUser, "alice"))
+	assert.Equal(t, "login:alice", KeyByLogin(KeyByIP(0))(authed))
+}
+
+func TestKeyByAPIKey(t *testing.T) {
+	keys := NewAPIKeys(map[string]string{"partner": "s3cret"})
+	key := KeyByAPIKey(keys, KeyByIP(0))
+
+	testCases := []struct {
+		name string
+
+		apiKey string
+
+		expected string
+	}{
+		{name: "1. Known_Key", apiKey: "s3cret", expected: "key:partner"},
+		{name: "2. Unknown_Key_Falls_Back_To_IP", apiKey: "made-up", expected: "ip:10.0.0.1"},
+		{name: "3. No_Key", expected: "ip:10.0.0.1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = "10.0.0.1:51234"
+			if tc.apiKey != "" {
+				req.Header.Set(APIKeyHeader, tc.apiKey)
+			}
+			assert.Equal(t, tc.expected, key(req))
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	var order []string
+	mw := func(name string) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	Chain(mw("auth"), mw("limit"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		order = append(order, "handler")
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, []string{"auth", "limit", "handler"}, order)
+}
@@ -0,0 +1,233 @@
+package moderation
+
+// mockgen  -source=handler.go -destination=handler_mock_test.go -package=moderation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+)
+
+type service interface {
+	ReportPost(postID uint, reporter, reason, comment string) (*Report, error)
+	ListCases(moderator, status string) ([]*Case, error)
+	GetCase(id uint, moderator string) (*Case, error)
+	Claim(id uint, moderator string) (*Case, error)
+	Resolve(id uint, moderator, action, note string) (*Case, error)
+	ListAudit(moderator string, filter AuditFilter) ([]*AuditEntry, error)
+}
+
+type Handler struct {
+	service service
+	logger  *zap.Logger
+}
+
+func NewHandler(service service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+type reportRequest struct {
+	Reason  string `json:"reason"`
+	Comment string `json:"comment"`
+}
+
+type resolveRequest struct {
+	Action string `json:"action"`
+	Note   string `json:"note"`
+}
+
+// writeError переводит ошибки модерации в HTTP-статусы.
+func (h *Handler) writeError(w http.ResponseWriter, err error, msg string, fields ...zap.Field) {
+	switch {
+	case errors.Is(err, post.ErrPostNotFound), errors.Is(err, ErrCaseNotFound):
+		http.Error(w, "Not Found", http.StatusNotFound)
+	case errors.Is(err, ErrNotModerator):
+		http.Error(w, "Forbidden: "+err.Error(), http.StatusForbidden)
+	case errors.Is(err, ErrAlreadyReported), errors.Is(err, ErrCaseClaimed),
+		errors.Is(err, ErrCaseResolved), errors.Is(err, ErrNotAssignee):
+		http.Error(w, "Conflict: "+err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrInvalidReason), errors.Is(err, ErrInvalidComment), errors.Is(err, ErrInvalidNote),
+		errors.Is(err, ErrOwnPost), errors.Is(err, ErrUnknownAction), errors.Is(err, ErrUnknownStatus):
+		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+	default:
+		h.logger.Error(msg, append(fields, zap.Error(err))...)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// Report обрабатывает POST /posts/{id}/report.
+func (h *Handler) Report(w http.ResponseWriter, r *http.Request) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) != 4 || parts[1] != "posts" || parts[3] != "report" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	id64, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		http.Error(w, "Bad Request: invalid id", http.StatusBadRequest)
+		return
+	}
+	postID := uint(id64)
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req reportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Bad Request: invalid JSON", http.StatusBadRequest)
+		return
+	}
+	rep, err := h.service.ReportPost(postID, login, req.Reason, req.Comment)
+	if err != nil {
+		h.writeError(w, err, "Failed to report post", zap.Uint("post_id", postID), zap.String("reporter", login))
+		return
+	}
+	writeJSON(w, http.StatusCreated, rep)
+}
+
+// Cases обрабатывает GET /moderation/cases?status=open|claimed|resolved.
+func (h *Handler) Cases(w http.ResponseWriter, r *http.Request) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cases, err := h.service.ListCases(login, r.URL.Query().Get("status"))
+	if err != nil {
+		h.writeError(w, err, "Failed to list moderation cases", zap.String("moderator", login))
+		return
+	}
+	writeJSON(w, http.StatusOK, cases)
+}
+
+// Case обрабатывает GET /moderation/cases/{id}, POST /moderation/cases/{id}/claim
+// и POST /moderation/cases/{id}/resolve.
+func (h *Handler) Case(w http.ResponseWriter, r *http.Request) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+	if len(parts) < 4 || len(parts) > 5 || parts[1] != "moderation" || parts[2] != "cases" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	id64, err := strconv.ParseUint(parts[3], 10, 64)
+	if err != nil {
+		http.Error(w, "Bad Request: invalid id", http.StatusBadRequest)
+		return
+	}
+	id := uint(id64)
+
+	if len(parts) == 4 {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		c, err := h.service.GetCase(id, login)
+		if err != nil {
+			h.writeError(w, err, "Failed to get moderation case", zap.Uint("case_id", id))
+			return
+		}
+		writeJSON(w, http.StatusOK, c)
+		return
+	}
+
+	var c *Case
+	switch parts[4] {
+	case "claim":
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		c, err = h.service.Claim(id, login)
+	case "resolve":
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var req resolveRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Bad Request: invalid JSON", http.StatusBadRequest)
+			return
+		}
+		c, err = h.service.Resolve(id, login, req.Action, req.Note)
+	default:
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		h.writeError(w, err, "Failed to update moderation case", zap.Uint("case_id", id), zap.String("moderator", login))
+		return
+	}
+	writeJSON(w, http.StatusOK, c)
+}
+
+// Audit обрабатывает GET /moderation/audit?actor=&target=&post_id=&limit=.
+func (h *Handler) Audit(w http.ResponseWriter, r *http.Request) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	filter := AuditFilter{Actor: q.Get("actor"), Target: q.Get("target")}
+	if v := q.Get("post_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			http.Error(w, "Bad Request: invalid post_id", http.StatusBadRequest)
+			return
+		}
+		filter.PostID = uint(id)
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, "Bad Request: invalid limit", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = limit
+	}
+
+	entries, err := h.service.ListAudit(login, filter)
+	if err != nil {
+		h.writeError(w, err, "Failed to list moderation audit", zap.String("moderator", login))
+		return
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+
+// Package moderation is a generated GoMock package.
+package moderation
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockservice is a mock of service interface.
+type Mockservice struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceMockRecorder
+}
+
+// MockserviceMockRecorder is the mock recorder for Mockservice.
+type MockserviceMockRecorder struct {
+	mock *Mockservice
+}
+
+// NewMockservice creates a new mock instance.
+func NewMockservice(ctrl *gomock.Controller) *Mockservice {
+	mock := &Mockservice{ctrl: ctrl}
+	mock.recorder = &MockserviceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockservice) EXPECT() *MockserviceMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *Mockservice) Claim(id uint, moderator string) (*Case, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", id, moderator)
+	ret0, _ := ret[0].(*Case)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockserviceMockRecorder) Claim(id, moderator interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*Mockservice)(nil).Claim), id, moderator)
+}
+
+// GetCase mocks base method.
+func (m *Mockservice) GetCase(id uint, moderator string) (*Case, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCase", id, moderator)
+	ret0, _ := ret[0].(*Case)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCase indicates an expected call of GetCase.
+func (mr *MockserviceMockRecorder) GetCase(id, moderator interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCase", reflect.TypeOf((*Mockservice)(nil).GetCase), id, moderator)
+}
+
+// ListAudit mocks base method.
+func (m *Mockservice) ListAudit(moderator string, filter AuditFilter) ([]*AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAudit", moderator, filter)
+	ret0, _ := ret[0].([]*AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAudit indicates an expected call of ListAudit.
+func (mr *MockserviceMockRecorder) ListAudit(moderator, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAudit", reflect.TypeOf((*Mockservice)(nil).ListAudit), moderator, filter)
+}
+
+// ListCases mocks base method.
+func (m *Mockservice) ListCases(moderator, status string) ([]*Case, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCases", moderator, status)
+	ret0, _ := ret[0].([]*Case)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCases indicates an expected call of ListCases.
+func (mr *MockserviceMockRecorder) ListCases(moderator, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCases", reflect.TypeOf((*Mockservice)(nil).ListCases), moderator, status)
+}
+
+// ReportPost mocks base method.
+func (m *Mockservice) ReportPost(postID uint, reporter, reason, comment string) (*Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportPost", postID, reporter, reason, comment)
+	ret0, _ := ret[0].(*Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportPost indicates an expected call of ReportPost.
+func (mr *MockserviceMockRecorder) ReportPost(postID, reporter, reason, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportPost", reflect.TypeOf((*Mockservice)(nil).ReportPost), postID, reporter, reason, comment)
+}
+
+// Resolve mocks base method.
+func (m *Mockservice) Resolve(id uint, moderator, action, note string) (*Case, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", id, moderator, action, note)
+	ret0, _ := ret[0].(*Case)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockserviceMockRecorder) Resolve(id, moderator, action, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*Mockservice)(nil).Resolve), id, moderator, action, note)
+}
@@ -0,0 +1,190 @@
+package moderation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+func newRequest(method, path, body, user string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if user != "" {
+		req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, user))
+	}
+	return req
+}
+
+func TestHandler_Report(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		path       string
+		body       string
+		user       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name: "1. Reported",
+			path: "/posts/7/report",
+			body: `{"reason":"fraud","comment":"Fake photos"}`,
+			user: "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().ReportPost(uint(7), "alice", ReasonFraud, "Fake photos").Return(&Report{ID: 1}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "2. Already_Reported",
+			path: "/posts/7/report",
+			body: `{"reason":"fraud"}`,
+			user: "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().ReportPost(uint(7), "alice", ReasonFraud, "").Return(nil, ErrAlreadyReported)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name: "3. Unknown_Reason",
+			path: "/posts/7/report",
+			body: `{"reason":"boring"}`,
+			user: "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().ReportPost(uint(7), "alice", "boring", "").Return(nil, ErrInvalidReason)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "4. Post_Not_Found",
+			path: "/posts/9/report",
+			body: `{"reason":"spam"}`,
+			user: "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().ReportPost(uint(9), "alice", ReasonSpam, "").Return(nil, post.ErrPostNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "5. Unauthorized",
+			path:         "/posts/7/report",
+			body:         `{"reason":"spam"}`,
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "6. Invalid_JSON",
+			path:         "/posts/7/report",
+			body:         `{`,
+			user:         "alice",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+			rr := httptest.NewRecorder()
+
+			handler.Report(rr, newRequest(http.MethodPost, tc.path, tc.body, tc.user))
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestHandler_Case(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		method     string
+		path       string
+		body       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name:   "1. Get",
+			method: http.MethodGet,
+			path:   "/moderation/cases/1",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetCase(uint(1), "mod").Return(&Case{ID: 1}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "2. Claim_Taken",
+			method: http.MethodPost,
+			path:   "/moderation/cases/1/claim",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Claim(uint(1), "mod").Return(nil, ErrCaseClaimed)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:   "3. Resolve",
+			method: http.MethodPost,
+			path:   "/moderation/cases/1/resolve",
+			body:   `{"action":"warn","note":"first time"}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Resolve(uint(1), "mod", ActionWarn, "first time").Return(&Case{ID: 1}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "4. Not_Moderator",
+			method: http.MethodGet,
+			path:   "/moderation/cases/1",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetCase(uint(1), "mod").Return(nil, ErrNotModerator)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "5. Unknown_Action",
+			method:       http.MethodPost,
+			path:         "/moderation/cases/1/delete",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "6. Invalid_ID",
+			method:       http.MethodGet,
+			path:         "/moderation/cases/abc",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+			rr := httptest.NewRecorder()
+
+			handler.Case(rr, newRequest(tc.method, tc.path, tc.body, "mod"))
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
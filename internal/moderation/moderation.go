@@ -0,0 +1,165 @@
+package moderation
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// KindModeration — уведомление владельцу о решении модератора по его посту.
+const KindModeration = "moderation"
+
+// Причины жалобы.
+const (
+	ReasonProhibited  = "prohibited"
+	ReasonFraud       = "fraud"
+	ReasonCounterfeit = "counterfeit"
+	ReasonSpam        = "spam"
+	ReasonOffensive   = "offensive"
+	ReasonOther       = "other"
+)
+
+var reasons = map[string]bool{
+	ReasonProhibited:  true,
+	ReasonFraud:       true,
+	ReasonCounterfeit: true,
+	ReasonSpam:        true,
+	ReasonOffensive:   true,
+	ReasonOther:       true,
+}
+
+// Состояния дела в очереди модерации.
+//
+//	open ─claim─▶ claimed ─resolve─▶ resolved
+const (
+	StatusOpen     = "open"
+	StatusClaimed  = "claimed"
+	StatusResolved = "resolved"
+)
+
+// Решения модератора по делу.
+const (
+	// ActionDismiss — жалобы необоснованны; автоматически скрытый пост возвращается в ленту
+	ActionDismiss = "dismiss"
+	// ActionHide — пост остаётся скрытым
+	ActionHide = "hide"
+	// ActionWarn — владелец получает предупреждение, пост возвращается в ленту
+	ActionWarn = "warn"
+	// ActionBan — владелец блокируется, все его посты скрываются
+	ActionBan = "ban"
+)
+
+var actions = map[string]bool{
+	ActionDismiss: true,
+	ActionHide:    true,
+	ActionWarn:    true,
+	ActionBan:     true,
+}
+
+// ActionAutoHide — запись журнала об автоматическом скрытии поста после жалоб.
+const ActionAutoHide = "auto_hide"
+
+// ActionFlag — запись журнала о посте, отправленном на модерацию фильтром контента.
+const ActionFlag = "flag"
+
+// ActorSystem — автор автоматических записей журнала.
+const ActorSystem = "system"
+
+const (
+	maxCommentLength  = 1000
+	maxNoteLength     = 1000
+	defaultAuditLimit = 50
+	maxAuditLimit     = 200
+)
+
+var (
+	ErrInvalidReason   = errors.New("unknown report reason")
+	ErrInvalidComment  = errors.New("comment must not exceed 1000 characters")
+	ErrInvalidNote     = errors.New("note must not exceed 1000 characters")
+	ErrOwnPost         = errors.New("cannot report your own post")
+	ErrAlreadyReported = errors.New("you have already reported this post")
+	ErrCaseNotFound    = errors.New("moderation case not found")
+	ErrCaseClaimed     = errors.New("case is claimed by another moderator")
+	ErrCaseResolved    = errors.New("case is already resolved")
+	ErrNotAssignee     = errors.New("claim the case before resolving it")
+	ErrNotModerator    = errors.New("only moderators can access the moderation queue")
+	ErrUnknownAction   = errors.New("unknown moderation action")
+	ErrUnknownStatus   = errors.New("status must be open, claimed or resolved")
+)
+
+// Report — жалоба пользователя на пост. Пользователь может пожаловаться на пост один раз.
+type Report struct {
+	ID        uint      `json:"id"`
+	PostID    uint      `json:"post_id"`
+	CaseID    uint      `json:"case_id"`
+	Reporter  string    `json:"reporter"`
+	Reason    string    `json:"reason"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Case — дело в очереди модерации: все жалобы на пост и замечания фильтра контента до решения модератора.
+// На пост одновременно открыто не больше одного дела.
+type Case struct {
+	ID           uint   `json:"id"`
+	PostID       uint   `json:"post_id"`
+	Owner        string `json:"owner"`
+	Status       string `json:"status"`
+	ReportsCount int    `json:"reports_count"`
+	// AutoHidden — пост скрыт автоматически, когда жалоб набралось достаточно
+	AutoHidden bool   `json:"auto_hidden"`
+	Assignee   string `json:"assignee,omitempty"`
+	Resolution string `json:"resolution,omitempty"`
+	Note       string `json:"note,omitempty"`
+	// Flags — замечания фильтра контента в виде check/code
+	Flags []string `json:"flags,omitempty"`
+
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+
+	Reports []*Report `json:"reports,omitempty"`
+}
+
+// AuditEntry — запись журнала модерации.
+type AuditEntry struct {
+	ID     uint   `json:"id"`
+	Actor  string `json:"actor"`
+	Action string `json:"action"`
+	CaseID uint   `json:"case_id,omitempty"`
+	PostID uint   `json:"post_id,omitempty"`
+	// Target — пользователь, которого касается решение (владелец поста)
+	Target    string    `json:"target,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuditFilter — фильтры журнала; пустые поля не ограничивают выборку.
+type AuditFilter struct {
+	Actor  string
+	Target string
+	PostID uint
+	Limit  int
+}
+
+func (f *AuditFilter) normalize() {
+	if f.Limit <= 0 {
+		f.Limit = defaultAuditLimit
+	}
+	if f.Limit > maxAuditLimit {
+		f.Limit = maxAuditLimit
+	}
+}
+
+// validateReport проверяет причину и комментарий жалобы и обрезает пробелы у комментария.
+func validateReport(r *Report) error {
+	if !reasons[r.Reason] {
+		return ErrInvalidReason
+	}
+	r.Comment = strings.TrimSpace(r.Comment)
+	if utf8.RuneCountInString(r.Comment) > maxCommentLength {
+		return ErrInvalidComment
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package moderation
+
+// mockgen  -source=service.go -destination=service_mock_test.go -package=moderation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/auth"
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+type storage interface {
+	Report(rep *Report, owner string, threshold int) (*Case, bool, error)
+	Flag(postID uint, owner string, flags []string) (*Case, error)
+	ListCases(status string) ([]*Case, error)
+	GetCase(id uint) (*Case, error)
+	Claim(id uint, moderator string) (*Case, error)
+	Resolve(id uint, moderator, action, note string) (*Case, error)
+	ListAudit(filter AuditFilter) ([]*AuditEntry, error)
+}
+
+type posts interface {
+	GetByID(id uint) (*post.Post, error)
+}
+
+type users interface {
+	GetRole(login string) (string, error)
+}
+
+type notifier interface {
+	Notify(msg notify.Message) error
+}
+
+type Service struct {
+	repository storage
+	posts      posts
+	users      users
+	notifier   notifier
+	// autoHide — сколько жалоб скрывает пост до решения модератора; 0 — не скрывать
+	autoHide int
+	logger   *zap.Logger
+}
+
+type Option func(*Service)
+
+// WithNotifier включает уведомления владельцу поста о скрытии и решениях модератора.
+func WithNotifier(notifier notifier) Option {
+	return func(s *Service) {
+		s.notifier = notifier
+	}
+}
+
+func NewService(repository storage, posts posts, users users, autoHide int, logger *zap.Logger, opts ...Option) *Service {
+	s := &Service{
+		repository: repository,
+		posts:      posts,
+		users:      users,
+		autoHide:   autoHide,
+		logger:     logger,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ReportPost принимает жалобу на пост. На свой пост пожаловаться нельзя.
+func (s *Service) ReportPost(postID uint, reporter, reason, comment string) (*Report, error) {
+	rep := &Report{PostID: postID, Reporter: reporter, Reason: reason, Comment: comment}
+	if err := validateReport(rep); err != nil {
+		return nil, err
+	}
+	p, err := s.posts.GetByID(postID)
+	if err != nil {
+		return nil, err
+	}
+	if p.Owner == reporter {
+		return nil, ErrOwnPost
+	}
+
+	c, hidden, err := s.repository.Report(rep, p.Owner, s.autoHide)
+	if err != nil {
+		return nil, err
+	}
+	if hidden {
+		s.notify(c, fmt.Sprintf("Your post %q is hidden pending moderator review", p.Title))
+	}
+	return rep, nil
+}
+
+// OnPostFlagged ставит в очередь модерации пост, сохранённый с замечаниями фильтра контента.
+func (s *Service) OnPostFlagged(p *post.Post) {
+	flags := make([]string, 0, len(p.ContentFlags))
+	for _, r := range p.ContentFlags {
+		flags = append(flags, r.Check+"/"+r.Code)
+	}
+	if _, err := s.repository.Flag(p.ID, p.Owner, flags); err != nil {
+		s.logger.Warn("Failed to flag post for moderation", zap.Uint("post_id", p.ID), zap.Error(err))
+	}
+}
+
+// ListCases возвращает очередь модерации; пустой status — открытые дела.
+func (s *Service) ListCases(moderator, status string) ([]*Case, error) {
+	if err := s.requireModerator(moderator); err != nil {
+		return nil, err
+	}
+	if status == "" {
+		status = StatusOpen
+	}
+	switch status {
+	case StatusOpen, StatusClaimed, StatusResolved:
+	default:
+		return nil, ErrUnknownStatus
+	}
+	return s.repository.ListCases(status)
+}
+
+func (s *Service) GetCase(id uint, moderator string) (*Case, error) {
+	if err := s.requireModerator(moderator); err != nil {
+		return nil, err
+	}
+	return s.repository.GetCase(id)
+}
+
+// Claim берёт дело в работу, чтобы два модератора не решали его одновременно.
+func (s *Service) Claim(id uint, moderator string) (*Case, error) {
+	if err := s.requireModerator(moderator); err != nil {
+		return nil, err
+	}
+	c, err := s.repository.GetCase(id)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case c.Status == StatusResolved:
+		return nil, ErrCaseResolved
+	case c.Status == StatusClaimed && c.Assignee != moderator:
+		return nil, ErrCaseClaimed
+	}
+	return s.repository.Claim(id, moderator)
+}
+
+// Resolve закрывает дело решением action. Решить можно только дело, взятое в работу этим модератором.
+func (s *Service) Resolve(id uint, moderator, action, note string) (*Case, error) {
+	if err := s.requireModerator(moderator); err != nil {
+		return nil, err
+	}
+	if !actions[action] {
+		return nil, ErrUnknownAction
+	}
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > maxNoteLength {
+		return nil, ErrInvalidNote
+	}
+
+	c, err := s.repository.GetCase(id)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case c.Status == StatusResolved:
+		return nil, ErrCaseResolved
+	case c.Status != StatusClaimed || c.Assignee != moderator:
+		return nil, ErrNotAssignee
+	}
+
+	resolved, err := s.repository.Resolve(id, moderator, action, note)
+	if err != nil {
+		return nil, err
+	}
+	switch action {
+	case ActionHide:
+		s.notify(resolved, fmt.Sprintf("Your post #%d was removed by a moderator", resolved.PostID))
+	case ActionWarn:
+		s.notify(resolved, fmt.Sprintf("You received a warning for post #%d", resolved.PostID))
+	case ActionBan:
+		s.notify(resolved, "Your account was banned by a moderator")
+	}
+	return resolved, nil
+}
+
+func (s *Service) ListAudit(moderator string, filter AuditFilter) ([]*AuditEntry, error) {
+	if err := s.requireModerator(moderator); err != nil {
+		return nil, err
+	}
+	filter.normalize()
+	return s.repository.ListAudit(filter)
+}
+
+func (s *Service) requireModerator(login string) error {
+	role, err := s.users.GetRole(login)
+	if err != nil {
+		return err
+	}
+	if role != auth.RoleModerator {
+		return ErrNotModerator
+	}
+	return nil
+}
+
+func (s *Service) notify(c *Case, title string) {
+	if s.notifier == nil {
+		return
+	}
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return
+	}
+	err = s.notifier.Notify(notify.Message{
+		Recipient: c.Owner,
+		Kind:      KindModeration,
+		Title:     title,
+		Payload:   payload,
+		Channel:   notify.ChannelInbox,
+	})
+	if err != nil {
+		s.logger.Warn(
+			"Failed to queue moderation notification",
+			zap.Uint("case_id", c.ID),
+			zap.Error(err),
+		)
+	}
+}
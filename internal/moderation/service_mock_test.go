@@ -0,0 +1,255 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package moderation is a generated GoMock package.
+package moderation
+
+import (
+	reflect "reflect"
+
+	notify "github.com/TemirB/rest-api-marketplace/internal/notify"
+	post "github.com/TemirB/rest-api-marketplace/internal/post"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockstorage is a mock of storage interface.
+type Mockstorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockstorageMockRecorder
+}
+
+// MockstorageMockRecorder is the mock recorder for Mockstorage.
+type MockstorageMockRecorder struct {
+	mock *Mockstorage
+}
+
+// NewMockstorage creates a new mock instance.
+func NewMockstorage(ctrl *gomock.Controller) *Mockstorage {
+	mock := &Mockstorage{ctrl: ctrl}
+	mock.recorder = &MockstorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstorage) EXPECT() *MockstorageMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *Mockstorage) Claim(id uint, moderator string) (*Case, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", id, moderator)
+	ret0, _ := ret[0].(*Case)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockstorageMockRecorder) Claim(id, moderator interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*Mockstorage)(nil).Claim), id, moderator)
+}
+
+// Flag mocks base method.
+func (m *Mockstorage) Flag(postID uint, owner string, flags []string) (*Case, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flag", postID, owner, flags)
+	ret0, _ := ret[0].(*Case)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Flag indicates an expected call of Flag.
+func (mr *MockstorageMockRecorder) Flag(postID, owner, flags interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flag", reflect.TypeOf((*Mockstorage)(nil).Flag), postID, owner, flags)
+}
+
+// GetCase mocks base method.
+func (m *Mockstorage) GetCase(id uint) (*Case, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCase", id)
+	ret0, _ := ret[0].(*Case)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCase indicates an expected call of GetCase.
+func (mr *MockstorageMockRecorder) GetCase(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCase", reflect.TypeOf((*Mockstorage)(nil).GetCase), id)
+}
+
+// ListAudit mocks base method.
+func (m *Mockstorage) ListAudit(filter AuditFilter) ([]*AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAudit", filter)
+	ret0, _ := ret[0].([]*AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAudit indicates an expected call of ListAudit.
+func (mr *MockstorageMockRecorder) ListAudit(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAudit", reflect.TypeOf((*Mockstorage)(nil).ListAudit), filter)
+}
+
+// ListCases mocks base method.
+func (m *Mockstorage) ListCases(status string) ([]*Case, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCases", status)
+	ret0, _ := ret[0].([]*Case)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCases indicates an expected call of ListCases.
+func (mr *MockstorageMockRecorder) ListCases(status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCases", reflect.TypeOf((*Mockstorage)(nil).ListCases), status)
+}
+
+// Report mocks base method.
+func (m *Mockstorage) Report(rep *Report, owner string, threshold int) (*Case, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Report", rep, owner, threshold)
+	ret0, _ := ret[0].(*Case)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Report indicates an expected call of Report.
+func (mr *MockstorageMockRecorder) Report(rep, owner, threshold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Report", reflect.TypeOf((*Mockstorage)(nil).Report), rep, owner, threshold)
+}
+
+// Resolve mocks base method.
+func (m *Mockstorage) Resolve(id uint, moderator, action, note string) (*Case, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", id, moderator, action, note)
+	ret0, _ := ret[0].(*Case)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockstorageMockRecorder) Resolve(id, moderator, action, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*Mockstorage)(nil).Resolve), id, moderator, action, note)
+}
+
+// Mockposts is a mock of posts interface.
+type Mockposts struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostsMockRecorder
+}
+
+// MockpostsMockRecorder is the mock recorder for Mockposts.
+type MockpostsMockRecorder struct {
+	mock *Mockposts
+}
+
+// NewMockposts creates a new mock instance.
+func NewMockposts(ctrl *gomock.Controller) *Mockposts {
+	mock := &Mockposts{ctrl: ctrl}
+	mock.recorder = &MockpostsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockposts) EXPECT() *MockpostsMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *Mockposts) GetByID(id uint) (*post.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*post.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockpostsMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*Mockposts)(nil).GetByID), id)
+}
+
+// Mockusers is a mock of users interface.
+type Mockusers struct {
+	ctrl     *gomock.Controller
+	recorder *MockusersMockRecorder
+}
+
+// MockusersMockRecorder is the mock recorder for Mockusers.
+type MockusersMockRecorder struct {
+	mock *Mockusers
+}
+
+// NewMockusers creates a new mock instance.
+func NewMockusers(ctrl *gomock.Controller) *Mockusers {
+	mock := &Mockusers{ctrl: ctrl}
+	mock.recorder = &MockusersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockusers) EXPECT() *MockusersMockRecorder {
+	return m.recorder
+}
+
+// GetRole mocks base method.
+func (m *Mockusers) GetRole(login string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", login)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockusersMockRecorder) GetRole(login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*Mockusers)(nil).GetRole), login)
+}
+
+// Mocknotifier is a mock of notifier interface.
+type Mocknotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MocknotifierMockRecorder
+}
+
+// MocknotifierMockRecorder is the mock recorder for Mocknotifier.
+type MocknotifierMockRecorder struct {
+	mock *Mocknotifier
+}
+
+// NewMocknotifier creates a new mock instance.
+func NewMocknotifier(ctrl *gomock.Controller) *Mocknotifier {
+	mock := &Mocknotifier{ctrl: ctrl}
+	mock.recorder = &MocknotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocknotifier) EXPECT() *MocknotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *Mocknotifier) Notify(msg notify.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MocknotifierMockRecorder) Notify(msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*Mocknotifier)(nil).Notify), msg)
+}
@@ -0,0 +1,340 @@
+package moderation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/auth"
+	"github.com/TemirB/rest-api-marketplace/internal/content"
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+func TestService_ReportPost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	listing := &post.Post{ID: 7, Owner: "bob", Title: "Phone"}
+
+	testCases := []struct {
+		name string
+
+		reporter   string
+		reason     string
+		setupMocks func(repo *Mockstorage, p *Mockposts, n *Mocknotifier)
+
+		expectedErr error
+	}{
+		{
+			name:     "1. Reported",
+			reporter: "alice",
+			reason:   ReasonFraud,
+			setupMocks: func(repo *Mockstorage, p *Mockposts, n *Mocknotifier) {
+				p.EXPECT().GetByID(uint(7)).Return(listing, nil)
+				repo.EXPECT().Report(gomock.Any(), "bob", 3).Return(&Case{ID: 1, PostID: 7, Owner: "bob", ReportsCount: 1}, false, nil)
+			},
+		},
+		{
+			name:     "2. Auto_Hidden",
+			reporter: "carol",
+			reason:   ReasonSpam,
+			setupMocks: func(repo *Mockstorage, p *Mockposts, n *Mocknotifier) {
+				p.EXPECT().GetByID(uint(7)).Return(listing, nil)
+				repo.EXPECT().Report(gomock.Any(), "bob", 3).
+					Return(&Case{ID: 1, PostID: 7, Owner: "bob", ReportsCount: 3, AutoHidden: true}, true, nil)
+				n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+					assert.Equal(t, "bob", msg.Recipient)
+					assert.Equal(t, KindModeration, msg.Kind)
+					return nil
+				})
+			},
+		},
+		{
+			name:     "3. Own_Post",
+			reporter: "bob",
+			reason:   ReasonSpam,
+			setupMocks: func(repo *Mockstorage, p *Mockposts, n *Mocknotifier) {
+				p.EXPECT().GetByID(uint(7)).Return(listing, nil)
+			},
+			expectedErr: ErrOwnPost,
+		},
+		{
+			name:     "4. Already_Reported",
+			reporter: "alice",
+			reason:   ReasonFraud,
+			setupMocks: func(repo *Mockstorage, p *Mockposts, n *Mocknotifier) {
+				p.EXPECT().GetByID(uint(7)).Return(listing, nil)
+				repo.EXPECT().Report(gomock.Any(), "bob", 3).Return(nil, false, ErrAlreadyReported)
+			},
+			expectedErr: ErrAlreadyReported,
+		},
+		{
+			name:        "5. Unknown_Reason",
+			reporter:    "alice",
+			reason:      "boring",
+			setupMocks:  func(repo *Mockstorage, p *Mockposts, n *Mocknotifier) {},
+			expectedErr: ErrInvalidReason,
+		},
+		{
+			name:     "6. Post_Not_Found",
+			reporter: "alice",
+			reason:   ReasonOther,
+			setupMocks: func(repo *Mockstorage, p *Mockposts, n *Mocknotifier) {
+				p.EXPECT().GetByID(uint(7)).Return(nil, post.ErrPostNotFound)
+			},
+			expectedErr: post.ErrPostNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			p := NewMockposts(ctrl)
+			n := NewMocknotifier(ctrl)
+			tc.setupMocks(repo, p, n)
+			service := NewService(repo, p, NewMockusers(ctrl), 3, zap.NewNop(), WithNotifier(n))
+
+			rep, err := service.ReportPost(7, tc.reporter, tc.reason, "  looks fake  ")
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "looks fake", rep.Comment)
+		})
+	}
+}
+
+func TestService_Claim(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		role       string
+		current    *Case
+		setupMocks func(repo *Mockstorage)
+
+		expectedErr error
+	}{
+		{
+			name:    "1. Claimed",
+			role:    auth.RoleModerator,
+			current: &Case{ID: 1, Status: StatusOpen},
+			setupMocks: func(repo *Mockstorage) {
+				repo.EXPECT().Claim(uint(1), "mod").Return(&Case{ID: 1, Status: StatusClaimed, Assignee: "mod"}, nil)
+			},
+		},
+		{
+			name:        "2. Claimed_By_Another",
+			role:        auth.RoleModerator,
+			current:     &Case{ID: 1, Status: StatusClaimed, Assignee: "other"},
+			setupMocks:  func(repo *Mockstorage) {},
+			expectedErr: ErrCaseClaimed,
+		},
+		{
+			name:        "3. Resolved",
+			role:        auth.RoleModerator,
+			current:     &Case{ID: 1, Status: StatusResolved},
+			setupMocks:  func(repo *Mockstorage) {},
+			expectedErr: ErrCaseResolved,
+		},
+		{
+			name:        "4. Not_Moderator",
+			role:        auth.RoleUser,
+			setupMocks:  func(repo *Mockstorage) {},
+			expectedErr: ErrNotModerator,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			users := NewMockusers(ctrl)
+			users.EXPECT().GetRole("mod").Return(tc.role, nil)
+			if tc.current != nil {
+				repo.EXPECT().GetCase(uint(1)).Return(tc.current, nil)
+			}
+			tc.setupMocks(repo)
+			service := NewService(repo, NewMockposts(ctrl), users, 3, zap.NewNop())
+
+			c, err := service.Claim(1, "mod")
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "mod", c.Assignee)
+		})
+	}
+}
+
+func TestService_Resolve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	claimed := func() *Case {
+		return &Case{ID: 1, PostID: 7, Owner: "bob", Status: StatusClaimed, Assignee: "mod"}
+	}
+
+	testCases := []struct {
+		name string
+
+		action     string
+		note       string
+		setupMocks func(repo *Mockstorage, n *Mocknotifier)
+
+		expectedErr error
+	}{
+		{
+			name:   "1. Ban",
+			action: ActionBan,
+			note:   " counterfeit seller ",
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().GetCase(uint(1)).Return(claimed(), nil)
+				repo.EXPECT().Resolve(uint(1), "mod", ActionBan, "counterfeit seller").
+					Return(&Case{ID: 1, PostID: 7, Owner: "bob", Status: StatusResolved, Resolution: ActionBan}, nil)
+				n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+					assert.Equal(t, "bob", msg.Recipient)
+					return nil
+				})
+			},
+		},
+		{
+			name:   "2. Dismiss_Without_Notification",
+			action: ActionDismiss,
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().GetCase(uint(1)).Return(claimed(), nil)
+				repo.EXPECT().Resolve(uint(1), "mod", ActionDismiss, "").
+					Return(&Case{ID: 1, Owner: "bob", Status: StatusResolved, Resolution: ActionDismiss}, nil)
+			},
+		},
+		{
+			name:   "3. Not_Claimed",
+			action: ActionHide,
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().GetCase(uint(1)).Return(&Case{ID: 1, Status: StatusOpen}, nil)
+			},
+			expectedErr: ErrNotAssignee,
+		},
+		{
+			name:   "4. Already_Resolved",
+			action: ActionHide,
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().GetCase(uint(1)).Return(&Case{ID: 1, Status: StatusResolved}, nil)
+			},
+			expectedErr: ErrCaseResolved,
+		},
+		{
+			name:        "5. Unknown_Action",
+			action:      "delete",
+			setupMocks:  func(repo *Mockstorage, n *Mocknotifier) {},
+			expectedErr: ErrUnknownAction,
+		},
+		{
+			name:   "6. Case_Not_Found",
+			action: ActionWarn,
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().GetCase(uint(1)).Return(nil, ErrCaseNotFound)
+			},
+			expectedErr: ErrCaseNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			users := NewMockusers(ctrl)
+			users.EXPECT().GetRole("mod").Return(auth.RoleModerator, nil)
+			n := NewMocknotifier(ctrl)
+			tc.setupMocks(repo, n)
+			service := NewService(repo, NewMockposts(ctrl), users, 3, zap.NewNop(), WithNotifier(n))
+
+			c, err := service.Resolve(1, "mod", tc.action, tc.note)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, StatusResolved, c.Status)
+		})
+	}
+}
+
+func TestService_ListAudit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		filter     AuditFilter
+		setupMocks func(repo *Mockstorage, users *Mockusers)
+
+		expectedErr error
+	}{
+		{
+			name:   "1. Default_Limit",
+			filter: AuditFilter{Target: "bob"},
+			setupMocks: func(repo *Mockstorage, users *Mockusers) {
+				users.EXPECT().GetRole("mod").Return(auth.RoleModerator, nil)
+				repo.EXPECT().ListAudit(AuditFilter{Target: "bob", Limit: defaultAuditLimit}).Return([]*AuditEntry{}, nil)
+			},
+		},
+		{
+			name:   "2. Limit_Capped",
+			filter: AuditFilter{Limit: 1000},
+			setupMocks: func(repo *Mockstorage, users *Mockusers) {
+				users.EXPECT().GetRole("mod").Return(auth.RoleModerator, nil)
+				repo.EXPECT().ListAudit(AuditFilter{Limit: maxAuditLimit}).Return([]*AuditEntry{}, nil)
+			},
+		},
+		{
+			name:   "3. Role_Lookup_Failed",
+			filter: AuditFilter{},
+			setupMocks: func(repo *Mockstorage, users *Mockusers) {
+				users.EXPECT().GetRole("mod").Return("", errors.New("db is down"))
+			},
+			expectedErr: errors.New("db is down"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			users := NewMockusers(ctrl)
+			tc.setupMocks(repo, users)
+			service := NewService(repo, NewMockposts(ctrl), users, 3, zap.NewNop())
+
+			_, err := service.ListAudit("mod", tc.filter)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_OnPostFlagged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	repo.EXPECT().Flag(uint(7), "bob", []string{"contacts/phone", "spam/caps"}).Return(&Case{ID: 1}, nil)
+	service := NewService(repo, NewMockposts(ctrl), NewMockusers(ctrl), 3, zap.NewNop())
+
+	service.OnPostFlagged(&post.Post{
+		ID:    7,
+		Owner: "bob",
+		ContentFlags: []content.Reason{
+			{Check: "contacts", Code: "phone"},
+			{Check: "spam", Code: "caps"},
+		},
+	})
+}
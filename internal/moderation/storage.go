@@ -0,0 +1,398 @@
+package moderation
+
+// mockgen  -source=storage.go -destination=storage_mock_test.go -package=moderation
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+const (
+	pgForeignKeyViolation = "23503"
+	pgUniqueViolation     = "23505"
+)
+
+const (
+	caseColumns   = "id, post_id, owner, status, reports_count, auto_hidden, assignee, resolution, note, flags, created_at, updated_at, resolved_at"
+	reportColumns = "id, post_id, case_id, reporter, reason, comment, created_at"
+	auditColumns  = "id, actor, action, case_id, post_id, target, note, created_at"
+)
+
+type Repository interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+	Query(query string, args ...any) (*sql.Rows, error)
+	Begin() (*sql.Tx, error)
+}
+
+type Storage struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+func NewStorage(repository Repository, logger *zap.Logger) *Storage {
+	return &Storage{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanCase читает строку в порядке caseColumns.
+func scanCase(row rowScanner, c *Case) error {
+	var (
+		assignee, resolution, note sql.NullString
+		resolvedAt                 sql.NullTime
+	)
+	err := row.Scan(
+		&c.ID, &c.PostID, &c.Owner, &c.Status, &c.ReportsCount, &c.AutoHidden,
+		&assignee, &resolution, &note, pq.Array(&c.Flags), &c.CreatedAt, &c.UpdatedAt, &resolvedAt,
+	)
+	if err != nil {
+		return err
+	}
+	c.Assignee = assignee.String
+	c.Resolution = resolution.String
+	c.Note = note.String
+	c.ResolvedAt = nil
+	if resolvedAt.Valid {
+		c.ResolvedAt = &resolvedAt.Time
+	}
+	return nil
+}
+
+// writeAudit добавляет запись в журнал модерации в рамках транзакции решения.
+func writeAudit(tx *sql.Tx, e *AuditEntry) error {
+	query := `
+		INSERT INTO moderation_audit (actor, action, case_id, post_id, target, note)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+	`
+	_, err := tx.Exec(query, e.Actor, e.Action, e.CaseID, e.PostID, e.Target, e.Note)
+	return err
+}
+
+// Report сохраняет жалобу и добавляет её в открытое дело по посту, заводя дело, если его нет.
+// Когда жалоб в деле становится не меньше threshold (0 — никогда), пост скрывается до решения
+// модератора. Возвращает дело и признак того, что пост скрыт этой жалобой.
+func (r *Storage) Report(rep *Report, owner string, threshold int) (*Case, bool, error) {
+	tx, err := r.repository.Begin()
+	if err != nil {
+		return nil, false, errors.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO moderation_cases (post_id, owner) VALUES ($1, $2)
+		ON CONFLICT (post_id) WHERE status <> 'resolved'
+		DO UPDATE SET reports_count = moderation_cases.reports_count + 1, updated_at = NOW()
+		RETURNING ` + caseColumns
+
+	var c Case
+	if err := scanCase(tx.QueryRow(query, rep.PostID, owner), &c); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgForeignKeyViolation {
+			return nil, false, post.ErrPostNotFound
+		}
+		r.logger.Error("Failed to open moderation case", zap.Uint("post_id", rep.PostID), zap.Error(err))
+		return nil, false, errors.Errorf("failed to open moderation case: %v", err)
+	}
+
+	query = `
+		INSERT INTO reports (post_id, case_id, reporter, reason, comment)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		RETURNING id, created_at
+	`
+	err = tx.QueryRow(query, rep.PostID, c.ID, rep.Reporter, rep.Reason, rep.Comment).Scan(&rep.ID, &rep.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgUniqueViolation {
+			return nil, false, ErrAlreadyReported
+		}
+		r.logger.Error(
+			"Failed to create report",
+			zap.Uint("post_id", rep.PostID),
+			zap.String("reporter", rep.Reporter),
+			zap.Error(err),
+		)
+		return nil, false, errors.Errorf("failed to create report: %v", err)
+	}
+	rep.CaseID = c.ID
+
+	hidden := false
+	if threshold > 0 && c.ReportsCount >= threshold && !c.AutoHidden {
+		res, err := tx.Exec(`UPDATE posts SET hidden = TRUE WHERE id = $1 AND NOT hidden`, rep.PostID)
+		if err != nil {
+			return nil, false, errors.Errorf("failed to hide post: %v", err)
+		}
+		if n, _ := res.RowsAffected(); n > 0 {
+			hidden = true
+			c.AutoHidden = true
+			if _, err := tx.Exec(`UPDATE moderation_cases SET auto_hidden = TRUE WHERE id = $1`, c.ID); err != nil {
+				return nil, false, errors.Errorf("failed to update moderation case: %v", err)
+			}
+			err := writeAudit(tx, &AuditEntry{
+				Actor:  ActorSystem,
+				Action: ActionAutoHide,
+				CaseID: c.ID,
+				PostID: c.PostID,
+				Target: c.Owner,
+				Note:   fmt.Sprintf("%d reports", c.ReportsCount),
+			})
+			if err != nil {
+				return nil, false, errors.Errorf("failed to write audit: %v", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, errors.Errorf("failed to commit report: %v", err)
+	}
+	return &c, hidden, nil
+}
+
+// Flag ставит пост в очередь модерации с замечаниями фильтра контента, заводя дело,
+// если открытого нет, и пишет запись в журнал.
+func (r *Storage) Flag(postID uint, owner string, flags []string) (*Case, error) {
+	tx, err := r.repository.Begin()
+	if err != nil {
+		return nil, errors.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO moderation_cases (post_id, owner, reports_count, flags) VALUES ($1, $2, 0, $3)
+		ON CONFLICT (post_id) WHERE status <> 'resolved'
+		DO UPDATE SET
+			flags = ARRAY(SELECT DISTINCT unnest(moderation_cases.flags || EXCLUDED.flags) ORDER BY 1),
+			updated_at = NOW()
+		RETURNING ` + caseColumns
+
+	var c Case
+	if err := scanCase(tx.QueryRow(query, postID, owner, pq.Array(flags)), &c); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgForeignKeyViolation {
+			return nil, post.ErrPostNotFound
+		}
+		r.logger.Error("Failed to flag post", zap.Uint("post_id", postID), zap.Error(err))
+		return nil, errors.Errorf("failed to flag post: %v", err)
+	}
+
+	err = writeAudit(tx, &AuditEntry{
+		Actor:  ActorSystem,
+		Action: ActionFlag,
+		CaseID: c.ID,
+		PostID: c.PostID,
+		Target: c.Owner,
+		Note:   strings.Join(flags, ", "),
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to write audit: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Errorf("failed to commit flag: %v", err)
+	}
+	return &c, nil
+}
+
+// ListCases возвращает дела в состоянии status; сначала дела с большим числом жалоб.
+func (r *Storage) ListCases(status string) ([]*Case, error) {
+	query := `SELECT ` + caseColumns + ` FROM moderation_cases WHERE status = $1 ORDER BY reports_count DESC, id`
+
+	rows, err := r.repository.Query(query, status)
+	if err != nil {
+		r.logger.Error("Failed to list moderation cases", zap.String("status", status), zap.Error(err))
+		return nil, errors.Errorf("failed to list moderation cases: %v", err)
+	}
+	defer rows.Close()
+
+	cases := []*Case{}
+	for rows.Next() {
+		var c Case
+		if err := scanCase(rows, &c); err != nil {
+			return nil, errors.Errorf("failed to scan moderation case: %v", err)
+		}
+		cases = append(cases, &c)
+	}
+	return cases, rows.Err()
+}
+
+// GetCase возвращает дело вместе с жалобами.
+func (r *Storage) GetCase(id uint) (*Case, error) {
+	query := `SELECT ` + caseColumns + ` FROM moderation_cases WHERE id = $1`
+
+	var c Case
+	if err := scanCase(r.repository.QueryRow(query, id), &c); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCaseNotFound
+		}
+		r.logger.Error("Failed to get moderation case", zap.Uint("id", id), zap.Error(err))
+		return nil, errors.Errorf("failed to get moderation case: %v", err)
+	}
+
+	rows, err := r.repository.Query(`SELECT `+reportColumns+` FROM reports WHERE case_id = $1 ORDER BY id`, id)
+	if err != nil {
+		r.logger.Error("Failed to list reports", zap.Uint("case_id", id), zap.Error(err))
+		return nil, errors.Errorf("failed to list reports: %v", err)
+	}
+	defer rows.Close()
+
+	c.Reports = []*Report{}
+	for rows.Next() {
+		var (
+			rep     Report
+			comment sql.NullString
+		)
+		if err := rows.Scan(&rep.ID, &rep.PostID, &rep.CaseID, &rep.Reporter, &rep.Reason, &comment, &rep.CreatedAt); err != nil {
+			return nil, errors.Errorf("failed to scan report: %v", err)
+		}
+		rep.Comment = comment.String
+		c.Reports = append(c.Reports, &rep)
+	}
+	return &c, rows.Err()
+}
+
+// Claim закрепляет открытое дело за модератором. Повторный claim тем же модератором ничего не меняет.
+func (r *Storage) Claim(id uint, moderator string) (*Case, error) {
+	query := `
+		UPDATE moderation_cases SET status = 'claimed', assignee = $2, updated_at = NOW()
+		WHERE id = $1 AND (status = 'open' OR (status = 'claimed' AND assignee = $2))
+		RETURNING ` + caseColumns
+
+	var c Case
+	if err := scanCase(r.repository.QueryRow(query, id, moderator), &c); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrCaseClaimed
+		}
+		r.logger.Error("Failed to claim moderation case", zap.Uint("id", id), zap.Error(err))
+		return nil, errors.Errorf("failed to claim moderation case: %v", err)
+	}
+	return &c, nil
+}
+
+// Resolve закрывает дело решением action, применяет его к посту и владельцу и пишет
+// запись в журнал — всё в одной транзакции. Закрыть можно только дело, закреплённое за moderator.
+func (r *Storage) Resolve(id uint, moderator, action, note string) (*Case, error) {
+	tx, err := r.repository.Begin()
+	if err != nil {
+		return nil, errors.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE moderation_cases
+		SET status = 'resolved', resolution = $3, note = NULLIF($4, ''), resolved_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND status = 'claimed' AND assignee = $2
+		RETURNING ` + caseColumns
+
+	var c Case
+	if err := scanCase(tx.QueryRow(query, id, moderator, action, note), &c); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotAssignee
+		}
+		r.logger.Error("Failed to resolve moderation case", zap.Uint("id", id), zap.Error(err))
+		return nil, errors.Errorf("failed to resolve moderation case: %v", err)
+	}
+
+	var statements []string
+	switch action {
+	case ActionDismiss, ActionWarn:
+		// Возвращаем в ленту только пост, скрытый жалобами по этому делу
+		if c.AutoHidden {
+			statements = append(statements, `UPDATE posts SET hidden = FALSE WHERE id = $1`)
+		}
+	case ActionHide:
+		statements = append(statements, `UPDATE posts SET hidden = TRUE WHERE id = $1`)
+	case ActionBan:
+		statements = append(statements,
+			`UPDATE users SET banned_at = COALESCE(banned_at, NOW()) WHERE login = (SELECT owner FROM posts WHERE id = $1)`,
+			`UPDATE posts SET hidden = TRUE WHERE owner = (SELECT owner FROM posts WHERE id = $1)`,
+		)
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(stmt, c.PostID); err != nil {
+			r.logger.Error(
+				"Failed to apply moderation decision",
+				zap.Uint("case_id", c.ID),
+				zap.String("action", action),
+				zap.Error(err),
+			)
+			return nil, errors.Errorf("failed to apply moderation decision: %v", err)
+		}
+	}
+
+	err = writeAudit(tx, &AuditEntry{
+		Actor:  moderator,
+		Action: action,
+		CaseID: c.ID,
+		PostID: c.PostID,
+		Target: c.Owner,
+		Note:   note,
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to write audit: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Errorf("failed to commit moderation decision: %v", err)
+	}
+	return &c, nil
+}
+
+// ListAudit возвращает записи журнала, новые первыми.
+func (r *Storage) ListAudit(filter AuditFilter) ([]*AuditEntry, error) {
+	var (
+		sb   strings.Builder
+		args []any
+	)
+	sb.WriteString(`SELECT ` + auditColumns + ` FROM moderation_audit WHERE 1=1`)
+	if filter.Actor != "" {
+		args = append(args, filter.Actor)
+		sb.WriteString(fmt.Sprintf(" AND actor = $%d", len(args)))
+	}
+	if filter.Target != "" {
+		args = append(args, filter.Target)
+		sb.WriteString(fmt.Sprintf(" AND target = $%d", len(args)))
+	}
+	if filter.PostID != 0 {
+		args = append(args, filter.PostID)
+		sb.WriteString(fmt.Sprintf(" AND post_id = $%d", len(args)))
+	}
+	args = append(args, filter.Limit)
+	sb.WriteString(fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args)))
+
+	rows, err := r.repository.Query(sb.String(), args...)
+	if err != nil {
+		r.logger.Error("Failed to list moderation audit", zap.Any("filter", filter), zap.Error(err))
+		return nil, errors.Errorf("failed to list moderation audit: %v", err)
+	}
+	defer rows.Close()
+
+	entries := []*AuditEntry{}
+	for rows.Next() {
+		var (
+			e              AuditEntry
+			caseID, postID sql.NullInt64
+			target, note   sql.NullString
+		)
+		if err := rows.Scan(&e.ID, &e.Actor, &e.Action, &caseID, &postID, &target, &note, &e.CreatedAt); err != nil {
+			return nil, errors.Errorf("failed to scan audit entry: %v", err)
+		}
+		e.CaseID = uint(caseID.Int64)
+		e.PostID = uint(postID.Int64)
+		e.Target = target.String
+		e.Note = note.String
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
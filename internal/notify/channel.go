@@ -0,0 +1,207 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// SignatureHeader содержит HMAC-SHA256 тела запроса вебхука, если задан секрет.
+const SignatureHeader = "X-Marketplace-Signature"
+
+type inbox interface {
+	Insert(n *Notification) error
+}
+
+// InboxChannel складывает уведомления во входящие. Дайджест сохраняется
+// одним уведомлением вида KindDigest со списком сообщений в payload.
+type InboxChannel struct {
+	inbox    inbox
+	onInsert []func(*Notification)
+}
+
+type InboxOption func(*InboxChannel)
+
+// WithOnInsert добавляет обработчик, вызываемый после сохранения уведомления во входящие.
+func WithOnInsert(fn func(*Notification)) InboxOption {
+	return func(c *InboxChannel) {
+		c.onInsert = append(c.onInsert, fn)
+	}
+}
+
+func NewInboxChannel(inbox inbox, opts ...InboxOption) *InboxChannel {
+	c := &InboxChannel{inbox: inbox}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *InboxChannel) insert(n *Notification) error {
+	if err := c.inbox.Insert(n); err != nil {
+		return err
+	}
+	for _, fn := range c.onInsert {
+		fn(n)
+	}
+	return nil
+}
+
+func (c *InboxChannel) Deliver(recipient, _ string, msgs []Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	if len(msgs) == 1 {
+		m := msgs[0]
+		return c.insert(&Notification{
+			Recipient: recipient,
+			Kind:      m.Kind,
+			Title:     m.Title,
+			Payload:   m.Payload,
+			CreatedAt: m.CreatedAt,
+		})
+	}
+
+	payload, err := json.Marshal(msgs)
+	if err != nil {
+		return err
+	}
+	return c.insert(&Notification{
+		Recipient: recipient,
+		Kind:      KindDigest,
+		Title:     fmt.Sprintf("%d new notifications", len(msgs)),
+		Payload:   payload,
+		CreatedAt: msgs[len(msgs)-1].CreatedAt,
+	})
+}
+
+// ErrForbiddenAddress — адрес вебхука не публичный: loopback, частная сеть, link-local
+// (в том числе метаданные облака) и т.п. Туда сервер запросы не отправляет.
+var ErrForbiddenAddress = errors.New("webhook address is not public")
+
+// resolveTimeout ограничивает разрешение имени хоста при проверке URL вебхука.
+const resolveTimeout = 3 * time.Second
+
+// PublicIP сообщает, можно ли отправлять вебхук на ip.
+func PublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast()
+}
+
+// ValidateWebhookURL проверяет, что raw — абсолютный http(s) URL, все адреса хоста которого публичные.
+// Адрес проверяется ещё раз при каждой отправке: DNS мог с тех пор измениться.
+func ValidateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("not an absolute http(s) URL")
+	}
+
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if !PublicIP(ip) {
+			return ErrForbiddenAddress
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("cannot resolve %s", host)
+	}
+	for _, addr := range addrs {
+		if !PublicIP(addr.IP) {
+			return ErrForbiddenAddress
+		}
+	}
+	return nil
+}
+
+// WebhookChannel отправляет сообщения POST-запросом на URL подписки:
+// {"recipient": "...", "notifications": [ Message ]}.
+// Соединения только с публичными адресами, редиректы не выполняются.
+type WebhookChannel struct {
+	client *http.Client
+	secret []byte
+}
+
+func NewWebhookChannel(secret string, timeout time.Duration) *WebhookChannel {
+	return newWebhookChannel(secret, timeout, PublicIP)
+}
+
+// newWebhookChannel подключает проверку адреса к каждому соединению, уже после разрешения имени.
+func newWebhookChannel(secret string, timeout time.Duration, allow func(net.IP) bool) *WebhookChannel {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !allow(ip) {
+				return ErrForbiddenAddress
+			}
+			return nil
+		},
+	}
+	return &WebhookChannel{
+		client: &http.Client{
+			Timeout: timeout,
+			// Прокси не используем: иначе проверялся бы адрес прокси, а не получателя
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: timeout,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		secret: []byte(secret),
+	}
+}
+
+func (c *WebhookChannel) Deliver(recipient, target string, msgs []Message) error {
+	body, err := json.Marshal(struct {
+		Recipient     string    `json:"recipient"`
+		Notifications []Message `json:"notifications"`
+	}{
+		Recipient:     recipient,
+		Notifications: msgs,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(c.secret) > 0 {
+		mac := hmac.New(sha256.New, c.secret)
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
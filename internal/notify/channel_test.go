@@ -0,0 +1,144 @@
+package notify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeInbox struct {
+	inserted []*Notification
+}
+
+func (f *fakeInbox) Insert(n *Notification) error {
+	f.inserted = append(f.inserted, n)
+	return nil
+}
+
+func TestInboxChannel_Deliver(t *testing.T) {
+	t.Run("1. Single_Message", func(t *testing.T) {
+		inbox := &fakeInbox{}
+		err := NewInboxChannel(inbox).Deliver("alice", "", []Message{
+			{Kind: "saved_search_match", Title: "New match", Payload: json.RawMessage(`{"post_id":1}`)},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, inbox.inserted, 1)
+		assert.Equal(t, "saved_search_match", inbox.inserted[0].Kind)
+		assert.Equal(t, "alice", inbox.inserted[0].Recipient)
+	})
+
+	t.Run("2. Digest", func(t *testing.T) {
+		inbox := &fakeInbox{}
+		err := NewInboxChannel(inbox).Deliver("alice", "", []Message{
+			{Kind: "saved_search_match", Title: "First"},
+			{Kind: "saved_search_match", Title: "Second"},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, inbox.inserted, 1)
+		assert.Equal(t, KindDigest, inbox.inserted[0].Kind)
+		assert.Equal(t, "2 new notifications", inbox.inserted[0].Title)
+
+		var msgs []Message
+		assert.NoError(t, json.Unmarshal(inbox.inserted[0].Payload, &msgs))
+		assert.Len(t, msgs, 2)
+	})
+}
+
+func TestWebhookChannel_Deliver(t *testing.T) {
+	var (
+		body      []byte
+		signature string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// Тестовый сервер слушает loopback, поэтому проверку адреса отключаем
+	ch := newWebhookChannel("secret", time.Second, func(net.IP) bool { return true })
+	msgs := []Message{{Kind: "saved_search_match", Title: "New match"}}
+
+	assert.NoError(t, ch.Deliver("alice", server.URL+"/hook", msgs))
+	assert.Contains(t, string(body), `"recipient":"alice"`)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), signature)
+
+	assert.Error(t, ch.Deliver("alice", server.URL+"/fail", msgs))
+}
+
+func TestWebhookChannel_Deliver_Forbidden(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/", http.StatusFound)
+	}))
+	defer server.Close()
+	msgs := []Message{{Kind: "saved_search_match", Title: "New match"}}
+
+	err := NewWebhookChannel("", time.Second).Deliver("alice", server.URL+"/hook", msgs)
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+	assert.Zero(t, calls)
+
+	// Редирект не выполняется и считается ошибкой доставки
+	ch := newWebhookChannel("", time.Second, func(net.IP) bool { return true })
+	assert.Error(t, ch.Deliver("alice", server.URL+"/hook", msgs))
+	assert.Equal(t, 1, calls)
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		url string
+
+		expectedErr error
+	}{
+		{name: "1. Public", url: "https://93.184.216.34/hook"},
+		{name: "2. Loopback", url: "http://127.0.0.1:8080/hook", expectedErr: ErrForbiddenAddress},
+		{name: "3. Localhost", url: "http://localhost/hook", expectedErr: ErrForbiddenAddress},
+		{name: "4. Private", url: "http://10.0.0.5/hook", expectedErr: ErrForbiddenAddress},
+		{name: "5. Metadata", url: "http://169.254.169.254/latest/meta-data/", expectedErr: ErrForbiddenAddress},
+		{name: "6. IPv6_Loopback", url: "http://[::1]/hook", expectedErr: ErrForbiddenAddress},
+		{name: "7. Mapped_Private", url: "http://[::ffff:192.168.1.1]/hook", expectedErr: ErrForbiddenAddress},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateWebhookURL(tc.url)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+	assert.Error(t, ValidateWebhookURL("ftp://93.184.216.34/x"))
+}
+
+func TestInboxChannel_OnInsert(t *testing.T) {
+	var inserted []*Notification
+	ch := NewInboxChannel(&fakeInbox{}, WithOnInsert(func(n *Notification) {
+		inserted = append(inserted, n)
+	}))
+
+	assert.NoError(t, ch.Deliver("alice", "", []Message{{Kind: "test", Title: "Hi"}}))
+	assert.Len(t, inserted, 1)
+	assert.Equal(t, "alice", inserted[0].Recipient)
+}
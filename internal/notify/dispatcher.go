@@ -0,0 +1,183 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrUnknownChannel = errors.New("unknown notification channel")
+	ErrQueueFull      = errors.New("notification queue is full")
+)
+
+const queueSize = 1024
+
+// Channel доставляет пачку сообщений одному получателю.
+// Для немедленной доставки в пачке одно сообщение.
+type Channel interface {
+	Deliver(recipient, target string, msgs []Message) error
+}
+
+type digestKey struct {
+	channel   string
+	recipient string
+	target    string
+}
+
+// Dispatcher принимает уведомления и доставляет их через зарегистрированные каналы.
+// У каждого канала своя очередь и своя горутина доставки (см. Run), поэтому медленный
+// вебхук не задерживает уведомления во входящие. Дайджесты хранятся в памяти и при
+// остановке сервиса отправляются досрочно.
+type Dispatcher struct {
+	channels map[string]Channel
+	queues   map[string]chan Message
+
+	mu      sync.Mutex
+	digests map[digestKey][]Message
+
+	logger *zap.Logger
+}
+
+func NewDispatcher(logger *zap.Logger) *Dispatcher {
+	return &Dispatcher{
+		channels: make(map[string]Channel),
+		queues:   make(map[string]chan Message),
+		digests:  make(map[digestKey][]Message),
+		logger:   logger,
+	}
+}
+
+// Register подключает канал. Вызывается до Run и первого Notify.
+func (d *Dispatcher) Register(name string, ch Channel) {
+	d.channels[name] = ch
+	d.queues[name] = make(chan Message, queueSize)
+}
+
+// Notify ставит сообщение в очередь доставки или в дайджест. Не блокирует.
+func (d *Dispatcher) Notify(msg Message) error {
+	if _, ok := d.channels[msg.Channel]; !ok {
+		return ErrUnknownChannel
+	}
+	if msg.CreatedAt.IsZero() {
+		msg.CreatedAt = time.Now().UTC()
+	}
+
+	if msg.Digest {
+		key := digestKey{channel: msg.Channel, recipient: msg.Recipient, target: msg.Target}
+		d.mu.Lock()
+		d.digests[key] = append(d.digests[key], msg)
+		d.mu.Unlock()
+		return nil
+	}
+
+	select {
+	case d.queues[msg.Channel] <- msg:
+		return nil
+	default:
+		d.logger.Warn(
+			"Notification dropped: queue is full",
+			zap.String("channel", msg.Channel),
+			zap.String("recipient", msg.Recipient),
+			zap.String("kind", msg.Kind),
+		)
+		return ErrQueueFull
+	}
+}
+
+// Run запускает доставку по каждому каналу и раз в digestInterval отправляет дайджесты.
+// Возвращается после того, как очереди разобраны и дайджесты отправлены.
+func (d *Dispatcher) Run(ctx context.Context, digestInterval time.Duration) {
+	var wg sync.WaitGroup
+	for _, queue := range d.queues {
+		wg.Add(1)
+		go func(queue chan Message) {
+			defer wg.Done()
+			d.work(ctx, queue)
+		}(queue)
+	}
+
+	ticker := time.NewTicker(digestInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			d.Flush()
+			return
+		case <-ticker.C:
+			d.Flush()
+		}
+	}
+}
+
+// Flush немедленно отправляет все накопленные дайджесты. Каналы отправляют свои
+// дайджесты параллельно.
+func (d *Dispatcher) Flush() {
+	d.mu.Lock()
+	digests := d.digests
+	d.digests = make(map[digestKey][]Message)
+	d.mu.Unlock()
+
+	byChannel := make(map[string][]digestKey)
+	for key := range digests {
+		byChannel[key.channel] = append(byChannel[key.channel], key)
+	}
+
+	var wg sync.WaitGroup
+	for _, keys := range byChannel {
+		wg.Add(1)
+		go func(keys []digestKey) {
+			defer wg.Done()
+			for _, key := range keys {
+				d.deliver(key, digests[key])
+			}
+		}(keys)
+	}
+	wg.Wait()
+}
+
+// work доставляет сообщения одного канала по порядку, пока не отменён ctx,
+// после чего досылает то, что осталось в очереди.
+func (d *Dispatcher) work(ctx context.Context, queue chan Message) {
+	for {
+		select {
+		case <-ctx.Done():
+			d.drain(queue)
+			return
+		case msg := <-queue:
+			d.deliverOne(msg)
+		}
+	}
+}
+
+func (d *Dispatcher) drain(queue chan Message) {
+	for {
+		select {
+		case msg := <-queue:
+			d.deliverOne(msg)
+		default:
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) deliverOne(msg Message) {
+	d.deliver(digestKey{channel: msg.Channel, recipient: msg.Recipient, target: msg.Target}, []Message{msg})
+}
+
+func (d *Dispatcher) deliver(key digestKey, msgs []Message) {
+	if err := d.channels[key.channel].Deliver(key.recipient, key.target, msgs); err != nil {
+		d.logger.Error(
+			"Failed to deliver notifications",
+			zap.String("channel", key.channel),
+			zap.String("recipient", key.recipient),
+			zap.Int("count", len(msgs)),
+			zap.Error(err),
+		)
+	}
+}
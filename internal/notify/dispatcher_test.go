@@ -0,0 +1,147 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type delivery struct {
+	recipient string
+	target    string
+	msgs      []Message
+}
+
+type fakeChannel struct {
+	mu         sync.Mutex
+	deliveries []delivery
+	delivered  chan struct{}
+}
+
+func newFakeChannel() *fakeChannel {
+	return &fakeChannel{delivered: make(chan struct{}, 16)}
+}
+
+func (c *fakeChannel) Deliver(recipient, target string, msgs []Message) error {
+	c.mu.Lock()
+	c.deliveries = append(c.deliveries, delivery{recipient: recipient, target: target, msgs: msgs})
+	c.mu.Unlock()
+	c.delivered <- struct{}{}
+	return nil
+}
+
+type blockingChannel struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (c *blockingChannel) Deliver(recipient, target string, msgs []Message) error {
+	c.started <- struct{}{}
+	<-c.release
+	return nil
+}
+
+func TestDispatcher_Notify(t *testing.T) {
+	t.Run("1. Unknown_Channel", func(t *testing.T) {
+		d := NewDispatcher(zap.NewNop())
+		assert.ErrorIs(t, d.Notify(Message{Channel: "sms"}), ErrUnknownChannel)
+	})
+
+	t.Run("2. Immediate_Delivery", func(t *testing.T) {
+		ch := newFakeChannel()
+		d := NewDispatcher(zap.NewNop())
+		d.Register(ChannelInbox, ch)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go d.Run(ctx, time.Hour)
+
+		assert.NoError(t, d.Notify(Message{Recipient: "alice", Kind: "test", Channel: ChannelInbox}))
+
+		select {
+		case <-ch.delivered:
+		case <-time.After(time.Second):
+			t.Fatal("message was not delivered")
+		}
+		assert.Len(t, ch.deliveries, 1)
+		assert.Equal(t, "alice", ch.deliveries[0].recipient)
+		assert.False(t, ch.deliveries[0].msgs[0].CreatedAt.IsZero())
+	})
+
+	t.Run("3. Digest_Groups_By_Recipient_And_Target", func(t *testing.T) {
+		ch := newFakeChannel()
+		d := NewDispatcher(zap.NewNop())
+		d.Register(ChannelWebhook, ch)
+
+		assert.NoError(t, d.Notify(Message{Recipient: "alice", Title: "1", Channel: ChannelWebhook, Target: "https://a", Digest: true}))
+		assert.NoError(t, d.Notify(Message{Recipient: "alice", Title: "2", Channel: ChannelWebhook, Target: "https://a", Digest: true}))
+		assert.NoError(t, d.Notify(Message{Recipient: "alice", Title: "3", Channel: ChannelWebhook, Target: "https://b", Digest: true}))
+		assert.Empty(t, ch.deliveries)
+
+		d.Flush()
+
+		sizes := map[string]int{}
+		for _, del := range ch.deliveries {
+			sizes[del.target] = len(del.msgs)
+		}
+		assert.Equal(t, map[string]int{"https://a": 2, "https://b": 1}, sizes)
+
+		d.Flush()
+		assert.Len(t, ch.deliveries, 2)
+	})
+
+	t.Run("4. Digest_Flushed_On_Shutdown", func(t *testing.T) {
+		ch := newFakeChannel()
+		d := NewDispatcher(zap.NewNop())
+		d.Register(ChannelInbox, ch)
+		assert.NoError(t, d.Notify(Message{Recipient: "bob", Channel: ChannelInbox, Digest: true}))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			d.Run(ctx, time.Hour)
+			close(done)
+		}()
+		cancel()
+		<-done
+
+		assert.Len(t, ch.deliveries, 1)
+	})
+
+	t.Run("5. Slow_Webhook_Does_Not_Block_Inbox", func(t *testing.T) {
+		inbox := newFakeChannel()
+		webhook := &blockingChannel{started: make(chan struct{}, 1), release: make(chan struct{})}
+		d := NewDispatcher(zap.NewNop())
+		d.Register(ChannelInbox, inbox)
+		d.Register(ChannelWebhook, webhook)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			d.Run(ctx, time.Hour)
+			close(done)
+		}()
+
+		assert.NoError(t, d.Notify(Message{Recipient: "alice", Channel: ChannelWebhook, Target: "https://a"}))
+		select {
+		case <-webhook.started:
+		case <-time.After(time.Second):
+			t.Fatal("webhook delivery did not start")
+		}
+
+		assert.NoError(t, d.Notify(Message{Recipient: "bob", Channel: ChannelInbox}))
+		select {
+		case <-inbox.delivered:
+		case <-time.After(time.Second):
+			t.Fatal("inbox delivery waited for the webhook")
+		}
+
+		close(webhook.release)
+		cancel()
+		<-done
+	})
+}
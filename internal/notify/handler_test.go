@@ -0,0 +1,85 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+)
+
+func TestHandler_ListNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := NewMockservice(ctrl)
+	handler := NewHandler(mockService, zap.NewNop())
+
+	mockService.EXPECT().ListNotifications("alice", true).Return([]*Notification{{ID: 1, Kind: KindDigest}}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/me/notifications?unread=true", nil)
+	req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, "alice"))
+	rr := httptest.NewRecorder()
+
+	handler.ListNotifications(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"kind":"digest"`)
+}
+
+func TestHandler_MarkRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		path       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name: "1. Marked",
+			path: "/me/notifications/3/read",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().MarkRead(uint(3), "alice").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name: "2. Not_Found",
+			path: "/me/notifications/4/read",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().MarkRead(uint(4), "alice").Return(ErrNotificationNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "3. Invalid_ID",
+			path:         "/me/notifications/x/read",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+
+			req := httptest.NewRequest(http.MethodPost, tc.path, nil)
+			req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, "alice"))
+			rr := httptest.NewRecorder()
+
+			handler.MarkRead(rr, req)
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package notify
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Каналы доставки уведомлений.
+const (
+	ChannelInbox   = "inbox"
+	ChannelWebhook = "webhook"
+)
+
+// KindDigest — уведомление, объединяющее несколько сообщений из дайджеста.
+const KindDigest = "digest"
+
+// Message — уведомление, которое нужно доставить получателю через канал Channel.
+// Сообщения с Digest копятся и доставляются одной пачкой раз в интервал дайджеста.
+type Message struct {
+	Recipient string          `json:"-"`
+	Kind      string          `json:"kind"`
+	Title     string          `json:"title"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+
+	Channel string `json:"-"`
+	Target  string `json:"-"` // адрес для канала, например URL вебхука
+	Digest  bool   `json:"-"`
+}
+
+// Notification — уведомление во входящих пользователя.
+type Notification struct {
+	ID        uint            `json:"id"`
+	Recipient string          `json:"-"`
+	Kind      string          `json:"kind"`
+	Title     string          `json:"title"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	ReadAt    *time.Time      `json:"read_at,omitempty"`
+}
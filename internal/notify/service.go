@@ -0,0 +1,33 @@
+package notify
+
+// mockgen  -source=service.go -destination=service_mock_test.go -package=notify
+
+import (
+	"go.uber.org/zap"
+)
+
+type storage interface {
+	List(recipient string, unreadOnly bool) ([]*Notification, error)
+	MarkRead(id uint, recipient string) error
+}
+
+// Service — входящие уведомления пользователя.
+type Service struct {
+	repository storage
+	logger     *zap.Logger
+}
+
+func NewService(repository storage, logger *zap.Logger) *Service {
+	return &Service{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+func (s *Service) ListNotifications(recipient string, unreadOnly bool) ([]*Notification, error) {
+	return s.repository.List(recipient, unreadOnly)
+}
+
+func (s *Service) MarkRead(id uint, recipient string) error {
+	return s.repository.MarkRead(id, recipient)
+}
@@ -0,0 +1,110 @@
+package notify
+
+// mockgen  -source=storage.go -destination=storage_mock_test.go -package=notify
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var ErrNotificationNotFound = errors.New("notification not found")
+
+type Repository interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+type Storage struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+func NewStorage(repository Repository, logger *zap.Logger) *Storage {
+	return &Storage{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+func (r *Storage) Insert(n *Notification) error {
+	query := `
+		INSERT INTO notifications (recipient, kind, title, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+	var payload any
+	if len(n.Payload) > 0 {
+		payload = string(n.Payload)
+	}
+	err := r.repository.QueryRow(query, n.Recipient, n.Kind, n.Title, payload, n.CreatedAt).Scan(&n.ID)
+	if err != nil {
+		r.logger.Error(
+			"Failed to insert notification",
+			zap.String("recipient", n.Recipient),
+			zap.Error(err),
+		)
+		return errors.Errorf("failed to insert notification: %v", err)
+	}
+	return nil
+}
+
+// List возвращает уведомления получателя, новые первыми.
+func (r *Storage) List(recipient string, unreadOnly bool) ([]*Notification, error) {
+	query := `
+		SELECT id, recipient, kind, title, payload, created_at, read_at
+		FROM notifications
+		WHERE recipient = $1 AND ($2 = FALSE OR read_at IS NULL)
+		ORDER BY created_at DESC, id DESC
+	`
+	rows, err := r.repository.Query(query, recipient, unreadOnly)
+	if err != nil {
+		r.logger.Error(
+			"Failed to list notifications",
+			zap.String("recipient", recipient),
+			zap.Error(err),
+		)
+		return nil, errors.Wrap(err, "failed to list notifications")
+	}
+	defer rows.Close()
+
+	notifications := []*Notification{}
+	for rows.Next() {
+		var (
+			n       Notification
+			payload sql.NullString
+			readAt  sql.NullTime
+		)
+		if err := rows.Scan(&n.ID, &n.Recipient, &n.Kind, &n.Title, &payload, &n.CreatedAt, &readAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan notification")
+		}
+		if payload.Valid {
+			n.Payload = []byte(payload.String)
+		}
+		if readAt.Valid {
+			n.ReadAt = &readAt.Time
+		}
+		notifications = append(notifications, &n)
+	}
+	return notifications, rows.Err()
+}
+
+// MarkRead отмечает уведомление прочитанным. Повторная отметка не меняет время прочтения.
+func (r *Storage) MarkRead(id uint, recipient string) error {
+	query := `UPDATE notifications SET read_at = COALESCE(read_at, NOW()) WHERE id = $1 AND recipient = $2`
+	res, err := r.repository.Exec(query, id, recipient)
+	if err != nil {
+		r.logger.Error(
+			"Failed to mark notification as read",
+			zap.Uint("id", id),
+			zap.Error(err),
+		)
+		return errors.Errorf("failed to mark notification as read: %v", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
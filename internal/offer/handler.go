@@ -0,0 +1,210 @@
+package offer
+
+// mockgen  -source=handler.go -destination=handler_mock_test.go -package=offer
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/block"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+type service interface {
+	MakeOffer(postID uint, buyer string, price money.Money, message string) (*Offer, error)
+	GetOffer(id uint, login string) (*Offer, error)
+	ListPostOffers(postID uint, login string) ([]*Offer, error)
+	ListOffers(login, role, status string) ([]*Offer, error)
+	Respond(id uint, actor, action string, counter *money.Money) (*Offer, error)
+}
+
+type Handler struct {
+	service service
+	logger  *zap.Logger
+}
+
+func NewHandler(service service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+type offerRequest struct {
+	Price   money.Money `json:"price"`
+	Message string      `json:"message"`
+}
+
+type counterRequest struct {
+	Price *money.Money `json:"price"`
+}
+
+// writeError переводит ошибки предложений в HTTP-статусы.
+func (h *Handler) writeError(w http.ResponseWriter, err error, msg string, fields ...zap.Field) {
+	switch {
+	case errors.Is(err, ErrOfferNotFound), errors.Is(err, post.ErrPostNotFound):
+		http.Error(w, "Not Found", http.StatusNotFound)
+	case errors.Is(err, ErrNotParticipant), errors.Is(err, ErrNotYourTurn), errors.Is(err, block.ErrBlocked):
+		http.Error(w, "Forbidden: "+err.Error(), http.StatusForbidden)
+	case errors.Is(err, ErrOfferClosed), errors.Is(err, ErrOfferExists), errors.Is(err, ErrPostNotAvailable):
+		http.Error(w, "Conflict: "+err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrOwnPost), errors.Is(err, ErrInvalidPrice), errors.Is(err, ErrInvalidCounter),
+		errors.Is(err, ErrInvalidMessage), errors.Is(err, ErrUnknownAction):
+		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+	default:
+		h.logger.Error(msg, append(fields, zap.Error(err))...)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// PostOffers обрабатывает /posts/{id}/offers: POST — сделать предложение, GET — предложения по своему посту.
+func (h *Handler) PostOffers(w http.ResponseWriter, r *http.Request) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) != 4 || parts[1] != "posts" || parts[3] != "offers" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	id64, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		http.Error(w, "Bad Request: invalid id", http.StatusBadRequest)
+		return
+	}
+	postID := uint(id64)
+
+	switch r.Method {
+	case http.MethodPost:
+		var req offerRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Bad Request: invalid JSON", http.StatusBadRequest)
+			return
+		}
+		o, err := h.service.MakeOffer(postID, login, req.Price, req.Message)
+		if err != nil {
+			h.writeError(w, err, "Failed to make offer", zap.Uint("post_id", postID))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(o)
+
+	case http.MethodGet:
+		offers, err := h.service.ListPostOffers(postID, login)
+		if err != nil {
+			h.writeError(w, err, "Failed to list post offers", zap.Uint("post_id", postID))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(offers)
+
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// ListOffers — GET /me/offers?role=buyer|seller&status=.
+func (h *Handler) ListOffers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+	role := q.Get("role")
+	if role != "" && role != "buyer" && role != "seller" {
+		http.Error(w, "Bad Request: role must be buyer or seller", http.StatusBadRequest)
+		return
+	}
+
+	offers, err := h.service.ListOffers(login, role, q.Get("status"))
+	if err != nil {
+		h.writeError(w, err, "Failed to list offers", zap.String("login", login))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(offers)
+}
+
+// Offer обрабатывает GET /offers/{id} и POST /offers/{id}/{accept|reject|counter|withdraw}.
+func (h *Handler) Offer(w http.ResponseWriter, r *http.Request) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || len(parts) > 4 || parts[1] != "offers" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	id64, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		http.Error(w, "Bad Request: invalid id", http.StatusBadRequest)
+		return
+	}
+	id := uint(id64)
+
+	if len(parts) == 3 {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		o, err := h.service.GetOffer(id, login)
+		if err != nil {
+			h.writeError(w, err, "Failed to get offer", zap.Uint("offer_id", id))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(o)
+		return
+	}
+
+	action := parts[3]
+	switch action {
+	case ActionAccept, ActionReject, ActionCounter, ActionWithdraw:
+	default:
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var counter *money.Money
+	if action == ActionCounter {
+		var req counterRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Price == nil {
+			http.Error(w, "Bad Request: invalid JSON or price", http.StatusBadRequest)
+			return
+		}
+		counter = req.Price
+	}
+
+	o, err := h.service.Respond(id, login, action, counter)
+	if err != nil {
+		h.writeError(w, err, "Failed to respond to offer", zap.Uint("offer_id", id), zap.String("action", action))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(o)
+}
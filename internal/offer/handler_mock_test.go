@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+
+// Package offer is a generated GoMock package.
+package offer
+
+import (
+	reflect "reflect"
+
+	money "github.com/TemirB/rest-api-marketplace/pkg/money"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockservice is a mock of service interface.
+type Mockservice struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceMockRecorder
+}
+
+// MockserviceMockRecorder is the mock recorder for Mockservice.
+type MockserviceMockRecorder struct {
+	mock *Mockservice
+}
+
+// NewMockservice creates a new mock instance.
+func NewMockservice(ctrl *gomock.Controller) *Mockservice {
+	mock := &Mockservice{ctrl: ctrl}
+	mock.recorder = &MockserviceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockservice) EXPECT() *MockserviceMockRecorder {
+	return m.recorder
+}
+
+// GetOffer mocks base method.
+func (m *Mockservice) GetOffer(id uint, login string) (*Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOffer", id, login)
+	ret0, _ := ret[0].(*Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOffer indicates an expected call of GetOffer.
+func (mr *MockserviceMockRecorder) GetOffer(id, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOffer", reflect.TypeOf((*Mockservice)(nil).GetOffer), id, login)
+}
+
+// ListOffers mocks base method.
+func (m *Mockservice) ListOffers(login, role, status string) ([]*Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOffers", login, role, status)
+	ret0, _ := ret[0].([]*Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOffers indicates an expected call of ListOffers.
+func (mr *MockserviceMockRecorder) ListOffers(login, role, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOffers", reflect.TypeOf((*Mockservice)(nil).ListOffers), login, role, status)
+}
+
+// ListPostOffers mocks base method.
+func (m *Mockservice) ListPostOffers(postID uint, login string) ([]*Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPostOffers", postID, login)
+	ret0, _ := ret[0].([]*Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPostOffers indicates an expected call of ListPostOffers.
+func (mr *MockserviceMockRecorder) ListPostOffers(postID, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostOffers", reflect.TypeOf((*Mockservice)(nil).ListPostOffers), postID, login)
+}
+
+// MakeOffer mocks base method.
+func (m *Mockservice) MakeOffer(postID uint, buyer string, price money.Money, message string) (*Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MakeOffer", postID, buyer, price, message)
+	ret0, _ := ret[0].(*Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MakeOffer indicates an expected call of MakeOffer.
+func (mr *MockserviceMockRecorder) MakeOffer(postID, buyer, price, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeOffer", reflect.TypeOf((*Mockservice)(nil).MakeOffer), postID, buyer, price, message)
+}
+
+// Respond mocks base method.
+func (m *Mockservice) Respond(id uint, actor, action string, counter *money.Money) (*Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Respond", id, actor, action, counter)
+	ret0, _ := ret[0].(*Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Respond indicates an expected call of Respond.
+func (mr *MockserviceMockRecorder) Respond(id, actor, action, counter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Respond", reflect.TypeOf((*Mockservice)(nil).Respond), id, actor, action, counter)
+}
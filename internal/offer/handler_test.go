@@ -0,0 +1,218 @@
+package offer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+func newRequest(method, path, body, user string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if user != "" {
+		req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, user))
+	}
+	return req
+}
+
+func TestHandler_PostOffers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		method     string
+		path       string
+		body       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name:   "1. Created",
+			method: http.MethodPost,
+			path:   "/posts/1/offers",
+			body:   `{"price":{"amount":"800","currency":"RUB"},"message":"Cash today"}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().MakeOffer(uint(1), "alice", money.MustParse("800", "RUB"), "Cash today").Return(&Offer{ID: 5}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "2. Open_Offer_Exists",
+			method: http.MethodPost,
+			path:   "/posts/1/offers",
+			body:   `{"price":800}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().MakeOffer(uint(1), "alice", gomock.Any(), "").Return(nil, ErrOfferExists)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:   "3. Post_Not_Found",
+			method: http.MethodPost,
+			path:   "/posts/1/offers",
+			body:   `{"price":800}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().MakeOffer(uint(1), "alice", gomock.Any(), "").Return(nil, post.ErrPostNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "4. List_Not_Owner",
+			method: http.MethodGet,
+			path:   "/posts/1/offers",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().ListPostOffers(uint(1), "alice").Return(nil, ErrNotParticipant)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "5. Invalid_ID",
+			method:       http.MethodGet,
+			path:         "/posts/abc/offers",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+			rr := httptest.NewRecorder()
+
+			handler.PostOffers(rr, newRequest(tc.method, tc.path, tc.body, "alice"))
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestHandler_Offer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	counter := money.MustParse("900", "RUB")
+
+	testCases := []struct {
+		name string
+
+		method     string
+		path       string
+		body       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name:   "1. Get",
+			method: http.MethodGet,
+			path:   "/offers/5",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetOffer(uint(5), "bob").Return(&Offer{ID: 5}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "2. Accept",
+			method: http.MethodPost,
+			path:   "/offers/5/accept",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Respond(uint(5), "bob", ActionAccept, nil).Return(&Offer{ID: 5, Status: StatusAccepted}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "3. Counter",
+			method: http.MethodPost,
+			path:   "/offers/5/counter",
+			body:   `{"price":{"amount":"900","currency":"RUB"}}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Respond(uint(5), "bob", ActionCounter, &counter).Return(&Offer{ID: 5, Status: StatusCountered}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "4. Counter_Without_Price",
+			method:       http.MethodPost,
+			path:         "/offers/5/counter",
+			body:         `{}`,
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "5. Closed",
+			method: http.MethodPost,
+			path:   "/offers/5/reject",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Respond(uint(5), "bob", ActionReject, nil).Return(nil, ErrOfferClosed)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:   "6. Not_Your_Turn",
+			method: http.MethodPost,
+			path:   "/offers/5/withdraw",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Respond(uint(5), "bob", ActionWithdraw, nil).Return(nil, ErrNotYourTurn)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "7. Unknown_Action",
+			method:       http.MethodPost,
+			path:         "/offers/5/haggle",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "8. Wrong_Method",
+			method:       http.MethodGet,
+			path:         "/offers/5/accept",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+			rr := httptest.NewRecorder()
+
+			handler.Offer(rr, newRequest(tc.method, tc.path, tc.body, "bob"))
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestHandler_ListOffers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := NewMockservice(ctrl)
+	mockService.EXPECT().ListOffers("alice", "buyer", StatusPending).Return([]*Offer{{ID: 5}}, nil)
+	handler := NewHandler(mockService, zap.NewNop())
+
+	rr := httptest.NewRecorder()
+	handler.ListOffers(rr, newRequest(http.MethodGet, "/me/offers?role=buyer&status=pending", "", "alice"))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.ListOffers(rr, newRequest(http.MethodGet, "/me/offers?role=admin", "", "alice"))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,154 @@
+package offer
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+// Состояния предложения. pending и countered — открытые: в pending ход продавца,
+// в countered (продавец предложил свою цену) — ход покупателя. Остальные конечные.
+const (
+	StatusPending   = "pending"
+	StatusAccepted  = "accepted"
+	StatusRejected  = "rejected"
+	StatusCountered = "countered"
+	StatusExpired   = "expired"
+	StatusWithdrawn = "withdrawn"
+)
+
+// Действия участников над предложением.
+const (
+	ActionAccept   = "accept"
+	ActionReject   = "reject"
+	ActionCounter  = "counter"
+	ActionWithdraw = "withdraw"
+)
+
+// KindOffer — уведомление о новом предложении или смене его состояния.
+const KindOffer = "offer"
+
+const maxMessageLength = 500
+
+var (
+	ErrOfferNotFound    = errors.New("offer not found")
+	ErrNotParticipant   = errors.New("not a participant of the offer")
+	ErrNotYourTurn      = errors.New("it is not your turn to act on the offer")
+	ErrOfferClosed      = errors.New("offer is no longer open")
+	ErrOfferExists      = errors.New("you already have an open offer for this post")
+	ErrOwnPost          = errors.New("cannot make an offer on your own post")
+	ErrPostNotAvailable = errors.New("post is not available for offers")
+	ErrInvalidPrice     = errors.New("offer must be positive, in the post currency and below the asking price")
+	ErrInvalidCounter   = errors.New("counter offer must be above the offer and not above the asking price")
+	ErrInvalidMessage   = errors.New("message must not exceed 500 characters")
+	ErrUnknownAction    = errors.New("unknown offer action")
+)
+
+// Offer — предложение цены покупателя по посту.
+type Offer struct {
+	ID     uint   `json:"id"`
+	PostID uint   `json:"post_id"`
+	Buyer  string `json:"buyer"`
+	Seller string `json:"seller"`
+
+	Price        money.Money  `json:"price"`
+	CounterPrice *money.Money `json:"counter_price,omitempty"`
+	Message      string       `json:"message,omitempty"`
+	Status       string       `json:"status"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// IsOpen сообщает, ждёт ли предложение ответа.
+func (o *Offer) IsOpen() bool {
+	return o.Status == StatusPending || o.Status == StatusCountered
+}
+
+// IsParticipant сообщает, видит ли пользователь предложение.
+func (o *Offer) IsParticipant(login string) bool {
+	return login != "" && (login == o.Buyer || login == o.Seller)
+}
+
+// FinalPrice — цена сделки: встречная цена продавца, если она была, иначе цена покупателя.
+func (o *Offer) FinalPrice() money.Money {
+	if o.CounterPrice != nil {
+		return *o.CounterPrice
+	}
+	return o.Price
+}
+
+// Next возвращает состояние, в которое переходит предложение, когда actor выполняет action.
+// Отвечать (accept, reject, counter) может тот, чей сейчас ход; отозвать — только покупатель;
+// встречную цену предлагает только продавец.
+func (o *Offer) Next(actor, action string) (string, error) {
+	if !o.IsParticipant(actor) {
+		return "", ErrNotParticipant
+	}
+	if !o.IsOpen() {
+		return "", ErrOfferClosed
+	}
+
+	turn := o.Seller
+	if o.Status == StatusCountered {
+		turn = o.Buyer
+	}
+
+	switch action {
+	case ActionWithdraw:
+		if actor != o.Buyer {
+			return "", ErrNotYourTurn
+		}
+		return StatusWithdrawn, nil
+	case ActionAccept, ActionReject:
+		if actor != turn {
+			return "", ErrNotYourTurn
+		}
+		if action == ActionAccept {
+			return StatusAccepted, nil
+		}
+		return StatusRejected, nil
+	case ActionCounter:
+		if actor != turn || actor != o.Seller {
+			return "", ErrNotYourTurn
+		}
+		return StatusCountered, nil
+	default:
+		return "", ErrUnknownAction
+	}
+}
+
+// validatePrice проверяет, что предложение положительное, в валюте поста и ниже запрошенной цены.
+func validatePrice(price, asking money.Money) error {
+	if !price.IsPositive() || price.Currency() != asking.Currency() {
+		return ErrInvalidPrice
+	}
+	if cmp, err := price.Cmp(asking); err != nil || cmp >= 0 {
+		return ErrInvalidPrice
+	}
+	return nil
+}
+
+// validateCounter проверяет, что встречная цена выше предложения и не выше запрошенной.
+func validateCounter(counter, offered, asking money.Money) error {
+	if counter.Currency() != offered.Currency() {
+		return ErrInvalidCounter
+	}
+	if cmp, err := counter.Cmp(offered); err != nil || cmp <= 0 {
+		return ErrInvalidCounter
+	}
+	if cmp, err := counter.Cmp(asking); err != nil || cmp > 0 {
+		return ErrInvalidCounter
+	}
+	return nil
+}
+
+func validateMessage(msg string) error {
+	if utf8.RuneCountInString(msg) > maxMessageLength {
+		return ErrInvalidMessage
+	}
+	return nil
+}
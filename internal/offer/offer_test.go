@@ -0,0 +1,86 @@
+package offer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+func TestOffer_Next(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		status string
+		actor  string
+		action string
+
+		expected    string
+		expectedErr error
+	}{
+		{name: "1. Seller_Accepts_Pending", status: StatusPending, actor: "bob", action: ActionAccept, expected: StatusAccepted},
+		{name: "2. Seller_Rejects_Pending", status: StatusPending, actor: "bob", action: ActionReject, expected: StatusRejected},
+		{name: "3. Seller_Counters_Pending", status: StatusPending, actor: "bob", action: ActionCounter, expected: StatusCountered},
+		{name: "4. Buyer_Cannot_Accept_Own_Offer", status: StatusPending, actor: "alice", action: ActionAccept, expectedErr: ErrNotYourTurn},
+		{name: "5. Buyer_Accepts_Counter", status: StatusCountered, actor: "alice", action: ActionAccept, expected: StatusAccepted},
+		{name: "6. Seller_Cannot_Accept_Own_Counter", status: StatusCountered, actor: "bob", action: ActionAccept, expectedErr: ErrNotYourTurn},
+		{name: "7. Buyer_Cannot_Counter", status: StatusCountered, actor: "alice", action: ActionCounter, expectedErr: ErrNotYourTurn},
+		{name: "8. Buyer_Withdraws_Countered", status: StatusCountered, actor: "alice", action: ActionWithdraw, expected: StatusWithdrawn},
+		{name: "9. Seller_Cannot_Withdraw", status: StatusPending, actor: "bob", action: ActionWithdraw, expectedErr: ErrNotYourTurn},
+		{name: "10. Closed_Offer", status: StatusExpired, actor: "bob", action: ActionAccept, expectedErr: ErrOfferClosed},
+		{name: "11. Stranger", status: StatusPending, actor: "eve", action: ActionAccept, expectedErr: ErrNotParticipant},
+		{name: "12. Unknown_Action", status: StatusPending, actor: "bob", action: "ignore", expectedErr: ErrUnknownAction},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := &Offer{Buyer: "alice", Seller: "bob", Status: tc.status}
+
+			next, err := o.Next(tc.actor, tc.action)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, next)
+		})
+	}
+}
+
+func TestValidatePrice(t *testing.T) {
+	asking := money.MustParse("1000", "RUB")
+
+	testCases := []struct {
+		name string
+
+		price money.Money
+
+		wantErr bool
+	}{
+		{name: "1. Below_Asking", price: money.MustParse("800", "RUB")},
+		{name: "2. Equal_To_Asking", price: money.MustParse("1000", "RUB"), wantErr: true},
+		{name: "3. Zero", price: money.MustParse("0", "RUB"), wantErr: true},
+		{name: "4. Other_Currency", price: money.MustParse("10", "USD"), wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePrice(tc.price, asking)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPrice)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestOffer_FinalPrice(t *testing.T) {
+	counter := money.MustParse("900", "RUB")
+	o := &Offer{Price: money.MustParse("800", "RUB")}
+	assert.Equal(t, money.MustParse("800", "RUB"), o.FinalPrice())
+
+	o.CounterPrice = &counter
+	assert.Equal(t, counter, o.FinalPrice())
+}
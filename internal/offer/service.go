@@ -0,0 +1,302 @@
+package offer
+
+// mockgen  -source=service.go -destination=service_mock_test.go -package=offer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+type storage interface {
+	Create(o *Offer) error
+	GetByID(id uint) (*Offer, error)
+	ListByPost(postID uint) ([]*Offer, error)
+	ListByUser(login, role, status string) ([]*Offer, error)
+	Transition(id uint, from, to string, counter *money.Money, expiresAt time.Time) (*Offer, error)
+	Accept(id uint, from string) (*Offer, []*Offer, error)
+	Expire() ([]*Offer, error)
+}
+
+type posts interface {
+	GetPostByID(id uint) (*post.Post, error)
+}
+
+type notifier interface {
+	Notify(msg notify.Message) error
+}
+
+// blocks запрещает торг, если кто-то из участников заблокировал другого.
+type blocks interface {
+	Check(a, b string) error
+}
+
+type Service struct {
+	repository storage
+	posts      posts
+	ttl        time.Duration
+	notifier   notifier
+	blocks     blocks
+	now        func() time.Time
+	logger     *zap.Logger
+}
+
+type Option func(*Service)
+
+// WithNotifier включает уведомления участникам о новых предложениях и ответах на них.
+func WithNotifier(notifier notifier) Option {
+	return func(s *Service) {
+		s.notifier = notifier
+	}
+}
+
+// WithBlocks запрещает предлагать цену и торговаться тем, с кем есть блокировка.
+func WithBlocks(blocks blocks) Option {
+	return func(s *Service) {
+		s.blocks = blocks
+	}
+}
+
+// NewService создаёт сервис предложений; ttl — сколько предложение (и встречная цена) ждёт ответа.
+func NewService(repository storage, posts posts, ttl time.Duration, logger *zap.Logger, opts ...Option) *Service {
+	s := &Service{
+		repository: repository,
+		posts:      posts,
+		ttl:        ttl,
+		now:        time.Now,
+		logger:     logger,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// MakeOffer создаёт предложение покупателя. Валюта по умолчанию — валюта поста.
+func (s *Service) MakeOffer(postID uint, buyer string, price money.Money, message string) (*Offer, error) {
+	p, err := s.posts.GetPostByID(postID)
+	if err != nil {
+		return nil, err
+	}
+	if p.Owner == buyer {
+		return nil, ErrOwnPost
+	}
+	if p.Hidden || p.Status != post.StatusActive || p.ListingType == post.ListingAuction {
+		return nil, ErrPostNotAvailable
+	}
+	if err := s.checkBlocked(buyer, p.Owner); err != nil {
+		return nil, err
+	}
+
+	if !price.HasCurrency() {
+		price = price.WithCurrency(p.Price.Currency())
+	}
+	if err := price.Validate(); err != nil {
+		return nil, ErrInvalidPrice
+	}
+	if err := validatePrice(price, p.Price); err != nil {
+		return nil, err
+	}
+	if err := validateMessage(message); err != nil {
+		return nil, err
+	}
+
+	o := &Offer{
+		PostID:    postID,
+		Buyer:     buyer,
+		Seller:    p.Owner,
+		Price:     price,
+		Message:   message,
+		ExpiresAt: s.now().Add(s.ttl),
+	}
+	if err := s.repository.Create(o); err != nil {
+		return nil, err
+	}
+	s.notify(o.Seller, o, fmt.Sprintf("New offer: %s from %s", o.Price, o.Buyer))
+	return o, nil
+}
+
+// GetOffer возвращает предложение участнику сделки.
+func (s *Service) GetOffer(id uint, login string) (*Offer, error) {
+	o, err := s.repository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !o.IsParticipant(login) {
+		return nil, ErrNotParticipant
+	}
+	return o, nil
+}
+
+// ListPostOffers возвращает предложения по посту; доступно только владельцу поста.
+func (s *Service) ListPostOffers(postID uint, login string) ([]*Offer, error) {
+	p, err := s.posts.GetPostByID(postID)
+	if err != nil {
+		return nil, err
+	}
+	if p.Owner != login {
+		return nil, ErrNotParticipant
+	}
+	return s.repository.ListByPost(postID)
+}
+
+func (s *Service) ListOffers(login, role, status string) ([]*Offer, error) {
+	return s.repository.ListByUser(login, role, status)
+}
+
+// Respond выполняет действие участника над предложением. counter нужен только для ActionCounter.
+// Принятие резервирует пост и отклоняет остальные открытые предложения по нему.
+func (s *Service) Respond(id uint, actor, action string, counter *money.Money) (*Offer, error) {
+	o, err := s.repository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	next, err := o.Next(actor, action)
+	if err != nil {
+		return nil, err
+	}
+	if o.ExpiresAt.Before(s.now()) {
+		return nil, ErrOfferClosed
+	}
+	// Отклонить или отозвать предложение можно и после блокировки, продолжить торг — нет
+	if next == StatusAccepted || next == StatusCountered {
+		if err := s.checkBlocked(o.Buyer, o.Seller); err != nil {
+			return nil, err
+		}
+	}
+
+	switch next {
+	case StatusAccepted:
+		accepted, rejected, err := s.repository.Accept(o.ID, o.Status)
+		if err != nil {
+			return nil, err
+		}
+		s.notifyChange(accepted, actor)
+		for _, r := range rejected {
+			s.notify(r.Buyer, r, fmt.Sprintf("Your offer of %s was declined: the seller accepted another offer", r.Price))
+		}
+		return accepted, nil
+
+	case StatusCountered:
+		if counter == nil {
+			return nil, ErrInvalidCounter
+		}
+		price := *counter
+		if !price.HasCurrency() {
+			price = price.WithCurrency(o.Price.Currency())
+		}
+		p, err := s.posts.GetPostByID(o.PostID)
+		if err != nil {
+			return nil, err
+		}
+		if err := price.Validate(); err != nil {
+			return nil, ErrInvalidCounter
+		}
+		if err := validateCounter(price, o.Price, p.Price); err != nil {
+			return nil, err
+		}
+		updated, err := s.repository.Transition(o.ID, o.Status, next, &price, s.now().Add(s.ttl))
+		if err != nil {
+			return nil, err
+		}
+		s.notifyChange(updated, actor)
+		return updated, nil
+
+	default:
+		updated, err := s.repository.Transition(o.ID, o.Status, next, nil, time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		s.notifyChange(updated, actor)
+		return updated, nil
+	}
+}
+
+// ExpireOffers закрывает просроченные предложения и уведомляет покупателей.
+func (s *Service) ExpireOffers() error {
+	expired, err := s.repository.Expire()
+	if err != nil {
+		return err
+	}
+	for _, o := range expired {
+		s.notify(o.Buyer, o, fmt.Sprintf("Your offer of %s has expired", o.Price))
+	}
+	return nil
+}
+
+// Run периодически закрывает просроченные предложения, пока не отменён ctx.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.ExpireOffers(); err != nil {
+				s.logger.Warn("Failed to expire offers", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (s *Service) checkBlocked(a, b string) error {
+	if s.blocks == nil {
+		return nil
+	}
+	return s.blocks.Check(a, b)
+}
+
+// notifyChange сообщает второй стороне о действии actor.
+func (s *Service) notifyChange(o *Offer, actor string) {
+	recipient := o.Seller
+	if actor == o.Seller {
+		recipient = o.Buyer
+	}
+	var title string
+	switch o.Status {
+	case StatusAccepted:
+		title = fmt.Sprintf("Offer accepted: %s", o.FinalPrice())
+	case StatusRejected:
+		title = fmt.Sprintf("Offer rejected by %s", actor)
+	case StatusCountered:
+		title = fmt.Sprintf("Counter offer: %s", o.CounterPrice)
+	case StatusWithdrawn:
+		title = fmt.Sprintf("Offer withdrawn by %s", actor)
+	default:
+		return
+	}
+	s.notify(recipient, o, title)
+}
+
+func (s *Service) notify(recipient string, o *Offer, title string) {
+	if s.notifier == nil {
+		return
+	}
+	payload, err := json.Marshal(o)
+	if err != nil {
+		return
+	}
+	err = s.notifier.Notify(notify.Message{
+		Recipient: recipient,
+		Kind:      KindOffer,
+		Title:     title,
+		Payload:   payload,
+		Channel:   notify.ChannelInbox,
+	})
+	if err != nil {
+		s.logger.Warn(
+			"Failed to queue offer notification",
+			zap.Uint("offer_id", o.ID),
+			zap.Error(err),
+		)
+	}
+}
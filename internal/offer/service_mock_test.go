@@ -0,0 +1,255 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package offer is a generated GoMock package.
+package offer
+
+import (
+	reflect "reflect"
+	time "time"
+
+	notify "github.com/TemirB/rest-api-marketplace/internal/notify"
+	post "github.com/TemirB/rest-api-marketplace/internal/post"
+	money "github.com/TemirB/rest-api-marketplace/pkg/money"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockstorage is a mock of storage interface.
+type Mockstorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockstorageMockRecorder
+}
+
+// MockstorageMockRecorder is the mock recorder for Mockstorage.
+type MockstorageMockRecorder struct {
+	mock *Mockstorage
+}
+
+// NewMockstorage creates a new mock instance.
+func NewMockstorage(ctrl *gomock.Controller) *Mockstorage {
+	mock := &Mockstorage{ctrl: ctrl}
+	mock.recorder = &MockstorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstorage) EXPECT() *MockstorageMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *Mockstorage) Accept(id uint, from string) (*Offer, []*Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", id, from)
+	ret0, _ := ret[0].(*Offer)
+	ret1, _ := ret[1].([]*Offer)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockstorageMockRecorder) Accept(id, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*Mockstorage)(nil).Accept), id, from)
+}
+
+// Create mocks base method.
+func (m *Mockstorage) Create(o *Offer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", o)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockstorageMockRecorder) Create(o interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*Mockstorage)(nil).Create), o)
+}
+
+// Expire mocks base method.
+func (m *Mockstorage) Expire() ([]*Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expire")
+	ret0, _ := ret[0].([]*Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Expire indicates an expected call of Expire.
+func (mr *MockstorageMockRecorder) Expire() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expire", reflect.TypeOf((*Mockstorage)(nil).Expire))
+}
+
+// GetByID mocks base method.
+func (m *Mockstorage) GetByID(id uint) (*Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockstorageMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*Mockstorage)(nil).GetByID), id)
+}
+
+// ListByPost mocks base method.
+func (m *Mockstorage) ListByPost(postID uint) ([]*Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByPost", postID)
+	ret0, _ := ret[0].([]*Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByPost indicates an expected call of ListByPost.
+func (mr *MockstorageMockRecorder) ListByPost(postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByPost", reflect.TypeOf((*Mockstorage)(nil).ListByPost), postID)
+}
+
+// ListByUser mocks base method.
+func (m *Mockstorage) ListByUser(login, role, status string) ([]*Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", login, role, status)
+	ret0, _ := ret[0].([]*Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockstorageMockRecorder) ListByUser(login, role, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*Mockstorage)(nil).ListByUser), login, role, status)
+}
+
+// Transition mocks base method.
+func (m *Mockstorage) Transition(id uint, from, to string, counter *money.Money, expiresAt time.Time) (*Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", id, from, to, counter, expiresAt)
+	ret0, _ := ret[0].(*Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockstorageMockRecorder) Transition(id, from, to, counter, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*Mockstorage)(nil).Transition), id, from, to, counter, expiresAt)
+}
+
+// Mockposts is a mock of posts interface.
+type Mockposts struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostsMockRecorder
+}
+
+// MockpostsMockRecorder is the mock recorder for Mockposts.
+type MockpostsMockRecorder struct {
+	mock *Mockposts
+}
+
+// NewMockposts creates a new mock instance.
+func NewMockposts(ctrl *gomock.Controller) *Mockposts {
+	mock := &Mockposts{ctrl: ctrl}
+	mock.recorder = &MockpostsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockposts) EXPECT() *MockpostsMockRecorder {
+	return m.recorder
+}
+
+// GetPostByID mocks base method.
+func (m *Mockposts) GetPostByID(id uint) (*post.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostByID", id)
+	ret0, _ := ret[0].(*post.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostByID indicates an expected call of GetPostByID.
+func (mr *MockpostsMockRecorder) GetPostByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostByID", reflect.TypeOf((*Mockposts)(nil).GetPostByID), id)
+}
+
+// Mocknotifier is a mock of notifier interface.
+type Mocknotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MocknotifierMockRecorder
+}
+
+// MocknotifierMockRecorder is the mock recorder for Mocknotifier.
+type MocknotifierMockRecorder struct {
+	mock *Mocknotifier
+}
+
+// NewMocknotifier creates a new mock instance.
+func NewMocknotifier(ctrl *gomock.Controller) *Mocknotifier {
+	mock := &Mocknotifier{ctrl: ctrl}
+	mock.recorder = &MocknotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocknotifier) EXPECT() *MocknotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *Mocknotifier) Notify(msg notify.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MocknotifierMockRecorder) Notify(msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*Mocknotifier)(nil).Notify), msg)
+}
+
+// Mockblocks is a mock of blocks interface.
+type Mockblocks struct {
+	ctrl     *gomock.Controller
+	recorder *MockblocksMockRecorder
+}
+
+// MockblocksMockRecorder is the mock recorder for Mockblocks.
+type MockblocksMockRecorder struct {
+	mock *Mockblocks
+}
+
+// NewMockblocks creates a new mock instance.
+func NewMockblocks(ctrl *gomock.Controller) *Mockblocks {
+	mock := &Mockblocks{ctrl: ctrl}
+	mock.recorder = &MockblocksMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockblocks) EXPECT() *MockblocksMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *Mockblocks) Check(a, b string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", a, b)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockblocksMockRecorder) Check(a, b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*Mockblocks)(nil).Check), a, b)
+}
@@ -0,0 +1,318 @@
+package offer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/block"
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+var testNow = time.Date(2025, 7, 21, 12, 0, 0, 0, time.UTC)
+
+func newTestService(repo storage, posts posts, n notifier) *Service {
+	s := NewService(repo, posts, 48*time.Hour, zap.NewNop(), WithNotifier(n))
+	s.now = func() time.Time { return testNow }
+	return s
+}
+
+func activePost() *post.Post {
+	return &post.Post{ID: 1, Owner: "bob", Price: money.MustParse("1000", "RUB"), Status: post.StatusActive}
+}
+
+func TestService_MakeOffer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		buyer      string
+		price      money.Money
+		setupMocks func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier)
+
+		expectedErr error
+	}{
+		{
+			name:  "1. Created_In_Post_Currency",
+			buyer: "alice",
+			price: money.New(money.NewDecimal(800, 0), ""),
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier) {
+				posts.EXPECT().GetPostByID(uint(1)).Return(activePost(), nil)
+				repo.EXPECT().Create(&Offer{
+					PostID:    1,
+					Buyer:     "alice",
+					Seller:    "bob",
+					Price:     money.New(money.NewDecimal(800, 0), "RUB"),
+					ExpiresAt: testNow.Add(48 * time.Hour),
+				}).Return(nil)
+				n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+					assert.Equal(t, "bob", msg.Recipient)
+					assert.Equal(t, KindOffer, msg.Kind)
+					return nil
+				})
+			},
+		},
+		{
+			name:  "2. Own_Post",
+			buyer: "bob",
+			price: money.MustParse("800", "RUB"),
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier) {
+				posts.EXPECT().GetPostByID(uint(1)).Return(activePost(), nil)
+			},
+			expectedErr: ErrOwnPost,
+		},
+		{
+			name:  "3. Post_Reserved",
+			buyer: "alice",
+			price: money.MustParse("800", "RUB"),
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier) {
+				p := activePost()
+				p.Status = post.StatusReserved
+				posts.EXPECT().GetPostByID(uint(1)).Return(p, nil)
+			},
+			expectedErr: ErrPostNotAvailable,
+		},
+		{
+			name:  "4. Not_Below_Asking",
+			buyer: "alice",
+			price: money.MustParse("1200", "RUB"),
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier) {
+				posts.EXPECT().GetPostByID(uint(1)).Return(activePost(), nil)
+			},
+			expectedErr: ErrInvalidPrice,
+		},
+		{
+			name:  "5. Open_Offer_Exists",
+			buyer: "alice",
+			price: money.MustParse("800", "RUB"),
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier) {
+				posts.EXPECT().GetPostByID(uint(1)).Return(activePost(), nil)
+				repo.EXPECT().Create(gomock.Any()).Return(ErrOfferExists)
+			},
+			expectedErr: ErrOfferExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			posts := NewMockposts(ctrl)
+			n := NewMocknotifier(ctrl)
+			tc.setupMocks(repo, posts, n)
+			service := newTestService(repo, posts, n)
+
+			o, err := service.MakeOffer(1, tc.buyer, tc.price, "")
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "bob", o.Seller)
+		})
+	}
+}
+
+func TestService_Respond(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pending := func() *Offer {
+		return &Offer{
+			ID:        5,
+			PostID:    1,
+			Buyer:     "alice",
+			Seller:    "bob",
+			Price:     money.MustParse("800", "RUB"),
+			Status:    StatusPending,
+			ExpiresAt: testNow.Add(time.Hour),
+		}
+	}
+	counter := money.MustParse("900", "RUB")
+	tooHigh := money.MustParse("1500", "RUB")
+
+	testCases := []struct {
+		name string
+
+		actor      string
+		action     string
+		counter    *money.Money
+		setupMocks func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier)
+
+		expectedStatus string
+		expectedErr    error
+	}{
+		{
+			name:   "1. Accept_Rejects_Competing_Offers",
+			actor:  "bob",
+			action: ActionAccept,
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(5)).Return(pending(), nil)
+				accepted := pending()
+				accepted.Status = StatusAccepted
+				rejected := &Offer{ID: 6, PostID: 1, Buyer: "carol", Seller: "bob", Status: StatusRejected}
+				repo.EXPECT().Accept(uint(5), StatusPending).Return(accepted, []*Offer{rejected}, nil)
+
+				gomock.InOrder(
+					n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+						assert.Equal(t, "alice", msg.Recipient)
+						return nil
+					}),
+					n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+						assert.Equal(t, "carol", msg.Recipient)
+						return nil
+					}),
+				)
+			},
+			expectedStatus: StatusAccepted,
+		},
+		{
+			name:    "2. Counter",
+			actor:   "bob",
+			action:  ActionCounter,
+			counter: &counter,
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(5)).Return(pending(), nil)
+				posts.EXPECT().GetPostByID(uint(1)).Return(activePost(), nil)
+				countered := pending()
+				countered.Status = StatusCountered
+				countered.CounterPrice = &counter
+				repo.EXPECT().Transition(uint(5), StatusPending, StatusCountered, &counter, testNow.Add(48*time.Hour)).
+					Return(countered, nil)
+				n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+					assert.Equal(t, "alice", msg.Recipient)
+					return nil
+				})
+			},
+			expectedStatus: StatusCountered,
+		},
+		{
+			name:    "3. Counter_Above_Asking",
+			actor:   "bob",
+			action:  ActionCounter,
+			counter: &tooHigh,
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(5)).Return(pending(), nil)
+				posts.EXPECT().GetPostByID(uint(1)).Return(activePost(), nil)
+			},
+			expectedErr: ErrInvalidCounter,
+		},
+		{
+			name:   "4. Buyer_Withdraws",
+			actor:  "alice",
+			action: ActionWithdraw,
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(5)).Return(pending(), nil)
+				withdrawn := pending()
+				withdrawn.Status = StatusWithdrawn
+				repo.EXPECT().Transition(uint(5), StatusPending, StatusWithdrawn, nil, time.Time{}).Return(withdrawn, nil)
+				n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+					assert.Equal(t, "bob", msg.Recipient)
+					return nil
+				})
+			},
+			expectedStatus: StatusWithdrawn,
+		},
+		{
+			name:   "5. Expired_But_Not_Yet_Swept",
+			actor:  "bob",
+			action: ActionAccept,
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier) {
+				o := pending()
+				o.ExpiresAt = testNow.Add(-time.Minute)
+				repo.EXPECT().GetByID(uint(5)).Return(o, nil)
+			},
+			expectedErr: ErrOfferClosed,
+		},
+		{
+			name:   "6. Post_Already_Reserved",
+			actor:  "bob",
+			action: ActionAccept,
+			setupMocks: func(repo *Mockstorage, posts *Mockposts, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(5)).Return(pending(), nil)
+				repo.EXPECT().Accept(uint(5), StatusPending).Return(nil, nil, ErrPostNotAvailable)
+			},
+			expectedErr: ErrPostNotAvailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			posts := NewMockposts(ctrl)
+			n := NewMocknotifier(ctrl)
+			tc.setupMocks(repo, posts, n)
+			service := newTestService(repo, posts, n)
+
+			o, err := service.Respond(5, tc.actor, tc.action, tc.counter)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, o.Status)
+		})
+	}
+}
+
+func TestService_Blocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	posts := NewMockposts(ctrl)
+	blocks := NewMockblocks(ctrl)
+	service := NewService(repo, posts, 48*time.Hour, zap.NewNop(), WithBlocks(blocks))
+	service.now = func() time.Time { return testNow }
+
+	posts.EXPECT().GetPostByID(uint(1)).Return(activePost(), nil)
+	blocks.EXPECT().Check("alice", "bob").Return(block.ErrBlocked).Times(2)
+
+	_, err := service.MakeOffer(1, "alice", money.MustParse("800", "RUB"), "")
+	assert.ErrorIs(t, err, block.ErrBlocked)
+
+	pending := &Offer{
+		ID:        5,
+		PostID:    1,
+		Buyer:     "alice",
+		Seller:    "bob",
+		Price:     money.MustParse("800", "RUB"),
+		Status:    StatusPending,
+		ExpiresAt: testNow.Add(time.Hour),
+	}
+	repo.EXPECT().GetByID(uint(5)).Return(pending, nil).Times(2)
+
+	counter := money.MustParse("900", "RUB")
+	_, err = service.Respond(5, "bob", ActionCounter, &counter)
+	assert.ErrorIs(t, err, block.ErrBlocked)
+
+	// Отклонить предложение после блокировки можно
+	rejected := *pending
+	rejected.Status = StatusRejected
+	repo.EXPECT().Transition(uint(5), StatusPending, StatusRejected, nil, time.Time{}).Return(&rejected, nil)
+	o, err := service.Respond(5, "bob", ActionReject, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusRejected, o.Status)
+}
+
+func TestService_ExpireOffers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	n := NewMocknotifier(ctrl)
+	repo.EXPECT().Expire().Return([]*Offer{{ID: 5, Buyer: "alice", Seller: "bob", Status: StatusExpired}}, nil)
+	n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+		assert.Equal(t, "alice", msg.Recipient)
+		return nil
+	})
+	service := newTestService(repo, NewMockposts(ctrl), n)
+
+	assert.NoError(t, service.ExpireOffers())
+}
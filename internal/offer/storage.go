@@ -0,0 +1,321 @@
+package offer
+
+// mockgen  -source=storage.go -destination=storage_mock_test.go -package=offer
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+const (
+	pgForeignKeyViolation = "23503"
+	pgUniqueViolation     = "23505"
+)
+
+const offerColumns = "id, post_id, buyer, seller, price, counter_price, currency, message, status, created_at, updated_at, expires_at"
+
+// openStatuses — условие SQL на открытые предложения.
+const openStatuses = "status IN ('pending', 'countered')"
+
+type Repository interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+	Query(query string, args ...any) (*sql.Rows, error)
+	Begin() (*sql.Tx, error)
+}
+
+type Storage struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+func NewStorage(repository Repository, logger *zap.Logger) *Storage {
+	return &Storage{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanOffer читает строку в порядке offerColumns. Суммы хранятся в NUMERIC, поэтому сканируем их строками.
+func scanOffer(row rowScanner, o *Offer) error {
+	var (
+		price, currency string
+		counter         sql.NullString
+		message         sql.NullString
+	)
+	err := row.Scan(
+		&o.ID, &o.PostID, &o.Buyer, &o.Seller,
+		&price, &counter, &currency, &message, &o.Status,
+		&o.CreatedAt, &o.UpdatedAt, &o.ExpiresAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	amount, err := money.ParseDecimal(price)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse offer price")
+	}
+	o.Price = money.New(amount, money.Currency(currency))
+
+	o.CounterPrice = nil
+	if counter.Valid {
+		amount, err := money.ParseDecimal(counter.String)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse counter price")
+		}
+		m := money.New(amount, money.Currency(currency))
+		o.CounterPrice = &m
+	}
+	o.Message = message.String
+	return nil
+}
+
+func scanOffers(rows *sql.Rows) ([]*Offer, error) {
+	defer rows.Close()
+
+	offers := []*Offer{}
+	for rows.Next() {
+		var o Offer
+		if err := scanOffer(rows, &o); err != nil {
+			return nil, err
+		}
+		offers = append(offers, &o)
+	}
+	return offers, rows.Err()
+}
+
+// Create блокирует строку поста (SELECT ... FOR UPDATE) и создаёт предложение, только если пост
+// по-прежнему продаётся по фиксированной цене и не скрыт модерацией. auction.Storage.Create берёт ту же блокировку,
+// поэтому пост не может стать аукционным с открытым предложением.
+func (r *Storage) Create(o *Offer) error {
+	tx, err := r.repository.Begin()
+	if err != nil {
+		return errors.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	var available bool
+	query := `SELECT status = 'active' AND listing_type = 'fixed' AND NOT hidden FROM posts WHERE id = $1 FOR UPDATE`
+	if err := tx.QueryRow(query, o.PostID).Scan(&available); err != nil {
+		if err == sql.ErrNoRows {
+			return post.ErrPostNotFound
+		}
+		return errors.Errorf("failed to lock post: %v", err)
+	}
+	if !available {
+		return ErrPostNotAvailable
+	}
+
+	query = `
+		INSERT INTO offers (post_id, buyer, seller, price, currency, message, expires_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7)
+		RETURNING id, status, created_at, updated_at
+	`
+	err = tx.QueryRow(
+		query,
+		o.PostID,
+		o.Buyer,
+		o.Seller,
+		o.Price.Amount().String(),
+		o.Price.Currency(),
+		o.Message,
+		o.ExpiresAt,
+	).Scan(&o.ID, &o.Status, &o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case pgUniqueViolation:
+				return ErrOfferExists
+			case pgForeignKeyViolation:
+				return post.ErrPostNotFound
+			}
+		}
+		r.logger.Error(
+			"Failed to create offer",
+			zap.Uint("post_id", o.PostID),
+			zap.String("buyer", o.Buyer),
+			zap.Error(err),
+		)
+		return errors.Errorf("failed to create offer: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Errorf("failed to commit offer: %v", err)
+	}
+	return nil
+}
+
+func (r *Storage) GetByID(id uint) (*Offer, error) {
+	query := `SELECT ` + offerColumns + ` FROM offers WHERE id = $1`
+
+	var o Offer
+	if err := scanOffer(r.repository.QueryRow(query, id), &o); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrOfferNotFound
+		}
+		r.logger.Error("Failed to get offer", zap.Uint("id", id), zap.Error(err))
+		return nil, errors.Errorf("failed to get offer: %v", err)
+	}
+	return &o, nil
+}
+
+// ListByPost возвращает предложения по посту, новые сначала.
+func (r *Storage) ListByPost(postID uint) ([]*Offer, error) {
+	query := `SELECT ` + offerColumns + ` FROM offers WHERE post_id = $1 ORDER BY id DESC`
+
+	rows, err := r.repository.Query(query, postID)
+	if err != nil {
+		r.logger.Error("Failed to list post offers", zap.Uint("post_id", postID), zap.Error(err))
+		return nil, errors.Errorf("failed to list offers: %v", err)
+	}
+	return scanOffers(rows)
+}
+
+// ListByUser возвращает предложения, где login — покупатель (role=buyer), продавец (role=seller)
+// или любой из них (пустая роль). Пустой status — все состояния.
+func (r *Storage) ListByUser(login, role, status string) ([]*Offer, error) {
+	var where string
+	switch role {
+	case "buyer":
+		where = "buyer = $1"
+	case "seller":
+		where = "seller = $1"
+	default:
+		where = "(buyer = $1 OR seller = $1)"
+	}
+	query := `SELECT ` + offerColumns + ` FROM offers WHERE ` + where +
+		` AND ($2 = '' OR status = $2) ORDER BY id DESC`
+
+	rows, err := r.repository.Query(query, login, status)
+	if err != nil {
+		r.logger.Error("Failed to list user offers", zap.String("login", login), zap.Error(err))
+		return nil, errors.Errorf("failed to list offers: %v", err)
+	}
+	return scanOffers(rows)
+}
+
+// Transition переводит открытое предложение из состояния from в to. Если предложение успело
+// измениться или истечь, возвращает ErrOfferClosed. counter задаётся только для встречной цены,
+// тогда же продлевается срок ответа до expiresAt.
+func (r *Storage) Transition(id uint, from, to string, counter *money.Money, expiresAt time.Time) (*Offer, error) {
+	var counterAmount any
+	if counter != nil {
+		counterAmount = counter.Amount().String()
+	}
+	query := `
+		UPDATE offers
+		SET status = $3,
+		    counter_price = COALESCE($4::NUMERIC, counter_price),
+		    expires_at = CASE WHEN $4::NUMERIC IS NULL THEN expires_at ELSE $5 END,
+		    updated_at = NOW()
+		WHERE id = $1 AND status = $2 AND expires_at > NOW()
+		RETURNING ` + offerColumns
+
+	var o Offer
+	if err := scanOffer(r.repository.QueryRow(query, id, from, to, counterAmount, expiresAt), &o); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrOfferClosed
+		}
+		r.logger.Error(
+			"Failed to update offer",
+			zap.Uint("id", id),
+			zap.String("status", to),
+			zap.Error(err),
+		)
+		return nil, errors.Errorf("failed to update offer: %v", err)
+	}
+	return &o, nil
+}
+
+// Accept в одной транзакции принимает предложение и резервирует под него единицу товара;
+// если она была последней, пост становится reserved, а остальные открытые предложения отклоняются.
+// Возвращает принятое и отклонённые предложения.
+func (r *Storage) Accept(id uint, from string) (*Offer, []*Offer, error) {
+	tx, err := r.repository.Begin()
+	if err != nil {
+		return nil, nil, errors.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	var accepted Offer
+	query := `
+		UPDATE offers SET status = 'accepted', updated_at = NOW()
+		WHERE id = $1 AND status = $2 AND expires_at > NOW()
+		RETURNING ` + offerColumns
+	if err := scanOffer(tx.QueryRow(query, id, from), &accepted); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, ErrOfferClosed
+		}
+		r.logger.Error("Failed to accept offer", zap.Uint("id", id), zap.Error(err))
+		return nil, nil, errors.Errorf("failed to accept offer: %v", err)
+	}
+
+	// Принятое предложение резервирует одну единицу товара. Условие на остаток защищает
+	// от продажи лишнего при одновременном принятии нескольких предложений; скрытый модерацией
+	// пост не продаётся
+	query = `
+		UPDATE posts
+		SET quantity_sold = quantity_sold + 1,
+			status = CASE WHEN quantity_sold + 1 = quantity THEN 'reserved' ELSE status END
+		WHERE id = $1 AND status = 'active' AND listing_type = 'fixed' AND quantity_sold < quantity AND NOT hidden
+		RETURNING status
+	`
+	var status string
+	if err := tx.QueryRow(query, accepted.PostID).Scan(&status); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, ErrPostNotAvailable
+		}
+		r.logger.Error("Failed to reserve post", zap.Uint("post_id", accepted.PostID), zap.Error(err))
+		return nil, nil, errors.Errorf("failed to reserve post: %v", err)
+	}
+
+	// Остальные открытые предложения отклоняются, только когда зарезервирована последняя единица
+	rejected := []*Offer{}
+	if status == post.StatusReserved {
+		query = `
+			UPDATE offers SET status = 'rejected', updated_at = NOW()
+			WHERE post_id = $1 AND id <> $2 AND ` + openStatuses + `
+			RETURNING ` + offerColumns
+		rows, err := tx.Query(query, accepted.PostID, accepted.ID)
+		if err != nil {
+			r.logger.Error("Failed to reject competing offers", zap.Uint("post_id", accepted.PostID), zap.Error(err))
+			return nil, nil, errors.Errorf("failed to reject competing offers: %v", err)
+		}
+		if rejected, err = scanOffers(rows); err != nil {
+			return nil, nil, errors.Errorf("failed to reject competing offers: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, errors.Errorf("failed to commit offer acceptance: %v", err)
+	}
+	return &accepted, rejected, nil
+}
+
+// Expire переводит в expired открытые предложения с истёкшим сроком и возвращает их.
+func (r *Storage) Expire() ([]*Offer, error) {
+	query := `
+		UPDATE offers SET status = 'expired', updated_at = NOW()
+		WHERE ` + openStatuses + ` AND expires_at <= NOW()
+		RETURNING ` + offerColumns
+
+	rows, err := r.repository.Query(query)
+	if err != nil {
+		r.logger.Error("Failed to expire offers", zap.Error(err))
+		return nil, errors.Errorf("failed to expire offers: %v", err)
+	}
+	return scanOffers(rows)
+}
@@ -0,0 +1,218 @@
+package order
+
+// mockgen  -source=handler.go -destination=handler_mock_test.go -package=order
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/block"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+)
+
+const maxWebhookSize = 64 << 10
+
+type service interface {
+	CreateOrder(postID uint, buyer string, quantity int) (*Order, error)
+	GetOrder(id uint, login string) (*Order, error)
+	ListOrders(login, role, status string) ([]*Order, error)
+	Pay(id uint, buyer string) (*Payment, error)
+	Act(id uint, actor, action string) (*Order, error)
+	HandleWebhook(header http.Header, payload []byte) error
+}
+
+type Handler struct {
+	service service
+	logger  *zap.Logger
+}
+
+func NewHandler(service service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+type createRequest struct {
+	PostID   uint `json:"post_id"`
+	Quantity *int `json:"quantity,omitempty"`
+}
+
+// writeError переводит ошибки заказов в HTTP-статусы.
+func (h *Handler) writeError(w http.ResponseWriter, err error, msg string, fields ...zap.Field) {
+	switch {
+	case errors.Is(err, ErrOrderNotFound), errors.Is(err, post.ErrPostNotFound):
+		http.Error(w, "Not Found", http.StatusNotFound)
+	case errors.Is(err, ErrNotParticipant), errors.Is(err, ErrNotAllowed), errors.Is(err, block.ErrBlocked):
+		http.Error(w, "Forbidden: "+err.Error(), http.StatusForbidden)
+	case errors.Is(err, ErrInvalidState), errors.Is(err, ErrPostNotAvailable), errors.Is(err, ErrInsufficientQuantity),
+		errors.Is(err, ErrPriceChanged):
+		http.Error(w, "Conflict: "+err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrOwnPost), errors.Is(err, ErrUnknownAction),
+		errors.Is(err, ErrInvalidQuantity), errors.Is(err, ErrReservedQuantity),
+		errors.Is(err, ErrNoItems), errors.Is(err, ErrDuplicateItem):
+		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+	case errors.Is(err, ErrUnknownPayment):
+		http.Error(w, "Bad Gateway: "+err.Error(), http.StatusBadGateway)
+	default:
+		h.logger.Error(msg, append(fields, zap.Error(err))...)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+// CreateOrder — POST /orders.
+func (h *Handler) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req createRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.PostID == 0 {
+		http.Error(w, "Bad Request: invalid JSON or post_id", http.StatusBadRequest)
+		return
+	}
+
+	quantity := 1
+	if req.Quantity != nil {
+		quantity = *req.Quantity
+	}
+
+	o, err := h.service.CreateOrder(req.PostID, login, quantity)
+	if err != nil {
+		h.writeError(w, err, "Failed to create order", zap.Uint("post_id", req.PostID))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(o)
+}
+
+// ListOrders — GET /me/orders?role=buyer|seller&status=.
+func (h *Handler) ListOrders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	q := r.URL.Query()
+	role := q.Get("role")
+	if role != "" && role != "buyer" && role != "seller" {
+		http.Error(w, "Bad Request: role must be buyer or seller", http.StatusBadRequest)
+		return
+	}
+
+	orders, err := h.service.ListOrders(login, role, q.Get("status"))
+	if err != nil {
+		h.writeError(w, err, "Failed to list orders", zap.String("login", login))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(orders)
+}
+
+// Order обрабатывает GET /orders/{id}, POST /orders/{id}/pay и POST /orders/{id}/{ship|deliver|complete|cancel|refund}.
+func (h *Handler) Order(w http.ResponseWriter, r *http.Request) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || len(parts) > 4 || parts[1] != "orders" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	id64, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		http.Error(w, "Bad Request: invalid id", http.StatusBadRequest)
+		return
+	}
+	id := uint(id64)
+
+	if len(parts) == 3 {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		o, err := h.service.GetOrder(id, login)
+		if err != nil {
+			h.writeError(w, err, "Failed to get order", zap.Uint("order_id", id))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(o)
+		return
+	}
+
+	action := parts[3]
+	if _, ok := rules[action]; !ok && action != "pay" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var result any
+	if action == "pay" {
+		result, err = h.service.Pay(id, login)
+	} else {
+		result, err = h.service.Act(id, login, action)
+	}
+	if err != nil {
+		h.writeError(w, err, "Failed to update order", zap.Uint("order_id", id), zap.String("action", action))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// PaymentWebhook — POST /payments/webhook, вызывается платёжным провайдером.
+func (h *Handler) PaymentWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookSize))
+	if err != nil {
+		http.Error(w, "Bad Request: invalid body", http.StatusBadRequest)
+		return
+	}
+
+	err = h.service.HandleWebhook(r.Header, payload)
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusNoContent)
+	case errors.Is(err, ErrInvalidSignature):
+		http.Error(w, "Unauthorized: "+err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, ErrOrderNotFound), errors.Is(err, ErrUnknownPayment), errors.Is(err, ErrPaymentMismatch),
+		errors.Is(err, ErrInvalidPayload):
+		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+	default:
+		h.logger.Error("Failed to handle payment webhook", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+
+// Package order is a generated GoMock package.
+package order
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockservice is a mock of service interface.
+type Mockservice struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceMockRecorder
+}
+
+// MockserviceMockRecorder is the mock recorder for Mockservice.
+type MockserviceMockRecorder struct {
+	mock *Mockservice
+}
+
+// NewMockservice creates a new mock instance.
+func NewMockservice(ctrl *gomock.Controller) *Mockservice {
+	mock := &Mockservice{ctrl: ctrl}
+	mock.recorder = &MockserviceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockservice) EXPECT() *MockserviceMockRecorder {
+	return m.recorder
+}
+
+// Act mocks base method.
+func (m *Mockservice) Act(id uint, actor, action string) (*Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Act", id, actor, action)
+	ret0, _ := ret[0].(*Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Act indicates an expected call of Act.
+func (mr *MockserviceMockRecorder) Act(id, actor, action interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Act", reflect.TypeOf((*Mockservice)(nil).Act), id, actor, action)
+}
+
+// CreateOrder mocks base method.
+func (m *Mockservice) CreateOrder(postID uint, buyer string, quantity int) (*Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", postID, buyer, quantity)
+	ret0, _ := ret[0].(*Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockserviceMockRecorder) CreateOrder(postID, buyer, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*Mockservice)(nil).CreateOrder), postID, buyer, quantity)
+}
+
+// GetOrder mocks base method.
+func (m *Mockservice) GetOrder(id uint, login string) (*Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", id, login)
+	ret0, _ := ret[0].(*Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockserviceMockRecorder) GetOrder(id, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*Mockservice)(nil).GetOrder), id, login)
+}
+
+// HandleWebhook mocks base method.
+func (m *Mockservice) HandleWebhook(header http.Header, payload []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleWebhook", header, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleWebhook indicates an expected call of HandleWebhook.
+func (mr *MockserviceMockRecorder) HandleWebhook(header, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleWebhook", reflect.TypeOf((*Mockservice)(nil).HandleWebhook), header, payload)
+}
+
+// ListOrders mocks base method.
+func (m *Mockservice) ListOrders(login, role, status string) ([]*Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", login, role, status)
+	ret0, _ := ret[0].([]*Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockserviceMockRecorder) ListOrders(login, role, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*Mockservice)(nil).ListOrders), login, role, status)
+}
+
+// Pay mocks base method.
+func (m *Mockservice) Pay(id uint, buyer string) (*Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pay", id, buyer)
+	ret0, _ := ret[0].(*Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pay indicates an expected call of Pay.
+func (mr *MockserviceMockRecorder) Pay(id, buyer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pay", reflect.TypeOf((*Mockservice)(nil).Pay), id, buyer)
+}
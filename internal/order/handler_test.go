@@ -0,0 +1,249 @@
+package order
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+func newRequest(method, path, body, user string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if user != "" {
+		req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, user))
+	}
+	return req
+}
+
+func TestHandler_CreateOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		body       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name: "1. Created",
+			body: `{"post_id":1}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().CreateOrder(uint(1), "alice", 1).Return(&Order{ID: 3}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "2. Sold_Out",
+			body: `{"post_id":1}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().CreateOrder(uint(1), "alice", 1).Return(nil, ErrPostNotAvailable)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name: "3. Post_Not_Found",
+			body: `{"post_id":1}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().CreateOrder(uint(1), "alice", 1).Return(nil, post.ErrPostNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "4. Missing_Post_ID",
+			body:         `{}`,
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "5. Several_Items",
+			body: `{"post_id":1,"quantity":3}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().CreateOrder(uint(1), "alice", 3).Return(&Order{ID: 3, Items: []*Item{{PostID: 1, Quantity: 3}}}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "6. Not_Enough_In_Stock",
+			body: `{"post_id":1,"quantity":3}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().CreateOrder(uint(1), "alice", 3).Return(nil, ErrInsufficientQuantity)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name: "7. Zero_Quantity",
+			body: `{"post_id":1,"quantity":0}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().CreateOrder(uint(1), "alice", 0).Return(nil, ErrInvalidQuantity)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+			rr := httptest.NewRecorder()
+
+			handler.CreateOrder(rr, newRequest(http.MethodPost, "/orders", tc.body, "alice"))
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestHandler_Order(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		method     string
+		path       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name:   "1. Get",
+			method: http.MethodGet,
+			path:   "/orders/3",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetOrder(uint(3), "bob").Return(&Order{ID: 3}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "2. Pay",
+			method: http.MethodPost,
+			path:   "/orders/3/pay",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Pay(uint(3), "bob").Return(&Payment{ID: "fake_pay_3_1"}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "3. Ship",
+			method: http.MethodPost,
+			path:   "/orders/3/ship",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Act(uint(3), "bob", ActionShip).Return(&Order{ID: 3, Status: StatusShipped}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "4. Invalid_State",
+			method: http.MethodPost,
+			path:   "/orders/3/complete",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Act(uint(3), "bob", ActionComplete).Return(nil, ErrInvalidState)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:   "5. Stranger",
+			method: http.MethodGet,
+			path:   "/orders/3",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetOrder(uint(3), "bob").Return(nil, ErrNotParticipant)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "6. Unknown_Action",
+			method:       http.MethodPost,
+			path:         "/orders/3/lose",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "7. Wrong_Method",
+			method:       http.MethodGet,
+			path:         "/orders/3/ship",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "8. Invalid_ID",
+			method:       http.MethodGet,
+			path:         "/orders/abc",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+			rr := httptest.NewRecorder()
+
+			handler.Order(rr, newRequest(tc.method, tc.path, "", "bob"))
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestHandler_PaymentWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name: "1. Accepted",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().HandleWebhook(gomock.Any(), []byte(`{"id":"evt_1"}`)).Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name: "2. Invalid_Signature",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().HandleWebhook(gomock.Any(), gomock.Any()).Return(ErrInvalidSignature)
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "3. Amount_Mismatch",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().HandleWebhook(gomock.Any(), gomock.Any()).Return(ErrPaymentMismatch)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+			rr := httptest.NewRecorder()
+
+			handler.PaymentWebhook(rr, newRequest(http.MethodPost, "/payments/webhook", `{"id":"evt_1"}`, ""))
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package order
+
+import (
+	"errors"
+	"time"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+// Состояния заказа.
+//
+//	created ─▶ paid ─▶ shipped ─▶ delivered ─▶ completed
+//	   │         └────────┴───────────┴──▶ refunded
+//	   └──▶ cancelled
+const (
+	StatusCreated   = "created"
+	StatusPaid      = "paid"
+	StatusShipped   = "shipped"
+	StatusDelivered = "delivered"
+	StatusCompleted = "completed"
+	StatusCancelled = "cancelled"
+	StatusRefunded  = "refunded"
+)
+
+// Действия участников над заказом. Оплату (created → paid) подтверждает только провайдер через вебхук.
+const (
+	ActionShip     = "ship"
+	ActionDeliver  = "deliver"
+	ActionComplete = "complete"
+	ActionCancel   = "cancel"
+	ActionRefund   = "refund"
+)
+
+// KindOrder — уведомление о новом заказе или смене его состояния.
+const KindOrder = "order"
+
+var (
+	ErrOrderNotFound    = errors.New("order not found")
+	ErrNotParticipant   = errors.New("not a participant of the order")
+	ErrNotAllowed       = errors.New("action is not allowed for you")
+	ErrInvalidState     = errors.New("action is not allowed in the current order state")
+	ErrUnknownAction    = errors.New("unknown order action")
+	ErrOwnPost          = errors.New("cannot buy your own post")
+	ErrPostNotAvailable = errors.New("post is not available for purchase")
+	ErrPaymentMismatch  = errors.New("payment does not match the order")
+
+	ErrInvalidQuantity      = errors.New("quantity must be at least 1")
+	ErrInsufficientQuantity = errors.New("not enough items in stock")
+	ErrReservedQuantity     = errors.New("an accepted offer or won auction covers exactly one item")
+	ErrPriceChanged         = errors.New("price of the post has changed")
+	ErrNoItems              = errors.New("order has no items")
+	ErrDuplicateItem        = errors.New("post is listed twice in the order")
+)
+
+// Order — заказ покупателя у одного продавца. Все позиции заказа в одной валюте.
+type Order struct {
+	ID        uint        `json:"id"`
+	Buyer     string      `json:"buyer"`
+	Seller    string      `json:"seller"`
+	Items     []*Item     `json:"items"`
+	Total     money.Money `json:"total"`
+	Status    string      `json:"status"`
+	PaymentID string      `json:"payment_id,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Item — позиция заказа: единицы одного поста по цене на момент покупки.
+type Item struct {
+	PostID   uint        `json:"post_id"`
+	Price    money.Money `json:"price"`
+	Quantity int         `json:"quantity"`
+	// OfferID — принятое предложение, по цене которого куплена позиция
+	OfferID uint `json:"offer_id,omitempty"`
+
+	// Expected — цена, которую видел покупатель. Если к моменту покупки цена поста
+	// изменилась, заказ не создаётся
+	Expected *money.Money `json:"-"`
+}
+
+// IsParticipant сообщает, видит ли пользователь заказ.
+func (o *Order) IsParticipant(login string) bool {
+	return login != "" && (login == o.Buyer || login == o.Seller)
+}
+
+// Counterpart возвращает второго участника заказа.
+func (o *Order) Counterpart(login string) string {
+	if login == o.Buyer {
+		return o.Seller
+	}
+	return o.Buyer
+}
+
+// Кто может выполнить действие.
+const (
+	byBuyer  = "buyer"
+	bySeller = "seller"
+	byAny    = "any"
+)
+
+type rule struct {
+	from []string
+	to   string
+	by   string
+}
+
+var rules = map[string]rule{
+	ActionShip:     {from: []string{StatusPaid}, to: StatusShipped, by: bySeller},
+	ActionDeliver:  {from: []string{StatusShipped}, to: StatusDelivered, by: byBuyer},
+	ActionComplete: {from: []string{StatusDelivered}, to: StatusCompleted, by: byBuyer},
+	ActionCancel:   {from: []string{StatusCreated}, to: StatusCancelled, by: byAny},
+	ActionRefund:   {from: []string{StatusPaid, StatusShipped, StatusDelivered}, to: StatusRefunded, by: bySeller},
+}
+
+// Next возвращает состояние, в которое переходит заказ, когда actor выполняет action.
+func (o *Order) Next(actor, action string) (string, error) {
+	if !o.IsParticipant(actor) {
+		return "", ErrNotParticipant
+	}
+	r, ok := rules[action]
+	if !ok {
+		return "", ErrUnknownAction
+	}
+	if (r.by == byBuyer && actor != o.Buyer) || (r.by == bySeller && actor != o.Seller) {
+		return "", ErrNotAllowed
+	}
+	for _, from := range r.from {
+		if o.Status == from {
+			return r.to, nil
+		}
+	}
+	return "", ErrInvalidState
+}
+
+// releasesPost сообщает, возвращаются ли единицы товара на остаток при переходе в status.
+func releasesPost(status string) bool {
+	return status == StatusCancelled || status == StatusRefunded
+}
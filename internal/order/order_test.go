@@ -0,0 +1,47 @@
+package order
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrder_Next(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		status string
+		actor  string
+		action string
+
+		expected    string
+		expectedErr error
+	}{
+		{name: "1. Seller_Ships_Paid", status: StatusPaid, actor: "bob", action: ActionShip, expected: StatusShipped},
+		{name: "2. Buyer_Cannot_Ship", status: StatusPaid, actor: "alice", action: ActionShip, expectedErr: ErrNotAllowed},
+		{name: "3. Cannot_Ship_Unpaid", status: StatusCreated, actor: "bob", action: ActionShip, expectedErr: ErrInvalidState},
+		{name: "4. Buyer_Confirms_Delivery", status: StatusShipped, actor: "alice", action: ActionDeliver, expected: StatusDelivered},
+		{name: "5. Buyer_Completes", status: StatusDelivered, actor: "alice", action: ActionComplete, expected: StatusCompleted},
+		{name: "6. Buyer_Cancels_Created", status: StatusCreated, actor: "alice", action: ActionCancel, expected: StatusCancelled},
+		{name: "7. Seller_Cancels_Created", status: StatusCreated, actor: "bob", action: ActionCancel, expected: StatusCancelled},
+		{name: "8. Cannot_Cancel_Paid", status: StatusPaid, actor: "alice", action: ActionCancel, expectedErr: ErrInvalidState},
+		{name: "9. Seller_Refunds_Shipped", status: StatusShipped, actor: "bob", action: ActionRefund, expected: StatusRefunded},
+		{name: "10. Cannot_Refund_Completed", status: StatusCompleted, actor: "bob", action: ActionRefund, expectedErr: ErrInvalidState},
+		{name: "11. Stranger", status: StatusPaid, actor: "eve", action: ActionShip, expectedErr: ErrNotParticipant},
+		{name: "12. Unknown_Action", status: StatusPaid, actor: "bob", action: "lose", expectedErr: ErrUnknownAction},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := &Order{Buyer: "alice", Seller: "bob", Status: tc.status}
+
+			next, err := o.Next(tc.actor, tc.action)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, next)
+		})
+	}
+}
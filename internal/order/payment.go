@@ -0,0 +1,203 @@
+package order
+
+// mockgen  -source=payment.go -destination=payment_mock_test.go -package=order
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+// Статусы платежа и типы событий провайдера.
+const (
+	PaymentPending = "pending"
+
+	EventPaymentSucceeded = "payment.succeeded"
+	EventPaymentFailed    = "payment.failed"
+)
+
+// FakeSignatureHeader — заголовок с подписью вебхука фейкового провайдера.
+const FakeSignatureHeader = "X-Payment-Signature"
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrUnknownPayment   = errors.New("unknown payment")
+	ErrInvalidPayload   = errors.New("invalid webhook payload")
+)
+
+// PaymentRequest — что нужно оплатить.
+type PaymentRequest struct {
+	OrderID     uint
+	Amount      money.Money
+	Description string
+}
+
+// Payment — платёж у провайдера. Результат приходит позже вебхуком.
+type Payment struct {
+	ID      string      `json:"id"`
+	OrderID uint        `json:"order_id"`
+	Amount  money.Money `json:"amount"`
+	Status  string      `json:"status"`
+}
+
+// PaymentEvent — событие провайдера о результате платежа.
+type PaymentEvent struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	PaymentID string      `json:"payment_id"`
+	OrderID   uint        `json:"order_id"`
+	Amount    money.Money `json:"amount"`
+	Reason    string      `json:"reason,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// PaymentProvider — платёжный провайдер. Оплата асинхронная: CreatePayment только
+// заводит платёж, а результат провайдер присылает вебхуком, который разбирает ParseWebhook.
+type PaymentProvider interface {
+	CreatePayment(req PaymentRequest) (*Payment, error)
+	Refund(paymentID string, amount money.Money) error
+	// ParseWebhook проверяет подпись и разбирает событие из тела вебхука.
+	ParseWebhook(header http.Header, payload []byte) (*PaymentEvent, error)
+}
+
+// FakeProvider — детерминированный провайдер для разработки и тестов. Одобряет все платежи,
+// кроме сумм с копейками .13 (например, 100.13), которые отклоняет. Если задан callbackURL,
+// сразу после CreatePayment отправляет подписанный вебхук с результатом и, как настоящие
+// провайдеры, повторяет доставку, пока сервис не ответит 2xx.
+type FakeProvider struct {
+	secret      string
+	callbackURL string
+	retries     []time.Duration
+	client      *http.Client
+	seq         atomic.Uint64
+	logger      *zap.Logger
+}
+
+func NewFakeProvider(secret, callbackURL string, logger *zap.Logger) *FakeProvider {
+	return &FakeProvider{
+		secret:      secret,
+		callbackURL: callbackURL,
+		retries:     []time.Duration{time.Second, 5 * time.Second, 30 * time.Second},
+		client:      &http.Client{Timeout: 5 * time.Second},
+		logger:      logger,
+	}
+}
+
+const fakePaymentPrefix = "fake_pay_"
+
+// declines сообщает, отклонит ли фейковый провайдер сумму.
+func declines(amount money.Money) bool {
+	d, err := amount.Amount().Rescale(2)
+	if err != nil {
+		return false
+	}
+	return strings.HasSuffix(d.String(), ".13")
+}
+
+func (p *FakeProvider) CreatePayment(req PaymentRequest) (*Payment, error) {
+	payment := &Payment{
+		ID:      fmt.Sprintf("%s%d_%d", fakePaymentPrefix, req.OrderID, p.seq.Add(1)),
+		OrderID: req.OrderID,
+		Amount:  req.Amount,
+		Status:  PaymentPending,
+	}
+
+	event := &PaymentEvent{
+		ID:        "evt_" + payment.ID,
+		Type:      EventPaymentSucceeded,
+		PaymentID: payment.ID,
+		OrderID:   req.OrderID,
+		Amount:    req.Amount,
+		CreatedAt: time.Now().UTC(),
+	}
+	if declines(req.Amount) {
+		event.Type = EventPaymentFailed
+		event.Reason = "card_declined"
+	}
+	if p.callbackURL != "" {
+		go p.send(event)
+	}
+	return payment, nil
+}
+
+func (p *FakeProvider) Refund(paymentID string, amount money.Money) error {
+	if !strings.HasPrefix(paymentID, fakePaymentPrefix) {
+		return ErrUnknownPayment
+	}
+	return nil
+}
+
+// Sign возвращает значение заголовка подписи для тела вебхука.
+func (p *FakeProvider) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(p.secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ParseWebhook без ключа отклоняет любой вебхук: неподписанное событие мог прислать кто угодно.
+func (p *FakeProvider) ParseWebhook(header http.Header, payload []byte) (*PaymentEvent, error) {
+	if p.secret == "" || !hmac.Equal([]byte(header.Get(FakeSignatureHeader)), []byte(p.Sign(payload))) {
+		return nil, ErrInvalidSignature
+	}
+	var event PaymentEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if !strings.HasPrefix(event.PaymentID, fakePaymentPrefix) {
+		return nil, ErrUnknownPayment
+	}
+	return &event, nil
+}
+
+// send доставляет вебхук. Первая попытка может прийти раньше, чем сервис сохранит
+// ID платежа у заказа, поэтому отказ повторяется с задержками из retries.
+func (p *FakeProvider) send(event *PaymentEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	for attempt := 0; ; attempt++ {
+		if p.deliver(event.PaymentID, payload) || attempt == len(p.retries) {
+			return
+		}
+		time.Sleep(p.retries[attempt])
+	}
+}
+
+func (p *FakeProvider) deliver(paymentID string, payload []byte) bool {
+	req, err := http.NewRequest(http.MethodPost, p.callbackURL, bytes.NewReader(payload))
+	if err != nil {
+		p.logger.Warn("Failed to build payment webhook", zap.Error(err))
+		return true
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(FakeSignatureHeader, p.Sign(payload))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		p.logger.Warn("Failed to deliver payment webhook", zap.String("payment_id", paymentID), zap.Error(err))
+		return false
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		p.logger.Warn(
+			"Payment webhook rejected",
+			zap.String("payment_id", paymentID),
+			zap.Int("status", resp.StatusCode),
+		)
+		return false
+	}
+	return true
+}
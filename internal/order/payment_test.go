@@ -0,0 +1,132 @@
+package order
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+func TestFakeProvider_Webhook(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		amount money.Money
+
+		expectedType string
+	}{
+		{name: "1. Approved", amount: money.MustParse("1000", "RUB"), expectedType: EventPaymentSucceeded},
+		{name: "2. Declined", amount: money.MustParse("100.13", "RUB"), expectedType: EventPaymentFailed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var provider *FakeProvider
+			events := make(chan *PaymentEvent, 1)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				payload, _ := io.ReadAll(r.Body)
+				event, err := provider.ParseWebhook(r.Header, payload)
+				assert.NoError(t, err)
+				events <- event
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer srv.Close()
+			provider = NewFakeProvider("secret", srv.URL, zap.NewNop())
+
+			payment, err := provider.CreatePayment(PaymentRequest{OrderID: 7, Amount: tc.amount})
+			assert.NoError(t, err)
+			assert.Equal(t, PaymentPending, payment.Status)
+
+			select {
+			case event := <-events:
+				assert.Equal(t, tc.expectedType, event.Type)
+				assert.Equal(t, payment.ID, event.PaymentID)
+				assert.Equal(t, uint(7), event.OrderID)
+				assert.True(t, tc.amount.Equal(event.Amount))
+			case <-time.After(5 * time.Second):
+				t.Fatal("webhook was not delivered")
+			}
+		})
+	}
+}
+
+func TestFakeProvider_Webhook_Retry(t *testing.T) {
+	var calls atomic.Int32
+	delivered := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Первый вебхук пришёл раньше, чем заказ узнал ID платежа
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		close(delivered)
+	}))
+	defer srv.Close()
+
+	provider := NewFakeProvider("secret", srv.URL, zap.NewNop())
+	provider.retries = []time.Duration{10 * time.Millisecond}
+
+	_, err := provider.CreatePayment(PaymentRequest{OrderID: 7, Amount: money.MustParse("1000", "RUB")})
+	assert.NoError(t, err)
+
+	select {
+	case <-delivered:
+		assert.Equal(t, int32(2), calls.Load())
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not redelivered")
+	}
+}
+
+func TestFakeProvider_ParseWebhook(t *testing.T) {
+	provider := NewFakeProvider("secret", "", zap.NewNop())
+	unsigned := NewFakeProvider("", "", zap.NewNop())
+	payload, _ := json.Marshal(PaymentEvent{Type: EventPaymentSucceeded, PaymentID: "fake_pay_7_1", OrderID: 7})
+	unknown, _ := json.Marshal(PaymentEvent{Type: EventPaymentSucceeded, PaymentID: "other_1", OrderID: 7})
+
+	testCases := []struct {
+		name string
+
+		payload   []byte
+		signature string
+		noSecret  bool
+
+		expectedErr error
+	}{
+		{name: "1. Valid", payload: payload, signature: provider.Sign(payload)},
+		{name: "2. Missing_Signature", payload: payload, expectedErr: ErrInvalidSignature},
+		{name: "3. Tampered_Payload", payload: append(payload, ' '), signature: provider.Sign(payload), expectedErr: ErrInvalidSignature},
+		{name: "4. Invalid_JSON", payload: []byte("{"), signature: provider.Sign([]byte("{")), expectedErr: ErrInvalidPayload},
+		{name: "5. Unknown_Payment", payload: unknown, signature: provider.Sign(unknown), expectedErr: ErrUnknownPayment},
+		{name: "6. No_Secret", payload: payload, signature: unsigned.Sign(payload), noSecret: true, expectedErr: ErrInvalidSignature},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			if tc.signature != "" {
+				header.Set(FakeSignatureHeader, tc.signature)
+			}
+
+			p := provider
+			if tc.noSecret {
+				p = unsigned
+			}
+			event, err := p.ParseWebhook(header, tc.payload)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "fake_pay_7_1", event.PaymentID)
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package order
+
+// mockgen  -source=service.go -destination=service_mock_test.go -package=order
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+type storage interface {
+	Create(buyer string, items []*Item, checkSeller func(seller string) error) ([]*Order, error)
+	GetByID(id uint) (*Order, error)
+	ListByUser(login, role, status string) ([]*Order, error)
+	SetPayment(id uint, paymentID string) error
+	Transition(id uint, from, to, paymentID string) (*Order, error)
+	Refund(id uint, from string, refund func(*Order) error) (*Order, error)
+	Expire(ttl time.Duration) ([]*Order, error)
+	RefundPayment(orderID uint, paymentID string, amount money.Money, refund func() error) (bool, error)
+}
+
+type notifier interface {
+	Notify(msg notify.Message) error
+}
+
+type blocks interface {
+	Check(a, b string) error
+}
+
+type Service struct {
+	repository storage
+	payments   PaymentProvider
+	notifier   notifier
+	blocks     blocks
+	paymentTTL time.Duration
+	logger     *zap.Logger
+}
+
+type Option func(*Service)
+
+// WithNotifier включает уведомления участникам о новых заказах и смене их состояния.
+func WithNotifier(notifier notifier) Option {
+	return func(s *Service) {
+		s.notifier = notifier
+	}
+}
+
+// WithBlocks запрещает покупать у пользователя, если кто-то из двоих заблокировал другого.
+func WithBlocks(blocks blocks) Option {
+	return func(s *Service) {
+		s.blocks = blocks
+	}
+}
+
+// WithPaymentTTL задаёт, сколько неоплаченный заказ держит товар, прежде чем Run его отменит.
+func WithPaymentTTL(ttl time.Duration) Option {
+	return func(s *Service) {
+		s.paymentTTL = ttl
+	}
+}
+
+func NewService(repository storage, payments PaymentProvider, logger *zap.Logger, opts ...Option) *Service {
+	s := &Service{
+		repository: repository,
+		payments:   payments,
+		logger:     logger,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// CreateOrder оформляет покупку quantity единиц товара; они сразу списываются с остатка.
+func (s *Service) CreateOrder(postID uint, buyer string, quantity int) (*Order, error) {
+	if quantity < 1 {
+		return nil, ErrInvalidQuantity
+	}
+	orders, err := s.repository.Create(buyer, []*Item{{PostID: postID, Quantity: quantity}}, s.checkSeller(buyer))
+	if err != nil {
+		return nil, err
+	}
+	o := orders[0]
+	s.notify(o.Seller, o, fmt.Sprintf("New order #%d from %s", o.ID, o.Buyer))
+	return o, nil
+}
+
+// Checkout покупает несколько позиций разом. Позиции разных продавцов попадают в разные
+// заказы; если купить нельзя хотя бы одну позицию, не создаётся ни один заказ.
+func (s *Service) Checkout(buyer string, items []*Item) ([]*Order, error) {
+	if len(items) == 0 {
+		return nil, ErrNoItems
+	}
+	seen := make(map[uint]bool, len(items))
+	for _, item := range items {
+		if item.Quantity < 1 {
+			return nil, ErrInvalidQuantity
+		}
+		if seen[item.PostID] {
+			return nil, ErrDuplicateItem
+		}
+		seen[item.PostID] = true
+	}
+
+	orders, err := s.repository.Create(buyer, items, s.checkSeller(buyer))
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range orders {
+		s.notify(o.Seller, o, fmt.Sprintf("New order #%d from %s", o.ID, o.Buyer))
+	}
+	return orders, nil
+}
+
+// GetOrder возвращает заказ участнику сделки.
+func (s *Service) GetOrder(id uint, login string) (*Order, error) {
+	o, err := s.repository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !o.IsParticipant(login) {
+		return nil, ErrNotParticipant
+	}
+	return o, nil
+}
+
+func (s *Service) ListOrders(login, role, status string) ([]*Order, error) {
+	return s.repository.ListByUser(login, role, status)
+}
+
+// Pay заводит платёж у провайдера. Заказ станет оплаченным, когда придёт вебхук. Новая попытка
+// заменяет прежний платёж; если прежний всё-таки пройдёт, HandlePaymentEvent вернёт по нему деньги.
+func (s *Service) Pay(id uint, buyer string) (*Payment, error) {
+	o, err := s.GetOrder(id, buyer)
+	if err != nil {
+		return nil, err
+	}
+	if buyer != o.Buyer {
+		return nil, ErrNotAllowed
+	}
+	if o.Status != StatusCreated {
+		return nil, ErrInvalidState
+	}
+
+	payment, err := s.payments.CreatePayment(PaymentRequest{
+		OrderID:     o.ID,
+		Amount:      o.Total,
+		Description: fmt.Sprintf("Order #%d", o.ID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repository.SetPayment(o.ID, payment.ID); err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// Act выполняет действие участника над заказом. Возврат проводится у провайдера внутри перехода,
+// поэтому одновременные запросы не вернут деньги дважды.
+func (s *Service) Act(id uint, actor, action string) (*Order, error) {
+	o, err := s.repository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	next, err := o.Next(actor, action)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *Order
+	if next == StatusRefunded {
+		updated, err = s.repository.Refund(o.ID, o.Status, func(o *Order) error {
+			return s.payments.Refund(o.PaymentID, o.Total)
+		})
+	} else {
+		updated, err = s.repository.Transition(o.ID, o.Status, next, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.notify(updated.Counterpart(actor), updated, fmt.Sprintf("Order #%d is %s", updated.ID, updated.Status))
+	return updated, nil
+}
+
+// HandleWebhook проверяет и применяет вебхук платёжного провайдера. Повторная доставка
+// уже учтённого события ничего не меняет.
+func (s *Service) HandleWebhook(header http.Header, payload []byte) error {
+	event, err := s.payments.ParseWebhook(header, payload)
+	if err != nil {
+		return err
+	}
+	return s.HandlePaymentEvent(event)
+}
+
+func (s *Service) HandlePaymentEvent(event *PaymentEvent) error {
+	o, err := s.repository.GetByID(event.OrderID)
+	if err != nil {
+		return err
+	}
+	if event.PaymentID != o.PaymentID {
+		// Платёж заменён новой попыткой оплаты (Pay перезаписывает payment_id). Если он всё же прошёл,
+		// заказ им не оплачивается, а деньги возвращаются покупателю
+		if event.Type == EventPaymentSucceeded {
+			return s.refundPayment(o, event, fmt.Sprintf("Earlier payment for order #%d refunded", o.ID))
+		}
+		return nil
+	}
+	if !event.Amount.Equal(o.Total) {
+		s.logger.Warn(
+			"Payment amount does not match order",
+			zap.Uint("order_id", o.ID),
+			zap.String("payment_id", event.PaymentID),
+			zap.String("amount", event.Amount.String()),
+		)
+		return ErrPaymentMismatch
+	}
+	if o.Status == StatusCancelled && event.Type == EventPaymentSucceeded {
+		// Оплата пришла после отмены заказа: товар уже вернулся на остаток, поэтому деньги возвращаем
+		return s.refundPayment(o, event, fmt.Sprintf("Payment for cancelled order #%d refunded", o.ID))
+	}
+	if o.Status != StatusCreated {
+		return nil
+	}
+
+	switch event.Type {
+	case EventPaymentSucceeded:
+		paid, err := s.repository.Transition(o.ID, StatusCreated, StatusPaid, event.PaymentID)
+		if err == ErrInvalidState {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.notify(paid.Seller, paid, fmt.Sprintf("Order #%d is paid", paid.ID))
+		s.notify(paid.Buyer, paid, fmt.Sprintf("Payment for order #%d received", paid.ID))
+	case EventPaymentFailed:
+		// Заказ остаётся неоплаченным: покупатель может попробовать ещё раз или отменить его
+		s.notify(o.Buyer, o, fmt.Sprintf("Payment for order #%d failed: %s", o.ID, event.Reason))
+	}
+	return nil
+}
+
+// refundPayment возвращает прошедший платёж, которым заказ не оплачивается. Возврат записывается,
+// поэтому повторная доставка события деньги второй раз не вернёт.
+func (s *Service) refundPayment(o *Order, event *PaymentEvent, title string) error {
+	refunded, err := s.repository.RefundPayment(o.ID, event.PaymentID, event.Amount, func() error {
+		return s.payments.Refund(event.PaymentID, event.Amount)
+	})
+	if err != nil {
+		return err
+	}
+	if refunded {
+		s.logger.Warn(
+			"Refunded payment that does not pay the order",
+			zap.Uint("order_id", o.ID),
+			zap.String("payment_id", event.PaymentID),
+			zap.String("status", o.Status),
+		)
+		s.notify(o.Buyer, o, title)
+	}
+	return nil
+}
+
+// ExpireOrders отменяет заказы, не оплаченные за paymentTTL, и уведомляет участников.
+func (s *Service) ExpireOrders() error {
+	expired, err := s.repository.Expire(s.paymentTTL)
+	for _, o := range expired {
+		title := fmt.Sprintf("Order #%d was cancelled: not paid in time", o.ID)
+		s.notify(o.Buyer, o, title)
+		s.notify(o.Seller, o, title)
+	}
+	return err
+}
+
+// Run периодически отменяет неоплаченные заказы, пока не отменён ctx. Без WithPaymentTTL ничего не делает.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	if s.paymentTTL <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.ExpireOrders(); err != nil {
+				s.logger.Warn("Failed to expire orders", zap.Error(err))
+			}
+		}
+	}
+}
+
+// checkSeller возвращает проверку блокировок между покупателем и продавцом позиции
+// или nil, если блокировки не подключены. Продавец известен только хранилищу.
+func (s *Service) checkSeller(buyer string) func(seller string) error {
+	if s.blocks == nil {
+		return nil
+	}
+	return func(seller string) error {
+		return s.blocks.Check(buyer, seller)
+	}
+}
+
+func (s *Service) notify(recipient string, o *Order, title string) {
+	if s.notifier == nil {
+		return
+	}
+	payload, err := json.Marshal(o)
+	if err != nil {
+		return
+	}
+	err = s.notifier.Notify(notify.Message{
+		Recipient: recipient,
+		Kind:      KindOrder,
+		Title:     title,
+		Payload:   payload,
+		Channel:   notify.ChannelInbox,
+	})
+	if err != nil {
+		s.logger.Warn(
+			"Failed to queue order notification",
+			zap.Uint("order_id", o.ID),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,230 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package order is a generated GoMock package.
+package order
+
+import (
+	reflect "reflect"
+	time "time"
+
+	notify "github.com/TemirB/rest-api-marketplace/internal/notify"
+	money "github.com/TemirB/rest-api-marketplace/pkg/money"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockstorage is a mock of storage interface.
+type Mockstorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockstorageMockRecorder
+}
+
+// MockstorageMockRecorder is the mock recorder for Mockstorage.
+type MockstorageMockRecorder struct {
+	mock *Mockstorage
+}
+
+// NewMockstorage creates a new mock instance.
+func NewMockstorage(ctrl *gomock.Controller) *Mockstorage {
+	mock := &Mockstorage{ctrl: ctrl}
+	mock.recorder = &MockstorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstorage) EXPECT() *MockstorageMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *Mockstorage) Create(buyer string, items []*Item, checkSeller func(string) error) ([]*Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", buyer, items, checkSeller)
+	ret0, _ := ret[0].([]*Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockstorageMockRecorder) Create(buyer, items, checkSeller interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*Mockstorage)(nil).Create), buyer, items, checkSeller)
+}
+
+// Expire mocks base method.
+func (m *Mockstorage) Expire(ttl time.Duration) ([]*Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Expire", ttl)
+	ret0, _ := ret[0].([]*Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Expire indicates an expected call of Expire.
+func (mr *MockstorageMockRecorder) Expire(ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Expire", reflect.TypeOf((*Mockstorage)(nil).Expire), ttl)
+}
+
+// GetByID mocks base method.
+func (m *Mockstorage) GetByID(id uint) (*Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockstorageMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*Mockstorage)(nil).GetByID), id)
+}
+
+// ListByUser mocks base method.
+func (m *Mockstorage) ListByUser(login, role, status string) ([]*Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", login, role, status)
+	ret0, _ := ret[0].([]*Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockstorageMockRecorder) ListByUser(login, role, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*Mockstorage)(nil).ListByUser), login, role, status)
+}
+
+// Refund mocks base method.
+func (m *Mockstorage) Refund(id uint, from string, refund func(*Order) error) (*Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", id, from, refund)
+	ret0, _ := ret[0].(*Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockstorageMockRecorder) Refund(id, from, refund interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*Mockstorage)(nil).Refund), id, from, refund)
+}
+
+// RefundPayment mocks base method.
+func (m *Mockstorage) RefundPayment(orderID uint, paymentID string, amount money.Money, refund func() error) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundPayment", orderID, paymentID, amount, refund)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefundPayment indicates an expected call of RefundPayment.
+func (mr *MockstorageMockRecorder) RefundPayment(orderID, paymentID, amount, refund interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundPayment", reflect.TypeOf((*Mockstorage)(nil).RefundPayment), orderID, paymentID, amount, refund)
+}
+
+// SetPayment mocks base method.
+func (m *Mockstorage) SetPayment(id uint, paymentID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPayment", id, paymentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPayment indicates an expected call of SetPayment.
+func (mr *MockstorageMockRecorder) SetPayment(id, paymentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPayment", reflect.TypeOf((*Mockstorage)(nil).SetPayment), id, paymentID)
+}
+
+// Transition mocks base method.
+func (m *Mockstorage) Transition(id uint, from, to, paymentID string) (*Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transition", id, from, to, paymentID)
+	ret0, _ := ret[0].(*Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transition indicates an expected call of Transition.
+func (mr *MockstorageMockRecorder) Transition(id, from, to, paymentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transition", reflect.TypeOf((*Mockstorage)(nil).Transition), id, from, to, paymentID)
+}
+
+// Mocknotifier is a mock of notifier interface.
+type Mocknotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MocknotifierMockRecorder
+}
+
+// MocknotifierMockRecorder is the mock recorder for Mocknotifier.
+type MocknotifierMockRecorder struct {
+	mock *Mocknotifier
+}
+
+// NewMocknotifier creates a new mock instance.
+func NewMocknotifier(ctrl *gomock.Controller) *Mocknotifier {
+	mock := &Mocknotifier{ctrl: ctrl}
+	mock.recorder = &MocknotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocknotifier) EXPECT() *MocknotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *Mocknotifier) Notify(msg notify.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MocknotifierMockRecorder) Notify(msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*Mocknotifier)(nil).Notify), msg)
+}
+
+// Mockblocks is a mock of blocks interface.
+type Mockblocks struct {
+	ctrl     *gomock.Controller
+	recorder *MockblocksMockRecorder
+}
+
+// MockblocksMockRecorder is the mock recorder for Mockblocks.
+type MockblocksMockRecorder struct {
+	mock *Mockblocks
+}
+
+// NewMockblocks creates a new mock instance.
+func NewMockblocks(ctrl *gomock.Controller) *Mockblocks {
+	mock := &Mockblocks{ctrl: ctrl}
+	mock.recorder = &MockblocksMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockblocks) EXPECT() *MockblocksMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *Mockblocks) Check(a, b string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", a, b)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockblocksMockRecorder) Check(a, b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*Mockblocks)(nil).Check), a, b)
+}
@@ -0,0 +1,552 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/block"
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+func testOrder(status string) *Order {
+	return &Order{
+		ID:        3,
+		Buyer:     "alice",
+		Seller:    "bob",
+		Items:     []*Item{{PostID: 1, Price: money.MustParse("1000", "RUB"), Quantity: 1}},
+		Total:     money.MustParse("1000", "RUB"),
+		Status:    status,
+		PaymentID: "fake_pay_3_1",
+	}
+}
+
+func TestService_CreateOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		quantity   int
+		setupMocks func(repo *Mockstorage, n *Mocknotifier)
+
+		expectedErr error
+	}{
+		{
+			name:     "1. Created",
+			quantity: 3,
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().Create("alice", []*Item{{PostID: 1, Quantity: 3}}, gomock.Any()).Return([]*Order{testOrder(StatusCreated)}, nil)
+				n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+					assert.Equal(t, "bob", msg.Recipient)
+					assert.Equal(t, KindOrder, msg.Kind)
+					return nil
+				})
+			},
+		},
+		{
+			name:     "2. Post_Not_Available",
+			quantity: 1,
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().Create("alice", gomock.Any(), gomock.Any()).Return(nil, ErrPostNotAvailable)
+			},
+			expectedErr: ErrPostNotAvailable,
+		},
+		{
+			name:     "3. Not_Enough_In_Stock",
+			quantity: 5,
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().Create("alice", gomock.Any(), gomock.Any()).Return(nil, ErrInsufficientQuantity)
+			},
+			expectedErr: ErrInsufficientQuantity,
+		},
+		{
+			name:        "4. Zero_Quantity",
+			quantity:    0,
+			setupMocks:  func(repo *Mockstorage, n *Mocknotifier) {},
+			expectedErr: ErrInvalidQuantity,
+		},
+		{
+			name:     "5. Blocked",
+			quantity: 1,
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().Create("alice", gomock.Any(), gomock.Any()).DoAndReturn(
+					func(buyer string, items []*Item, checkSeller func(string) error) ([]*Order, error) {
+						return nil, checkSeller("bob")
+					},
+				)
+			},
+			expectedErr: block.ErrBlocked,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			n := NewMocknotifier(ctrl)
+			tc.setupMocks(repo, n)
+			blocks := NewMockblocks(ctrl)
+			blocks.EXPECT().Check("alice", "bob").Return(block.ErrBlocked).AnyTimes()
+			service := NewService(repo, NewMockPaymentProvider(ctrl), zap.NewNop(), WithNotifier(n), WithBlocks(blocks))
+
+			o, err := service.CreateOrder(1, "alice", tc.quantity)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, StatusCreated, o.Status)
+		})
+	}
+}
+
+func TestService_Checkout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		items      []*Item
+		setupMocks func(repo *Mockstorage, n *Mocknotifier)
+
+		expectedOrders int
+		expectedErr    error
+	}{
+		{
+			name:  "1. Split_By_Seller",
+			items: []*Item{{PostID: 1, Quantity: 1}, {PostID: 2, Quantity: 2}},
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				second := testOrder(StatusCreated)
+				second.ID, second.Seller = 4, "carol"
+				repo.EXPECT().Create("alice", gomock.Len(2), gomock.Any()).Return([]*Order{testOrder(StatusCreated), second}, nil)
+				n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+					assert.Equal(t, "bob", msg.Recipient)
+					return nil
+				})
+				n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+					assert.Equal(t, "carol", msg.Recipient)
+					return nil
+				})
+			},
+			expectedOrders: 2,
+		},
+		{
+			name:  "2. Price_Changed",
+			items: []*Item{{PostID: 1, Quantity: 1}},
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().Create("alice", gomock.Any(), gomock.Any()).Return(nil, ErrPriceChanged)
+			},
+			expectedErr: ErrPriceChanged,
+		},
+		{
+			name:        "3. No_Items",
+			setupMocks:  func(repo *Mockstorage, n *Mocknotifier) {},
+			expectedErr: ErrNoItems,
+		},
+		{
+			name:        "4. Duplicate_Post",
+			items:       []*Item{{PostID: 1, Quantity: 1}, {PostID: 1, Quantity: 2}},
+			setupMocks:  func(repo *Mockstorage, n *Mocknotifier) {},
+			expectedErr: ErrDuplicateItem,
+		},
+		{
+			name:        "5. Zero_Quantity",
+			items:       []*Item{{PostID: 1}},
+			setupMocks:  func(repo *Mockstorage, n *Mocknotifier) {},
+			expectedErr: ErrInvalidQuantity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			n := NewMocknotifier(ctrl)
+			tc.setupMocks(repo, n)
+			service := NewService(repo, NewMockPaymentProvider(ctrl), zap.NewNop(), WithNotifier(n))
+
+			orders, err := service.Checkout("alice", tc.items)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, orders, tc.expectedOrders)
+		})
+	}
+}
+
+func TestService_Pay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		login      string
+		setupMocks func(repo *Mockstorage, payments *MockPaymentProvider)
+
+		expectedErr error
+	}{
+		{
+			name:  "1. Payment_Created",
+			login: "alice",
+			setupMocks: func(repo *Mockstorage, payments *MockPaymentProvider) {
+				repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusCreated), nil)
+				payments.EXPECT().CreatePayment(PaymentRequest{
+					OrderID:     3,
+					Amount:      money.MustParse("1000", "RUB"),
+					Description: "Order #3",
+				}).Return(&Payment{ID: "fake_pay_3_2", OrderID: 3, Status: PaymentPending}, nil)
+				repo.EXPECT().SetPayment(uint(3), "fake_pay_3_2").Return(nil)
+			},
+		},
+		{
+			name:  "2. Seller_Cannot_Pay",
+			login: "bob",
+			setupMocks: func(repo *Mockstorage, payments *MockPaymentProvider) {
+				repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusCreated), nil)
+			},
+			expectedErr: ErrNotAllowed,
+		},
+		{
+			name:  "3. Already_Paid",
+			login: "alice",
+			setupMocks: func(repo *Mockstorage, payments *MockPaymentProvider) {
+				repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusPaid), nil)
+			},
+			expectedErr: ErrInvalidState,
+		},
+		{
+			name:  "4. Stranger",
+			login: "eve",
+			setupMocks: func(repo *Mockstorage, payments *MockPaymentProvider) {
+				repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusCreated), nil)
+			},
+			expectedErr: ErrNotParticipant,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			payments := NewMockPaymentProvider(ctrl)
+			tc.setupMocks(repo, payments)
+			service := NewService(repo, payments, zap.NewNop())
+
+			payment, err := service.Pay(3, tc.login)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "fake_pay_3_2", payment.ID)
+		})
+	}
+}
+
+func TestService_Act(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		actor      string
+		action     string
+		setupMocks func(repo *Mockstorage, payments *MockPaymentProvider, n *Mocknotifier)
+
+		expectedStatus string
+		expectedErr    error
+	}{
+		{
+			name:   "1. Ship",
+			actor:  "bob",
+			action: ActionShip,
+			setupMocks: func(repo *Mockstorage, payments *MockPaymentProvider, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusPaid), nil)
+				repo.EXPECT().Transition(uint(3), StatusPaid, StatusShipped, "").Return(testOrder(StatusShipped), nil)
+				n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+					assert.Equal(t, "alice", msg.Recipient)
+					return nil
+				})
+			},
+			expectedStatus: StatusShipped,
+		},
+		{
+			name:   "2. Refund_Goes_Through_Provider",
+			actor:  "bob",
+			action: ActionRefund,
+			setupMocks: func(repo *Mockstorage, payments *MockPaymentProvider, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusDelivered), nil)
+				repo.EXPECT().Refund(uint(3), StatusDelivered, gomock.Any()).DoAndReturn(
+					func(id uint, from string, refund func(*Order) error) (*Order, error) {
+						if err := refund(testOrder(StatusRefunded)); err != nil {
+							return nil, err
+						}
+						return testOrder(StatusRefunded), nil
+					},
+				)
+				payments.EXPECT().Refund("fake_pay_3_1", money.MustParse("1000", "RUB")).Return(nil)
+				n.EXPECT().Notify(gomock.Any()).Return(nil)
+			},
+			expectedStatus: StatusRefunded,
+		},
+		{
+			name:   "3. Refund_Failed",
+			actor:  "bob",
+			action: ActionRefund,
+			setupMocks: func(repo *Mockstorage, payments *MockPaymentProvider, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusPaid), nil)
+				repo.EXPECT().Refund(uint(3), StatusPaid, gomock.Any()).DoAndReturn(
+					func(id uint, from string, refund func(*Order) error) (*Order, error) {
+						return nil, refund(testOrder(StatusRefunded))
+					},
+				)
+				payments.EXPECT().Refund("fake_pay_3_1", gomock.Any()).Return(ErrUnknownPayment)
+			},
+			expectedErr: ErrUnknownPayment,
+		},
+		{
+			name:   "4. Concurrent_Refund",
+			actor:  "bob",
+			action: ActionRefund,
+			setupMocks: func(repo *Mockstorage, payments *MockPaymentProvider, n *Mocknotifier) {
+				// Возврат уже провёл параллельный запрос: провайдер повторно не вызывается
+				repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusPaid), nil)
+				repo.EXPECT().Refund(uint(3), StatusPaid, gomock.Any()).Return(nil, ErrInvalidState)
+			},
+			expectedErr: ErrInvalidState,
+		},
+		{
+			name:   "5. Concurrent_Change",
+			actor:  "alice",
+			action: ActionCancel,
+			setupMocks: func(repo *Mockstorage, payments *MockPaymentProvider, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusCreated), nil)
+				repo.EXPECT().Transition(uint(3), StatusCreated, StatusCancelled, "").Return(nil, ErrInvalidState)
+			},
+			expectedErr: ErrInvalidState,
+		},
+		{
+			name:   "6. Buyer_Cannot_Ship",
+			actor:  "alice",
+			action: ActionShip,
+			setupMocks: func(repo *Mockstorage, payments *MockPaymentProvider, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusPaid), nil)
+			},
+			expectedErr: ErrNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			payments := NewMockPaymentProvider(ctrl)
+			n := NewMocknotifier(ctrl)
+			tc.setupMocks(repo, payments, n)
+			service := NewService(repo, payments, zap.NewNop(), WithNotifier(n))
+
+			o, err := service.Act(3, tc.actor, tc.action)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, o.Status)
+		})
+	}
+}
+
+func TestService_HandlePaymentEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	succeeded := &PaymentEvent{
+		Type:      EventPaymentSucceeded,
+		PaymentID: "fake_pay_3_1",
+		OrderID:   3,
+		Amount:    money.MustParse("1000", "RUB"),
+	}
+
+	testCases := []struct {
+		name string
+
+		event      *PaymentEvent
+		setupMocks func(repo *Mockstorage, n *Mocknotifier)
+
+		expectedErr error
+	}{
+		{
+			name:  "1. Paid",
+			event: succeeded,
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusCreated), nil)
+				repo.EXPECT().Transition(uint(3), StatusCreated, StatusPaid, "fake_pay_3_1").Return(testOrder(StatusPaid), nil)
+				n.EXPECT().Notify(gomock.Any()).Return(nil).Times(2)
+			},
+		},
+		{
+			name:  "2. Duplicate_Delivery",
+			event: succeeded,
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusPaid), nil)
+			},
+		},
+		{
+			name:  "3. Concurrent_Delivery",
+			event: succeeded,
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusCreated), nil)
+				repo.EXPECT().Transition(uint(3), StatusCreated, StatusPaid, "fake_pay_3_1").Return(nil, ErrInvalidState)
+			},
+		},
+		{
+			name: "4. Failed_Keeps_Order_Open",
+			event: &PaymentEvent{
+				Type:      EventPaymentFailed,
+				PaymentID: "fake_pay_3_1",
+				OrderID:   3,
+				Amount:    money.MustParse("1000", "RUB"),
+				Reason:    "card_declined",
+			},
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusCreated), nil)
+				n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+					assert.Equal(t, "alice", msg.Recipient)
+					assert.Contains(t, msg.Title, "card_declined")
+					return nil
+				})
+			},
+		},
+		{
+			name: "5. Amount_Mismatch",
+			event: &PaymentEvent{
+				Type:      EventPaymentSucceeded,
+				PaymentID: "fake_pay_3_1",
+				OrderID:   3,
+				Amount:    money.MustParse("1", "RUB"),
+			},
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusCreated), nil)
+			},
+			expectedErr: ErrPaymentMismatch,
+		},
+		{
+			// Покупатель нажал «оплатить» ещё раз, а первый платёж всё-таки прошёл
+			name: "6. Superseded_Payment_Refunded",
+			event: &PaymentEvent{
+				Type:      EventPaymentSucceeded,
+				PaymentID: "fake_pay_3_0",
+				OrderID:   3,
+				Amount:    money.MustParse("1000", "RUB"),
+			},
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusCreated), nil)
+				repo.EXPECT().RefundPayment(uint(3), "fake_pay_3_0", money.MustParse("1000", "RUB"), gomock.Any()).Return(true, nil)
+				n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+					assert.Equal(t, "alice", msg.Recipient)
+					return nil
+				})
+			},
+		},
+		{
+			name: "7. Superseded_Payment_Failed",
+			event: &PaymentEvent{
+				Type:      EventPaymentFailed,
+				PaymentID: "fake_pay_3_0",
+				OrderID:   3,
+				Amount:    money.MustParse("1000", "RUB"),
+			},
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusCreated), nil)
+			},
+		},
+		{
+			name:  "8. Unknown_Order",
+			event: succeeded,
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(3)).Return(nil, ErrOrderNotFound)
+			},
+			expectedErr: ErrOrderNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			n := NewMocknotifier(ctrl)
+			tc.setupMocks(repo, n)
+			service := NewService(repo, NewMockPaymentProvider(ctrl), zap.NewNop(), WithNotifier(n))
+
+			err := service.HandlePaymentEvent(tc.event)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_HandlePaymentEvent_Cancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	payments := NewMockPaymentProvider(ctrl)
+	n := NewMocknotifier(ctrl)
+	repo.EXPECT().GetByID(uint(3)).Return(testOrder(StatusCancelled), nil).Times(2)
+	// Первая доставка возвращает деньги, повторная видит запись о возврате
+	refunded := false
+	repo.EXPECT().RefundPayment(uint(3), "fake_pay_3_1", money.MustParse("1000", "RUB"), gomock.Any()).DoAndReturn(
+		func(orderID uint, paymentID string, amount money.Money, refund func() error) (bool, error) {
+			if refunded {
+				return false, nil
+			}
+			refunded = true
+			return true, refund()
+		},
+	).Times(2)
+	payments.EXPECT().Refund("fake_pay_3_1", money.MustParse("1000", "RUB")).Return(nil)
+	n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+		assert.Equal(t, "alice", msg.Recipient)
+		return nil
+	})
+	service := NewService(repo, payments, zap.NewNop(), WithNotifier(n))
+
+	event := &PaymentEvent{
+		Type:      EventPaymentSucceeded,
+		PaymentID: "fake_pay_3_1",
+		OrderID:   3,
+		Amount:    money.MustParse("1000", "RUB"),
+	}
+	assert.NoError(t, service.HandlePaymentEvent(event))
+	assert.NoError(t, service.HandlePaymentEvent(event))
+}
+
+func TestService_ExpireOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	n := NewMocknotifier(ctrl)
+	repo.EXPECT().Expire(30*time.Minute).Return([]*Order{testOrder(StatusCancelled)}, nil)
+	var recipients []string
+	n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+		recipients = append(recipients, msg.Recipient)
+		return nil
+	}).Times(2)
+	service := NewService(repo, NewMockPaymentProvider(ctrl), zap.NewNop(),
+		WithNotifier(n),
+		WithPaymentTTL(30*time.Minute),
+	)
+
+	assert.NoError(t, service.ExpireOrders())
+	assert.ElementsMatch(t, []string{"alice", "bob"}, recipients)
+}
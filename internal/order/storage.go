@@ -0,0 +1,556 @@
+package order
+
+// mockgen  -source=storage.go -destination=storage_mock_test.go -package=order
+
+import (
+	"database/sql"
+	"slices"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+const (
+	orderColumns = "id, buyer, seller, total, currency, status, payment_id, created_at, updated_at"
+	itemColumns  = "order_id, post_id, price, quantity, offer_id"
+)
+
+type Repository interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+	Query(query string, args ...any) (*sql.Rows, error)
+	Begin() (*sql.Tx, error)
+}
+
+type Storage struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+func NewStorage(repository Repository, logger *zap.Logger) *Storage {
+	return &Storage{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// querier — общее у Repository и *sql.Tx, чтобы позиции читались и внутри транзакции.
+type querier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// scanOrder читает строку в порядке orderColumns. Суммы хранятся в NUMERIC, поэтому сканируем их строками.
+func scanOrder(row rowScanner, o *Order) error {
+	var (
+		total, currency string
+		paymentID       sql.NullString
+	)
+	err := row.Scan(
+		&o.ID, &o.Buyer, &o.Seller, &total, &currency,
+		&o.Status, &paymentID, &o.CreatedAt, &o.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	amount, err := money.ParseDecimal(total)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse order total")
+	}
+	o.Total = money.New(amount, money.Currency(currency))
+	o.PaymentID = paymentID.String
+	return nil
+}
+
+// loadItems дочитывает позиции заказов одним запросом.
+func loadItems(q querier, orders []*Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+	byID := make(map[uint]*Order, len(orders))
+	ids := make([]int64, 0, len(orders))
+	for _, o := range orders {
+		o.Items = []*Item{}
+		byID[o.ID] = o
+		ids = append(ids, int64(o.ID))
+	}
+
+	query := `SELECT ` + itemColumns + ` FROM order_items WHERE order_id = ANY($1) ORDER BY order_id, post_id`
+	rows, err := q.Query(query, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			item    Item
+			orderID uint
+			price   string
+			offerID sql.NullInt64
+		)
+		if err := rows.Scan(&orderID, &item.PostID, &price, &item.Quantity, &offerID); err != nil {
+			return err
+		}
+		o := byID[orderID]
+		amount, err := money.ParseDecimal(price)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse item price")
+		}
+		item.Price = money.New(amount, o.Total.Currency())
+		item.OfferID = uint(offerID.Int64)
+		o.Items = append(o.Items, &item)
+	}
+	return rows.Err()
+}
+
+func nullID(id uint) any {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// takeStock определяет цену позиции и списывает товар, пока строка поста заблокирована.
+// Покупатель принятого предложения получает зарезервированную под него единицу по согласованной
+// цене, победитель аукциона — лот по последней ставке; остальные покупают активное объявление
+// с фиксированной ценой по цене объявления, если на остатке хватает единиц.
+// Заполняет item.Price и item.OfferID и возвращает продавца.
+func takeStock(tx *sql.Tx, buyer string, item *Item) (string, error) {
+	var (
+		owner, price, currency, status, listingType string
+		quantity, sold                              int
+		hidden                                      bool
+	)
+	query := `
+		SELECT owner, price, currency, status, listing_type, quantity, quantity_sold, hidden
+		FROM posts WHERE id = $1 FOR UPDATE
+	`
+	err := tx.QueryRow(query, item.PostID).Scan(&owner, &price, &currency, &status, &listingType, &quantity, &sold, &hidden)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", post.ErrPostNotFound
+		}
+		return "", errors.Errorf("failed to lock post: %v", err)
+	}
+	if owner == buyer {
+		return "", ErrOwnPost
+	}
+	// Скрытый модерацией пост не продаётся, даже по принятому предложению
+	if hidden {
+		return "", ErrPostNotAvailable
+	}
+
+	// Единица под принятое предложение уже учтена в quantity_sold; одно предложение — одна позиция
+	var (
+		offerID                uint
+		agreed, agreedCurrency string
+		fromAuction            bool
+	)
+	query = `
+		SELECT id, COALESCE(counter_price, price), currency, FALSE FROM offers
+		WHERE post_id = $1 AND buyer = $2 AND status = 'accepted'
+			AND NOT EXISTS (SELECT 1 FROM order_items i WHERE i.offer_id = offers.id)
+		UNION ALL
+		SELECT 0, current_price, currency, TRUE FROM auctions
+		WHERE post_id = $1 AND winner = $2 AND $3 = 'reserved'
+		LIMIT 1
+	`
+	err = tx.QueryRow(query, item.PostID, buyer, status).Scan(&offerID, &agreed, &agreedCurrency, &fromAuction)
+	switch {
+	case err == nil:
+		if item.Quantity != 1 {
+			return "", ErrReservedQuantity
+		}
+		if err := setPrice(item, agreed, agreedCurrency); err != nil {
+			return "", err
+		}
+		// Пост распродан, когда выкуплена последняя зарезервированная единица
+		query = `
+			UPDATE posts SET status = 'sold'
+			WHERE id = $1 AND status = 'reserved' AND NOT EXISTS (
+				SELECT 1 FROM offers
+				WHERE offers.post_id = posts.id AND offers.status = 'accepted' AND offers.id <> $2
+					AND NOT EXISTS (SELECT 1 FROM order_items i WHERE i.offer_id = offers.id)
+			)
+		`
+		if _, err := tx.Exec(query, item.PostID, offerID); err != nil {
+			return "", errors.Errorf("failed to mark post as sold: %v", err)
+		}
+		if !fromAuction {
+			item.OfferID = offerID
+		}
+		return owner, nil
+	case err != sql.ErrNoRows:
+		return "", errors.Errorf("failed to find agreed price: %v", err)
+	}
+
+	if status != post.StatusActive || listingType != post.ListingFixed {
+		return "", ErrPostNotAvailable
+	}
+	if quantity-sold < item.Quantity {
+		return "", ErrInsufficientQuantity
+	}
+	if err := setPrice(item, price, currency); err != nil {
+		return "", err
+	}
+
+	// Условие на остаток дублирует проверку выше: CHECK в таблице не даст продать лишнее,
+	// даже если блокировку строки когда-нибудь уберут
+	query = `
+		UPDATE posts
+		SET quantity_sold = quantity_sold + $2,
+			status = CASE WHEN quantity_sold + $2 = quantity THEN 'sold' ELSE status END
+		WHERE id = $1 AND quantity_sold + $2 <= quantity
+	`
+	res, err := tx.Exec(query, item.PostID, item.Quantity)
+	if err != nil {
+		return "", errors.Errorf("failed to take stock: %v", err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return "", ErrInsufficientQuantity
+	}
+	return owner, nil
+}
+
+// setPrice записывает цену позиции и сверяет её с ценой, которую видел покупатель.
+func setPrice(item *Item, amount, currency string) error {
+	d, err := money.ParseDecimal(amount)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse price")
+	}
+	item.Price = money.New(d, money.Currency(currency))
+	if item.Expected != nil && !item.Expected.Equal(item.Price) {
+		return ErrPriceChanged
+	}
+	return nil
+}
+
+type orderKey struct {
+	seller   string
+	currency money.Currency
+}
+
+// Create в одной транзакции списывает товар по всем позициям и создаёт заказы — по одному на
+// продавца и валюту. Если хотя бы одну позицию купить нельзя, не создаётся ни один заказ.
+// Посты блокируются в порядке id, чтобы одновременные покупки не взаимоблокировались.
+// Непустой checkSeller проверяет продавца каждой позиции; его ошибка отменяет покупку.
+func (r *Storage) Create(buyer string, items []*Item, checkSeller func(seller string) error) ([]*Order, error) {
+	tx, err := r.repository.Begin()
+	if err != nil {
+		return nil, errors.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	sorted := slices.Clone(items)
+	slices.SortFunc(sorted, func(a, b *Item) int { return int(a.PostID) - int(b.PostID) })
+
+	var (
+		orders []*Order
+		byKey  = map[orderKey]*Order{}
+	)
+	for _, item := range sorted {
+		seller, err := takeStock(tx, buyer, item)
+		if err != nil {
+			return nil, err
+		}
+		if checkSeller != nil {
+			if err := checkSeller(seller); err != nil {
+				return nil, err
+			}
+		}
+		subtotal, err := item.Price.Times(item.Quantity)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to compute item total")
+		}
+
+		key := orderKey{seller: seller, currency: item.Price.Currency()}
+		o, ok := byKey[key]
+		if !ok {
+			o = &Order{Buyer: buyer, Seller: seller, Total: money.New(money.NewDecimal(0, 0), key.currency)}
+			byKey[key] = o
+			orders = append(orders, o)
+		}
+		if o.Total, err = o.Total.Add(subtotal); err != nil {
+			return nil, errors.Wrap(err, "failed to compute order total")
+		}
+		o.Items = append(o.Items, item)
+	}
+
+	for _, o := range orders {
+		query := `
+			INSERT INTO orders (buyer, seller, total, currency)
+			VALUES ($1, $2, $3, $4)
+			RETURNING ` + orderColumns
+		row := tx.QueryRow(query, o.Buyer, o.Seller, o.Total.Amount().String(), o.Total.Currency())
+		if err := scanOrder(row, o); err != nil {
+			r.logger.Error("Failed to create order", zap.String("buyer", buyer), zap.String("seller", o.Seller), zap.Error(err))
+			return nil, errors.Errorf("failed to create order: %v", err)
+		}
+
+		for _, item := range o.Items {
+			query := `INSERT INTO order_items (` + itemColumns + `) VALUES ($1, $2, $3, $4, $5)`
+			_, err := tx.Exec(query, o.ID, item.PostID, item.Price.Amount().String(), item.Quantity, nullID(item.OfferID))
+			if err != nil {
+				r.logger.Error("Failed to create order item", zap.Uint("order_id", o.ID), zap.Uint("post_id", item.PostID), zap.Error(err))
+				return nil, errors.Errorf("failed to create order item: %v", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Errorf("failed to commit order: %v", err)
+	}
+	return orders, nil
+}
+
+func (r *Storage) GetByID(id uint) (*Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1`
+
+	var o Order
+	if err := scanOrder(r.repository.QueryRow(query, id), &o); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrOrderNotFound
+		}
+		r.logger.Error("Failed to get order", zap.Uint("id", id), zap.Error(err))
+		return nil, errors.Errorf("failed to get order: %v", err)
+	}
+	if err := loadItems(r.repository, []*Order{&o}); err != nil {
+		r.logger.Error("Failed to get order items", zap.Uint("id", id), zap.Error(err))
+		return nil, errors.Errorf("failed to get order items: %v", err)
+	}
+	return &o, nil
+}
+
+// ListByUser возвращает заказы, где login — покупатель (role=buyer), продавец (role=seller)
+// или любой из них (пустая роль). Пустой status — все состояния.
+func (r *Storage) ListByUser(login, role, status string) ([]*Order, error) {
+	var where string
+	switch role {
+	case "buyer":
+		where = "buyer = $1"
+	case "seller":
+		where = "seller = $1"
+	default:
+		where = "(buyer = $1 OR seller = $1)"
+	}
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE ` + where +
+		` AND ($2 = '' OR status = $2) ORDER BY id DESC`
+
+	rows, err := r.repository.Query(query, login, status)
+	if err != nil {
+		r.logger.Error("Failed to list orders", zap.String("login", login), zap.Error(err))
+		return nil, errors.Errorf("failed to list orders: %v", err)
+	}
+	defer rows.Close()
+
+	orders := []*Order{}
+	for rows.Next() {
+		var o Order
+		if err := scanOrder(rows, &o); err != nil {
+			return nil, errors.Errorf("failed to scan order: %v", err)
+		}
+		orders = append(orders, &o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to list orders: %v", err)
+	}
+	if err := loadItems(r.repository, orders); err != nil {
+		r.logger.Error("Failed to list order items", zap.String("login", login), zap.Error(err))
+		return nil, errors.Errorf("failed to list order items: %v", err)
+	}
+	return orders, nil
+}
+
+// SetPayment запоминает платёж неоплаченного заказа.
+func (r *Storage) SetPayment(id uint, paymentID string) error {
+	query := `UPDATE orders SET payment_id = $2, updated_at = NOW() WHERE id = $1 AND status = 'created'`
+	if _, err := r.repository.Exec(query, id, paymentID); err != nil {
+		r.logger.Error("Failed to set order payment", zap.Uint("id", id), zap.Error(err))
+		return errors.Errorf("failed to set order payment: %v", err)
+	}
+	return nil
+}
+
+// RefundPayment записывает возврат платежа paymentID и вызывает refund до фиксации записи.
+// Если платёж уже возвращали, refund не вызывается и возвращается false. Одновременная вставка
+// того же платежа ждёт первую транзакцию, поэтому деньги не возвращаются дважды; ошибка refund
+// откатывает запись, и следующая доставка вебхука попробует снова.
+func (r *Storage) RefundPayment(orderID uint, paymentID string, amount money.Money, refund func() error) (bool, error) {
+	tx, err := r.repository.Begin()
+	if err != nil {
+		return false, errors.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO payment_refunds (payment_id, order_id, amount, currency)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (payment_id) DO NOTHING
+	`
+	res, err := tx.Exec(query, paymentID, orderID, amount.Amount().String(), amount.Currency())
+	if err != nil {
+		r.logger.Error(
+			"Failed to record payment refund",
+			zap.Uint("order_id", orderID),
+			zap.String("payment_id", paymentID),
+			zap.Error(err),
+		)
+		return false, errors.Errorf("failed to record payment refund: %v", err)
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return false, nil
+	}
+
+	if err := refund(); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, errors.Errorf("failed to commit payment refund: %v", err)
+	}
+	return true, nil
+}
+
+// Transition переводит заказ из from в to; если заказ успел измениться, возвращает ErrInvalidState.
+// Непустой paymentID сохраняется вместе с переходом. При отмене и возврате единицы товара
+// по всем позициям возвращаются на остаток в той же транзакции.
+func (r *Storage) Transition(id uint, from, to, paymentID string) (*Order, error) {
+	return r.transition(id, from, to, paymentID, nil)
+}
+
+// Refund переводит заказ из from в refunded и вызывает refund до фиксации перехода. Строка заказа
+// заблокирована до конца транзакции, поэтому при одновременных запросах refund вызывается только
+// у победившего, а остальные получают ErrInvalidState. Ошибка refund откатывает переход.
+func (r *Storage) Refund(id uint, from string, refund func(*Order) error) (*Order, error) {
+	return r.transition(id, from, StatusRefunded, "", refund)
+}
+
+func (r *Storage) transition(id uint, from, to, paymentID string, before func(*Order) error) (*Order, error) {
+	tx, err := r.repository.Begin()
+	if err != nil {
+		return nil, errors.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE orders
+		SET status = $3, payment_id = COALESCE(NULLIF($4, ''), payment_id), updated_at = NOW()
+		WHERE id = $1 AND status = $2
+		RETURNING ` + orderColumns
+
+	var o Order
+	if err := scanOrder(tx.QueryRow(query, id, from, to, paymentID), &o); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrInvalidState
+		}
+		r.logger.Error(
+			"Failed to update order",
+			zap.Uint("id", id),
+			zap.String("status", to),
+			zap.Error(err),
+		)
+		return nil, errors.Errorf("failed to update order: %v", err)
+	}
+
+	if releasesPost(to) {
+		if err := releaseStock(tx, o.ID); err != nil {
+			r.logger.Error("Failed to release posts", zap.Uint("order_id", o.ID), zap.Error(err))
+			return nil, errors.Errorf("failed to release posts: %v", err)
+		}
+	}
+
+	if err := loadItems(tx, []*Order{&o}); err != nil {
+		return nil, errors.Errorf("failed to get order items: %v", err)
+	}
+	if before != nil {
+		if err := before(&o); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Errorf("failed to commit order update: %v", err)
+	}
+	return &o, nil
+}
+
+// releaseStock возвращает единицы товара по позициям заказа на остаток. Распроданный пост снова
+// продаётся, только если единицы действительно освободились; статус reserved (единицы под принятые
+// предложения) не меняется. Лот аукциона возвращается в обычную продажу по цене объявления, а
+// закрытый аукцион удаляется, чтобы продавец мог выставить пост на аукцион заново.
+func releaseStock(tx *sql.Tx, orderID uint) error {
+	query := `
+		DELETE FROM auctions a
+		USING order_items i, posts p
+		WHERE i.order_id = $1 AND a.post_id = i.post_id AND p.id = i.post_id
+			AND p.listing_type = 'auction' AND a.status = 'closed'
+	`
+	if _, err := tx.Exec(query, orderID); err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE posts p
+		SET quantity_sold = p.quantity_sold - i.quantity,
+			listing_type = 'fixed',
+			status = CASE
+				WHEN p.listing_type = 'auction' THEN 'active'
+				WHEN p.status = 'sold' AND p.quantity_sold - i.quantity < p.quantity THEN 'active'
+				ELSE p.status
+			END
+		FROM order_items i
+		WHERE i.order_id = $1 AND p.id = i.post_id
+	`
+	_, err := tx.Exec(query, orderID)
+	return err
+}
+
+// Expire отменяет заказы, которые дольше ttl ждут оплаты, и возвращает товар на остаток.
+// Отсчёт идёт от создания заказа: повторные попытки оплаты срок не продлевают.
+func (r *Storage) Expire(ttl time.Duration) ([]*Order, error) {
+	query := `
+		SELECT id FROM orders
+		WHERE status = 'created' AND created_at <= NOW() - make_interval(secs => $1)
+		ORDER BY id`
+
+	rows, err := r.repository.Query(query, ttl.Seconds())
+	if err != nil {
+		r.logger.Error("Failed to list unpaid orders", zap.Error(err))
+		return nil, errors.Errorf("failed to list unpaid orders: %v", err)
+	}
+	var ids []uint
+	for rows.Next() {
+		var id uint
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, errors.Errorf("failed to scan order id: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, errors.Errorf("failed to list unpaid orders: %v", err)
+	}
+
+	expired := []*Order{}
+	for _, id := range ids {
+		o, err := r.transition(id, StatusCreated, StatusCancelled, "", nil)
+		if errors.Is(err, ErrInvalidState) {
+			// Заказ успели оплатить или отменить
+			continue
+		}
+		if err != nil {
+			return expired, err
+		}
+		expired = append(expired, o)
+	}
+	return expired, nil
+}
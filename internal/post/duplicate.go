@@ -0,0 +1,84 @@
+package post
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/content"
+)
+
+// Что делать с постом, похожим на недавний пост того же продавца.
+const (
+	DuplicatesOff = "off"
+	// DuplicatesMark — пост сохраняется со ссылкой на похожий в duplicate_of
+	DuplicatesMark = "mark"
+	// DuplicatesReject — пост не сохраняется, клиент получает ссылку на похожий
+	DuplicatesReject = "reject"
+)
+
+// DuplicateCheck — настройки поиска дублей среди постов продавца.
+type DuplicateCheck struct {
+	Mode string
+	// Threshold — с какого сходства текстов (0..1) пост считается дублем
+	Threshold float64
+	// Window — за какой период сравниваются посты
+	Window time.Duration
+}
+
+// DuplicateError — у продавца уже есть похожий активный пост.
+type DuplicateError struct {
+	ExistingID uint
+}
+
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("you already have a similar active post #%d", e.ExistingID)
+}
+
+// findDuplicate ищет среди recent пост с той же картинкой или с текстом, похожим не меньше
+// чем на threshold; из нескольких кандидатов выбирается самый похожий.
+func findDuplicate(post *Post, recent []*Post, threshold float64) *Post {
+	var (
+		best      *Post
+		bestScore float64
+	)
+	text := post.Title + " " + post.Description
+	for _, p := range recent {
+		if p.ID == post.ID {
+			continue
+		}
+		score := 1.0
+		if p.ImageURL != post.ImageURL {
+			score = content.Similarity(text, p.Title+" "+p.Description)
+		}
+		if score >= threshold && score > bestScore {
+			best, bestScore = p, score
+		}
+	}
+	return best
+}
+
+// dedupe сравнивает новый пост с недавними активными постами владельца. Если их не удалось
+// получить, пост пропускается: публикация важнее защиты от дублей.
+func (s *Service) dedupe(post *Post) error {
+	post.DuplicateOf = nil
+	if s.duplicates.Mode == "" || s.duplicates.Mode == DuplicatesOff {
+		return nil
+	}
+	recent, err := s.repository.RecentByOwner(post.Owner, time.Now().Add(-s.duplicates.Window))
+	if err != nil {
+		s.logger.Warn("Failed to check post for duplicates", zap.String("owner", post.Owner), zap.Error(err))
+		return nil
+	}
+
+	existing := findDuplicate(post, recent, s.duplicates.Threshold)
+	if existing == nil {
+		return nil
+	}
+	if s.duplicates.Mode == DuplicatesReject {
+		return &DuplicateError{ExistingID: existing.ID}
+	}
+	post.DuplicateOf = &existing.ID
+	return nil
+}
@@ -0,0 +1,107 @@
+package post
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+func Test_CreatePost_Duplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newPost := func() *Post {
+		return &Post{
+			Title:       "iPhone 13 128 ГБ",
+			Description: "Продаю iPhone 13 128 ГБ, отличное состояние, полный комплект",
+			Price:       money.MustParse("50000", "RUB"),
+			ImageURL:    "https://example.com/iphone.jpg",
+			Owner:       "alice",
+		}
+	}
+	reposted := &Post{
+		ID:          3,
+		Title:       "iPhone 13 128гб",
+		Description: "Продаю iPhone 13 128 ГБ, отличное состояние и полный комплект!",
+		ImageURL:    "https://example.com/other.jpg",
+	}
+	samePhoto := &Post{ID: 4, Title: "Телефон", Description: "Срочно", ImageURL: "https://example.com/iphone.jpg"}
+	unrelated := &Post{ID: 5, Title: "Диван", Description: "Угловой, раскладной", ImageURL: "https://example.com/sofa.jpg"}
+
+	testCases := []struct {
+		name string
+
+		mode       string
+		setupMocks func(storage *Mockstorage)
+
+		expectedDuplicateOf *uint
+		expectedErr         error
+	}{
+		{
+			name: "1. Similar_Text_Rejected",
+			mode: DuplicatesReject,
+			setupMocks: func(storage *Mockstorage) {
+				storage.EXPECT().RecentByOwner("alice", gomock.Any()).Return([]*Post{unrelated, reposted}, nil)
+			},
+			expectedErr: &DuplicateError{ExistingID: 3},
+		},
+		{
+			name: "2. Same_Image_Marked",
+			mode: DuplicatesMark,
+			setupMocks: func(storage *Mockstorage) {
+				storage.EXPECT().RecentByOwner("alice", gomock.Any()).Return([]*Post{unrelated, samePhoto}, nil)
+				storage.EXPECT().Create(gomock.Any()).Return(nil)
+			},
+			expectedDuplicateOf: &samePhoto.ID,
+		},
+		{
+			name: "3. No_Duplicates",
+			mode: DuplicatesReject,
+			setupMocks: func(storage *Mockstorage) {
+				storage.EXPECT().RecentByOwner("alice", gomock.Any()).Return([]*Post{unrelated}, nil)
+				storage.EXPECT().Create(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "4. Lookup_Failed_Post_Created",
+			mode: DuplicatesReject,
+			setupMocks: func(storage *Mockstorage) {
+				storage.EXPECT().RecentByOwner("alice", gomock.Any()).Return(nil, errors.New("db is down"))
+				storage.EXPECT().Create(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "5. Off",
+			mode: DuplicatesOff,
+			setupMocks: func(storage *Mockstorage) {
+				storage.EXPECT().Create(gomock.Any()).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storage := NewMockstorage(ctrl)
+			tc.setupMocks(storage)
+			service := NewService(storage, zap.NewNop(), WithDuplicateCheck(DuplicateCheck{
+				Mode:      tc.mode,
+				Threshold: 0.6,
+				Window:    30 * 24 * time.Hour,
+			}))
+
+			p, err := service.CreatePost(newPost())
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedDuplicateOf, p.DuplicateOf)
+		})
+	}
+}
@@ -5,13 +5,20 @@ package post
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 
+	"github.com/TemirB/rest-api-marketplace/internal/content"
 	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+	"github.com/TemirB/rest-api-marketplace/internal/quota"
+	"github.com/TemirB/rest-api-marketplace/pkg/geo"
 	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
 	"go.uber.org/zap"
 )
 
@@ -22,18 +29,37 @@ type service interface {
 	DeletePost(id uint64) error
 
 	GetPostByID(id uint) (*Post, error)
+	GetPostFor(id uint, viewer string) (*Post, error)
+	GetPriceHistory(id uint, viewer string) ([]*PriceChange, error)
+	GetSimilar(id uint, viewer string) ([]*Post, error)
 }
 
 type Handler struct {
 	service service
+	onView  []func(*Post, *http.Request)
 	logger  *zap.Logger
 }
 
-func NewHandler(service service, logger *zap.Logger) *Handler {
-	return &Handler{
+// HandlerOption настраивает необязательные зависимости обработчика.
+type HandlerOption func(*Handler)
+
+// WithOnDetailView добавляет обработчик просмотра страницы поста не владельцем. Запрос нужен,
+// чтобы различать анонимных зрителей. Обработчик не должен блокировать.
+func WithOnDetailView(fn func(p *Post, r *http.Request)) HandlerOption {
+	return func(h *Handler) {
+		h.onView = append(h.onView, fn)
+	}
+}
+
+func NewHandler(service service, logger *zap.Logger, opts ...HandlerOption) *Handler {
+	h := &Handler{
 		service: service,
 		logger:  logger,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 func (h *Handler) CreatePost(w http.ResponseWriter, r *http.Request) {
@@ -52,15 +78,17 @@ func (h *Handler) CreatePost(w http.ResponseWriter, r *http.Request) {
 			"Internal Server Error: invalid user context",
 			zap.String("author", "jwt.GetLogin(r) == nil"),
 		)
-		http.Error(w, "Internal Server Error: invalid user context", http.StatusForbidden)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
 	var req struct {
-		Title       string  `json:"title"`
-		Description string  `json:"description"`
-		Price       float64 `json:"price"`
-		ImageURL    string  `json:"image_url"`
+		Title       string      `json:"title"`
+		Description string      `json:"description"`
+		Price       money.Money `json:"price"`
+		ImageURL    string      `json:"image_url"`
+		Location    *Location   `json:"location"`
+		Quantity    int         `json:"quantity"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -73,14 +101,37 @@ func (h *Handler) CreatePost(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	if !req.Price.HasCurrency() {
+		req.Price = req.Price.WithCurrency(money.DefaultCurrency)
+	}
 
 	newPost, err := h.service.CreatePost(&Post{
 		Title:       req.Title,
 		Description: req.Description,
 		Price:       req.Price,
 		ImageURL:    req.ImageURL,
+		Location:    req.Location,
+		Quantity:    req.Quantity,
 		Owner:       loginVal,
 	})
+	if writeContentError(w, err) || quota.WriteExceeded(w, err) {
+		return
+	}
+	var duplicateErr *DuplicateError
+	if errors.As(err, &duplicateErr) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(struct {
+			Error       string `json:"error"`
+			DuplicateOf uint   `json:"duplicate_of"`
+			URL         string `json:"url"`
+		}{
+			Error:       err.Error(),
+			DuplicateOf: duplicateErr.ExistingID,
+			URL:         fmt.Sprintf("/posts/%d", duplicateErr.ExistingID),
+		})
+		return
+	}
 	if err != nil {
 		h.logger.Error(
 			"Failed to create post",
@@ -101,6 +152,12 @@ func (h *Handler) CreatePost(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(newPost)
 }
 
+// ParseFeedQuery разбирает параметры ленты так же, как /posts/feed.
+// viewer — логин текущего пользователя, для которого вычисляются is_owner и is_favorite.
+func ParseFeedQuery(q url.Values, viewer string) (*SortParams, *FilterParams) {
+	return setSort(q), setFilter(q, viewer)
+}
+
 func setFilter(q url.Values, owner string) *FilterParams {
 	var (
 		minPrice float64
@@ -119,24 +176,59 @@ func setFilter(q url.Values, owner string) *FilterParams {
 		minPrice, maxPrice = maxPrice, minPrice
 	}
 
+	// Неизвестная валюта игнорируется так же, как некорректные границы цены
+	currency, _ := money.ParseCurrency(q.Get("currency"))
+
 	return &FilterParams{
 		MinPrice: minPrice,
 		MaxPrice: maxPrice,
 		Owner:    owner,
+		Currency: currency,
+		Near:     setNear(q),
+	}
+}
+
+// setNear разбирает lat, lon и radius_km. Без корректных координат гео-фильтр не применяется,
+// без радиуса — только вычисляется расстояние.
+func setNear(q url.Values) *GeoFilter {
+	lat, err := strconv.ParseFloat(q.Get("lat"), 64)
+	if err != nil {
+		return nil
+	}
+	lon, err := strconv.ParseFloat(q.Get("lon"), 64)
+	if err != nil {
+		return nil
+	}
+	if (geo.Point{Lat: lat, Lon: lon}).Validate() != nil {
+		return nil
+	}
+
+	radius, err := strconv.ParseFloat(q.Get("radius_km"), 64)
+	if err != nil || radius < 0 {
+		radius = 0
+	}
+	return &GeoFilter{
+		Lat:      lat,
+		Lon:      lon,
+		RadiusKm: radius,
 	}
 }
 
 func setSort(q url.Values) *SortParams {
-	sortBy := q.Get("sort_by")
-	if strings.ToLower(sortBy) == "price" {
-		sortBy = "price"
-	} else {
+	sortBy := strings.ToLower(q.Get("sort_by"))
+	switch sortBy {
+	case "price", "distance":
+	default:
 		sortBy = "created_at"
 	}
-	order := q.Get("order")
-	if strings.ToLower(order) == "asc" {
+	order := strings.ToLower(q.Get("order"))
+	switch {
+	case order == "asc":
 		order = "ASC"
-	} else {
+	case order == "" && sortBy == "distance":
+		// Ближайшие объявления по умолчанию идут первыми
+		order = "ASC"
+	default:
 		order = "DESC"
 	}
 	return &SortParams{
@@ -155,12 +247,31 @@ func (h *Handler) GetPosts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	q := r.URL.Query()
 	currentUser, _ := jwt.GetLogin(r)
-	filter := setFilter(q, currentUser)
-	sort := setSort(q)
+	sort, filter := ParseFeedQuery(r.URL.Query(), currentUser)
+
+	switch r.URL.Query().Get("scope") {
+	case "", "all":
+	case "following":
+		if currentUser == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		filter.FollowedBy = currentUser
+	default:
+		http.Error(w, "Bad Request: unknown scope", http.StatusBadRequest)
+		return
+	}
 
 	posts, err := h.service.GetPosts(sort, filter)
+	if errors.Is(err, ErrNoRateForCurrency) {
+		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, ErrRatesUnavailable) {
+		http.Error(w, "Service Unavailable: "+err.Error(), http.StatusServiceUnavailable)
+		return
+	}
 	if err != nil {
 		h.logger.Error(
 			"Failed to get posts",
@@ -245,6 +356,13 @@ func (h *Handler) UpdatePost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.UpdatePost(post); err != nil {
+		if writeContentError(w, err) {
+			return
+		}
+		if errors.Is(err, ErrQuantityBelowSold) {
+			http.Error(w, "Conflict: "+err.Error(), http.StatusConflict)
+			return
+		}
 		h.logger.Error("Failed to update post", zap.Error(err))
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -253,7 +371,129 @@ func (h *Handler) UpdatePost(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *Handler) PatchPost(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		h.logger.Info(
+			"Internal Server Error: invalid user context",
+			zap.String("author", "jwt.GetLogin(r) == nil"),
+		)
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) != 3 || parts[1] != "posts" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	id64, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		http.Error(w, "Bad Request: invalid id", http.StatusBadRequest)
+		return
+	}
+	id := uint(id64)
+
+	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (contentType != ContentTypeMergePatch && contentType != ContentTypeJSONPatch) {
+		w.Header().Set("Accept-Patch", ContentTypeMergePatch+", "+ContentTypeJSONPatch)
+		http.Error(w, "Unsupported Media Type", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	patch, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	post, err := h.service.GetPostByID(id)
+	if err != nil {
+		if errors.Is(err, ErrPostNotFound) {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error(
+			"Failed to get post",
+			zap.Uint("id", id),
+			zap.Error(err),
+		)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if post.Owner != login {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	if err := applyPatch(post, contentType, patch); err != nil {
+		h.logger.Info(
+			"Failed to apply patch",
+			zap.Uint("id", id),
+			zap.String("content_type", contentType),
+			zap.Error(err),
+		)
+		var patchErr *PatchError
+		switch {
+		case errors.Is(err, ErrTestFailed):
+			http.Error(w, "Conflict: "+err.Error(), http.StatusConflict)
+		case errors.As(err, &patchErr):
+			http.Error(w, "Unprocessable Entity: "+err.Error(), http.StatusUnprocessableEntity)
+		default:
+			http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	if err := validatePost(post); err != nil {
+		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.UpdatePost(post); err != nil {
+		if writeContentError(w, err) {
+			return
+		}
+		if errors.Is(err, ErrQuantityBelowSold) {
+			http.Error(w, "Conflict: "+err.Error(), http.StatusConflict)
+			return
+		}
+		h.logger.Error("Failed to update post", zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	post.IsOwner = true
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(post)
+}
+
+// writeContentError отвечает 422 с причинами, если пост отклонён фильтром контента.
+func writeContentError(w http.ResponseWriter, err error) bool {
+	var contentErr *ContentError
+	if !errors.As(err, &contentErr) {
+		return false
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(struct {
+		Error   string           `json:"error"`
+		Reasons []content.Reason `json:"reasons"`
+	}{
+		Error:   err.Error(),
+		Reasons: contentErr.Reasons,
+	})
+	return true
+}
+
 func mergePostUpdates(post *Post, updatePostRequest *UpdatePostRequest) {
+	currency := post.Price.Currency()
 	if updatePostRequest.Title != nil {
 		post.Title = *updatePostRequest.Title
 	}
@@ -262,10 +502,19 @@ func mergePostUpdates(post *Post, updatePostRequest *UpdatePostRequest) {
 	}
 	if updatePostRequest.Price != nil {
 		post.Price = *updatePostRequest.Price
+		if !post.Price.HasCurrency() {
+			post.Price = post.Price.WithCurrency(currency)
+		}
 	}
 	if updatePostRequest.ImageURL != nil {
 		post.ImageURL = *updatePostRequest.ImageURL
 	}
+	if updatePostRequest.Location != nil {
+		post.Location = updatePostRequest.Location
+	}
+	if updatePostRequest.Quantity != nil {
+		post.Quantity = *updatePostRequest.Quantity
+	}
 }
 
 func (h *Handler) DeletePost(w http.ResponseWriter, r *http.Request) {
@@ -302,6 +551,10 @@ func (h *Handler) DeletePost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.DeletePost(id64); err != nil {
+		if errors.Is(err, ErrPostHasOrders) {
+			http.Error(w, "Conflict: "+err.Error(), http.StatusConflict)
+			return
+		}
 		h.logger.Error("Failed to delete post", zap.Error(err))
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
@@ -329,7 +582,8 @@ func (h *Handler) GetPostByID(w http.ResponseWriter, r *http.Request) {
 	}
 
 	id := uint(id64)
-	post, err := h.service.GetPostByID(id)
+	login, _ := jwt.GetLogin(r)
+	post, err := h.service.GetPostFor(id, login)
 	if err != nil {
 		if errors.Is(err, ErrPostNotFound) {
 			http.Error(w, "Not Found", http.StatusNotFound)
@@ -343,9 +597,91 @@ func (h *Handler) GetPostByID(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
-	login, _ := jwt.GetLogin(r)
-	post.IsOwner = (login != "" && login == post.Owner)
+
+	if !post.IsOwner {
+		for _, fn := range h.onView {
+			fn(post, r)
+		}
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(post)
 }
+
+// GetPriceHistory — GET /posts/{id}/price-history.
+func (h *Handler) GetPriceHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) != 4 || parts[1] != "posts" || parts[3] != "price-history" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	id64, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		http.Error(w, "Bad Request: invalid id", http.StatusBadRequest)
+		return
+	}
+
+	login, _ := jwt.GetLogin(r)
+	history, err := h.service.GetPriceHistory(uint(id64), login)
+	if err != nil {
+		if errors.Is(err, ErrPostNotFound) {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error(
+			"Failed to get price history",
+			zap.Uint64("id", id64),
+			zap.Error(err),
+		)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
+
+// GetSimilar — GET /posts/{id}/similar.
+func (h *Handler) GetSimilar(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) != 4 || parts[1] != "posts" || parts[3] != "similar" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	id64, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		http.Error(w, "Bad Request: invalid id", http.StatusBadRequest)
+		return
+	}
+
+	login, _ := jwt.GetLogin(r)
+	posts, err := h.service.GetSimilar(uint(id64), login)
+	if err != nil {
+		if errors.Is(err, ErrPostNotFound) {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error(
+			"Failed to get similar posts",
+			zap.Uint64("id", id64),
+			zap.Error(err),
+		)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(posts)
+}
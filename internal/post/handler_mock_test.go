@@ -77,6 +77,21 @@ func (mr *MockserviceMockRecorder) GetPostByID(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostByID", reflect.TypeOf((*Mockservice)(nil).GetPostByID), id)
 }
 
+// GetPostFor mocks base method.
+func (m *Mockservice) GetPostFor(id uint, viewer string) (*Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostFor", id, viewer)
+	ret0, _ := ret[0].(*Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostFor indicates an expected call of GetPostFor.
+func (mr *MockserviceMockRecorder) GetPostFor(id, viewer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostFor", reflect.TypeOf((*Mockservice)(nil).GetPostFor), id, viewer)
+}
+
 // GetPosts mocks base method.
 func (m *Mockservice) GetPosts(sort *SortParams, filter *FilterParams) ([]*Post, error) {
 	m.ctrl.T.Helper()
@@ -92,6 +107,36 @@ func (mr *MockserviceMockRecorder) GetPosts(sort, filter interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPosts", reflect.TypeOf((*Mockservice)(nil).GetPosts), sort, filter)
 }
 
+// GetPriceHistory mocks base method.
+func (m *Mockservice) GetPriceHistory(id uint, viewer string) ([]*PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceHistory", id, viewer)
+	ret0, _ := ret[0].([]*PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceHistory indicates an expected call of GetPriceHistory.
+func (mr *MockserviceMockRecorder) GetPriceHistory(id, viewer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceHistory", reflect.TypeOf((*Mockservice)(nil).GetPriceHistory), id, viewer)
+}
+
+// GetSimilar mocks base method.
+func (m *Mockservice) GetSimilar(id uint, viewer string) ([]*Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSimilar", id, viewer)
+	ret0, _ := ret[0].([]*Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSimilar indicates an expected call of GetSimilar.
+func (mr *MockserviceMockRecorder) GetSimilar(id, viewer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSimilar", reflect.TypeOf((*Mockservice)(nil).GetSimilar), id, viewer)
+}
+
 // UpdatePost mocks base method.
 func (m *Mockservice) UpdatePost(post *Post) error {
 	m.ctrl.T.Helper()
@@ -9,12 +9,17 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 
+	"github.com/TemirB/rest-api-marketplace/internal/content"
 	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+	"github.com/TemirB/rest-api-marketplace/internal/quota"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
 )
 
 func Test_setFilter(t *testing.T) {
@@ -108,7 +113,35 @@ func Test_setFilter(t *testing.T) {
 			},
 		},
 		{
-			name:  "7. Owner_Filter_Param",
+			name: "7. Currency_Param",
+			q: url.Values{
+				"min_price": {"10"},
+				"currency":  {"usd"},
+			},
+			owner: "testuser",
+
+			expected: FilterParams{
+				MinPrice: 10,
+				MaxPrice: -1,
+				Owner:    "testuser",
+				Currency: money.USD,
+			},
+		},
+		{
+			name: "8. Unknown_Currency_Is_Ignored",
+			q: url.Values{
+				"currency": {"XYZ"},
+			},
+			owner: "testuser",
+
+			expected: FilterParams{
+				MinPrice: 0,
+				MaxPrice: -1,
+				Owner:    "testuser",
+			},
+		},
+		{
+			name:  "9. Owner_Filter_Param",
 			q:     url.Values{"owner": []string{"bob"}},
 			owner: "alice",
 
@@ -123,7 +156,7 @@ func Test_setFilter(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			actual := setFilter(tc.q, tc.owner)
-			if actual.MinPrice != tc.expected.MinPrice || actual.MaxPrice != tc.expected.MaxPrice || actual.Owner != tc.expected.Owner {
+			if actual.MinPrice != tc.expected.MinPrice || actual.MaxPrice != tc.expected.MaxPrice || actual.Owner != tc.expected.Owner || actual.Currency != tc.expected.Currency {
 				t.Errorf("Expected %+v, got %+v", tc.expected, actual)
 			}
 		})
@@ -206,7 +239,18 @@ func Test_setSort(t *testing.T) {
 			},
 		},
 		{
-			name: "7. Both_Sort_And_Order_Params_Are_Invalid",
+			name: "7. Distance_Defaults_To_Nearest_First",
+			q: url.Values{
+				"sort_by": {"distance"},
+			},
+
+			expected: SortParams{
+				Field:     "distance",
+				Direction: "ASC",
+			},
+		},
+		{
+			name: "8. Both_Sort_And_Order_Params_Are_Invalid",
 			q: url.Values{
 				"sort_by": {"invalid_sort_param"},
 				"order":   {"invalid_order_param"},
@@ -229,6 +273,63 @@ func Test_setSort(t *testing.T) {
 	}
 }
 
+func Test_setNear(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		q url.Values
+
+		expected *GeoFilter
+	}{
+		{
+			name:     "1. No_Coordinates",
+			q:        url.Values{},
+			expected: nil,
+		},
+		{
+			name: "2. Coordinates_And_Radius",
+			q: url.Values{
+				"lat":       {"55.75"},
+				"lon":       {"37.61"},
+				"radius_km": {"5"},
+			},
+			expected: &GeoFilter{Lat: 55.75, Lon: 37.61, RadiusKm: 5},
+		},
+		{
+			name: "3. Coordinates_Without_Radius",
+			q: url.Values{
+				"lat": {"55.75"},
+				"lon": {"37.61"},
+			},
+			expected: &GeoFilter{Lat: 55.75, Lon: 37.61},
+		},
+		{
+			name: "4. Latitude_Out_Of_Range",
+			q: url.Values{
+				"lat":       {"95"},
+				"lon":       {"37.61"},
+				"radius_km": {"5"},
+			},
+			expected: nil,
+		},
+		{
+			name: "5. Negative_Radius",
+			q: url.Values{
+				"lat":       {"55.75"},
+				"lon":       {"37.61"},
+				"radius_km": {"-5"},
+			},
+			expected: &GeoFilter{Lat: 55.75, Lon: 37.61},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, setNear(tc.q))
+		})
+	}
+}
+
 func TestHandler_GetPosts_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -236,8 +337,8 @@ func TestHandler_GetPosts_Success(t *testing.T) {
 	handler := NewHandler(mockService, zap.NewNop())
 
 	posts := []*Post{
-		{ID: 1, Title: "First", Price: 50, Owner: "alice", IsOwner: true},
-		{ID: 2, Title: "Second", Price: 150, Owner: "bob", IsOwner: false},
+		{ID: 1, Title: "First", Price: money.MustParse("50", "RUB"), Owner: "alice", IsOwner: true},
+		{ID: 2, Title: "Second", Price: money.MustParse("150", "RUB"), Owner: "bob", IsOwner: false},
 	}
 	mockService.EXPECT().GetPosts(gomock.Any(), gomock.Any()).Return(posts, nil)
 
@@ -293,14 +394,45 @@ func TestHandler_GetPosts_ServiceErr(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, rr.Code)
 }
 
+func TestHandler_GetPosts_CurrencyErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		serviceErr error
+
+		expectedCode int
+	}{
+		{name: "1. No_Rate_For_Currency", serviceErr: ErrNoRateForCurrency, expectedCode: http.StatusBadRequest},
+		{name: "2. Rates_Unavailable", serviceErr: ErrRatesUnavailable, expectedCode: http.StatusServiceUnavailable},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			handler := NewHandler(mockService, zap.NewNop())
+			mockService.EXPECT().GetPosts(gomock.Any(), gomock.Any()).Return(nil, tc.serviceErr)
+
+			req := httptest.NewRequest(http.MethodGet, "/posts/feed?currency=USD", nil)
+			rr := httptest.NewRecorder()
+
+			handler.GetPosts(rr, req)
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
 func TestHandler_GetPosts_NoAuth(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockService := NewMockservice(ctrl)
 	handler := NewHandler(mockService, zap.NewNop())
 	posts := []*Post{
-		{ID: 1, Title: "First", Owner: "alice", Price: 50, IsOwner: false},
-		{ID: 2, Title: "Second", Owner: "bob", Price: 150, IsOwner: false},
+		{ID: 1, Title: "First", Owner: "alice", Price: money.MustParse("50", "RUB"), IsOwner: false},
+		{ID: 2, Title: "Second", Owner: "bob", Price: money.MustParse("150", "RUB"), IsOwner: false},
 	}
 	mockService.EXPECT().GetPosts(gomock.Any(), gomock.Any()).Return(posts, nil)
 	req := httptest.NewRequest(http.MethodGet, "/posts/feed", nil)
@@ -321,8 +453,8 @@ func TestHandler_GetPosts_FilterByOtherOwner(t *testing.T) {
 	mockService := NewMockservice(ctrl)
 	handler := NewHandler(mockService, zap.NewNop())
 	posts := []*Post{
-		{ID: 1, Title: "Bob1", Owner: "bob", Price: 10, IsOwner: false},
-		{ID: 2, Title: "Bob2", Owner: "bob", Price: 20, IsOwner: false},
+		{ID: 1, Title: "Bob1", Owner: "bob", Price: money.MustParse("10", "RUB"), IsOwner: false},
+		{ID: 2, Title: "Bob2", Owner: "bob", Price: money.MustParse("20", "RUB"), IsOwner: false},
 	}
 	mockService.EXPECT().
 		GetPosts(gomock.Any(), gomock.Any()).
@@ -341,6 +473,78 @@ func TestHandler_GetPosts_FilterByOtherOwner(t *testing.T) {
 	}
 }
 
+func TestHandler_GetPosts_Scope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		url        string
+		user       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name: "1. Following",
+			url:  "/posts/feed?scope=following&sort_by=price&order=asc",
+			user: "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetPosts(gomock.Any(), gomock.Any()).DoAndReturn(func(sort *SortParams, filter *FilterParams) ([]*Post, error) {
+					assert.Equal(t, "alice", filter.FollowedBy)
+					assert.Equal(t, "price", sort.Field)
+					return []*Post{}, nil
+				})
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "2. All",
+			url:  "/posts/feed?scope=all",
+			user: "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetPosts(gomock.Any(), gomock.Any()).DoAndReturn(func(sort *SortParams, filter *FilterParams) ([]*Post, error) {
+					assert.Empty(t, filter.FollowedBy)
+					return []*Post{}, nil
+				})
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "3. Following_Unauthorized",
+			url:          "/posts/feed?scope=following",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "4. Unknown_Scope",
+			url:          "/posts/feed?scope=friends",
+			user:         "alice",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+
+			req := httptest.NewRequest(http.MethodGet, tc.url, nil)
+			if tc.user != "" {
+				req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, tc.user))
+			}
+			rr := httptest.NewRecorder()
+
+			handler.GetPosts(rr, req)
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
 func TestHandler_CreatePost(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -362,7 +566,7 @@ func TestHandler_CreatePost(t *testing.T) {
 			url:    "/posts",
 			body:   []byte(`{"title": "New Post", "price": 100, "owner": "alice", "description": "This is a new post", "image_url": "new_post.jpg"}`),
 			setupMocks: func(mockService *Mockservice) {
-				post := &Post{Title: "New Post", Price: 100, Owner: "alice", Description: "This is a new post", ImageURL: "new_post.jpg"}
+				post := &Post{Title: "New Post", Price: money.MustParse("100", "RUB"), Owner: "alice", Description: "This is a new post", ImageURL: "new_post.jpg"}
 				mockService.EXPECT().CreatePost(post).Return(post, nil)
 			},
 
@@ -413,6 +617,42 @@ func TestHandler_CreatePost(t *testing.T) {
 
 			expectedCode: http.StatusBadRequest,
 		},
+		{
+			name:   "6. Content_Rejected",
+			method: http.MethodPost,
+			url:    "/posts",
+			body:   []byte(`{"title": "Bike", "price": 100, "description": "www.example.com", "image_url": "bike.jpg"}`),
+			setupMocks: func(mockService *Mockservice) {
+				mockService.EXPECT().CreatePost(gomock.Any()).Return(nil, &ContentError{
+					Reasons: []content.Reason{{Check: "contacts", Code: "url", Action: content.ActionReject}},
+				})
+			},
+
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "7. Duplicate_Rejected",
+			method: http.MethodPost,
+			url:    "/posts",
+			body:   []byte(`{"title": "Bike", "price": 100, "description": "City bike", "image_url": "bike.jpg"}`),
+			setupMocks: func(mockService *Mockservice) {
+				mockService.EXPECT().CreatePost(gomock.Any()).Return(nil, &DuplicateError{ExistingID: 3})
+			},
+
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:   "8. Quota_Exceeded",
+			method: http.MethodPost,
+			url:    "/posts",
+			body:   []byte(`{"title": "Bike", "price": 100, "description": "City bike", "image_url": "bike.jpg"}`),
+			setupMocks: func(mockService *Mockservice) {
+				mockService.EXPECT().CreatePost(gomock.Any()).
+					Return(nil, &quota.ExceededError{Limit: quota.LimitDaily, RetryAfter: time.Hour})
+			},
+
+			expectedCode: http.StatusTooManyRequests,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -434,3 +674,407 @@ func TestHandler_CreatePost(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_PatchPost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	existing := func() *Post {
+		return &Post{
+			ID:          1,
+			Title:       "Old title",
+			Description: "Old description",
+			Price:       money.MustParse("100", "RUB"),
+			ImageURL:    "https://example.com/old.png",
+			Owner:       "alice",
+			Quantity:    1,
+		}
+	}
+
+	testCases := []struct {
+		name string
+
+		contentType string
+		body        string
+		login       string
+		setupMocks  func(mockService *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name:        "1. Valid_Merge_Patch",
+			contentType: ContentTypeMergePatch,
+			body:        `{"price":150}`,
+			login:       "alice",
+			setupMocks: func(mockService *Mockservice) {
+				mockService.EXPECT().GetPostByID(uint(1)).Return(existing(), nil)
+				updated := existing()
+				updated.Price = money.MustParse("150", "RUB")
+				mockService.EXPECT().UpdatePost(updated).Return(nil)
+			},
+
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "2. Valid_JSON_Patch",
+			contentType: ContentTypeJSONPatch + "; charset=utf-8",
+			body:        `[{"op":"replace","path":"/title","value":"New title"}]`,
+			login:       "alice",
+			setupMocks: func(mockService *Mockservice) {
+				mockService.EXPECT().GetPostByID(uint(1)).Return(existing(), nil)
+				mockService.EXPECT().UpdatePost(gomock.Any()).Return(nil)
+			},
+
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "3. Unsupported_Content_Type",
+			contentType:  "application/json",
+			body:         `{"price":150}`,
+			login:        "alice",
+			setupMocks:   func(mockService *Mockservice) {},
+			expectedCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "4. Not_Owner",
+			contentType: ContentTypeMergePatch,
+			body:        `{"price":150}`,
+			login:       "bob",
+			setupMocks: func(mockService *Mockservice) {
+				mockService.EXPECT().GetPostByID(uint(1)).Return(existing(), nil)
+			},
+
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:        "5. Failed_Test_Operation",
+			contentType: ContentTypeJSONPatch,
+			body:        `[{"op":"test","path":"/price","value":1}]`,
+			login:       "alice",
+			setupMocks: func(mockService *Mockservice) {
+				mockService.EXPECT().GetPostByID(uint(1)).Return(existing(), nil)
+			},
+
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:        "6. Unprocessable_Operation",
+			contentType: ContentTypeJSONPatch,
+			body:        `[{"op":"remove","path":"/missing"}]`,
+			login:       "alice",
+			setupMocks: func(mockService *Mockservice) {
+				mockService.EXPECT().GetPostByID(uint(1)).Return(existing(), nil)
+			},
+
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:        "7. Cleared_Required_Field",
+			contentType: ContentTypeMergePatch,
+			body:        `{"title":null}`,
+			login:       "alice",
+			setupMocks: func(mockService *Mockservice) {
+				mockService.EXPECT().GetPostByID(uint(1)).Return(existing(), nil)
+			},
+
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "8. Post_Not_Found",
+			contentType: ContentTypeMergePatch,
+			body:        `{"price":150}`,
+			login:       "alice",
+			setupMocks: func(mockService *Mockservice) {
+				mockService.EXPECT().GetPostByID(uint(1)).Return(nil, ErrPostNotFound)
+			},
+
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:        "9. Restock",
+			contentType: ContentTypeMergePatch,
+			body:        `{"quantity":20}`,
+			login:       "alice",
+			setupMocks: func(mockService *Mockservice) {
+				sold := existing()
+				sold.QuantitySold, sold.Status = 1, StatusSold
+				mockService.EXPECT().GetPostByID(uint(1)).Return(sold, nil)
+				mockService.EXPECT().UpdatePost(gomock.Any()).DoAndReturn(func(p *Post) error {
+					assert.Equal(t, 20, p.Quantity)
+					return nil
+				})
+			},
+
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:        "10. Quantity_Below_Sold",
+			contentType: ContentTypeMergePatch,
+			body:        `{"quantity":2}`,
+			login:       "alice",
+			setupMocks: func(mockService *Mockservice) {
+				p := existing()
+				p.Quantity, p.QuantitySold = 10, 4
+				mockService.EXPECT().GetPostByID(uint(1)).Return(p, nil)
+			},
+
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "11. Sold_Concurrently",
+			contentType: ContentTypeMergePatch,
+			body:        `{"quantity":5}`,
+			login:       "alice",
+			setupMocks: func(mockService *Mockservice) {
+				p := existing()
+				p.Quantity, p.QuantitySold = 10, 4
+				mockService.EXPECT().GetPostByID(uint(1)).Return(p, nil)
+				mockService.EXPECT().UpdatePost(gomock.Any()).Return(ErrQuantityBelowSold)
+			},
+
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:        "12. No_User",
+			contentType: ContentTypeMergePatch,
+			body:        `{"price":150}`,
+			setupMocks:  func(mockService *Mockservice) {},
+
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewMockservice(ctrl)
+			tc.setupMocks(service)
+			handler := NewHandler(service, zap.NewNop())
+
+			req := httptest.NewRequest(http.MethodPatch, "/posts/1", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			if tc.login != "" {
+				req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, tc.login))
+			}
+			rr := httptest.NewRecorder()
+
+			handler.PatchPost(rr, req)
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestHandler_GetPriceHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		path       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "1. History",
+			path: "/posts/1/price-history",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetPriceHistory(uint(1), "").Return([]*PriceChange{{
+					OldPrice: money.MustParse("100", "RUB"),
+					NewPrice: money.MustParse("80", "RUB"),
+				}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"old_price":{"amount":"100.00","currency":"RUB"}`,
+		},
+		{
+			name: "2. Post_Not_Found",
+			path: "/posts/2/price-history",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetPriceHistory(uint(2), "").Return(nil, ErrPostNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "3. Invalid_ID",
+			path:         "/posts/x/price-history",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			rr := httptest.NewRecorder()
+
+			handler.GetPriceHistory(rr, req)
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			if tc.expectedBody != "" {
+				assert.Contains(t, rr.Body.String(), tc.expectedBody)
+			}
+		})
+	}
+}
+
+func TestHandler_GetSimilar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		path       string
+		user       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name: "1. Similar",
+			path: "/posts/1/similar",
+			user: "bob",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetSimilar(uint(1), "bob").Return([]*Post{{ID: 2, Title: "Bike"}}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `"title":"Bike"`,
+		},
+		{
+			name: "2. Anonymous",
+			path: "/posts/1/similar",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetSimilar(uint(1), "").Return([]*Post{}, nil)
+			},
+			expectedCode: http.StatusOK,
+			expectedBody: `[]`,
+		},
+		{
+			name: "3. Post_Not_Found",
+			path: "/posts/2/similar",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetSimilar(uint(2), "").Return(nil, ErrPostNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "4. Invalid_ID",
+			path:         "/posts/x/similar",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.user != "" {
+				req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, tc.user))
+			}
+			rr := httptest.NewRecorder()
+
+			handler.GetSimilar(rr, req)
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+			if tc.expectedBody != "" {
+				assert.Contains(t, rr.Body.String(), tc.expectedBody)
+			}
+		})
+	}
+}
+
+func TestHandler_GetPostByID_OnDetailView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewMockservice(ctrl)
+	service.EXPECT().GetPostFor(uint(7), "").Return(&Post{ID: 7, Owner: "alice"}, nil)
+	service.EXPECT().GetPostFor(uint(7), "alice").Return(&Post{ID: 7, Owner: "alice", IsOwner: true}, nil)
+
+	var viewed []uint
+	handler := NewHandler(service, zap.NewNop(), WithOnDetailView(func(p *Post, r *http.Request) {
+		viewed = append(viewed, p.ID)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.GetPostByID(rr, httptest.NewRequest(http.MethodGet, "/posts/7", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// Просмотры владельца не считаются
+	req := httptest.NewRequest(http.MethodGet, "/posts/7", nil)
+	req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, "alice"))
+	rr = httptest.NewRecorder()
+	handler.GetPostByID(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	assert.Equal(t, []uint{7}, viewed)
+}
+
+func TestHandler_DeletePost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		user       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name: "1. Deleted",
+			user: "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetPostByID(uint(1)).Return(&Post{ID: 1, Owner: "alice"}, nil)
+				s.EXPECT().DeletePost(uint64(1)).Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name: "2. Has_Orders",
+			user: "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetPostByID(uint(1)).Return(&Post{ID: 1, Owner: "alice"}, nil)
+				s.EXPECT().DeletePost(uint64(1)).Return(ErrPostHasOrders)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name: "3. Not_Owner",
+			user: "bob",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetPostByID(uint(1)).Return(&Post{ID: 1, Owner: "alice"}, nil)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+
+			req := httptest.NewRequest(http.MethodDelete, "/posts/1", nil)
+			req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, tc.user))
+			rr := httptest.NewRecorder()
+
+			handler.DeletePost(rr, req)
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package post
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+func Test_applyPatch(t *testing.T) {
+	basePost := func() *Post {
+		return &Post{
+			ID:          1,
+			Title:       "Old title",
+			Description: "Old description",
+			Price:       money.MustParse("100", "USD"),
+			ImageURL:    "https://example.com/old.png",
+			Location:    &Location{Lat: 55.75, Lon: 37.61, Place: "Moscow"},
+			Owner:       "alice",
+		}
+	}
+
+	testCases := []struct {
+		name string
+
+		contentType string
+		patch       string
+
+		expected    *Post
+		expectedErr error
+		failedOp    int
+	}{
+		{
+			name:        "1. Merge_Patch_Replaces_Fields",
+			contentType: ContentTypeMergePatch,
+			patch:       `{"title":"New title","price":150.5}`,
+
+			expected: &Post{
+				ID:          1,
+				Title:       "New title",
+				Description: "Old description",
+				Price:       money.MustParse("150.5", "USD"),
+				ImageURL:    "https://example.com/old.png",
+				Location:    &Location{Lat: 55.75, Lon: 37.61, Place: "Moscow"},
+				Owner:       "alice",
+			},
+		},
+		{
+			name:        "2. Merge_Patch_Plain_Price_Keeps_Currency",
+			contentType: ContentTypeMergePatch,
+			patch:       `{"price":99}`,
+
+			expected: &Post{
+				ID:          1,
+				Title:       "Old title",
+				Description: "Old description",
+				Price:       money.MustParse("99", "USD"),
+				ImageURL:    "https://example.com/old.png",
+				Location:    &Location{Lat: 55.75, Lon: 37.61, Place: "Moscow"},
+				Owner:       "alice",
+			},
+		},
+		{
+			name:        "3. Merge_Patch_Null_Clears_Field",
+			contentType: ContentTypeMergePatch,
+			patch:       `{"description":null}`,
+
+			expected: &Post{
+				ID:          1,
+				Title:       "Old title",
+				Description: "",
+				Price:       money.MustParse("100", "USD"),
+				ImageURL:    "https://example.com/old.png",
+				Location:    &Location{Lat: 55.75, Lon: 37.61, Place: "Moscow"},
+				Owner:       "alice",
+			},
+		},
+		{
+			name:        "4. Merge_Patch_Null_Clears_Location",
+			contentType: ContentTypeMergePatch,
+			patch:       `{"location":null}`,
+
+			expected: &Post{
+				ID:          1,
+				Title:       "Old title",
+				Description: "Old description",
+				Price:       money.MustParse("100", "USD"),
+				ImageURL:    "https://example.com/old.png",
+				Owner:       "alice",
+			},
+		},
+		{
+			name:        "5. Merge_Patch_Unknown_Field",
+			contentType: ContentTypeMergePatch,
+			patch:       `{"owner":"bob"}`,
+
+			expectedErr: errors.New("unknown field"),
+		},
+		{
+			name:        "6. JSON_Patch_Test_And_Replace",
+			contentType: ContentTypeJSONPatch,
+			patch: `[
+				{"op":"test","path":"/title","value":"Old title"},
+				{"op":"replace","path":"/title","value":"New title"},
+				{"op":"copy","from":"/title","path":"/description"}
+			]`,
+
+			expected: &Post{
+				ID:          1,
+				Title:       "New title",
+				Description: "New title",
+				Price:       money.MustParse("100", "USD"),
+				ImageURL:    "https://example.com/old.png",
+				Location:    &Location{Lat: 55.75, Lon: 37.61, Place: "Moscow"},
+				Owner:       "alice",
+			},
+		},
+		{
+			name:        "7. JSON_Patch_Failed_Test",
+			contentType: ContentTypeJSONPatch,
+			patch: `[
+				{"op":"replace","path":"/price/amount","value":"120"},
+				{"op":"test","path":"/title","value":"Other title"}
+			]`,
+
+			expectedErr: ErrTestFailed,
+			failedOp:    1,
+		},
+		{
+			name:        "8. JSON_Patch_Missing_Path",
+			contentType: ContentTypeJSONPatch,
+			patch:       `[{"op":"remove","path":"/missing"}]`,
+
+			expectedErr: ErrPathNotFound,
+			failedOp:    0,
+		},
+		{
+			name:        "9. JSON_Patch_Unknown_Operation",
+			contentType: ContentTypeJSONPatch,
+			patch:       `[{"op":"increment","path":"/price","value":1}]`,
+
+			expectedErr: errors.New("unknown operation"),
+			failedOp:    0,
+		},
+		{
+			name:        "10. JSON_Patch_Null_Value",
+			contentType: ContentTypeJSONPatch,
+			patch: `[
+				{"op":"replace","path":"/location","value":null},
+				{"op":"test","path":"/location","value":null}
+			]`,
+
+			expected: &Post{
+				ID:          1,
+				Title:       "Old title",
+				Description: "Old description",
+				Price:       money.MustParse("100", "USD"),
+				ImageURL:    "https://example.com/old.png",
+				Owner:       "alice",
+			},
+		},
+		{
+			name:        "11. JSON_Patch_Missing_Value",
+			contentType: ContentTypeJSONPatch,
+			patch:       `[{"op":"add","path":"/title"}]`,
+
+			expectedErr: errors.New("missing value"),
+			failedOp:    0,
+		},
+		{
+			name:        "12. Unsupported_Content_Type",
+			contentType: "application/json",
+			patch:       `{"title":"New title"}`,
+
+			expectedErr: ErrUnsupportedPatch,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			post := basePost()
+			err := applyPatch(post, tc.contentType, []byte(tc.patch))
+
+			if tc.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, post)
+				return
+			}
+
+			assert.Error(t, err)
+			var patchErr *PatchError
+			if errors.As(err, &patchErr) {
+				assert.Equal(t, tc.failedOp, patchErr.Index)
+			}
+			if errors.Is(tc.expectedErr, ErrTestFailed) ||
+				errors.Is(tc.expectedErr, ErrPathNotFound) ||
+				errors.Is(tc.expectedErr, ErrUnsupportedPatch) {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.Contains(t, err.Error(), tc.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func Test_PatchError_NamesOperation(t *testing.T) {
+	err := &PatchError{Index: 2, Op: "replace", Path: "/price", Err: ErrPathNotFound}
+
+	assert.Equal(t, "operation 2 (replace /price): path not found", err.Error())
+	assert.ErrorIs(t, err, ErrPathNotFound)
+}
@@ -1,30 +1,124 @@
 package post
 
-import "time"
+import (
+	"time"
+
+	"github.com/TemirB/rest-api-marketplace/internal/content"
+	"github.com/TemirB/rest-api-marketplace/pkg/geo"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+// Статусы поста: зарезервированный пост больше не принимает предложения цены,
+// проданный (sold) — распродан, все единицы товара проданы.
+const (
+	StatusActive   = "active"
+	StatusReserved = "reserved"
+	StatusSold     = "sold"
+)
+
+// Типы объявлений: с фиксированной ценой или аукцион.
+const (
+	ListingFixed   = "fixed"
+	ListingAuction = "auction"
+)
 
 type Post struct {
 	ID          uint
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"` // float можно заменить на decimal для большей точности, но для простоты оставим float
-	ImageURL    string  `json:"image_url"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price"`
+	ImageURL    string      `json:"image_url"`
+	Location    *Location   `json:"location,omitempty"`
 
 	CreatedAt time.Time `json:"created_at"`
 	Owner     string    `json:"owner"`
 	IsOwner   bool      `json:"is_owner,omitempty"`
+	Status    string    `json:"status"`
+
+	ListingType string `json:"listing_type"`
+
+	// Quantity — сколько единиц товара выставлено, QuantitySold — сколько из них продано
+	// или зарезервировано под принятые предложения
+	Quantity     int `json:"quantity"`
+	QuantitySold int `json:"quantity_sold"`
+
+	// Hidden — пост скрыт модерацией: его нет в ленте, купить его нельзя, а видит его только владелец
+	Hidden bool `json:"hidden,omitempty"`
+
+	// DuplicateOf — похожий пост того же продавца, если новый пост пропущен как возможный дубль
+	DuplicateOf *uint `json:"duplicate_of,omitempty"`
+
+	// Promotion — вид действующего продвижения поста (featured или highlighted)
+	Promotion string `json:"promotion,omitempty"`
+
+	IsFavorite     bool `json:"is_favorite,omitempty"`
+	FavoritesCount int  `json:"favorites_count"`
+
+	// SellerRating — средняя оценка продавца по видимым отзывам (нет, пока отзывов нет),
+	// SellerReviews — их число
+	SellerRating  *float64 `json:"seller_rating,omitempty"`
+	SellerReviews int      `json:"seller_reviews"`
+
+	// ReducedFrom — прежняя цена, если последнее изменение цены было снижением
+	ReducedFrom *money.Money `json:"reduced_from,omitempty"`
+
+	// DisplayPrice — цена, переведённая в валюту, запрошенную в ленте
+	DisplayPrice *money.Money `json:"display_price,omitempty"`
+	// DistanceKm — расстояние до точки, переданной в ленту через lat/lon
+	DistanceKm *float64 `json:"distance_km,omitempty"`
+
+	// ContentFlags — замечания фильтра контента, с которыми пост отправлен на модерацию;
+	// возвращаются только в ответе на создание или изменение поста
+	ContentFlags []content.Reason `json:"content_flags,omitempty"`
+}
+
+// Available возвращает, сколько единиц ещё можно купить.
+func (p *Post) Available() int {
+	return p.Quantity - p.QuantitySold
+}
+
+// Location — место, где можно забрать товар.
+type Location struct {
+	Lat   float64 `json:"lat"`
+	Lon   float64 `json:"lon"`
+	Place string  `json:"place,omitempty"`
+}
+
+// PriceChange — запись истории цен поста.
+type PriceChange struct {
+	PostID    uint        `json:"-"`
+	OldPrice  money.Money `json:"old_price"`
+	NewPrice  money.Money `json:"new_price"`
+	ChangedAt time.Time   `json:"changed_at"`
+}
+
+// DropPercent возвращает снижение цены в процентах; 0, если цена выросла
+// или сменилась валюта.
+func (c *PriceChange) DropPercent() float64 {
+	if c.OldPrice.Currency() != c.NewPrice.Currency() || !c.OldPrice.IsPositive() {
+		return 0
+	}
+	oldAmount := c.OldPrice.Amount().Float64()
+	drop := (oldAmount - c.NewPrice.Amount().Float64()) / oldAmount * 100
+	if drop < 0 {
+		return 0
+	}
+	return drop
 }
 
 type UpdatePostRequest struct {
-	Title       *string  `json:"title,omitempty"`
-	Description *string  `json:"description,omitempty"`
-	Price       *float64 `json:"price,omitempty"`
-	ImageURL    *string  `json:"image_url,omitempty"`
+	Title       *string      `json:"title,omitempty"`
+	Description *string      `json:"description,omitempty"`
+	Price       *money.Money `json:"price,omitempty"`
+	ImageURL    *string      `json:"image_url,omitempty"`
+	Location    *Location    `json:"location,omitempty"`
+	Quantity    *int         `json:"quantity,omitempty"`
 }
 
 func NewPost(
 	title,
 	description string,
-	price float64,
+	price money.Money,
 	imageURL, ownerLogin string,
 ) *Post {
 	return &Post{
@@ -37,12 +131,70 @@ func NewPost(
 }
 
 type SortParams struct {
-	Field     string // "price" | "created_at"
-	Direction string // "asc" | "desc"
+	Field     string `json:"sort_by"` // "price" | "created_at" | "distance"
+	Direction string `json:"order"`   // "asc" | "desc"
+}
+
+// GeoFilter ограничивает ленту кругом радиуса RadiusKm вокруг точки.
+// При RadiusKm == 0 расстояние только вычисляется (для сортировки и distance_km).
+type GeoFilter struct {
+	Lat      float64 `json:"lat"`
+	Lon      float64 `json:"lon"`
+	RadiusKm float64 `json:"radius_km,omitempty"`
 }
 
+// FilterParams сериализуется в JSON для сохранённых поисков; поля,
+// зависящие от зрителя и текущего запроса, в JSON не попадают.
 type FilterParams struct {
-	MinPrice float64
-	MaxPrice float64
-	Owner    string
+	MinPrice float64 `json:"min_price"`
+	MaxPrice float64 `json:"max_price"`
+	Owner    string  `json:"-"`
+
+	// Currency — валюта, в которой заданы MinPrice/MaxPrice и в которой сортируется цена.
+	// Пустая строка означает сравнение цен без конвертации.
+	Currency money.Currency `json:"currency,omitempty"`
+	Rates    *money.Rates   `json:"-"`
+
+	Near *GeoFilter `json:"near,omitempty"`
+
+	// FavoritedBy оставляет только посты из избранного этого пользователя
+	FavoritedBy string `json:"-"`
+	// FollowedBy оставляет только посты продавцов, на которых подписан этот пользователь
+	FollowedBy string `json:"-"`
+}
+
+// Match проверяет пост по тем же правилам, что и GetAll: границы цены (с конвертацией,
+// если задана Currency) и радиус. Owner, FavoritedBy и FollowedBy не учитываются.
+func (f *FilterParams) Match(p *Post, rates *money.Rates) bool {
+	if f.MinPrice > 0 || f.MaxPrice >= 0 {
+		price := p.Price
+		if f.Currency != "" {
+			if rates == nil {
+				return false
+			}
+			converted, err := rates.Convert(p.Price, f.Currency)
+			if err != nil {
+				return false
+			}
+			price = converted
+		}
+		amount := price.Amount().Float64()
+		if amount < f.MinPrice || (f.MaxPrice >= 0 && amount > f.MaxPrice) {
+			return false
+		}
+	}
+
+	if f.Near != nil && f.Near.RadiusKm > 0 {
+		if p.Location == nil {
+			return false
+		}
+		distance := geo.Distance(
+			geo.Point{Lat: f.Near.Lat, Lon: f.Near.Lon},
+			geo.Point{Lat: p.Location.Lat, Lon: p.Location.Lon},
+		)
+		if distance > f.Near.RadiusKm {
+			return false
+		}
+	}
+	return true
 }
@@ -4,13 +4,15 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
 )
 
 func TestNewPost(t *testing.T) {
 	type opts struct {
 		title       string
 		description string
-		price       float64
+		price       money.Money
 		imageURL    string
 		ownerLogin  string
 	}
@@ -27,7 +29,7 @@ func TestNewPost(t *testing.T) {
 			postOpts: opts{
 				title:       "Test Post",
 				description: "This is a test post description.",
-				price:       99.99,
+				price:       money.MustParse("99.99", "RUB"),
 				imageURL:    "http://example.com/image.jpg",
 				ownerLogin:  "testuser",
 			},
@@ -52,3 +54,79 @@ func TestNewPost(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterParams_Match(t *testing.T) {
+	rates := &money.Rates{
+		Base:  money.RUB,
+		Rates: map[money.Currency]money.Decimal{money.USD: money.NewDecimal(125, 4)},
+	}
+	moscow := &Location{Lat: 55.7558, Lon: 37.6173}
+
+	testCases := []struct {
+		name string
+
+		filter FilterParams
+		post   Post
+		rates  *money.Rates
+
+		expected bool
+	}{
+		{name: "1. No_Bounds", filter: FilterParams{MaxPrice: -1}, post: Post{Price: money.MustParse("1", "RUB")}, expected: true},
+		{name: "2. In_Range", filter: FilterParams{MinPrice: 10, MaxPrice: 20}, post: Post{Price: money.MustParse("15", "RUB")}, expected: true},
+		{name: "3. Above_Max", filter: FilterParams{MaxPrice: 20}, post: Post{Price: money.MustParse("20.01", "RUB")}, expected: false},
+		{name: "4. Below_Min_No_Max", filter: FilterParams{MinPrice: 10, MaxPrice: -1}, post: Post{Price: money.MustParse("9", "RUB")}, expected: false},
+		{
+			name:     "5. Converted_To_Filter_Currency",
+			filter:   FilterParams{MinPrice: 10, MaxPrice: 15, Currency: money.USD},
+			post:     Post{Price: money.MustParse("1000", "RUB")},
+			rates:    rates,
+			expected: true,
+		},
+		{
+			name:     "6. No_Rates",
+			filter:   FilterParams{MinPrice: 10, MaxPrice: 15, Currency: money.USD},
+			post:     Post{Price: money.MustParse("1000", "RUB")},
+			expected: false,
+		},
+		{
+			name:     "7. Within_Radius",
+			filter:   FilterParams{MaxPrice: -1, Near: &GeoFilter{Lat: 55.75, Lon: 37.62, RadiusKm: 5}},
+			post:     Post{Price: money.MustParse("1", "RUB"), Location: moscow},
+			expected: true,
+		},
+		{
+			name:     "8. Without_Location",
+			filter:   FilterParams{MaxPrice: -1, Near: &GeoFilter{Lat: 55.75, Lon: 37.62, RadiusKm: 5}},
+			post:     Post{Price: money.MustParse("1", "RUB")},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.filter.Match(&tc.post, tc.rates))
+		})
+	}
+}
+
+func TestPriceChange_DropPercent(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		oldPrice money.Money
+		newPrice money.Money
+
+		expected float64
+	}{
+		{name: "1. Drop", oldPrice: money.MustParse("200", "RUB"), newPrice: money.MustParse("150", "RUB"), expected: 25},
+		{name: "2. Increase", oldPrice: money.MustParse("100", "RUB"), newPrice: money.MustParse("150", "RUB"), expected: 0},
+		{name: "3. Currency_Changed", oldPrice: money.MustParse("100", "USD"), newPrice: money.MustParse("50", "RUB"), expected: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			change := &PriceChange{OldPrice: tc.oldPrice, NewPrice: tc.newPrice}
+			assert.Equal(t, tc.expected, change.DropPercent())
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package post
+
+import (
+	"slices"
+)
+
+// Виды продвижения поста.
+const (
+	// PromotionFeatured — пост ставится на рекламные позиции ленты
+	PromotionFeatured = "featured"
+	// PromotionHighlighted — пост остаётся на своём месте, но выделяется в ленте
+	PromotionHighlighted = "highlighted"
+)
+
+// interleave ставит продвигаемые посты featured (в порядке очереди показа) на позиции slots
+// ленты posts и убирает их из органической выдачи, поэтому пост не попадает в ленту дважды.
+// В ленту попадают только featured, прошедшие фильтры ленты; не поместившиеся на позиции
+// остаются на своих органических местах.
+func interleave(posts []*Post, featured []uint, slots []int) []*Post {
+	usable := make([]int, 0, len(slots))
+	for _, slot := range slots {
+		if slot >= 0 && slot < len(posts) {
+			usable = append(usable, slot)
+		}
+	}
+	slices.Sort(usable)
+	usable = slices.Compact(usable)
+	if len(usable) == 0 || len(featured) == 0 {
+		return posts
+	}
+
+	byID := make(map[uint]*Post, len(posts))
+	for _, p := range posts {
+		byID[p.ID] = p
+	}
+	var (
+		promoted []*Post
+		picked   = make(map[uint]bool)
+	)
+	for _, id := range featured {
+		if p, ok := byID[id]; ok && !picked[id] && len(promoted) < len(usable) {
+			promoted = append(promoted, p)
+			picked[id] = true
+		}
+	}
+	if len(promoted) == 0 {
+		return posts
+	}
+
+	organic := make([]*Post, 0, len(posts)-len(promoted))
+	for _, p := range posts {
+		if !picked[p.ID] {
+			organic = append(organic, p)
+		}
+	}
+
+	result := make([]*Post, 0, len(posts))
+	for pos := 0; pos < len(posts); pos++ {
+		if len(promoted) > 0 && len(usable) > 0 && usable[0] == pos {
+			result = append(result, promoted[0])
+			promoted, usable = promoted[1:], usable[1:]
+			continue
+		}
+		result = append(result, organic[0])
+		organic = organic[1:]
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package post
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_interleave(t *testing.T) {
+	feed := func(ids ...uint) []*Post {
+		posts := make([]*Post, 0, len(ids))
+		for _, id := range ids {
+			posts = append(posts, &Post{ID: id})
+		}
+		return posts
+	}
+	ids := func(posts []*Post) []uint {
+		res := make([]uint, 0, len(posts))
+		for _, p := range posts {
+			res = append(res, p.ID)
+		}
+		return res
+	}
+
+	testCases := []struct {
+		name string
+
+		posts    []*Post
+		featured []uint
+		slots    []int
+
+		expected []uint
+	}{
+		{
+			name:     "1. Featured_Moved_To_Slots",
+			posts:    feed(1, 2, 3, 4, 5, 6),
+			featured: []uint{5, 2},
+			slots:    []int{0, 3},
+			expected: []uint{5, 1, 3, 2, 4, 6},
+		},
+		{
+			name:     "2. Featured_Not_In_Feed_Skipped",
+			posts:    feed(1, 2, 3),
+			featured: []uint{9, 3},
+			slots:    []int{0, 2},
+			expected: []uint{3, 1, 2},
+		},
+		{
+			name:     "3. More_Featured_Than_Slots",
+			posts:    feed(1, 2, 3, 4),
+			featured: []uint{4, 3},
+			slots:    []int{1},
+			expected: []uint{1, 4, 2, 3},
+		},
+		{
+			name:     "4. Slots_Beyond_Feed",
+			posts:    feed(1, 2),
+			featured: []uint{2},
+			slots:    []int{5},
+			expected: []uint{1, 2},
+		},
+		{
+			name:     "5. Unsorted_Duplicate_Slots",
+			posts:    feed(1, 2, 3, 4),
+			featured: []uint{3, 4},
+			slots:    []int{2, 0, 2},
+			expected: []uint{3, 1, 4, 2},
+		},
+		{
+			name:     "6. No_Featured",
+			posts:    feed(1, 2),
+			slots:    []int{0},
+			expected: []uint{1, 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ids(interleave(tc.posts, tc.featured, tc.slots)))
+		})
+	}
+}
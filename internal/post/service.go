@@ -1,32 +1,187 @@
 package post
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
 	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/block"
+	"github.com/TemirB/rest-api-marketplace/internal/content"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+var (
+	ErrRatesUnavailable  = errors.New("exchange rates are unavailable")
+	ErrNoRateForCurrency = errors.New("no exchange rate for requested currency")
 )
 
+// ContentError — пост отклонён фильтром контента; Reasons объясняют клиенту почему.
+type ContentError struct {
+	Reasons []content.Reason
+}
+
+func (e *ContentError) Error() string {
+	codes := make([]string, 0, len(e.Reasons))
+	for _, r := range e.Reasons {
+		codes = append(codes, r.Check+"/"+r.Code)
+	}
+	return fmt.Sprintf("post rejected by content filter: %s", strings.Join(codes, ", "))
+}
+
 // mockgen  -source=service.go -destination=service_mock_test.go -package=post
 
 type storage interface {
 	Create(post *Post) error
+	CreateTx(tx *sql.Tx, post *Post) error
 	GetAll(sort *SortParams, filter *FilterParams) ([]*Post, error)
-	Update(post *Post) error
+	Update(post *Post) (*PriceChange, error)
+	PriceHistory(postID uint) ([]*PriceChange, error)
 	Delete(id uint64) error
 	GetByID(id uint) (*Post, error)
+	GetByIDFor(id uint, viewer string) (*Post, error)
+	RecentByOwner(owner string, since time.Time) ([]*Post, error)
+	Similar(id uint, priceBand float64, rates *money.Rates, limit int) ([]*Post, error)
+}
+
+type ratesProvider interface {
+	Rates() (*money.Rates, error)
+}
+
+type contentFilter interface {
+	Check(doc *content.Document) *content.Verdict
+}
+
+// quotas списывает создание объявления с квоты пользователя.
+type quotas interface {
+	ConsumeListing(login string, create func(*sql.Tx) error) error
+}
+
+type blocks interface {
+	ListBlocked(blocker string) ([]string, error)
+	Check(a, b string) error
 }
 
 type Service struct {
-	repository storage
-	logger     *zap.Logger
+	repository   storage
+	rates        ratesProvider
+	filter       contentFilter
+	duplicates   DuplicateCheck
+	quotas       quotas
+	blocks       blocks
+	similar      SimilarParams
+	similarCache similarCache
+	onCreate     []func(*Post)
+	onPrice      []func(*Post, *PriceChange)
+	onFlag       []func(*Post)
+	onFeed       []func([]*Post)
+	onView       []func(*Post, string)
+	logger       *zap.Logger
+}
+
+// Option настраивает необязательные зависимости сервиса.
+type Option func(*Service)
+
+// WithRates подключает курсы валют для фильтрации и отображения цен в валюте покупателя.
+func WithRates(rates ratesProvider) Option {
+	return func(s *Service) {
+		s.rates = rates
+	}
+}
+
+// WithOnCreate добавляет обработчик, который вызывается после успешного создания поста.
+// Обработчик не должен блокировать: тяжёлую работу стоит уводить в горутину.
+func WithOnCreate(fn func(*Post)) Option {
+	return func(s *Service) {
+		s.onCreate = append(s.onCreate, fn)
+	}
+}
+
+// WithOnPriceChange добавляет обработчик изменения цены поста. Правила те же, что у WithOnCreate.
+func WithOnPriceChange(fn func(*Post, *PriceChange)) Option {
+	return func(s *Service) {
+		s.onPrice = append(s.onPrice, fn)
+	}
+}
+
+// WithContentFilter подключает проверку текста новых и изменённых постов.
+func WithContentFilter(filter contentFilter) Option {
+	return func(s *Service) {
+		s.filter = filter
+	}
+}
+
+// WithDuplicateCheck включает поиск дублей среди недавних постов продавца при создании поста.
+func WithDuplicateCheck(check DuplicateCheck) Option {
+	return func(s *Service) {
+		s.duplicates = check
+	}
+}
+
+// WithQuotas ограничивает создание объявлений квотами пользователя.
+func WithQuotas(quotas quotas) Option {
+	return func(s *Service) {
+		s.quotas = quotas
+	}
+}
+
+// WithSimilar задаёт число похожих постов, допустимый разброс цены и срок кэширования выдачи.
+func WithSimilar(params SimilarParams) Option {
+	return func(s *Service) {
+		s.similar = params
+	}
 }
 
-func NewService(repository storage, logger *zap.Logger) *Service {
-	return &Service{
+// WithBlocks убирает из похожих объявлений посты пользователей, заблокированных зрителем.
+func WithBlocks(blocks blocks) Option {
+	return func(s *Service) {
+		s.blocks = blocks
+	}
+}
+
+// WithOnFlag добавляет обработчик поста, сохранённого с замечаниями фильтра контента
+// (они в ContentFlags). Правила те же, что у WithOnCreate.
+func WithOnFlag(fn func(*Post)) Option {
+	return func(s *Service) {
+		s.onFlag = append(s.onFlag, fn)
+	}
+}
+
+// WithOnFeed добавляет обработчик ленты, отданной клиенту. Правила те же, что у WithOnCreate.
+func WithOnFeed(fn func([]*Post)) Option {
+	return func(s *Service) {
+		s.onFeed = append(s.onFeed, fn)
+	}
+}
+
+// WithOnView добавляет обработчик просмотра поста зрителем (пустой — аноним).
+// Правила те же, что у WithOnCreate.
+func WithOnView(fn func(p *Post, viewer string)) Option {
+	return func(s *Service) {
+		s.onView = append(s.onView, fn)
+	}
+}
+
+func NewService(repository storage, logger *zap.Logger, opts ...Option) *Service {
+	s := &Service{
 		repository: repository,
+		similar:    defaultSimilar,
 		logger:     logger,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
+// CreatePost создаёт пост; если количество не указано, выставляется одна единица товара.
 func (s *Service) CreatePost(post *Post) (*Post, error) {
+	if post.Quantity == 0 {
+		post.Quantity = 1
+	}
 	err := validatePost(post)
 	if err != nil {
 		s.logger.Error(
@@ -35,16 +190,70 @@ func (s *Service) CreatePost(post *Post) (*Post, error) {
 		)
 		return nil, err
 	}
-	err = s.repository.Create(post)
+	if err := s.screen(post); err != nil {
+		return nil, err
+	}
+	if err := s.dedupe(post); err != nil {
+		return nil, err
+	}
+	// Квота списывается последней, чтобы отклонённые проверками посты её не тратили, и в одной
+	// транзакции с созданием поста: иначе параллельные запросы увидели бы одно и то же число
+	// активных объявлений
+	if s.quotas != nil {
+		err = s.quotas.ConsumeListing(post.Owner, func(tx *sql.Tx) error {
+			return s.repository.CreateTx(tx, post)
+		})
+	} else {
+		err = s.repository.Create(post)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	for _, fn := range s.onCreate {
+		fn(post)
+	}
+	s.flagged(post)
 	return post, nil
 }
 
 func (s *Service) GetPosts(sort *SortParams, filter *FilterParams) ([]*Post, error) {
-	return s.repository.GetAll(sort, filter)
+	if filter != nil && filter.Currency != "" {
+		if s.rates == nil {
+			return nil, ErrRatesUnavailable
+		}
+		rates, err := s.rates.Rates()
+		if err != nil {
+			s.logger.Error(
+				"Failed to get exchange rates",
+				zap.Error(err),
+			)
+			return nil, ErrRatesUnavailable
+		}
+		if !rates.Has(filter.Currency) {
+			return nil, ErrNoRateForCurrency
+		}
+		filter.Rates = rates
+	}
+
+	posts, err := s.repository.GetAll(sort, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter != nil && filter.Rates != nil {
+		for _, p := range posts {
+			converted, err := filter.Rates.Convert(p.Price, filter.Currency)
+			if err != nil {
+				continue
+			}
+			p.DisplayPrice = &converted
+		}
+	}
+	for _, fn := range s.onFeed {
+		fn(posts)
+	}
+	return posts, nil
 }
 
 func (s *Service) DeletePost(id uint64) error {
@@ -60,10 +269,109 @@ func (s *Service) UpdatePost(post *Post) error {
 		)
 		return err
 	}
+	if err := s.screen(post); err != nil {
+		return err
+	}
+
+	change, err := s.repository.Update(post)
+	if err != nil {
+		return err
+	}
+	if change != nil {
+		for _, fn := range s.onPrice {
+			fn(post, change)
+		}
+	}
+	s.flagged(post)
+	return nil
+}
+
+// screen прогоняет текст поста через фильтр контента. Отклонённый пост возвращает
+// ContentError, замечания с действием flag сохраняются в ContentFlags.
+func (s *Service) screen(post *Post) error {
+	post.ContentFlags = nil
+	if s.filter == nil {
+		return nil
+	}
+	verdict := s.filter.Check(&content.Document{
+		PostID:      post.ID,
+		Owner:       post.Owner,
+		Title:       post.Title,
+		Description: post.Description,
+	})
+	switch verdict.Action {
+	case content.ActionReject:
+		s.logger.Info(
+			"Post rejected by content filter",
+			zap.String("owner", post.Owner),
+			zap.Any("reasons", verdict.Reasons),
+		)
+		return &ContentError{Reasons: verdict.Reasons}
+	case content.ActionFlag:
+		post.ContentFlags = verdict.Reasons
+	}
+	return nil
+}
 
-	return s.repository.Update(post)
+func (s *Service) flagged(post *Post) {
+	if len(post.ContentFlags) == 0 {
+		return
+	}
+	for _, fn := range s.onFlag {
+		fn(post)
+	}
+}
+
+// GetPriceHistory возвращает историю цен существующего поста, если зритель может его видеть.
+func (s *Service) GetPriceHistory(id uint, viewer string) ([]*PriceChange, error) {
+	p, err := s.repository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkVisible(p, viewer); err != nil {
+		return nil, err
+	}
+	return s.repository.PriceHistory(id)
 }
 
 func (s *Service) GetPostByID(id uint) (*Post, error) {
 	return s.repository.GetByID(id)
 }
+
+// GetPostFor возвращает пост с флагами is_owner/is_favorite для зрителя.
+// Скрытый модерацией пост видит только владелец; если владелец и зритель заблокировали
+// друг друга, пост для зрителя не существует.
+func (s *Service) GetPostFor(id uint, viewer string) (*Post, error) {
+	p, err := s.repository.GetByIDFor(id, viewer)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkVisible(p, viewer); err != nil {
+		return nil, err
+	}
+	for _, fn := range s.onView {
+		fn(p, viewer)
+	}
+	return p, nil
+}
+
+// checkVisible возвращает ErrPostNotFound, если пост скрыт модерацией или его владелец и зритель
+// заблокировали друг друга. Владелец видит свой пост всегда, анонимный зритель — любой видимый.
+func (s *Service) checkVisible(p *Post, viewer string) error {
+	if viewer == p.Owner {
+		return nil
+	}
+	if p.Hidden {
+		return ErrPostNotFound
+	}
+	if s.blocks == nil || viewer == "" {
+		return nil
+	}
+	if err := s.blocks.Check(viewer, p.Owner); err != nil {
+		if errors.Is(err, block.ErrBlocked) {
+			return ErrPostNotFound
+		}
+		return err
+	}
+	return nil
+}
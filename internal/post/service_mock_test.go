@@ -5,8 +5,12 @@
 package post
 
 import (
+	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
+	content "github.com/TemirB/rest-api-marketplace/internal/content"
+	money "github.com/TemirB/rest-api-marketplace/pkg/money"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -47,6 +51,20 @@ func (mr *MockstorageMockRecorder) Create(post interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*Mockstorage)(nil).Create), post)
 }
 
+// CreateTx mocks base method.
+func (m *Mockstorage) CreateTx(tx *sql.Tx, post *Post) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTx", tx, post)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTx indicates an expected call of CreateTx.
+func (mr *MockstorageMockRecorder) CreateTx(tx, post interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTx", reflect.TypeOf((*Mockstorage)(nil).CreateTx), tx, post)
+}
+
 // Delete mocks base method.
 func (m *Mockstorage) Delete(id uint64) error {
 	m.ctrl.T.Helper()
@@ -91,12 +109,73 @@ func (mr *MockstorageMockRecorder) GetByID(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*Mockstorage)(nil).GetByID), id)
 }
 
+// GetByIDFor mocks base method.
+func (m *Mockstorage) GetByIDFor(id uint, viewer string) (*Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDFor", id, viewer)
+	ret0, _ := ret[0].(*Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDFor indicates an expected call of GetByIDFor.
+func (mr *MockstorageMockRecorder) GetByIDFor(id, viewer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDFor", reflect.TypeOf((*Mockstorage)(nil).GetByIDFor), id, viewer)
+}
+
+// PriceHistory mocks base method.
+func (m *Mockstorage) PriceHistory(postID uint) ([]*PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PriceHistory", postID)
+	ret0, _ := ret[0].([]*PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PriceHistory indicates an expected call of PriceHistory.
+func (mr *MockstorageMockRecorder) PriceHistory(postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PriceHistory", reflect.TypeOf((*Mockstorage)(nil).PriceHistory), postID)
+}
+
+// RecentByOwner mocks base method.
+func (m *Mockstorage) RecentByOwner(owner string, since time.Time) ([]*Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecentByOwner", owner, since)
+	ret0, _ := ret[0].([]*Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecentByOwner indicates an expected call of RecentByOwner.
+func (mr *MockstorageMockRecorder) RecentByOwner(owner, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecentByOwner", reflect.TypeOf((*Mockstorage)(nil).RecentByOwner), owner, since)
+}
+
+// Similar mocks base method.
+func (m *Mockstorage) Similar(id uint, priceBand float64, rates *money.Rates, limit int) ([]*Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Similar", id, priceBand, rates, limit)
+	ret0, _ := ret[0].([]*Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Similar indicates an expected call of Similar.
+func (mr *MockstorageMockRecorder) Similar(id, priceBand, rates, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Similar", reflect.TypeOf((*Mockstorage)(nil).Similar), id, priceBand, rates, limit)
+}
+
 // Update mocks base method.
-func (m *Mockstorage) Update(post *Post) error {
+func (m *Mockstorage) Update(post *Post) (*PriceChange, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", post)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
@@ -104,3 +183,167 @@ func (mr *MockstorageMockRecorder) Update(post interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*Mockstorage)(nil).Update), post)
 }
+
+// MockratesProvider is a mock of ratesProvider interface.
+type MockratesProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockratesProviderMockRecorder
+}
+
+// MockratesProviderMockRecorder is the mock recorder for MockratesProvider.
+type MockratesProviderMockRecorder struct {
+	mock *MockratesProvider
+}
+
+// NewMockratesProvider creates a new mock instance.
+func NewMockratesProvider(ctrl *gomock.Controller) *MockratesProvider {
+	mock := &MockratesProvider{ctrl: ctrl}
+	mock.recorder = &MockratesProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockratesProvider) EXPECT() *MockratesProviderMockRecorder {
+	return m.recorder
+}
+
+// Rates mocks base method.
+func (m *MockratesProvider) Rates() (*money.Rates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rates")
+	ret0, _ := ret[0].(*money.Rates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rates indicates an expected call of Rates.
+func (mr *MockratesProviderMockRecorder) Rates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rates", reflect.TypeOf((*MockratesProvider)(nil).Rates))
+}
+
+// MockcontentFilter is a mock of contentFilter interface.
+type MockcontentFilter struct {
+	ctrl     *gomock.Controller
+	recorder *MockcontentFilterMockRecorder
+}
+
+// MockcontentFilterMockRecorder is the mock recorder for MockcontentFilter.
+type MockcontentFilterMockRecorder struct {
+	mock *MockcontentFilter
+}
+
+// NewMockcontentFilter creates a new mock instance.
+func NewMockcontentFilter(ctrl *gomock.Controller) *MockcontentFilter {
+	mock := &MockcontentFilter{ctrl: ctrl}
+	mock.recorder = &MockcontentFilterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcontentFilter) EXPECT() *MockcontentFilterMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockcontentFilter) Check(doc *content.Document) *content.Verdict {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", doc)
+	ret0, _ := ret[0].(*content.Verdict)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockcontentFilterMockRecorder) Check(doc interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockcontentFilter)(nil).Check), doc)
+}
+
+// Mockquotas is a mock of quotas interface.
+type Mockquotas struct {
+	ctrl     *gomock.Controller
+	recorder *MockquotasMockRecorder
+}
+
+// MockquotasMockRecorder is the mock recorder for Mockquotas.
+type MockquotasMockRecorder struct {
+	mock *Mockquotas
+}
+
+// NewMockquotas creates a new mock instance.
+func NewMockquotas(ctrl *gomock.Controller) *Mockquotas {
+	mock := &Mockquotas{ctrl: ctrl}
+	mock.recorder = &MockquotasMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockquotas) EXPECT() *MockquotasMockRecorder {
+	return m.recorder
+}
+
+// ConsumeListing mocks base method.
+func (m *Mockquotas) ConsumeListing(login string, create func(*sql.Tx) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeListing", login, create)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeListing indicates an expected call of ConsumeListing.
+func (mr *MockquotasMockRecorder) ConsumeListing(login, create interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeListing", reflect.TypeOf((*Mockquotas)(nil).ConsumeListing), login, create)
+}
+
+// Mockblocks is a mock of blocks interface.
+type Mockblocks struct {
+	ctrl     *gomock.Controller
+	recorder *MockblocksMockRecorder
+}
+
+// MockblocksMockRecorder is the mock recorder for Mockblocks.
+type MockblocksMockRecorder struct {
+	mock *Mockblocks
+}
+
+// NewMockblocks creates a new mock instance.
+func NewMockblocks(ctrl *gomock.Controller) *Mockblocks {
+	mock := &Mockblocks{ctrl: ctrl}
+	mock.recorder = &MockblocksMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockblocks) EXPECT() *MockblocksMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *Mockblocks) Check(a, b string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", a, b)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockblocksMockRecorder) Check(a, b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*Mockblocks)(nil).Check), a, b)
+}
+
+// ListBlocked mocks base method.
+func (m *Mockblocks) ListBlocked(blocker string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBlocked", blocker)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBlocked indicates an expected call of ListBlocked.
+func (mr *MockblocksMockRecorder) ListBlocked(blocker interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBlocked", reflect.TypeOf((*Mockblocks)(nil).ListBlocked), blocker)
+}
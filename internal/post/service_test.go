@@ -1,12 +1,21 @@
 package post
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	gomock "github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/block"
+	"github.com/TemirB/rest-api-marketplace/internal/content"
+	"github.com/TemirB/rest-api-marketplace/internal/quota"
+	"github.com/TemirB/rest-api-marketplace/pkg/geo"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
 )
 
 func Test_CreatePost(t *testing.T) {
@@ -27,7 +36,7 @@ func Test_CreatePost(t *testing.T) {
 			post: &Post{
 				Title:       "Test post",
 				Description: "This is a test post",
-				Price:       100.50,
+				Price:       money.MustParse("100.50", "RUB"),
 				ImageURL:    "https://example.com/image.jpg",
 			},
 
@@ -40,7 +49,7 @@ func Test_CreatePost(t *testing.T) {
 
 			post: &Post{
 				Description: "This is a test post",
-				Price:       100.50,
+				Price:       money.MustParse("100.50", "RUB"),
 				ImageURL:    "https://example.com/image.jpg",
 			},
 			setupMocks: func(storage *Mockstorage, post *Post) {},
@@ -62,32 +71,102 @@ func Test_CreatePost(t *testing.T) {
 			post: &Post{
 				Title:       "Test post",
 				Description: "This is a test post",
-				Price:       -100.50,
+				Price:       money.MustParse("-100.50", "RUB"),
+				ImageURL:    "https://example.com/image.jpg",
+			},
+			setupMocks: func(storage *Mockstorage, post *Post) {},
+
+			expectedError: ErrNegativePrice,
+		},
+		{
+			name: "Zero price",
+
+			post: &Post{
+				Title:       "Test post",
+				Description: "This is a test post",
+				Price:       money.MustParse("0", "RUB"),
 				ImageURL:    "https://example.com/image.jpg",
 			},
 			setupMocks: func(storage *Mockstorage, post *Post) {},
 
 			expectedError: ErrNegativePrice,
 		},
+		{
+			name: "Too many decimal places for currency",
+
+			post: &Post{
+				Title:       "Test post",
+				Description: "This is a test post",
+				Price:       money.New(money.NewDecimal(10050, 2), "JPY"),
+				ImageURL:    "https://example.com/image.jpg",
+			},
+			setupMocks: func(storage *Mockstorage, post *Post) {},
+
+			expectedError: ErrInvalidPrice,
+		},
+		{
+			name: "Invalid location",
+
+			post: &Post{
+				Title:       "Test post",
+				Description: "This is a test post",
+				Price:       money.MustParse("100.50", "RUB"),
+				ImageURL:    "https://example.com/image.jpg",
+				Location:    &Location{Lat: 120, Lon: 30},
+			},
+			setupMocks: func(storage *Mockstorage, post *Post) {},
+
+			expectedError: geo.ErrInvalidPoint,
+		},
 		{
 			name: "Missing image URL",
 
 			post: &Post{
 				Title:       "Test post",
 				Description: "This is a test post",
-				Price:       100.50,
+				Price:       money.MustParse("100.50", "RUB"),
 			},
 			setupMocks: func(storage *Mockstorage, post *Post) {},
 
 			expectedError: ErrRequiredURL,
 		},
+		{
+			name: "Quantity defaults to one",
+
+			post: &Post{
+				Title:       "Test post",
+				Description: "This is a test post",
+				Price:       money.MustParse("100.50", "RUB"),
+				ImageURL:    "https://example.com/image.jpg",
+			},
+			setupMocks: func(storage *Mockstorage, post *Post) {
+				storage.EXPECT().Create(gomock.Any()).DoAndReturn(func(p *Post) error {
+					assert.Equal(t, 1, p.Quantity)
+					return nil
+				})
+			},
+		},
+		{
+			name: "Negative quantity",
+
+			post: &Post{
+				Title:       "Test post",
+				Description: "This is a test post",
+				Price:       money.MustParse("100.50", "RUB"),
+				ImageURL:    "https://example.com/image.jpg",
+				Quantity:    -5,
+			},
+			setupMocks: func(storage *Mockstorage, post *Post) {},
+
+			expectedError: ErrInvalidQuantity,
+		},
 		{
 			name: "Storage error",
 
 			post: &Post{
 				Title:       "Test post",
 				Description: "This is a test post",
-				Price:       100.50,
+				Price:       money.MustParse("100.50", "RUB"),
 				ImageURL:    "https://example.com/image.jpg",
 			},
 			setupMocks: func(storage *Mockstorage, post *Post) {
@@ -113,3 +192,404 @@ func Test_CreatePost(t *testing.T) {
 		})
 	}
 }
+
+type stubRates struct {
+	rates *money.Rates
+	err   error
+}
+
+func (s *stubRates) Rates() (*money.Rates, error) {
+	return s.rates, s.err
+}
+
+func Test_GetPosts_Currency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rates := &money.Rates{
+		Base: money.RUB,
+		Rates: map[money.Currency]money.Decimal{
+			money.USD: money.NewDecimal(125, 4),
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		provider   ratesProvider
+		filter     *FilterParams
+		setupMocks func(storage *Mockstorage)
+
+		expectedDisplay []string
+		expectedError   error
+	}{
+		{
+			name:     "1. Converted_Display_Prices",
+			provider: &stubRates{rates: rates},
+			filter:   &FilterParams{MaxPrice: -1, Currency: money.USD},
+			setupMocks: func(storage *Mockstorage) {
+				storage.EXPECT().GetAll(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(sort *SortParams, filter *FilterParams) ([]*Post, error) {
+						assert.Same(t, rates, filter.Rates)
+						return []*Post{
+							{ID: 1, Price: money.MustParse("100", "RUB")},
+							{ID: 2, Price: money.MustParse("3", "USD")},
+							{ID: 3, Price: money.MustParse("3", "GBP")},
+						}, nil
+					},
+				)
+			},
+
+			expectedDisplay: []string{"1.25 USD", "3.00 USD", ""},
+		},
+		{
+			name:       "2. No_Provider",
+			filter:     &FilterParams{MaxPrice: -1, Currency: money.USD},
+			setupMocks: func(storage *Mockstorage) {},
+
+			expectedError: ErrRatesUnavailable,
+		},
+		{
+			name:       "3. Rates_Not_Loaded",
+			provider:   &stubRates{err: fmt.Errorf("not loaded")},
+			filter:     &FilterParams{MaxPrice: -1, Currency: money.USD},
+			setupMocks: func(storage *Mockstorage) {},
+
+			expectedError: ErrRatesUnavailable,
+		},
+		{
+			name:       "4. Currency_Without_Rate",
+			provider:   &stubRates{rates: rates},
+			filter:     &FilterParams{MaxPrice: -1, Currency: "GBP"},
+			setupMocks: func(storage *Mockstorage) {},
+
+			expectedError: ErrNoRateForCurrency,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storage := NewMockstorage(ctrl)
+			tc.setupMocks(storage)
+			var opts []Option
+			if tc.provider != nil {
+				opts = append(opts, WithRates(tc.provider))
+			}
+			service := NewService(storage, zap.NewNop(), opts...)
+
+			posts, err := service.GetPosts(nil, tc.filter)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			for i, p := range posts {
+				if tc.expectedDisplay[i] == "" {
+					assert.Nil(t, p.DisplayPrice)
+					continue
+				}
+				assert.Equal(t, tc.expectedDisplay[i], p.DisplayPrice.String())
+			}
+		})
+	}
+}
+
+func Test_UpdatePost_PriceChangeHook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	post := &Post{
+		ID:          1,
+		Title:       "Bike",
+		Description: "City bike",
+		Price:       money.MustParse("80", "RUB"),
+		ImageURL:    "https://example.com/image.jpg",
+		Quantity:    1,
+	}
+	change := &PriceChange{PostID: 1, OldPrice: money.MustParse("100", "RUB"), NewPrice: post.Price}
+
+	testCases := []struct {
+		name string
+
+		change *PriceChange
+
+		expectedCalls int
+	}{
+		{name: "1. Price_Changed", change: change, expectedCalls: 1},
+		{name: "2. Price_Unchanged", change: nil, expectedCalls: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storage := NewMockstorage(ctrl)
+			storage.EXPECT().Update(post).Return(tc.change, nil)
+
+			var calls int
+			service := NewService(storage, zap.NewNop(), WithOnPriceChange(func(p *Post, c *PriceChange) {
+				assert.Equal(t, post, p)
+				assert.Equal(t, change, c)
+				calls++
+			}))
+
+			assert.NoError(t, service.UpdatePost(post))
+			assert.Equal(t, tc.expectedCalls, calls)
+		})
+	}
+}
+
+func Test_GetPriceHistory_PostNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := NewMockstorage(ctrl)
+	storage.EXPECT().GetByID(uint(9)).Return(nil, ErrPostNotFound)
+
+	_, err := NewService(storage, zap.NewNop()).GetPriceHistory(9, "bob")
+	assert.ErrorIs(t, err, ErrPostNotFound)
+}
+
+func Test_GetPriceHistory_Hidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := NewMockstorage(ctrl)
+	storage.EXPECT().GetByID(uint(8)).Return(&Post{ID: 8, Owner: "alice", Hidden: true}, nil).Times(2)
+	storage.EXPECT().PriceHistory(uint(8)).Return([]*PriceChange{}, nil)
+	service := NewService(storage, zap.NewNop())
+
+	// Историю скрытого поста видит только владелец
+	_, err := service.GetPriceHistory(8, "bob")
+	assert.ErrorIs(t, err, ErrPostNotFound)
+	_, err = service.GetPriceHistory(8, "alice")
+	assert.NoError(t, err)
+}
+
+func Test_PostView_Blocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		viewer     string
+		setupMocks func(blocks *Mockblocks)
+
+		expectedErr error
+	}{
+		{
+			name:   "1. Not_Blocked",
+			viewer: "bob",
+			setupMocks: func(blocks *Mockblocks) {
+				blocks.EXPECT().Check("bob", "alice").Return(nil).Times(2)
+			},
+		},
+		{
+			name:   "2. Blocked",
+			viewer: "bob",
+			setupMocks: func(blocks *Mockblocks) {
+				blocks.EXPECT().Check("bob", "alice").Return(block.ErrBlocked).Times(2)
+			},
+			expectedErr: ErrPostNotFound,
+		},
+		{
+			name:       "3. Owner",
+			viewer:     "alice",
+			setupMocks: func(blocks *Mockblocks) {},
+		},
+		{
+			name:       "4. Anonymous",
+			viewer:     "",
+			setupMocks: func(blocks *Mockblocks) {},
+		},
+		{
+			name:   "5. Blocks_Error",
+			viewer: "bob",
+			setupMocks: func(blocks *Mockblocks) {
+				blocks.EXPECT().Check("bob", "alice").Return(errors.New("db is down")).Times(2)
+			},
+			expectedErr: errors.New("db is down"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storage := NewMockstorage(ctrl)
+			blocks := NewMockblocks(ctrl)
+			tc.setupMocks(blocks)
+			p := &Post{ID: 7, Owner: "alice"}
+			storage.EXPECT().GetByIDFor(uint(7), tc.viewer).Return(p, nil)
+			storage.EXPECT().GetByID(uint(7)).Return(p, nil)
+			if tc.expectedErr == nil {
+				storage.EXPECT().PriceHistory(uint(7)).Return([]*PriceChange{}, nil)
+			}
+			service := NewService(storage, zap.NewNop(), WithBlocks(blocks))
+
+			_, errPost := service.GetPostFor(7, tc.viewer)
+			_, errHistory := service.GetPriceHistory(7, tc.viewer)
+			if tc.expectedErr == nil {
+				assert.NoError(t, errPost)
+				assert.NoError(t, errHistory)
+				return
+			}
+			assert.EqualError(t, errPost, tc.expectedErr.Error())
+			assert.EqualError(t, errHistory, tc.expectedErr.Error())
+		})
+	}
+}
+
+func Test_CreatePost_ContentFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newPost := func() *Post {
+		return &Post{
+			Title:       "Bike",
+			Description: "Call me +7 999 123 45 67",
+			Price:       money.MustParse("80", "RUB"),
+			ImageURL:    "https://example.com/image.jpg",
+			Owner:       "alice",
+		}
+	}
+	reason := content.Reason{Check: "contacts", Code: "phone", Message: "phone numbers are not allowed"}
+
+	testCases := []struct {
+		name string
+
+		verdict    *content.Verdict
+		setupMocks func(storage *Mockstorage)
+
+		expectedFlags   int
+		expectedReasons []content.Reason
+	}{
+		{
+			name:    "1. Allowed",
+			verdict: &content.Verdict{Action: content.ActionAllow},
+			setupMocks: func(storage *Mockstorage) {
+				storage.EXPECT().Create(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "2. Flagged",
+			verdict: &content.Verdict{Action: content.ActionFlag, Reasons: []content.Reason{reason}},
+			setupMocks: func(storage *Mockstorage) {
+				storage.EXPECT().Create(gomock.Any()).Return(nil)
+			},
+			expectedFlags: 1,
+		},
+		{
+			name:            "3. Rejected",
+			verdict:         &content.Verdict{Action: content.ActionReject, Reasons: []content.Reason{reason}},
+			setupMocks:      func(storage *Mockstorage) {},
+			expectedReasons: []content.Reason{reason},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storage := NewMockstorage(ctrl)
+			tc.setupMocks(storage)
+			filter := NewMockcontentFilter(ctrl)
+			filter.EXPECT().Check(&content.Document{
+				Owner:       "alice",
+				Title:       "Bike",
+				Description: "Call me +7 999 123 45 67",
+			}).Return(tc.verdict)
+
+			var flagged int
+			service := NewService(storage, zap.NewNop(),
+				WithContentFilter(filter),
+				WithOnFlag(func(p *Post) { flagged++ }),
+			)
+
+			p, err := service.CreatePost(newPost())
+			if tc.expectedReasons != nil {
+				var contentErr *ContentError
+				assert.ErrorAs(t, err, &contentErr)
+				assert.Equal(t, tc.expectedReasons, contentErr.Reasons)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, p.ContentFlags, tc.expectedFlags)
+			assert.Equal(t, tc.expectedFlags, flagged)
+		})
+	}
+}
+
+func Test_CreatePost_Quota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newPost := func() *Post {
+		return &Post{
+			Title:       "Bike",
+			Description: "City bike",
+			Price:       money.MustParse("80", "RUB"),
+			ImageURL:    "https://example.com/image.jpg",
+			Owner:       "alice",
+		}
+	}
+	exceeded := &quota.ExceededError{Limit: quota.LimitHourly, RetryAfter: time.Minute}
+
+	testCases := []struct {
+		name string
+
+		quotaErr   error
+		setupMocks func(storage *Mockstorage)
+
+		expectedErr error
+	}{
+		{
+			name: "1. Within_Quota",
+			setupMocks: func(storage *Mockstorage) {
+				storage.EXPECT().CreateTx(nil, gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:        "2. Quota_Exceeded",
+			quotaErr:    exceeded,
+			setupMocks:  func(storage *Mockstorage) {},
+			expectedErr: exceeded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			storage := NewMockstorage(ctrl)
+			tc.setupMocks(storage)
+			quotas := NewMockquotas(ctrl)
+			quotas.EXPECT().ConsumeListing("alice", gomock.Any()).DoAndReturn(
+				func(login string, create func(*sql.Tx) error) error {
+					if tc.quotaErr != nil {
+						return tc.quotaErr
+					}
+					return create(nil)
+				},
+			)
+
+			service := NewService(storage, zap.NewNop(), WithQuotas(quotas))
+			_, err := service.CreatePost(newPost())
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func Test_GetPostFor_OnView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := NewMockstorage(ctrl)
+	storage.EXPECT().GetByIDFor(uint(7), "bob").Return(&Post{ID: 7, Owner: "alice", Promotion: PromotionFeatured}, nil)
+	storage.EXPECT().GetByIDFor(uint(8), "bob").Return(&Post{ID: 8, Owner: "alice", Hidden: true}, nil)
+
+	var viewed []uint
+	service := NewService(storage, zap.NewNop(), WithOnView(func(p *Post, viewer string) {
+		assert.Equal(t, "bob", viewer)
+		viewed = append(viewed, p.ID)
+	}))
+
+	_, err := service.GetPostFor(7, "bob")
+	assert.NoError(t, err)
+	_, err = service.GetPostFor(8, "bob")
+	assert.ErrorIs(t, err, ErrPostNotFound)
+	assert.Equal(t, []uint{7}, viewed)
+}
@@ -0,0 +1,132 @@
+package post
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+	"go.uber.org/zap"
+)
+
+// maxSimilarCached ограничивает число постов, для которых хранится выдача похожих.
+const maxSimilarCached = 10000
+
+// SimilarParams — настройки выдачи похожих объявлений.
+type SimilarParams struct {
+	Limit int
+	// PriceBand — на какую долю цена похожего поста может отличаться от цены исходного
+	PriceBand float64
+	// CacheTTL — сколько хранится выдача для поста; 0 — без кэша
+	CacheTTL time.Duration
+}
+
+var defaultSimilar = SimilarParams{Limit: 12, PriceBand: 0.5}
+
+type similarEntry struct {
+	posts   []*Post
+	expires time.Time
+}
+
+// similarCache хранит выдачу похожих постов, чтобы открытие популярного поста не гоняло
+// полнотекстовый запрос каждый раз.
+type similarCache struct {
+	mu      sync.Mutex
+	entries map[uint]similarEntry
+}
+
+func (c *similarCache) get(id uint, now time.Time) ([]*Post, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[id]
+	if !ok || !now.Before(e.expires) {
+		return nil, false
+	}
+	return e.posts, true
+}
+
+func (c *similarCache) put(id uint, posts []*Post, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[uint]similarEntry)
+	}
+	if len(c.entries) >= maxSimilarCached {
+		for k, e := range c.entries {
+			if e.expires.Before(expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	if len(c.entries) >= maxSimilarCached {
+		clear(c.entries)
+	}
+	c.entries[id] = similarEntry{posts: posts, expires: expires}
+}
+
+// GetSimilar возвращает активные посты других продавцов с общими словами в заголовке
+// или описании и ценой в пределах PriceBand от цены поста id, самые похожие первыми.
+// Цены в разных валютах сравниваются по курсам; без курсов — только в одной валюте.
+// Категорий у постов нет, поэтому категория в подборе не участвует.
+// Скрытый модерацией пост видит только владелец, поэтому для остальных его нет.
+// Посты пользователей, которых зритель заблокировал, из выдачи убираются.
+func (s *Service) GetSimilar(id uint, viewer string) ([]*Post, error) {
+	p, err := s.repository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Hidden && p.Owner != viewer {
+		return nil, ErrPostNotFound
+	}
+
+	now := time.Now()
+	similar, ok := s.similarCache.get(id, now)
+	if !ok {
+		similar, err = s.repository.Similar(id, s.similar.PriceBand, s.similarRates(), s.similar.Limit)
+		if err != nil {
+			return nil, err
+		}
+		if s.similar.CacheTTL > 0 {
+			s.similarCache.put(id, similar, now.Add(s.similar.CacheTTL))
+		}
+	}
+
+	var blocked []string
+	if s.blocks != nil && viewer != "" {
+		blocked, err = s.blocks.ListBlocked(viewer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Выдача в кэше общая для всех зрителей, поэтому фильтр и флаги зрителя применяются к копиям
+	res := make([]*Post, 0, len(similar))
+	for _, sp := range similar {
+		if slices.Contains(blocked, sp.Owner) {
+			continue
+		}
+		cp := *sp
+		cp.IsOwner = cp.Owner == viewer
+		res = append(res, &cp)
+	}
+	return res, nil
+}
+
+// similarRates возвращает курсы для сравнения цен похожих постов. Без курсов выдача
+// всё равно строится, но сравнивает цены только в валюте исходного поста.
+func (s *Service) similarRates() *money.Rates {
+	if s.rates == nil {
+		return nil
+	}
+	rates, err := s.rates.Rates()
+	if err != nil {
+		s.logger.Warn(
+			"Failed to get exchange rates for similar posts",
+			zap.Error(err),
+		)
+		return nil
+	}
+	return rates
+}
@@ -0,0 +1,138 @@
+package post
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func Test_GetSimilar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	similar := []*Post{{ID: 8, Owner: "bob"}, {ID: 9, Owner: "carol"}}
+
+	storage := NewMockstorage(ctrl)
+	storage.EXPECT().GetByID(uint(7)).Return(&Post{ID: 7, Owner: "alice"}, nil).Times(2)
+	// Вторая выдача берётся из кэша
+	storage.EXPECT().Similar(uint(7), 0.3, nil, 5).Return(similar, nil).Times(1)
+
+	service := NewService(storage, zap.NewNop(), WithSimilar(SimilarParams{
+		Limit:     5,
+		PriceBand: 0.3,
+		CacheTTL:  time.Minute,
+	}))
+
+	posts, err := service.GetSimilar(7, "bob")
+	assert.NoError(t, err)
+	assert.Len(t, posts, 2)
+	assert.True(t, posts[0].IsOwner)
+	assert.False(t, posts[1].IsOwner)
+
+	posts, err = service.GetSimilar(7, "")
+	assert.NoError(t, err)
+	assert.False(t, posts[0].IsOwner)
+	// Флаги зрителя не попадают в кэш
+	assert.False(t, similar[0].IsOwner)
+}
+
+func Test_GetSimilar_NoCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := NewMockstorage(ctrl)
+	storage.EXPECT().GetByID(uint(7)).Return(&Post{ID: 7, Owner: "alice"}, nil).Times(2)
+	storage.EXPECT().Similar(uint(7), defaultSimilar.PriceBand, nil, defaultSimilar.Limit).Return([]*Post{}, nil).Times(2)
+
+	service := NewService(storage, zap.NewNop())
+	for range 2 {
+		posts, err := service.GetSimilar(7, "bob")
+		assert.NoError(t, err)
+		assert.Empty(t, posts)
+	}
+}
+
+func Test_GetSimilar_Hidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := NewMockstorage(ctrl)
+	storage.EXPECT().GetByID(uint(7)).Return(&Post{ID: 7, Owner: "alice", Hidden: true}, nil).Times(2)
+	storage.EXPECT().Similar(uint(7), gomock.Any(), gomock.Any(), gomock.Any()).Return([]*Post{}, nil)
+
+	service := NewService(storage, zap.NewNop())
+
+	_, err := service.GetSimilar(7, "bob")
+	assert.ErrorIs(t, err, ErrPostNotFound)
+	_, err = service.GetSimilar(7, "alice")
+	assert.NoError(t, err)
+}
+
+func Test_GetSimilar_Blocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storage := NewMockstorage(ctrl)
+	storage.EXPECT().GetByID(uint(7)).Return(&Post{ID: 7, Owner: "alice"}, nil).Times(2)
+	storage.EXPECT().Similar(uint(7), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]*Post{{ID: 8, Owner: "bob"}, {ID: 9, Owner: "carol"}}, nil).Times(2)
+	blocks := NewMockblocks(ctrl)
+	blocks.EXPECT().ListBlocked("dave").Return([]string{"carol"}, nil)
+
+	service := NewService(storage, zap.NewNop(), WithBlocks(blocks))
+
+	posts, err := service.GetSimilar(7, "dave")
+	assert.NoError(t, err)
+	assert.Len(t, posts, 1)
+	assert.Equal(t, "bob", posts[0].Owner)
+
+	// Анониму блокировки не применяются
+	posts, err = service.GetSimilar(7, "")
+	assert.NoError(t, err)
+	assert.Len(t, posts, 2)
+}
+
+func Test_GetSimilar_Rates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rates := &money.Rates{Base: "RUB", Rates: map[money.Currency]money.Decimal{"USD": money.NewDecimal(125, 4)}}
+
+	storage := NewMockstorage(ctrl)
+	storage.EXPECT().GetByID(uint(7)).Return(&Post{ID: 7, Owner: "alice"}, nil).Times(2)
+	ratesProvider := NewMockratesProvider(ctrl)
+	gomock.InOrder(
+		ratesProvider.EXPECT().Rates().Return(rates, nil),
+		storage.EXPECT().Similar(uint(7), defaultSimilar.PriceBand, rates, defaultSimilar.Limit).Return([]*Post{}, nil),
+		// Без курсов выдача строится, цены сравниваются только в одной валюте
+		ratesProvider.EXPECT().Rates().Return(nil, errors.New("rates file is missing")),
+		storage.EXPECT().Similar(uint(7), defaultSimilar.PriceBand, nil, defaultSimilar.Limit).Return([]*Post{}, nil),
+	)
+
+	service := NewService(storage, zap.NewNop(), WithRates(ratesProvider))
+	for range 2 {
+		_, err := service.GetSimilar(7, "bob")
+		assert.NoError(t, err)
+	}
+}
+
+func Test_similarCache(t *testing.T) {
+	var c similarCache
+	now := time.Now()
+
+	_, ok := c.get(1, now)
+	assert.False(t, ok)
+
+	c.put(1, []*Post{{ID: 2}}, now.Add(time.Minute))
+	posts, ok := c.get(1, now)
+	assert.True(t, ok)
+	assert.Len(t, posts, 1)
+
+	_, ok = c.get(1, now.Add(time.Minute))
+	assert.False(t, ok)
+}
@@ -5,13 +5,171 @@ package post
 import (
 	"database/sql"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/geo"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+var (
+	ErrPostNotFound  = errors.New("post not found")
+	ErrPostHasOrders = errors.New("post has orders and cannot be deleted")
 )
 
-var ErrPostNotFound = errors.New("post not found")
+const (
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+)
+
+// recentByOwnerLimit ограничивает выборку для поиска дублей: сравнение идёт в памяти.
+const recentByOwnerLimit = 200
+
+const postColumns = "id, title, description, price, currency, image_url, lat, lon, place, owner, created_at, status, listing_type, quantity, quantity_sold, hidden, duplicate_of," +
+	" (SELECT COUNT(*) FROM favorites f WHERE f.post_id = posts.id) AS favorites_count," +
+	" (SELECT CASE WHEN h.old_currency = posts.currency AND h.old_price > posts.price THEN h.old_price END" +
+	" FROM price_history h WHERE h.post_id = posts.id ORDER BY h.id DESC LIMIT 1) AS reduced_from," +
+	" seller_rating::float8, seller_reviews, promotion"
+
+// postsFrom — источник строк для postColumns: к постам один раз присоединяются денормализованный
+// рейтинг продавца из users и действующее продвижение (featured важнее highlighted). Подзапросы
+// отдают только свои колонки, поэтому имена колонок постов остаются однозначными.
+const postsFrom = "posts" +
+	" LEFT JOIN (SELECT login AS seller_login, seller_rating, seller_reviews FROM users) seller" +
+	" ON seller.seller_login = posts.owner" +
+	" LEFT JOIN (SELECT DISTINCT ON (pr.post_id) pr.post_id AS promoted_id, pr.kind AS promotion FROM promotions pr" +
+	" WHERE pr.status = 'active' AND pr.ends_at > NOW() ORDER BY pr.post_id, pr.kind = 'featured' DESC) promoted" +
+	" ON promoted.promoted_id = posts.id"
+
+// featuredQuery — очередь показа продвигаемых постов: реже показанные первыми.
+const featuredQuery = `
+	SELECT pr.post_id FROM promotions pr
+	WHERE pr.kind = 'featured' AND pr.status = 'active' AND pr.ends_at > NOW()
+	ORDER BY pr.impressions, pr.id
+`
+
+// similarQuery выбирает посты, похожие на пост $1: с общими словами (от трёх букв) в заголовке
+// или описании — по колонке terms с GIN-индексом — и с ценой от $2 до $3 цены исходного.
+// Цена исходного поста считается выражением %[1]s, цена кандидата — %[2]s (см. Similar). Посты того же
+// продавца, скрытые и не активные не подходят.
+const similarQuery = `
+	WITH src AS (
+		SELECT id AS src_id, owner AS src_owner, currency AS src_currency, %[1]s AS src_price,
+			(SELECT to_tsquery('simple', string_agg(quote_literal(l), ' | '))
+				FROM unnest(tsvector_to_array(terms)) AS l WHERE length(l) >= 3) AS q
+		FROM posts WHERE id = $1
+	)
+	SELECT ` + postColumns + ` FROM ` + postsFrom + `, src
+	WHERE posts.terms @@ src.q
+		AND posts.id <> src_id AND posts.owner <> src_owner
+		AND posts.status = 'active' AND NOT posts.hidden
+		AND %[2]s BETWEEN src_price * $2::numeric AND src_price * $3::numeric
+	ORDER BY ts_rank(posts.terms, src.q) DESC, abs(%[2]s - src_price), posts.id DESC
+	LIMIT $4
+`
+
+// isFavoriteColumn — флаг избранного для зрителя, логин которого передаётся параметром $n.
+func isFavoriteColumn(idx int) string {
+	return fmt.Sprintf(", EXISTS(SELECT 1 FROM favorites f WHERE f.post_id = posts.id AND f.login = $%d) AS is_favorite", idx)
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanPost читает строку в порядке postColumns, затем дополнительные колонки в extra.
+// Цена хранится в NUMERIC, поэтому сканируем её строкой, чтобы не терять точность.
+func scanPost(row rowScanner, post *Post, extra ...any) error {
+	var (
+		price, currency string
+		lat, lon        sql.NullFloat64
+		place           sql.NullString
+		reducedFrom     sql.NullString
+		sellerRating    sql.NullFloat64
+		duplicateOf     sql.NullInt64
+		promotion       sql.NullString
+	)
+	dest := []any{
+		&post.ID,
+		&post.Title,
+		&post.Description,
+		&price,
+		&currency,
+		&post.ImageURL,
+		&lat,
+		&lon,
+		&place,
+		&post.Owner,
+		&post.CreatedAt,
+		&post.Status,
+		&post.ListingType,
+		&post.Quantity,
+		&post.QuantitySold,
+		&post.Hidden,
+		&duplicateOf,
+		&post.FavoritesCount,
+		&reducedFrom,
+		&sellerRating,
+		&post.SellerReviews,
+		&promotion,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+
+	amount, err := money.ParseDecimal(price)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse price")
+	}
+	post.Price = money.New(amount, money.Currency(currency))
+
+	post.ReducedFrom = nil
+	if reducedFrom.Valid {
+		old, err := money.ParseDecimal(reducedFrom.String)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse previous price")
+		}
+		m := money.New(old, post.Price.Currency())
+		post.ReducedFrom = &m
+	}
+
+	post.DuplicateOf = nil
+	if duplicateOf.Valid {
+		id := uint(duplicateOf.Int64)
+		post.DuplicateOf = &id
+	}
+
+	post.Promotion = promotion.String
+
+	post.SellerRating = nil
+	if sellerRating.Valid {
+		post.SellerRating = &sellerRating.Float64
+	}
+
+	post.Location = nil
+	if lat.Valid && lon.Valid {
+		post.Location = &Location{Lat: lat.Float64, Lon: lon.Float64, Place: place.String}
+	}
+	return nil
+}
+
+// locationArgs возвращает lat, lon и place для записи в БД; NULL, если места нет.
+func locationArgs(post *Post) (any, any, any) {
+	if post.Location == nil {
+		return nil, nil, nil
+	}
+	var place any
+	if post.Location.Place != "" {
+		place = post.Location.Place
+	}
+	return post.Location.Lat, post.Location.Lon, place
+}
 
 type Repository interface {
 	Exec(query string, args ...any) (sql.Result, error)
@@ -19,32 +177,69 @@ type Repository interface {
 	Query(query string, args ...any) (*sql.Rows, error)
 }
 
+// rowQuerier — общее у Repository и *sql.Tx.
+type rowQuerier interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
 type Storage struct {
 	repository Repository
-	logger     *zap.Logger
+	// feedSlots — позиции ленты (с нуля) для продвигаемых постов
+	feedSlots []int
+	logger    *zap.Logger
+}
+
+// StorageOption настраивает хранилище.
+type StorageOption func(*Storage)
+
+// WithFeedSlots задаёт позиции ленты (с нуля), на которые GetAll ставит продвигаемые посты.
+func WithFeedSlots(slots []int) StorageOption {
+	return func(r *Storage) {
+		r.feedSlots = slots
+	}
 }
 
-func NewStorage(repository Repository, logger *zap.Logger) *Storage {
-	return &Storage{
+func NewStorage(repository Repository, logger *zap.Logger, opts ...StorageOption) *Storage {
+	r := &Storage{
 		repository: repository,
 		logger:     logger,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 func (r *Storage) Create(post *Post) error {
+	return r.create(r.repository, post)
+}
+
+// CreateTx создаёт пост внутри транзакции tx, например вместе со списанием квоты.
+func (r *Storage) CreateTx(tx *sql.Tx, post *Post) error {
+	return r.create(tx, post)
+}
+
+func (r *Storage) create(q rowQuerier, post *Post) error {
 	query := `
-		INSERT INTO posts (title, description, price, image_url, owner)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, created_at
+		INSERT INTO posts (title, description, price, currency, image_url, lat, lon, place, owner, quantity, duplicate_of)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at, status, listing_type, quantity_sold
 	`
-	err := r.repository.QueryRow(
+	lat, lon, place := locationArgs(post)
+	err := q.QueryRow(
 		query,
 		post.Title,
 		post.Description,
-		post.Price,
+		post.Price.Amount().String(),
+		post.Price.Currency(),
 		post.ImageURL,
+		lat,
+		lon,
+		place,
 		post.Owner,
-	).Scan(&post.ID, &post.CreatedAt)
+		post.Quantity,
+		post.DuplicateOf,
+	).Scan(&post.ID, &post.CreatedAt, &post.Status, &post.ListingType, &post.QuantitySold)
 
 	if err != nil {
 		r.logger.Error(
@@ -56,23 +251,80 @@ func (r *Storage) Create(post *Post) error {
 	return nil
 }
 
+// RecentByOwner возвращает активные видимые посты владельца, созданные после since, новые первыми.
+func (r *Storage) RecentByOwner(owner string, since time.Time) ([]*Post, error) {
+	query := `SELECT ` + postColumns + ` FROM ` + postsFrom + `
+		WHERE owner = $1 AND created_at >= $2 AND status = 'active' AND NOT hidden
+		ORDER BY id DESC
+		LIMIT $3`
+
+	rows, err := r.repository.Query(query, owner, since, recentByOwnerLimit)
+	if err != nil {
+		r.logger.Error(
+			"Failed to get recent posts of owner",
+			zap.String("owner", owner),
+			zap.Error(err),
+		)
+		return nil, errors.Errorf("failed to get recent posts of owner: %v", err)
+	}
+	defer rows.Close()
+
+	var posts []*Post
+	for rows.Next() {
+		var post Post
+		if err := scanPost(rows, &post); err != nil {
+			return nil, errors.Errorf("failed to scan post: %v", err)
+		}
+		posts = append(posts, &post)
+	}
+	return posts, rows.Err()
+}
+
+// Similar возвращает до limit постов, похожих на пост id, с ценой, отличающейся не больше
+// чем на долю priceBand. Цены разных валют сравниваются по курсам rates; посты, цену которых
+// перевести нельзя (а без rates — все в другой валюте), не проходят ценовое условие.
+// Выдача строится по словам, поэтому пост без текста похожих не имеет.
+func (r *Storage) Similar(id uint, priceBand float64, rates *money.Rates, limit int) ([]*Post, error) {
+	lo := strconv.FormatFloat(max(1-priceBand, 0), 'f', -1, 64)
+	hi := strconv.FormatFloat(1+priceBand, 'f', -1, 64)
+
+	args := []interface{}{id, lo, hi, limit}
+	idx := 5
+	srcPrice, price := "price", "(CASE WHEN currency = src_currency THEN price END)"
+	if rates != nil {
+		price = normalizedPrice(&FilterParams{Currency: rates.Base, Rates: rates}, &args, &idx)
+		srcPrice = price
+	}
+	query := fmt.Sprintf(similarQuery, srcPrice, price)
+
+	rows, err := r.repository.Query(query, args...)
+	if err != nil {
+		r.logger.Error(
+			"Failed to get similar posts",
+			zap.Uint("id", id),
+			zap.Error(err),
+		)
+		return nil, errors.Errorf("failed to get similar posts: %v", err)
+	}
+	defer rows.Close()
+
+	posts := []*Post{}
+	for rows.Next() {
+		var post Post
+		if err := scanPost(rows, &post); err != nil {
+			return nil, errors.Errorf("failed to scan post: %v", err)
+		}
+		posts = append(posts, &post)
+	}
+	return posts, rows.Err()
+}
+
 func (r *Storage) GetByID(id uint) (*Post, error) {
-	const query = `
-        SELECT id, title, description, price, image_url, owner, created_at
-        FROM posts WHERE id = $1
-    `
+	const query = `SELECT ` + postColumns + ` FROM ` + postsFrom + ` WHERE id = $1`
 	row := r.repository.QueryRow(query, id)
 
 	var post Post
-	err := row.Scan(
-		&post.ID,
-		&post.Title,
-		&post.Description,
-		&post.Price,
-		&post.ImageURL,
-		&post.Owner,
-		&post.CreatedAt,
-	)
+	err := scanPost(row, &post)
 	if err != nil {
 		r.logger.Error(
 			"Failed to get post by ID",
@@ -88,18 +340,117 @@ func (r *Storage) GetByID(id uint) (*Post, error) {
 	return &post, nil
 }
 
-func setField(query string, sort *SortParams) string {
-	switch sort.Field {
-	case "price":
-		query += fmt.Sprintf(" ORDER BY price %s", sort.Direction)
-	case "created_at":
-		query += fmt.Sprintf(" ORDER BY created_at %s", sort.Direction)
+// GetByIDFor возвращает пост с флагами, вычисленными для зрителя viewer (пустой — аноним).
+func (r *Storage) GetByIDFor(id uint, viewer string) (*Post, error) {
+	if viewer == "" {
+		return r.GetByID(id)
+	}
+
+	query := `SELECT ` + postColumns + isFavoriteColumn(2) + ` FROM ` + postsFrom + ` WHERE id = $1`
+	row := r.repository.QueryRow(query, id, viewer)
+
+	var post Post
+	err := scanPost(row, &post, &post.IsFavorite)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPostNotFound
+		}
+		r.logger.Error(
+			"Failed to get post by ID",
+			zap.Uint("id", id),
+			zap.String("viewer", viewer),
+			zap.Error(err),
+		)
+		return nil, errors.Errorf("failed to get post: %v", err)
+	}
+	post.IsOwner = post.Owner == viewer
+
+	return &post, nil
+}
+
+func setField(query string, sort *SortParams, priceExpr, distanceExpr string) string {
+	direction := "DESC"
+	if strings.EqualFold(sort.Direction, "ASC") {
+		direction = "ASC"
+	}
+
+	switch {
+	case sort.Field == "distance" && distanceExpr != "":
+		query += fmt.Sprintf(" ORDER BY %s %s NULLS LAST", distanceExpr, direction)
+	case sort.Field == "price":
+		query += fmt.Sprintf(" ORDER BY %s %s NULLS LAST", priceExpr, direction)
+	case sort.Field == "created_at":
+		query += fmt.Sprintf(" ORDER BY created_at %s", direction)
 	default:
 		query += " ORDER BY created_at DESC"
 	}
 	return query
 }
 
+// normalizedPrice возвращает SQL-выражение цены в валюте фильтра.
+// Цены в валютах без курса превращаются в NULL и не проходят ценовой фильтр.
+func normalizedPrice(filter *FilterParams, args *[]interface{}, idx *int) string {
+	if filter.Currency == "" || filter.Rates == nil {
+		return "price"
+	}
+
+	currencies := make([]money.Currency, 0, len(filter.Rates.Rates)+1)
+	currencies = append(currencies, filter.Rates.Base)
+	for c := range filter.Rates.Rates {
+		if c != filter.Rates.Base {
+			currencies = append(currencies, c)
+		}
+	}
+	slices.Sort(currencies)
+
+	var sb strings.Builder
+	sb.WriteString("(CASE currency")
+	cases := 0
+	for _, c := range currencies {
+		factor, err := filter.Rates.Factor(c, filter.Currency)
+		if err != nil {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf(" WHEN $%d THEN price * $%d::numeric", *idx, *idx+1))
+		*args = append(*args, c.String(), factor.String())
+		*idx += 2
+		cases++
+	}
+	sb.WriteString(" END)")
+	if cases == 0 {
+		return "NULL::numeric"
+	}
+	return sb.String()
+}
+
+// distanceExpr — расстояние в километрах от точки ($latIdx, $latIdx+1) по формуле гаверсинусов.
+// Для постов без координат выражение равно NULL.
+func distanceExpr(latIdx int) string {
+	return fmt.Sprintf(
+		"(2 * %g * asin(LEAST(1, sqrt(power(sin(radians(lat - $%[2]d) / 2), 2)"+
+			" + cos(radians($%[2]d)) * cos(radians(lat)) * power(sin(radians(lon - $%[3]d) / 2), 2)))))",
+		geo.EarthRadiusKm, latIdx, latIdx+1,
+	)
+}
+
+// nearCondition добавляет предфильтр по прямоугольнику (использует индекс по lat, lon)
+// и точную проверку радиуса.
+func nearCondition(near *GeoFilter, distance string, args *[]interface{}, idx *int) string {
+	box := geo.BoundingBox(geo.Point{Lat: near.Lat, Lon: near.Lon}, near.RadiusKm)
+
+	lonOp := "AND"
+	if box.CrossesAntimeridian() {
+		lonOp = "OR"
+	}
+	cond := fmt.Sprintf(
+		" AND lat BETWEEN $%d AND $%d AND (lon >= $%d %s lon <= $%d) AND %s <= $%d",
+		*idx, *idx+1, *idx+2, lonOp, *idx+3, distance, *idx+4,
+	)
+	*args = append(*args, box.MinLat, box.MaxLat, box.MinLon, box.MaxLon, near.RadiusKm)
+	*idx += 5
+	return cond
+}
+
 func (r *Storage) GetAll(sort *SortParams, filter *FilterParams) ([]*Post, error) {
 	if filter == nil {
 		filter = &FilterParams{MinPrice: 0, MaxPrice: -1}
@@ -109,18 +460,55 @@ func (r *Storage) GetAll(sort *SortParams, filter *FilterParams) ([]*Post, error
 	}
 
 	var (
-		sb   strings.Builder
-		args []interface{}
-		idx  = 1
+		sb       strings.Builder
+		args     []interface{}
+		idx      = 1
+		columns  = postColumns
+		distance string
 	)
-	sb.WriteString("SELECT id, title, description, price, image_url, owner, created_at FROM posts WHERE 1=1")
 
+	if filter.Near != nil {
+		distance = distanceExpr(idx)
+		args = append(args, filter.Near.Lat, filter.Near.Lon)
+		idx += 2
+		columns += ", " + distance
+	}
+	if filter.Owner != "" {
+		columns += isFavoriteColumn(idx)
+		args = append(args, filter.Owner)
+		idx++
+	}
+	// Скрытые модерацией посты в ленту не попадают
+	sb.WriteString("SELECT " + columns + " FROM " + postsFrom + " WHERE NOT hidden")
+
+	if filter.FavoritedBy != "" {
+		sb.WriteString(fmt.Sprintf(" AND id IN (SELECT post_id FROM favorites WHERE login = $%d)", idx))
+		args = append(args, filter.FavoritedBy)
+		idx++
+	}
+	if filter.FollowedBy != "" {
+		sb.WriteString(fmt.Sprintf(" AND owner IN (SELECT seller FROM follows WHERE follower = $%d)", idx))
+		args = append(args, filter.FollowedBy)
+		idx++
+	}
+	// Посты пользователей, которых зритель заблокировал, в его ленту не попадают
+	if filter.Owner != "" {
+		sb.WriteString(fmt.Sprintf(" AND owner NOT IN (SELECT blocked FROM user_blocks WHERE blocker = $%d)", idx))
+		args = append(args, filter.Owner)
+		idx++
+	}
+
+	if filter.Near != nil && filter.Near.RadiusKm > 0 {
+		sb.WriteString(nearCondition(filter.Near, distance, &args, &idx))
+	}
+
+	price := normalizedPrice(filter, &args, &idx)
 	if filter.MaxPrice >= 0 {
-		sb.WriteString(fmt.Sprintf(" AND price BETWEEN $%d AND $%d", idx, idx+1))
+		sb.WriteString(fmt.Sprintf(" AND %s BETWEEN $%d AND $%d", price, idx, idx+1))
 		args = append(args, filter.MinPrice, filter.MaxPrice)
 		idx += 2
-	} else {
-		sb.WriteString(fmt.Sprintf(" AND price >= $%d", idx))
+	} else if filter.MinPrice > 0 {
+		sb.WriteString(fmt.Sprintf(" AND %s >= $%d", price, idx))
 		args = append(args, filter.MinPrice)
 		idx++
 	}
@@ -134,9 +522,9 @@ func (r *Storage) GetAll(sort *SortParams, filter *FilterParams) ([]*Post, error
 		   }
 	*/
 
-	sb.WriteString(fmt.Sprintf(" ORDER BY %s %s", sort.Field, sort.Direction))
+	query := setField(sb.String(), sort, price, distance)
 
-	rows, err := r.repository.Query(sb.String(), args...)
+	rows, err := r.repository.Query(query, args...)
 	if err != nil {
 		r.logger.Error("Failed to get posts",
 			zap.Error(err),
@@ -148,19 +536,24 @@ func (r *Storage) GetAll(sort *SortParams, filter *FilterParams) ([]*Post, error
 
 	var posts []*Post
 	for rows.Next() {
-		var p Post
-		if err := rows.Scan(
-			&p.ID,
-			&p.Title,
-			&p.Description,
-			&p.Price,
-			&p.ImageURL,
-			&p.Owner,
-			&p.CreatedAt,
-		); err != nil {
+		var (
+			p     Post
+			extra []any
+			dist  sql.NullFloat64
+		)
+		if filter.Near != nil {
+			extra = append(extra, &dist)
+		}
+		if filter.Owner != "" {
+			extra = append(extra, &p.IsFavorite)
+		}
+		if err := scanPost(rows, &p, extra...); err != nil {
 			r.logger.Error("Failed to scan post row", zap.Error(err))
 			continue
 		}
+		if dist.Valid {
+			p.DistanceKm = &dist.Float64
+		}
 		if filter.Owner != "" && p.Owner == filter.Owner {
 			p.IsOwner = true
 		}
@@ -171,31 +564,173 @@ func (r *Storage) GetAll(sort *SortParams, filter *FilterParams) ([]*Post, error
 		return nil, errors.Wrap(err, "error iterating over posts")
 	}
 
+	// В избранном и в ленте подписок рекламных позиций нет
+	if len(r.feedSlots) > 0 && filter.FavoritedBy == "" && filter.FollowedBy == "" {
+		featured, err := r.featured()
+		if err != nil {
+			r.logger.Warn("Failed to get featured posts", zap.Error(err))
+			return posts, nil
+		}
+		posts = interleave(posts, featured, r.feedSlots)
+	}
 	return posts, nil
 }
 
-func (r *Storage) Update(post *Post) error {
+// featured возвращает ID продвигаемых постов в порядке очереди показа.
+func (r *Storage) featured() ([]uint, error) {
+	rows, err := r.repository.Query(featuredQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uint
+	for rows.Next() {
+		var id uint
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Update сохраняет пост и, если изменилась цена, записывает прежнюю цену в историю
+// тем же запросом. Возвращает изменение цены или nil, если цена не менялась.
+// Изменение количества пересчитывает статус объявления с фиксированной ценой: пополнение
+// распроданного поста возвращает его в продажу, а сокращение до проданного — распродаёт.
+// Количество лотов аукциона не меняется.
+func (r *Storage) Update(post *Post) (*PriceChange, error) {
 	query := `
-        UPDATE posts
-        SET title=$1, description=$2, price=$3, image_url=$4
-        WHERE id=$5
+        WITH old AS (
+            SELECT id, price, currency FROM posts WHERE id=$9 FOR UPDATE
+        ), upd AS (
+            UPDATE posts p
+            SET title=$1, description=$2, price=$3, currency=$4, image_url=$5, lat=$6, lon=$7, place=$8,
+                quantity = CASE WHEN p.listing_type = 'fixed' THEN $10 ELSE p.quantity END,
+                status = CASE
+                    WHEN p.listing_type <> 'fixed' THEN p.status
+                    WHEN p.quantity_sold < $10 THEN 'active'
+                    WHEN p.status = 'active' THEN 'sold'
+                    ELSE p.status
+                END
+            FROM old
+            WHERE p.id = old.id
+            RETURNING old.price AS old_price, old.currency AS old_currency, p.price, p.currency,
+                p.status, p.quantity, p.quantity_sold
+        ), hist AS (
+            INSERT INTO price_history (post_id, old_price, old_currency, new_price, new_currency)
+            SELECT $9, old_price, old_currency, price, currency FROM upd
+            WHERE (old_price, old_currency) IS DISTINCT FROM (price, currency)
+            RETURNING old_price, old_currency, changed_at
+        )
+        SELECT upd.status, upd.quantity, upd.quantity_sold, hist.old_price, hist.old_currency, hist.changed_at
+        FROM upd LEFT JOIN hist ON TRUE
     `
-	_, err := r.repository.Exec(query, post.Title, post.Description, post.Price, post.ImageURL, post.ID)
+	lat, lon, place := locationArgs(post)
+	var (
+		oldPrice, oldCurrency sql.NullString
+		changedAt             sql.NullTime
+	)
+	err := r.repository.QueryRow(
+		query,
+		post.Title,
+		post.Description,
+		post.Price.Amount().String(),
+		post.Price.Currency(),
+		post.ImageURL,
+		lat,
+		lon,
+		place,
+		post.ID,
+		post.Quantity,
+	).Scan(&post.Status, &post.Quantity, &post.QuantitySold, &oldPrice, &oldCurrency, &changedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrPostNotFound
+	}
 	if err != nil {
+		// Между чтением и записью могли продать ещё единицы, и новое количество стало меньше проданного
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgCheckViolation {
+			return nil, ErrQuantityBelowSold
+		}
 		r.logger.Error(
 			"Failed to update post",
 			zap.Uint("id", post.ID),
 			zap.Error(err),
 		)
-		return errors.Errorf("failed to update post: %v", err)
+		return nil, errors.Errorf("failed to update post: %v", err)
 	}
-	return nil
+	if !oldPrice.Valid {
+		return nil, nil
+	}
+
+	amount, err := money.ParseDecimal(oldPrice.String)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse previous price")
+	}
+	return &PriceChange{
+		PostID:    post.ID,
+		OldPrice:  money.New(amount, money.Currency(oldCurrency.String)),
+		NewPrice:  post.Price,
+		ChangedAt: changedAt.Time,
+	}, nil
+}
+
+// PriceHistory возвращает изменения цены поста от старых к новым.
+func (r *Storage) PriceHistory(postID uint) ([]*PriceChange, error) {
+	query := `
+		SELECT old_price, old_currency, new_price, new_currency, changed_at
+		FROM price_history
+		WHERE post_id = $1
+		ORDER BY changed_at, id
+	`
+	rows, err := r.repository.Query(query, postID)
+	if err != nil {
+		r.logger.Error(
+			"Failed to get price history",
+			zap.Uint("post_id", postID),
+			zap.Error(err),
+		)
+		return nil, errors.Wrap(err, "failed to get price history")
+	}
+	defer rows.Close()
+
+	history := []*PriceChange{}
+	for rows.Next() {
+		var (
+			oldPrice, oldCurrency string
+			newPrice, newCurrency string
+			change                = PriceChange{PostID: postID}
+		)
+		if err := rows.Scan(&oldPrice, &oldCurrency, &newPrice, &newCurrency, &change.ChangedAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan price history")
+		}
+		oldAmount, err := money.ParseDecimal(oldPrice)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse price")
+		}
+		newAmount, err := money.ParseDecimal(newPrice)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse price")
+		}
+		change.OldPrice = money.New(oldAmount, money.Currency(oldCurrency))
+		change.NewPrice = money.New(newAmount, money.Currency(newCurrency))
+		history = append(history, &change)
+	}
+	return history, rows.Err()
 }
 
+// Delete удаляет пост. Пост, по которому есть заказы, удалить нельзя: позиции заказов
+// ссылаются на него (ON DELETE RESTRICT), и возвращается ErrPostHasOrders.
 func (r *Storage) Delete(id uint64) error {
 	query := `DELETE FROM posts WHERE id = $1`
 	_, err := r.repository.Exec(query, id)
 	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgForeignKeyViolation {
+			return ErrPostHasOrders
+		}
 		r.logger.Error(
 			"Failed to delete post",
 			zap.Uint64("id", id),
@@ -0,0 +1,124 @@
+package post
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+func Test_setField(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		sort         *SortParams
+		priceExpr    string
+		distanceExpr string
+
+		expected string
+	}{
+		{
+			name:      "1. Price_Ascending",
+			sort:      &SortParams{Field: "price", Direction: "asc"},
+			priceExpr: "price",
+			expected:  " ORDER BY price ASC NULLS LAST",
+		},
+		{
+			name:      "2. Created_At_Default_Direction",
+			sort:      &SortParams{Field: "created_at", Direction: "sideways"},
+			priceExpr: "price",
+			expected:  " ORDER BY created_at DESC",
+		},
+		{
+			name:         "3. Distance_With_Geo_Filter",
+			sort:         &SortParams{Field: "distance", Direction: "ASC"},
+			priceExpr:    "price",
+			distanceExpr: "dist",
+			expected:     " ORDER BY dist ASC NULLS LAST",
+		},
+		{
+			name:      "4. Distance_Without_Geo_Filter",
+			sort:      &SortParams{Field: "distance", Direction: "ASC"},
+			priceExpr: "price",
+			expected:  " ORDER BY created_at DESC",
+		},
+		{
+			name:      "5. Unknown_Field_Is_Not_Interpolated",
+			sort:      &SortParams{Field: "price; DROP TABLE posts", Direction: "ASC"},
+			priceExpr: "price",
+			expected:  " ORDER BY created_at DESC",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, setField("", tc.sort, tc.priceExpr, tc.distanceExpr))
+		})
+	}
+}
+
+func Test_normalizedPrice(t *testing.T) {
+	rates := &money.Rates{
+		Base: money.RUB,
+		Rates: map[money.Currency]money.Decimal{
+			money.USD: money.NewDecimal(125, 4),
+		},
+	}
+
+	t.Run("1. No_Currency", func(t *testing.T) {
+		var args []interface{}
+		idx := 1
+		expr := normalizedPrice(&FilterParams{}, &args, &idx)
+
+		assert.Equal(t, "price", expr)
+		assert.Empty(t, args)
+		assert.Equal(t, 1, idx)
+	})
+
+	t.Run("2. Converts_Every_Known_Currency", func(t *testing.T) {
+		args := []interface{}{"placeholder"}
+		idx := 2
+		expr := normalizedPrice(&FilterParams{Currency: money.USD, Rates: rates}, &args, &idx)
+
+		assert.Equal(t, "(CASE currency WHEN $2 THEN price * $3::numeric WHEN $4 THEN price * $5::numeric END)", expr)
+		assert.Equal(t, []interface{}{"placeholder", "RUB", "0.012500000000", "USD", "1.000000000000"}, args)
+		assert.Equal(t, 6, idx)
+	})
+}
+
+func Test_nearCondition(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		near *GeoFilter
+
+		expectedLonOp string
+	}{
+		{
+			name:          "1. Regular_Box",
+			near:          &GeoFilter{Lat: 55.75, Lon: 37.61, RadiusKm: 10},
+			expectedLonOp: "(lon >= $3 AND lon <= $4)",
+		},
+		{
+			name:          "2. Box_Crosses_Antimeridian",
+			near:          &GeoFilter{Lat: 64.7, Lon: 179.9, RadiusKm: 100},
+			expectedLonOp: "(lon >= $3 OR lon <= $4)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var args []interface{}
+			idx := 1
+			cond := nearCondition(tc.near, "dist", &args, &idx)
+
+			assert.Contains(t, cond, "lat BETWEEN $1 AND $2")
+			assert.Contains(t, cond, tc.expectedLonOp)
+			assert.Contains(t, cond, "dist <= $5")
+			assert.Len(t, args, 5)
+			assert.Equal(t, tc.near.RadiusKm, args[4])
+			assert.Equal(t, 6, idx)
+		})
+	}
+}
@@ -6,13 +6,20 @@ import (
 	"net/url"
 	"path"
 	"strings"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/geo"
 )
 
 var (
 	ErrMissingFields = fmt.Errorf("title and description are required")
 	ErrTooLong       = fmt.Errorf("title must not exceed 100 characters, description must not exceed 2000 characters")
 	ErrNegativePrice = fmt.Errorf("price must be a positive number")
+	ErrInvalidPrice  = fmt.Errorf("invalid price")
 	ErrRequiredURL   = fmt.Errorf("image URL is required")
+	ErrInvalidPlace  = fmt.Errorf("location place must not exceed 200 characters")
+
+	ErrInvalidQuantity   = fmt.Errorf("quantity must be at least 1")
+	ErrQuantityBelowSold = fmt.Errorf("quantity must not be less than the number of units already sold")
 )
 
 var imageExts = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".gif": true}
@@ -24,9 +31,27 @@ func validatePost(post *Post) error {
 	if len(post.Title) > 100 || len(post.Description) > 2000 {
 		return ErrTooLong
 	}
-	if post.Price < 0 {
+	if !post.Price.IsPositive() {
 		return ErrNegativePrice
 	}
+	if err := post.Price.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPrice, err)
+	}
+	if post.Quantity < 1 {
+		return ErrInvalidQuantity
+	}
+	if post.Quantity < post.QuantitySold {
+		return ErrQuantityBelowSold
+	}
+
+	if post.Location != nil {
+		if err := (geo.Point{Lat: post.Location.Lat, Lon: post.Location.Lon}).Validate(); err != nil {
+			return err
+		}
+		if len(post.Location.Place) > 200 {
+			return ErrInvalidPlace
+		}
+	}
 
 	u, err := url.ParseRequestURI(post.ImageURL)
 	if err != nil {
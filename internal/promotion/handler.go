@@ -0,0 +1,118 @@
+package promotion
+
+// mockgen  -source=handler.go -destination=handler_mock_test.go -package=promotion
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+)
+
+type service interface {
+	Promote(postID uint, owner, kind string, days int) (*Promotion, error)
+	ListMine(owner string) ([]*Promotion, error)
+}
+
+type Handler struct {
+	service service
+	logger  *zap.Logger
+}
+
+func NewHandler(service service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+type promoteRequest struct {
+	Kind string `json:"kind"`
+	Days int    `json:"days"`
+}
+
+// writeError переводит ошибки продвижения в HTTP-статусы.
+func (h *Handler) writeError(w http.ResponseWriter, err error, msg string, fields ...zap.Field) {
+	switch {
+	case errors.Is(err, post.ErrPostNotFound):
+		http.Error(w, "Not Found", http.StatusNotFound)
+	case errors.Is(err, ErrNotOwner):
+		http.Error(w, "Forbidden: "+err.Error(), http.StatusForbidden)
+	case errors.Is(err, ErrPostNotActive), errors.Is(err, ErrAlreadyPromoted):
+		http.Error(w, "Conflict: "+err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrUnknownKind), errors.Is(err, ErrInvalidDays):
+		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+	default:
+		h.logger.Error(msg, append(fields, zap.Error(err))...)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// Promote обрабатывает POST /posts/{id}/promote.
+func (h *Handler) Promote(w http.ResponseWriter, r *http.Request) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) != 4 || parts[1] != "posts" || parts[3] != "promote" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	id64, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		http.Error(w, "Bad Request: invalid id", http.StatusBadRequest)
+		return
+	}
+	postID := uint(id64)
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req promoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Bad Request: invalid JSON", http.StatusBadRequest)
+		return
+	}
+	promotion, err := h.service.Promote(postID, login, req.Kind, req.Days)
+	if err != nil {
+		h.writeError(w, err, "Failed to promote post", zap.Uint("post_id", postID), zap.String("owner", login))
+		return
+	}
+	writeJSON(w, http.StatusCreated, promotion)
+}
+
+// Promotions обрабатывает GET /me/promotions.
+func (h *Handler) Promotions(w http.ResponseWriter, r *http.Request) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	promotions, err := h.service.ListMine(login)
+	if err != nil {
+		h.writeError(w, err, "Failed to list promotions", zap.String("owner", login))
+		return
+	}
+	writeJSON(w, http.StatusOK, promotions)
+}
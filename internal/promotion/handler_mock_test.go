@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+
+// Package promotion is a generated GoMock package.
+package promotion
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockservice is a mock of service interface.
+type Mockservice struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceMockRecorder
+}
+
+// MockserviceMockRecorder is the mock recorder for Mockservice.
+type MockserviceMockRecorder struct {
+	mock *Mockservice
+}
+
+// NewMockservice creates a new mock instance.
+func NewMockservice(ctrl *gomock.Controller) *Mockservice {
+	mock := &Mockservice{ctrl: ctrl}
+	mock.recorder = &MockserviceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockservice) EXPECT() *MockserviceMockRecorder {
+	return m.recorder
+}
+
+// ListMine mocks base method.
+func (m *Mockservice) ListMine(owner string) ([]*Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMine", owner)
+	ret0, _ := ret[0].([]*Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMine indicates an expected call of ListMine.
+func (mr *MockserviceMockRecorder) ListMine(owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMine", reflect.TypeOf((*Mockservice)(nil).ListMine), owner)
+}
+
+// Promote mocks base method.
+func (m *Mockservice) Promote(postID uint, owner, kind string, days int) (*Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Promote", postID, owner, kind, days)
+	ret0, _ := ret[0].(*Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Promote indicates an expected call of Promote.
+func (mr *MockserviceMockRecorder) Promote(postID, owner, kind, days interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Promote", reflect.TypeOf((*Mockservice)(nil).Promote), postID, owner, kind, days)
+}
@@ -0,0 +1,141 @@
+package promotion
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+func newRequest(method, path, body, user string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if user != "" {
+		req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, user))
+	}
+	return req
+}
+
+func TestHandler_Promote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		method     string
+		path       string
+		body       string
+		user       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name:   "1. Promoted",
+			method: http.MethodPost,
+			path:   "/posts/7/promote",
+			body:   `{"kind":"featured","days":7}`,
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Promote(uint(7), "alice", post.PromotionFeatured, 7).Return(&Promotion{ID: 1}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:         "2. Unauthorized",
+			method:       http.MethodPost,
+			path:         "/posts/7/promote",
+			body:         `{"kind":"featured","days":7}`,
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "3. Not_Owner",
+			method: http.MethodPost,
+			path:   "/posts/7/promote",
+			body:   `{"kind":"featured","days":7}`,
+			user:   "bob",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Promote(uint(7), "bob", post.PromotionFeatured, 7).Return(nil, ErrNotOwner)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:   "4. Already_Promoted",
+			method: http.MethodPost,
+			path:   "/posts/7/promote",
+			body:   `{"kind":"highlighted","days":3}`,
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Promote(uint(7), "alice", post.PromotionHighlighted, 3).Return(nil, ErrAlreadyPromoted)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:   "5. Invalid_Days",
+			method: http.MethodPost,
+			path:   "/posts/7/promote",
+			body:   `{"kind":"featured","days":0}`,
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Promote(uint(7), "alice", post.PromotionFeatured, 0).Return(nil, ErrInvalidDays)
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:   "6. Post_Not_Found",
+			method: http.MethodPost,
+			path:   "/posts/9/promote",
+			body:   `{"kind":"featured","days":1}`,
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Promote(uint(9), "alice", post.PromotionFeatured, 1).Return(nil, post.ErrPostNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "7. Method_Not_Allowed",
+			method:       http.MethodGet,
+			path:         "/posts/7/promote",
+			user:         "alice",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewMockservice(ctrl)
+			tc.setupMocks(service)
+
+			handler := NewHandler(service, zap.NewNop())
+			rec := httptest.NewRecorder()
+			handler.Promote(rec, newRequest(tc.method, tc.path, tc.body, tc.user))
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestHandler_Promotions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewMockservice(ctrl)
+	service.EXPECT().ListMine("alice").Return([]*Promotion{{ID: 1, Impressions: 10, Clicks: 2}}, nil)
+
+	handler := NewHandler(service, zap.NewNop())
+	rec := httptest.NewRecorder()
+	handler.Promotions(rec, newRequest(http.MethodGet, "/me/promotions", "", "alice"))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"impressions":10`)
+}
@@ -0,0 +1,48 @@
+package promotion
+
+import (
+	"errors"
+	"time"
+
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+// KindPromotion — отчёт владельцу о закончившемся продвижении.
+const KindPromotion = "promotion"
+
+// Состояния продвижения. Действующее продвижение — active с ends_at в будущем;
+// закончившиеся помечает expired фоновая задача, отправляя владельцу отчёт.
+const (
+	StatusActive  = "active"
+	StatusExpired = "expired"
+)
+
+var kinds = map[string]bool{
+	post.PromotionFeatured:    true,
+	post.PromotionHighlighted: true,
+}
+
+// maxDays — на сколько дней можно продвинуть пост за раз.
+const maxDays = 30
+
+var (
+	ErrUnknownKind     = errors.New("unknown promotion kind")
+	ErrInvalidDays     = errors.New("days must be between 1 and 30")
+	ErrNotOwner        = errors.New("only the owner can promote the post")
+	ErrPostNotActive   = errors.New("only active posts can be promoted")
+	ErrAlreadyPromoted = errors.New("post already has an active promotion of this kind")
+)
+
+// Promotion — продвижение поста. Impressions — сколько раз пост показан в ленте,
+// Clicks — сколько раз его открыли другие пользователи, пока продвижение действовало.
+type Promotion struct {
+	ID          uint      `json:"id"`
+	PostID      uint      `json:"post_id"`
+	Owner       string    `json:"owner"`
+	Kind        string    `json:"kind"`
+	Status      string    `json:"status"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	Impressions int       `json:"impressions"`
+	Clicks      int       `json:"clicks"`
+}
@@ -0,0 +1,164 @@
+package promotion
+
+// mockgen  -source=service.go -destination=service_mock_test.go -package=promotion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+type storage interface {
+	Create(p *Promotion, days int) error
+	ListByOwner(owner string) ([]*Promotion, error)
+	Expire() ([]*Promotion, error)
+	RecordImpressions(postIDs []uint) error
+	RecordClick(postID uint) error
+}
+
+type posts interface {
+	GetByID(id uint) (*post.Post, error)
+}
+
+type notifier interface {
+	Notify(msg notify.Message) error
+}
+
+type Service struct {
+	repository storage
+	posts      posts
+	notifier   notifier
+	logger     *zap.Logger
+}
+
+type Option func(*Service)
+
+// WithNotifier включает отчёты владельцу о закончившихся продвижениях.
+func WithNotifier(notifier notifier) Option {
+	return func(s *Service) {
+		s.notifier = notifier
+	}
+}
+
+func NewService(repository storage, posts posts, logger *zap.Logger, opts ...Option) *Service {
+	s := &Service{
+		repository: repository,
+		posts:      posts,
+		logger:     logger,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Promote продвигает активный пост владельца на days дней.
+func (s *Service) Promote(postID uint, owner, kind string, days int) (*Promotion, error) {
+	if !kinds[kind] {
+		return nil, ErrUnknownKind
+	}
+	if days < 1 || days > maxDays {
+		return nil, ErrInvalidDays
+	}
+	p, err := s.posts.GetByID(postID)
+	if err != nil {
+		return nil, err
+	}
+	if p.Owner != owner {
+		return nil, ErrNotOwner
+	}
+	if p.Status != post.StatusActive || p.Hidden {
+		return nil, ErrPostNotActive
+	}
+
+	promotion := &Promotion{PostID: postID, Owner: owner, Kind: kind}
+	if err := s.repository.Create(promotion, days); err != nil {
+		return nil, err
+	}
+	return promotion, nil
+}
+
+// ListMine возвращает продвижения постов пользователя со статистикой.
+func (s *Service) ListMine(owner string) ([]*Promotion, error) {
+	return s.repository.ListByOwner(owner)
+}
+
+// OnFeed засчитывает показ продвигаемым постам ленты.
+func (s *Service) OnFeed(feed []*post.Post) {
+	var ids []uint
+	for _, p := range feed {
+		if p.Promotion != "" {
+			ids = append(ids, p.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	if err := s.repository.RecordImpressions(ids); err != nil {
+		s.logger.Warn("Failed to record promotion impressions", zap.Error(err))
+	}
+}
+
+// OnView засчитывает открытие продвигаемого поста; просмотры владельца не считаются.
+func (s *Service) OnView(p *post.Post, viewer string) {
+	if p.Promotion == "" || p.Owner == viewer {
+		return
+	}
+	if err := s.repository.RecordClick(p.ID); err != nil {
+		s.logger.Warn("Failed to record promotion click", zap.Uint("post_id", p.ID), zap.Error(err))
+	}
+}
+
+// Run периодически завершает закончившиеся продвижения, пока не отменён ctx.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.expire()
+		}
+	}
+}
+
+// expire завершает закончившиеся продвижения и отправляет владельцам отчёты.
+func (s *Service) expire() {
+	expired, err := s.repository.Expire()
+	if err != nil {
+		s.logger.Warn("Failed to expire promotions", zap.Error(err))
+		return
+	}
+	for _, p := range expired {
+		s.report(p)
+	}
+}
+
+func (s *Service) report(p *Promotion) {
+	if s.notifier == nil {
+		return
+	}
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return
+	}
+	err = s.notifier.Notify(notify.Message{
+		Recipient: p.Owner,
+		Kind:      KindPromotion,
+		Title: fmt.Sprintf("Promotion of post #%d ended: %d impressions, %d clicks",
+			p.PostID, p.Impressions, p.Clicks),
+		Payload: payload,
+		Channel: notify.ChannelInbox,
+	})
+	if err != nil {
+		s.logger.Warn("Failed to queue promotion report", zap.Uint("promotion_id", p.ID), zap.Error(err))
+	}
+}
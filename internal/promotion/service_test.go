@@ -0,0 +1,152 @@
+package promotion
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+func TestService_Promote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	listing := &post.Post{ID: 7, Owner: "alice", Status: post.StatusActive}
+
+	testCases := []struct {
+		name string
+
+		owner      string
+		kind       string
+		days       int
+		setupMocks func(repo *Mockstorage, p *Mockposts)
+
+		expectedErr error
+	}{
+		{
+			name:  "1. Promoted",
+			owner: "alice",
+			kind:  post.PromotionFeatured,
+			days:  7,
+			setupMocks: func(repo *Mockstorage, p *Mockposts) {
+				p.EXPECT().GetByID(uint(7)).Return(listing, nil)
+				repo.EXPECT().Create(&Promotion{PostID: 7, Owner: "alice", Kind: post.PromotionFeatured}, 7).Return(nil)
+			},
+		},
+		{
+			name:        "2. Unknown_Kind",
+			owner:       "alice",
+			kind:        "banner",
+			days:        7,
+			setupMocks:  func(repo *Mockstorage, p *Mockposts) {},
+			expectedErr: ErrUnknownKind,
+		},
+		{
+			name:        "3. Too_Long",
+			owner:       "alice",
+			kind:        post.PromotionHighlighted,
+			days:        31,
+			setupMocks:  func(repo *Mockstorage, p *Mockposts) {},
+			expectedErr: ErrInvalidDays,
+		},
+		{
+			name:  "4. Not_Owner",
+			owner: "bob",
+			kind:  post.PromotionFeatured,
+			days:  1,
+			setupMocks: func(repo *Mockstorage, p *Mockposts) {
+				p.EXPECT().GetByID(uint(7)).Return(listing, nil)
+			},
+			expectedErr: ErrNotOwner,
+		},
+		{
+			name:  "5. Sold_Post",
+			owner: "alice",
+			kind:  post.PromotionFeatured,
+			days:  1,
+			setupMocks: func(repo *Mockstorage, p *Mockposts) {
+				p.EXPECT().GetByID(uint(7)).Return(&post.Post{ID: 7, Owner: "alice", Status: post.StatusSold}, nil)
+			},
+			expectedErr: ErrPostNotActive,
+		},
+		{
+			name:  "6. Already_Promoted",
+			owner: "alice",
+			kind:  post.PromotionFeatured,
+			days:  3,
+			setupMocks: func(repo *Mockstorage, p *Mockposts) {
+				p.EXPECT().GetByID(uint(7)).Return(listing, nil)
+				repo.EXPECT().Create(gomock.Any(), 3).Return(ErrAlreadyPromoted)
+			},
+			expectedErr: ErrAlreadyPromoted,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			posts := NewMockposts(ctrl)
+			tc.setupMocks(repo, posts)
+
+			service := NewService(repo, posts, zap.NewNop())
+			_, err := service.Promote(7, tc.owner, tc.kind, tc.days)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestService_OnFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	repo.EXPECT().RecordImpressions([]uint{2, 3}).Return(nil)
+
+	service := NewService(repo, NewMockposts(ctrl), zap.NewNop())
+	service.OnFeed([]*post.Post{
+		{ID: 1},
+		{ID: 2, Promotion: post.PromotionFeatured},
+		{ID: 3, Promotion: post.PromotionHighlighted},
+	})
+	// Лента без продвигаемых постов в базу не ходит
+	service.OnFeed([]*post.Post{{ID: 1}})
+}
+
+func TestService_OnView(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	repo.EXPECT().RecordClick(uint(2)).Return(errors.New("db down"))
+
+	service := NewService(repo, NewMockposts(ctrl), zap.NewNop())
+	promoted := &post.Post{ID: 2, Owner: "alice", Promotion: post.PromotionFeatured}
+	service.OnView(promoted, "bob")
+	service.OnView(promoted, "alice")
+	service.OnView(&post.Post{ID: 1, Owner: "alice"}, "bob")
+}
+
+func TestService_Expire(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	repo.EXPECT().Expire().Return([]*Promotion{
+		{ID: 1, PostID: 7, Owner: "alice", Kind: post.PromotionFeatured, Impressions: 120, Clicks: 9},
+	}, nil)
+	n := NewMocknotifier(ctrl)
+	n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+		assert.Equal(t, "alice", msg.Recipient)
+		assert.Equal(t, KindPromotion, msg.Kind)
+		assert.Equal(t, "Promotion of post #7 ended: 120 impressions, 9 clicks", msg.Title)
+		return nil
+	})
+
+	service := NewService(repo, NewMockposts(ctrl), zap.NewNop(), WithNotifier(n))
+	service.expire()
+}
@@ -0,0 +1,125 @@
+package promotion
+
+// mockgen  -source=storage.go -destination=storage_mock_test.go -package=promotion
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const pgUniqueViolation = "23505"
+
+const promotionColumns = "id, post_id, owner, kind, status, starts_at, ends_at, impressions, clicks"
+
+type Repository interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+type Storage struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+func NewStorage(repository Repository, logger *zap.Logger) *Storage {
+	return &Storage{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanPromotion читает строку в порядке promotionColumns.
+func scanPromotion(row rowScanner, p *Promotion) error {
+	return row.Scan(&p.ID, &p.PostID, &p.Owner, &p.Kind, &p.Status, &p.StartsAt, &p.EndsAt, &p.Impressions, &p.Clicks)
+}
+
+func (r *Storage) scanAll(rows *sql.Rows) ([]*Promotion, error) {
+	defer rows.Close()
+
+	var promotions []*Promotion
+	for rows.Next() {
+		var p Promotion
+		if err := scanPromotion(rows, &p); err != nil {
+			return nil, errors.Errorf("failed to scan promotion: %v", err)
+		}
+		promotions = append(promotions, &p)
+	}
+	return promotions, rows.Err()
+}
+
+// Create запускает продвижение на days дней с текущего момента.
+func (r *Storage) Create(p *Promotion, days int) error {
+	query := `
+		INSERT INTO promotions (post_id, owner, kind, ends_at)
+		VALUES ($1, $2, $3, NOW() + make_interval(days => $4))
+		RETURNING ` + promotionColumns
+	err := scanPromotion(r.repository.QueryRow(query, p.PostID, p.Owner, p.Kind, days), p)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == pgUniqueViolation {
+			return ErrAlreadyPromoted
+		}
+		r.logger.Error("Failed to create promotion", zap.Uint("post_id", p.PostID), zap.Error(err))
+		return errors.Errorf("failed to create promotion: %v", err)
+	}
+	return nil
+}
+
+// ListByOwner возвращает продвижения постов владельца, новые первыми.
+func (r *Storage) ListByOwner(owner string) ([]*Promotion, error) {
+	rows, err := r.repository.Query(`SELECT `+promotionColumns+` FROM promotions WHERE owner = $1 ORDER BY id DESC`, owner)
+	if err != nil {
+		r.logger.Error("Failed to list promotions", zap.String("owner", owner), zap.Error(err))
+		return nil, errors.Errorf("failed to list promotions: %v", err)
+	}
+	return r.scanAll(rows)
+}
+
+// Expire помечает закончившиеся продвижения и возвращает их.
+func (r *Storage) Expire() ([]*Promotion, error) {
+	query := `
+		UPDATE promotions SET status = 'expired'
+		WHERE status = 'active' AND ends_at <= NOW()
+		RETURNING ` + promotionColumns
+	rows, err := r.repository.Query(query)
+	if err != nil {
+		return nil, errors.Errorf("failed to expire promotions: %v", err)
+	}
+	return r.scanAll(rows)
+}
+
+// RecordImpressions засчитывает показ в ленте действующим продвижениям постов postIDs.
+func (r *Storage) RecordImpressions(postIDs []uint) error {
+	ids := make([]int64, 0, len(postIDs))
+	for _, id := range postIDs {
+		ids = append(ids, int64(id))
+	}
+	_, err := r.repository.Exec(`
+		UPDATE promotions SET impressions = impressions + 1
+		WHERE post_id = ANY($1) AND status = 'active' AND ends_at > NOW()
+	`, pq.Array(ids))
+	if err != nil {
+		return errors.Errorf("failed to record impressions: %v", err)
+	}
+	return nil
+}
+
+// RecordClick засчитывает открытие поста действующим продвижениям поста.
+func (r *Storage) RecordClick(postID uint) error {
+	_, err := r.repository.Exec(`
+		UPDATE promotions SET clicks = clicks + 1
+		WHERE post_id = $1 AND status = 'active' AND ends_at > NOW()
+	`, postID)
+	if err != nil {
+		return errors.Errorf("failed to record click: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package quota
+
+// mockgen  -source=handler.go -destination=handler_mock_test.go -package=quota
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+)
+
+type service interface {
+	GetUsage(login string) (*Usage, error)
+}
+
+type Handler struct {
+	service service
+	logger  *zap.Logger
+}
+
+func NewHandler(service service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// WriteExceeded отвечает 429 с Retry-After, если err — превышение квоты.
+func WriteExceeded(w http.ResponseWriter, err error) bool {
+	var exceeded *ExceededError
+	if !errors.As(err, &exceeded) {
+		return false
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(int(exceeded.RetryAfter.Seconds())))
+	http.Error(w, "Too Many Requests: "+err.Error(), http.StatusTooManyRequests)
+	return true
+}
+
+// Quotas обрабатывает GET /me/quotas.
+func (h *Handler) Quotas(w http.ResponseWriter, r *http.Request) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	usage, err := h.service.GetUsage(login)
+	if errors.Is(err, ErrUserNotFound) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to get quota usage", zap.String("login", login), zap.Error(err))
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(usage)
+}
@@ -0,0 +1,108 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+)
+
+func newRequest(method, path, user string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	if user != "" {
+		req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, user))
+	}
+	return req
+}
+
+func TestHandler_Quotas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	remaining := 4
+
+	testCases := []struct {
+		name string
+
+		method     string
+		user       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name:   "1. Usage",
+			method: http.MethodGet,
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetUsage("alice").Return(&Usage{
+					Tier:           TierRegular,
+					ActiveListings: Allowance{Limit: 5, Used: 1, Remaining: &remaining},
+				}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "2. Unauthorized",
+			method:       http.MethodGet,
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "3. User_Not_Found",
+			method: http.MethodGet,
+			user:   "ghost",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetUsage("ghost").Return(nil, ErrUserNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "4. Storage_Error",
+			method: http.MethodGet,
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetUsage("alice").Return(nil, errors.New("db down"))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:         "5. Method_Not_Allowed",
+			method:       http.MethodPost,
+			user:         "alice",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewMockservice(ctrl)
+			tc.setupMocks(service)
+
+			handler := NewHandler(service, zap.NewNop())
+			rec := httptest.NewRecorder()
+			handler.Quotas(rec, newRequest(tc.method, "/me/quotas", tc.user))
+
+			assert.Equal(t, tc.expectedCode, rec.Code)
+		})
+	}
+}
+
+func TestWriteExceeded(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ok := WriteExceeded(rec, &ExceededError{Limit: LimitHourly, RetryAfter: 90 * time.Second})
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "90", rec.Header().Get("Retry-After"))
+
+	assert.False(t, WriteExceeded(httptest.NewRecorder(), errors.New("other")))
+}
@@ -0,0 +1,96 @@
+package quota
+
+import (
+	"time"
+)
+
+// Тарифы квот. Новые аккаунты ограничены сильнее, модераторы не ограничены.
+const (
+	TierNew       = "new"
+	TierRegular   = "regular"
+	TierModerator = "moderator"
+)
+
+// Лимиты, которые можно превысить.
+const (
+	LimitActive = "active_listings"
+	LimitHourly = "hourly"
+	LimitDaily  = "daily"
+)
+
+// Limits — лимиты тарифа; 0 — без ограничения.
+type Limits struct {
+	// Active — сколько непроданных объявлений может быть у пользователя одновременно
+	Active int
+	Hourly int
+	Daily  int
+}
+
+// Tiers — лимиты по тарифам. Аккаунт моложе NewAccountAge получает лимиты New.
+type Tiers struct {
+	NewAccountAge time.Duration
+	New           Limits
+	Regular       Limits
+}
+
+// Counts — текущее использование квоты.
+type Counts struct {
+	Role           string
+	AccountCreated time.Time
+
+	Active int
+	Hourly int
+	Daily  int
+	// HourlyResetAt и DailyResetAt — когда освободится место в окне: самое старое создание
+	// в окне плюс длина окна; нулевые, если в окне ничего нет
+	HourlyResetAt time.Time
+	DailyResetAt  time.Time
+
+	Now time.Time
+}
+
+// Allowance — лимит, сколько из него использовано и сколько осталось.
+type Allowance struct {
+	Limit int `json:"limit"`
+	Used  int `json:"used"`
+	// Remaining нет, если лимита нет
+	Remaining *int       `json:"remaining,omitempty"`
+	ResetAt   *time.Time `json:"reset_at,omitempty"`
+}
+
+// Usage — ответ GET /me/quotas.
+type Usage struct {
+	Tier           string    `json:"tier"`
+	ActiveListings Allowance `json:"active_listings"`
+	Hourly         Allowance `json:"hourly"`
+	Daily          Allowance `json:"daily"`
+}
+
+// ExceededError — лимит исчерпан; RetryAfter — когда стоит повторить запрос.
+type ExceededError struct {
+	Limit      string
+	RetryAfter time.Duration
+}
+
+func (e *ExceededError) Error() string {
+	switch e.Limit {
+	case LimitActive:
+		return "active listings limit reached, sell or delete a listing first"
+	case LimitHourly:
+		return "too many listings created in the last hour"
+	default:
+		return "too many listings created in the last day"
+	}
+}
+
+func allowance(limit, used int, resetAt time.Time) Allowance {
+	a := Allowance{Limit: limit, Used: used}
+	if limit > 0 {
+		remaining := max(limit-used, 0)
+		a.Remaining = &remaining
+	}
+	if !resetAt.IsZero() {
+		a.ResetAt = &resetAt
+	}
+	return a
+}
@@ -0,0 +1,108 @@
+package quota
+
+// mockgen  -source=service.go -destination=service_mock_test.go -package=quota
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/auth"
+)
+
+type storage interface {
+	Counts(login string) (*Counts, error)
+	Consume(login string, check func(*Counts) error, create func(*sql.Tx) error) error
+	Prune() (int64, error)
+}
+
+type Service struct {
+	repository storage
+	tiers      Tiers
+	logger     *zap.Logger
+}
+
+func NewService(repository storage, tiers Tiers, logger *zap.Logger) *Service {
+	return &Service{
+		repository: repository,
+		tiers:      tiers,
+		logger:     logger,
+	}
+}
+
+// tier выбирает тариф по роли и возрасту аккаунта.
+func (s *Service) tier(c *Counts) (string, Limits) {
+	switch {
+	case c.Role == auth.RoleModerator:
+		return TierModerator, Limits{}
+	case c.Now.Sub(c.AccountCreated) < s.tiers.NewAccountAge:
+		return TierNew, s.tiers.New
+	default:
+		return TierRegular, s.tiers.Regular
+	}
+}
+
+// ConsumeListing списывает создание объявления и создаёт его через create в той же транзакции
+// или возвращает ExceededError, если исчерпан один из лимитов тарифа.
+func (s *Service) ConsumeListing(login string, create func(*sql.Tx) error) error {
+	return s.repository.Consume(login, func(c *Counts) error {
+		_, limits := s.tier(c)
+		switch {
+		case limits.Active > 0 && c.Active >= limits.Active:
+			// Место освободится, только когда объявление продадут или удалят; час — подсказка,
+			// когда проверить снова
+			return &ExceededError{Limit: LimitActive, RetryAfter: time.Hour}
+		case limits.Hourly > 0 && c.Hourly >= limits.Hourly:
+			return &ExceededError{Limit: LimitHourly, RetryAfter: retryAfter(c.HourlyResetAt, c.Now)}
+		case limits.Daily > 0 && c.Daily >= limits.Daily:
+			return &ExceededError{Limit: LimitDaily, RetryAfter: retryAfter(c.DailyResetAt, c.Now)}
+		}
+		return nil
+	}, create)
+}
+
+// GetUsage возвращает тариф пользователя и оставшиеся лимиты.
+func (s *Service) GetUsage(login string) (*Usage, error) {
+	c, err := s.repository.Counts(login)
+	if err != nil {
+		return nil, err
+	}
+	tier, limits := s.tier(c)
+	return &Usage{
+		Tier:           tier,
+		ActiveListings: allowance(limits.Active, c.Active, time.Time{}),
+		Hourly:         allowance(limits.Hourly, c.Hourly, c.HourlyResetAt),
+		Daily:          allowance(limits.Daily, c.Daily, c.DailyResetAt),
+	}, nil
+}
+
+// Run периодически чистит журнал созданий, пока не отменён ctx.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.repository.Prune(); err != nil {
+				s.logger.Warn("Failed to prune quota usage", zap.Error(err))
+			}
+		}
+	}
+}
+
+// retryAfter округляет ожидание до целой секунды вверх, но не меньше секунды.
+func retryAfter(resetAt, now time.Time) time.Duration {
+	d := resetAt.Sub(now)
+	if d < time.Second {
+		return time.Second
+	}
+	if d%time.Second != 0 {
+		d = d.Truncate(time.Second) + time.Second
+	}
+	return d
+}
@@ -0,0 +1,135 @@
+package quota
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/auth"
+)
+
+var testTiers = Tiers{
+	NewAccountAge: 7 * 24 * time.Hour,
+	New:           Limits{Active: 5, Hourly: 2, Daily: 5},
+	Regular:       Limits{Active: 50, Hourly: 10, Daily: 30},
+}
+
+func TestService_ConsumeListing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-30 * 24 * time.Hour)
+
+	testCases := []struct {
+		name string
+
+		counts Counts
+
+		expectedErr error
+	}{
+		{
+			name:   "1. Allowed",
+			counts: Counts{Role: auth.RoleUser, AccountCreated: old, Active: 3, Hourly: 1, Daily: 4, Now: now},
+		},
+		{
+			name: "2. Hourly_Exceeded",
+			counts: Counts{
+				Role: auth.RoleUser, AccountCreated: old, Active: 3, Hourly: 10, Daily: 10,
+				HourlyResetAt: now.Add(10*time.Minute + 500*time.Millisecond), Now: now,
+			},
+			expectedErr: &ExceededError{Limit: LimitHourly, RetryAfter: 10*time.Minute + time.Second},
+		},
+		{
+			name: "3. Daily_Exceeded",
+			counts: Counts{
+				Role: auth.RoleUser, AccountCreated: old, Active: 3, Hourly: 1, Daily: 30,
+				DailyResetAt: now.Add(5 * time.Hour), Now: now,
+			},
+			expectedErr: &ExceededError{Limit: LimitDaily, RetryAfter: 5 * time.Hour},
+		},
+		{
+			name:        "4. Active_Exceeded",
+			counts:      Counts{Role: auth.RoleUser, AccountCreated: old, Active: 50, Now: now},
+			expectedErr: &ExceededError{Limit: LimitActive, RetryAfter: time.Hour},
+		},
+		{
+			name: "5. New_Account",
+			counts: Counts{
+				Role: auth.RoleUser, AccountCreated: now.Add(-24 * time.Hour), Active: 2, Hourly: 2, Daily: 2,
+				HourlyResetAt: now.Add(time.Minute), Now: now,
+			},
+			expectedErr: &ExceededError{Limit: LimitHourly, RetryAfter: time.Minute},
+		},
+		{
+			name:   "6. Moderator_Unlimited",
+			counts: Counts{Role: auth.RoleModerator, AccountCreated: now, Active: 500, Hourly: 100, Daily: 1000, Now: now},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			repo.EXPECT().Consume("alice", gomock.Any(), gomock.Any()).DoAndReturn(
+				func(login string, check func(*Counts) error, create func(*sql.Tx) error) error {
+					counts := tc.counts
+					if err := check(&counts); err != nil {
+						return err
+					}
+					return create(nil)
+				},
+			)
+
+			service := NewService(repo, testTiers, zap.NewNop())
+			err := service.ConsumeListing("alice", func(*sql.Tx) error { return nil })
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestService_ConsumeListing_StorageError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	repo.EXPECT().Consume("ghost", gomock.Any(), gomock.Any()).Return(ErrUserNotFound)
+
+	service := NewService(repo, testTiers, zap.NewNop())
+	assert.True(t, errors.Is(service.ConsumeListing("ghost", func(*sql.Tx) error { return nil }), ErrUserNotFound))
+}
+
+func TestService_GetUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	hourlyReset := now.Add(20 * time.Minute)
+
+	repo := NewMockstorage(ctrl)
+	repo.EXPECT().Counts("alice").Return(&Counts{
+		Role: auth.RoleUser, AccountCreated: now.Add(-time.Hour),
+		Active: 6, Hourly: 1, Daily: 1, HourlyResetAt: hourlyReset, DailyResetAt: now.Add(23 * time.Hour), Now: now,
+	}, nil)
+	repo.EXPECT().Counts("mod").Return(&Counts{Role: auth.RoleModerator, AccountCreated: now, Active: 3, Now: now}, nil)
+
+	service := NewService(repo, testTiers, zap.NewNop())
+
+	usage, err := service.GetUsage("alice")
+	assert.NoError(t, err)
+	assert.Equal(t, TierNew, usage.Tier)
+	assert.Equal(t, 0, *usage.ActiveListings.Remaining)
+	assert.Equal(t, 1, *usage.Hourly.Remaining)
+	assert.Equal(t, hourlyReset, *usage.Hourly.ResetAt)
+	assert.Nil(t, usage.ActiveListings.ResetAt)
+
+	usage, err = service.GetUsage("mod")
+	assert.NoError(t, err)
+	assert.Equal(t, TierModerator, usage.Tier)
+	assert.Nil(t, usage.ActiveListings.Remaining)
+	assert.Equal(t, 3, usage.ActiveListings.Used)
+}
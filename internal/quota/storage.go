@@ -0,0 +1,126 @@
+package quota
+
+// mockgen  -source=storage.go -destination=storage_mock_test.go -package=quota
+
+import (
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// ErrUserNotFound — квоты запрошены для несуществующего пользователя.
+var ErrUserNotFound = errors.New("user not found")
+
+// countsQuery считает использование квоты пользователя $1. Создания объявлений берутся
+// из журнала quota_usage, а не из posts, чтобы удаление поста не возвращало квоту.
+const countsQuery = `
+	SELECT
+		u.role,
+		u.created_at,
+		(SELECT COUNT(*) FROM posts p WHERE p.owner = u.login AND p.status <> 'sold'),
+		(SELECT COUNT(*) FROM quota_usage q WHERE q.login = u.login AND q.created_at > NOW() - INTERVAL '1 hour'),
+		(SELECT COUNT(*) FROM quota_usage q WHERE q.login = u.login AND q.created_at > NOW() - INTERVAL '1 day'),
+		(SELECT MIN(q.created_at) + INTERVAL '1 hour' FROM quota_usage q
+			WHERE q.login = u.login AND q.created_at > NOW() - INTERVAL '1 hour'),
+		(SELECT MIN(q.created_at) + INTERVAL '1 day' FROM quota_usage q
+			WHERE q.login = u.login AND q.created_at > NOW() - INTERVAL '1 day'),
+		NOW()
+	FROM users u
+	WHERE u.login = $1
+`
+
+type Repository interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+	Begin() (*sql.Tx, error)
+}
+
+type Storage struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+func NewStorage(repository Repository, logger *zap.Logger) *Storage {
+	return &Storage{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+func scanCounts(row *sql.Row) (*Counts, error) {
+	var (
+		c                       Counts
+		hourlyReset, dailyReset sql.NullTime
+	)
+	err := row.Scan(&c.Role, &c.AccountCreated, &c.Active, &c.Hourly, &c.Daily, &hourlyReset, &dailyReset, &c.Now)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	c.HourlyResetAt = hourlyReset.Time
+	c.DailyResetAt = dailyReset.Time
+	return &c, nil
+}
+
+// Counts возвращает текущее использование квоты.
+func (r *Storage) Counts(login string) (*Counts, error) {
+	c, err := scanCounts(r.repository.QueryRow(countsQuery, login))
+	if err != nil && !errors.Is(err, ErrUserNotFound) {
+		r.logger.Error("Failed to count quota usage", zap.String("login", login), zap.Error(err))
+		return nil, errors.Errorf("failed to count quota usage: %v", err)
+	}
+	return c, err
+}
+
+// Consume списывает одно создание объявления, если check разрешает его при текущем использовании,
+// и выполняет create в той же транзакции. Запросы одного пользователя выстраиваются в очередь
+// на advisory-блокировке, которая держится до фиксации вместе с созданным постом, поэтому
+// параллельные запросы не превысят ни лимиты в час и в день, ни число активных объявлений.
+// Если create вернул ошибку, квота не списывается.
+func (r *Storage) Consume(login string, check func(*Counts) error, create func(*sql.Tx) error) error {
+	tx, err := r.repository.Begin()
+	if err != nil {
+		return errors.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('quota:' || $1))`, login); err != nil {
+		return errors.Errorf("failed to lock quota: %v", err)
+	}
+
+	c, err := scanCounts(tx.QueryRow(countsQuery, login))
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return err
+		}
+		r.logger.Error("Failed to count quota usage", zap.String("login", login), zap.Error(err))
+		return errors.Errorf("failed to count quota usage: %v", err)
+	}
+	if err := check(c); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`INSERT INTO quota_usage (login) VALUES ($1)`, login); err != nil {
+		r.logger.Error("Failed to record quota usage", zap.String("login", login), zap.Error(err))
+		return errors.Errorf("failed to record quota usage: %v", err)
+	}
+	if err := create(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Errorf("failed to commit quota usage: %v", err)
+	}
+	return nil
+}
+
+// Prune удаляет из журнала записи старше суток: в лимиты они уже не входят.
+func (r *Storage) Prune() (int64, error) {
+	res, err := r.repository.Exec(`DELETE FROM quota_usage WHERE created_at < NOW() - INTERVAL '1 day'`)
+	if err != nil {
+		return 0, errors.Errorf("failed to prune quota usage: %v", err)
+	}
+	return res.RowsAffected()
+}
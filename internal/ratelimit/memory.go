@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	// fullAt — когда корзина наполнится и её можно забыть
+	fullAt time.Time
+}
+
+// Memory хранит корзины в памяти процесса. Подходит для одного инстанса.
+type Memory struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (m *Memory) Take(key string, limit Limit) (Decision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	capacity := float64(limit.Capacity())
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updatedAt: now}
+		m.buckets[key] = b
+	}
+
+	tokens := math.Min(capacity, b.tokens+now.Sub(b.updatedAt).Seconds()*limit.perSecond())
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+	b.tokens, b.updatedAt = tokens, now
+
+	d := decide(limit, tokens, allowed)
+	b.fullAt = now.Add(d.Reset)
+	return d, nil
+}
+
+// Prune забывает наполнившиеся корзины: новая корзина для того же ключа будет такой же.
+func (m *Memory) Prune() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	for key, b := range m.buckets {
+		if !b.fullAt.After(now) {
+			delete(m.buckets, key)
+		}
+	}
+}
+
+// Run периодически вызывает Prune, пока не отменён ctx.
+func (m *Memory) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Prune()
+		}
+	}
+}
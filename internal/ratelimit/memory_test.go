@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemory_Take(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+
+	// 6 запросов в минуту, до 3 подряд: токен каждые 10 секунд
+	limit := Limit{Rate: 6, Period: time.Minute, Burst: 3}
+
+	for i := 2; i >= 0; i-- {
+		d, err := m.Take("alice", limit)
+		assert.NoError(t, err)
+		assert.True(t, d.Allowed)
+		assert.Equal(t, 3, d.Limit)
+		assert.Equal(t, i, d.Remaining)
+	}
+
+	d, _ := m.Take("alice", limit)
+	assert.False(t, d.Allowed)
+	assert.Equal(t, 0, d.Remaining)
+	assert.Equal(t, 10*time.Second, d.RetryAfter)
+	assert.Equal(t, 30*time.Second, d.Reset)
+
+	// Другой ключ — другая корзина
+	d, _ = m.Take("bob", limit)
+	assert.True(t, d.Allowed)
+
+	now = now.Add(15 * time.Second)
+	d, _ = m.Take("alice", limit)
+	assert.True(t, d.Allowed)
+	assert.Equal(t, 0, d.Remaining)
+
+	d, _ = m.Take("alice", limit)
+	assert.False(t, d.Allowed)
+	assert.Equal(t, 5*time.Second, d.RetryAfter)
+}
+
+func TestMemory_Prune(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+
+	limit := Limit{Rate: 60, Period: time.Minute}
+	m.Take("alice", limit)
+	m.Take("bob", limit)
+
+	now = now.Add(500 * time.Millisecond)
+	m.Take("bob", limit)
+
+	now = now.Add(600 * time.Millisecond)
+	m.Prune()
+	assert.NotContains(t, m.buckets, "alice")
+	assert.Contains(t, m.buckets, "bob")
+}
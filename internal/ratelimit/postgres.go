@@ -0,0 +1,97 @@
+package ratelimit
+
+// mockgen  -source=postgres.go -destination=postgres_mock_test.go -package=ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// takeQuery пополняет корзину за прошедшее время и списывает токен одним запросом, поэтому
+// параллельные запросы с разных инстансов не спишут больше, чем есть. Если токена нет,
+// строка не обновляется и запрос ничего не возвращает.
+const takeQuery = `
+	INSERT INTO rate_limits AS b (key, tokens, updated_at, full_at)
+	VALUES ($1, $2::float8 - 1, NOW(), NOW() + make_interval(secs => 1 / $3::float8))
+	ON CONFLICT (key) DO UPDATE SET
+		tokens = LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) * $3::float8) - 1,
+		updated_at = NOW(),
+		full_at = NOW() + make_interval(secs =>
+			($2::float8 - LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) * $3::float8) + 1) / $3::float8)
+	WHERE LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) * $3::float8) >= 1
+	RETURNING tokens
+`
+
+type Repository interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// PGStore хранит корзины в таблице rate_limits, общей для всех инстансов.
+type PGStore struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+func NewPGStore(repository Repository, logger *zap.Logger) *PGStore {
+	return &PGStore{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+func (s *PGStore) Take(key string, limit Limit) (Decision, error) {
+	capacity, rate := float64(limit.Capacity()), limit.perSecond()
+
+	var tokens float64
+	err := s.repository.QueryRow(takeQuery, key, capacity, rate).Scan(&tokens)
+	if err == nil {
+		return decide(limit, tokens, true), nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		s.logger.Error("Failed to take rate limit token", zap.String("key", key), zap.Error(err))
+		return Decision{}, errors.Errorf("failed to take rate limit token: %v", err)
+	}
+
+	// Токена нет: узнаём, сколько накопилось, чтобы ответить, когда повторить
+	err = s.repository.QueryRow(`
+		SELECT LEAST($2::float8, tokens + EXTRACT(EPOCH FROM NOW() - updated_at) * $3::float8)
+		FROM rate_limits
+		WHERE key = $1
+	`, key, capacity, rate).Scan(&tokens)
+	if err != nil {
+		s.logger.Error("Failed to read rate limit bucket", zap.String("key", key), zap.Error(err))
+		return Decision{}, errors.Errorf("failed to read rate limit bucket: %v", err)
+	}
+	return decide(limit, tokens, false), nil
+}
+
+// Prune удаляет наполнившиеся корзины.
+func (s *PGStore) Prune() (int64, error) {
+	res, err := s.repository.Exec(`DELETE FROM rate_limits WHERE full_at < NOW()`)
+	if err != nil {
+		return 0, errors.Errorf("failed to prune rate limits: %v", err)
+	}
+	return res.RowsAffected()
+}
+
+// Run периодически вызывает Prune, пока не отменён ctx.
+func (s *PGStore) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.Prune(); err != nil {
+				s.logger.Warn("Failed to prune rate limits", zap.Error(err))
+			}
+		}
+	}
+}
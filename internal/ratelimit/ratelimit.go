@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Store списывает запросы с корзин токенов. Его реализуют Memory (в пределах процесса)
+// и PGStore (общие корзины для всех инстансов в Postgres).
+type Store interface {
+	Take(key string, limit Limit) (Decision, error)
+}
+
+// Limit — корзина токенов: вмещает Burst запросов и пополняется на Rate запросов за Period.
+type Limit struct {
+	Rate   int
+	Period time.Duration
+	// Burst — ёмкость корзины; 0 — равна Rate
+	Burst int
+}
+
+// Capacity возвращает ёмкость корзины.
+func (l Limit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Rate
+}
+
+// perSecond — сколько токенов добавляется в корзину за секунду.
+func (l Limit) perSecond() float64 {
+	return float64(l.Rate) / l.Period.Seconds()
+}
+
+// Decision — результат списания запроса.
+type Decision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset — через сколько корзина наполнится полностью
+	Reset time.Duration
+	// RetryAfter — через сколько появится токен, если запрос отклонён
+	RetryAfter time.Duration
+}
+
+// decide строит Decision по числу токенов в корзине после списания.
+func decide(limit Limit, tokens float64, allowed bool) Decision {
+	d := Decision{
+		Allowed:   allowed,
+		Limit:     limit.Capacity(),
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(limit.Capacity()) - tokens) / limit.perSecond()),
+	}
+	if !allowed {
+		d.RetryAfter = seconds((1 - tokens) / limit.perSecond())
+	}
+	return d
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Max(s, 0) * float64(time.Second))
+}
@@ -0,0 +1,178 @@
+package review
+
+// mockgen  -source=handler.go -destination=handler_mock_test.go -package=review
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/block"
+	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+)
+
+type service interface {
+	CreateReview(author, seller string, rating int, text string) (*Review, error)
+	ListReviews(seller, viewer string) ([]*Review, error)
+	GetProfile(login string) (*Profile, error)
+	Reply(id uint, seller, text string) (*Review, error)
+	SetHidden(id uint, moderator string, hidden bool) (*Review, error)
+}
+
+type Handler struct {
+	service service
+	logger  *zap.Logger
+}
+
+func NewHandler(service service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+type reviewRequest struct {
+	Rating int    `json:"rating"`
+	Text   string `json:"text"`
+}
+
+type replyRequest struct {
+	Text string `json:"text"`
+}
+
+// writeError переводит ошибки отзывов в HTTP-статусы.
+func (h *Handler) writeError(w http.ResponseWriter, err error, msg string, fields ...zap.Field) {
+	switch {
+	case errors.Is(err, ErrReviewNotFound), errors.Is(err, ErrUserNotFound):
+		http.Error(w, "Not Found", http.StatusNotFound)
+	case errors.Is(err, ErrNotEligible), errors.Is(err, ErrNotAllowed), errors.Is(err, ErrNotModerator),
+		errors.Is(err, block.ErrBlocked):
+		http.Error(w, "Forbidden: "+err.Error(), http.StatusForbidden)
+	case errors.Is(err, ErrAlreadyReviewed), errors.Is(err, ErrAlreadyReplied):
+		http.Error(w, "Conflict: "+err.Error(), http.StatusConflict)
+	case errors.Is(err, ErrOwnReview), errors.Is(err, ErrInvalidRating), errors.Is(err, ErrInvalidText):
+		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+	default:
+		h.logger.Error(msg, append(fields, zap.Error(err))...)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// User обрабатывает GET /users/{login} (профиль продавца), GET /users/{login}/reviews
+// и POST /users/{login}/reviews (оставить отзыв, нужна авторизация).
+func (h *Handler) User(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || len(parts) > 4 || parts[1] != "users" || parts[2] == "" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	seller := parts[2]
+	viewer, _ := jwt.GetLogin(r)
+
+	if len(parts) == 3 {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		p, err := h.service.GetProfile(seller)
+		if err != nil {
+			h.writeError(w, err, "Failed to get profile", zap.String("login", seller))
+			return
+		}
+		writeJSON(w, http.StatusOK, p)
+		return
+	}
+
+	if parts[3] != "reviews" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		reviews, err := h.service.ListReviews(seller, viewer)
+		if err != nil {
+			h.writeError(w, err, "Failed to list reviews", zap.String("seller", seller))
+			return
+		}
+		writeJSON(w, http.StatusOK, reviews)
+	case http.MethodPost:
+		if viewer == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var req reviewRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Bad Request: invalid JSON", http.StatusBadRequest)
+			return
+		}
+		rv, err := h.service.CreateReview(viewer, seller, req.Rating, req.Text)
+		if err != nil {
+			h.writeError(w, err, "Failed to create review", zap.String("seller", seller), zap.String("author", viewer))
+			return
+		}
+		writeJSON(w, http.StatusCreated, rv)
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Review обрабатывает POST /reviews/{id}/{reply|hide|unhide}.
+func (h *Handler) Review(w http.ResponseWriter, r *http.Request) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	parts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+	if len(parts) != 4 || parts[1] != "reviews" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	id64, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		http.Error(w, "Bad Request: invalid id", http.StatusBadRequest)
+		return
+	}
+	id := uint(id64)
+
+	action := parts[3]
+	switch action {
+	case ActionReply, ActionHide, ActionUnhide:
+	default:
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var rv *Review
+	if action == ActionReply {
+		var req replyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Bad Request: invalid JSON", http.StatusBadRequest)
+			return
+		}
+		rv, err = h.service.Reply(id, login, req.Text)
+	} else {
+		rv, err = h.service.SetHidden(id, login, action == ActionHide)
+	}
+	if err != nil {
+		h.writeError(w, err, "Failed to update review", zap.Uint("review_id", id), zap.String("action", action))
+		return
+	}
+	writeJSON(w, http.StatusOK, rv)
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+
+// Package review is a generated GoMock package.
+package review
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockservice is a mock of service interface.
+type Mockservice struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceMockRecorder
+}
+
+// MockserviceMockRecorder is the mock recorder for Mockservice.
+type MockserviceMockRecorder struct {
+	mock *Mockservice
+}
+
+// NewMockservice creates a new mock instance.
+func NewMockservice(ctrl *gomock.Controller) *Mockservice {
+	mock := &Mockservice{ctrl: ctrl}
+	mock.recorder = &MockserviceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockservice) EXPECT() *MockserviceMockRecorder {
+	return m.recorder
+}
+
+// CreateReview mocks base method.
+func (m *Mockservice) CreateReview(author, seller string, rating int, text string) (*Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", author, seller, rating, text)
+	ret0, _ := ret[0].(*Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockserviceMockRecorder) CreateReview(author, seller, rating, text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*Mockservice)(nil).CreateReview), author, seller, rating, text)
+}
+
+// GetProfile mocks base method.
+func (m *Mockservice) GetProfile(login string) (*Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", login)
+	ret0, _ := ret[0].(*Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockserviceMockRecorder) GetProfile(login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*Mockservice)(nil).GetProfile), login)
+}
+
+// ListReviews mocks base method.
+func (m *Mockservice) ListReviews(seller, viewer string) ([]*Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviews", seller, viewer)
+	ret0, _ := ret[0].([]*Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviews indicates an expected call of ListReviews.
+func (mr *MockserviceMockRecorder) ListReviews(seller, viewer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviews", reflect.TypeOf((*Mockservice)(nil).ListReviews), seller, viewer)
+}
+
+// Reply mocks base method.
+func (m *Mockservice) Reply(id uint, seller, text string) (*Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reply", id, seller, text)
+	ret0, _ := ret[0].(*Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reply indicates an expected call of Reply.
+func (mr *MockserviceMockRecorder) Reply(id, seller, text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reply", reflect.TypeOf((*Mockservice)(nil).Reply), id, seller, text)
+}
+
+// SetHidden mocks base method.
+func (m *Mockservice) SetHidden(id uint, moderator string, hidden bool) (*Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHidden", id, moderator, hidden)
+	ret0, _ := ret[0].(*Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetHidden indicates an expected call of SetHidden.
+func (mr *MockserviceMockRecorder) SetHidden(id, moderator, hidden interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHidden", reflect.TypeOf((*Mockservice)(nil).SetHidden), id, moderator, hidden)
+}
@@ -0,0 +1,206 @@
+package review
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+)
+
+func newRequest(method, path, body, user string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if user != "" {
+		req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, user))
+	}
+	return req
+}
+
+func TestHandler_User(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rating := 4.5
+
+	testCases := []struct {
+		name string
+
+		method     string
+		path       string
+		body       string
+		user       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name:   "1. Profile",
+			method: http.MethodGet,
+			path:   "/users/bob",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetProfile("bob").Return(&Profile{Login: "bob", Rating: &rating, ReviewsCount: 2}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "2. Profile_Not_Found",
+			method: http.MethodGet,
+			path:   "/users/ghost",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().GetProfile("ghost").Return(nil, ErrUserNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "3. List_Reviews",
+			method: http.MethodGet,
+			path:   "/users/bob/reviews",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().ListReviews("bob", "").Return([]*Review{}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "4. Create_Review",
+			method: http.MethodPost,
+			path:   "/users/bob/reviews",
+			body:   `{"rating":5,"text":"Great"}`,
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().CreateReview("alice", "bob", 5, "Great").Return(&Review{ID: 1}, nil)
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name:   "5. Not_Eligible",
+			method: http.MethodPost,
+			path:   "/users/bob/reviews",
+			body:   `{"rating":1,"text":"Bad"}`,
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().CreateReview("alice", "bob", 1, "Bad").Return(nil, ErrNotEligible)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:   "6. Already_Reviewed",
+			method: http.MethodPost,
+			path:   "/users/bob/reviews",
+			body:   `{"rating":1,"text":"Bad"}`,
+			user:   "alice",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().CreateReview("alice", "bob", 1, "Bad").Return(nil, ErrAlreadyReviewed)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "7. Create_Anonymous",
+			method:       http.MethodPost,
+			path:         "/users/bob/reviews",
+			body:         `{"rating":5,"text":"Great"}`,
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "8. Unknown_Path",
+			method:       http.MethodGet,
+			path:         "/users/bob/posts",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+			rr := httptest.NewRecorder()
+
+			handler.User(rr, newRequest(tc.method, tc.path, tc.body, tc.user))
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
+
+func TestHandler_Review(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		path       string
+		body       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name: "1. Reply",
+			path: "/reviews/3/reply",
+			body: `{"text":"Thanks"}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Reply(uint(3), "bob", "Thanks").Return(&Review{ID: 3}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "2. Second_Reply",
+			path: "/reviews/3/reply",
+			body: `{"text":"Thanks again"}`,
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().Reply(uint(3), "bob", "Thanks again").Return(nil, ErrAlreadyReplied)
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name: "3. Hide_Not_Moderator",
+			path: "/reviews/3/hide",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().SetHidden(uint(3), "bob", true).Return(nil, ErrNotModerator)
+			},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name: "4. Unhide",
+			path: "/reviews/3/unhide",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().SetHidden(uint(3), "bob", false).Return(&Review{ID: 3}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "5. Unknown_Action",
+			path:         "/reviews/3/delete",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "6. Invalid_ID",
+			path:         "/reviews/abc/hide",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+			rr := httptest.NewRecorder()
+
+			handler.Review(rr, newRequest(http.MethodPost, tc.path, tc.body, "bob"))
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package review
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// KindReview — уведомление о новом отзыве или ответе на него.
+const KindReview = "review"
+
+const maxTextLength = 2000
+
+var (
+	ErrReviewNotFound  = errors.New("review not found")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrOwnReview       = errors.New("cannot review yourself")
+	ErrNotEligible     = errors.New("only buyers with a completed order or a conversation with the seller can leave a review")
+	ErrAlreadyReviewed = errors.New("you have already reviewed this seller")
+	ErrAlreadyReplied  = errors.New("review already has a reply")
+	ErrNotAllowed      = errors.New("only the reviewed seller can reply")
+	ErrNotModerator    = errors.New("only moderators can hide reviews")
+	ErrInvalidRating   = errors.New("rating must be between 1 and 5")
+	ErrInvalidText     = errors.New("text must be between 1 and 2000 characters")
+	ErrUnknownAction   = errors.New("unknown review action")
+)
+
+// Действия над отзывом.
+const (
+	ActionReply  = "reply"
+	ActionHide   = "hide"
+	ActionUnhide = "unhide"
+)
+
+// Review — отзыв покупателя о продавце. Покупатель оставляет продавцу один отзыв.
+type Review struct {
+	ID        uint      `json:"id"`
+	Seller    string    `json:"seller"`
+	Author    string    `json:"author"`
+	Rating    int       `json:"rating"`
+	Text      string    `json:"text"`
+	Reply     *Reply    `json:"reply,omitempty"`
+	Hidden    bool      `json:"hidden,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Reply — единственный ответ продавца на отзыв.
+type Reply struct {
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Profile — публичный профиль продавца. Rating — средняя оценка по видимым отзывам.
+type Profile struct {
+	Login        string   `json:"login"`
+	Rating       *float64 `json:"rating,omitempty"`
+	ReviewsCount int      `json:"reviews_count"`
+}
+
+func validateRating(rating int) error {
+	if rating < 1 || rating > 5 {
+		return ErrInvalidRating
+	}
+	return nil
+}
+
+// normalizeText обрезает пробелы по краям и проверяет длину текста отзыва или ответа.
+func normalizeText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || utf8.RuneCountInString(text) > maxTextLength {
+		return "", ErrInvalidText
+	}
+	return text, nil
+}
@@ -0,0 +1,224 @@
+package review
+
+// mockgen  -source=service.go -destination=service_mock_test.go -package=review
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/auth"
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+)
+
+type storage interface {
+	Eligible(author, seller string) (bool, error)
+	Create(rv *Review) error
+	GetByID(id uint) (*Review, error)
+	ListBySeller(seller string, withHidden bool) ([]*Review, error)
+	Reply(id uint, text string) (*Review, error)
+	SetHidden(id uint, moderator string, hidden bool) (*Review, error)
+	GetProfile(login string) (*Profile, error)
+}
+
+type users interface {
+	GetRole(login string) (string, error)
+}
+
+type notifier interface {
+	Notify(msg notify.Message) error
+}
+
+// blocks — блокировки между пользователями.
+type blocks interface {
+	Check(a, b string) error
+	ListBlocked(blocker string) ([]string, error)
+}
+
+type Service struct {
+	repository storage
+	users      users
+	notifier   notifier
+	blocks     blocks
+	logger     *zap.Logger
+}
+
+type Option func(*Service)
+
+// WithNotifier включает уведомления продавцу о новых отзывах и автору — об ответе продавца.
+func WithNotifier(notifier notifier) Option {
+	return func(s *Service) {
+		s.notifier = notifier
+	}
+}
+
+// WithBlocks запрещает отзывы и ответы между теми, у кого есть блокировка, и скрывает
+// от зрителя отзывы заблокированных им авторов.
+func WithBlocks(blocks blocks) Option {
+	return func(s *Service) {
+		s.blocks = blocks
+	}
+}
+
+func NewService(repository storage, users users, logger *zap.Logger, opts ...Option) *Service {
+	s := &Service{
+		repository: repository,
+		users:      users,
+		logger:     logger,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// CreateReview оставляет отзыв о продавце. Писать может только покупатель, завершивший
+// заказ у продавца или переписывавшийся с ним.
+func (s *Service) CreateReview(author, seller string, rating int, text string) (*Review, error) {
+	if author == seller {
+		return nil, ErrOwnReview
+	}
+	if err := validateRating(rating); err != nil {
+		return nil, err
+	}
+	text, err := normalizeText(text)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkBlocked(author, seller); err != nil {
+		return nil, err
+	}
+
+	ok, err := s.repository.Eligible(author, seller)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNotEligible
+	}
+
+	rv := &Review{Seller: seller, Author: author, Rating: rating, Text: text}
+	if err := s.repository.Create(rv); err != nil {
+		return nil, err
+	}
+	s.notify(seller, rv, fmt.Sprintf("%s left you a %d-star review", author, rating))
+	return rv, nil
+}
+
+// ListReviews возвращает отзывы о продавце; модератор видит и скрытые. Отзывы авторов,
+// которых зритель заблокировал, ему не показываются.
+func (s *Service) ListReviews(seller, viewer string) ([]*Review, error) {
+	moderator, err := s.isModerator(viewer)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := s.repository.ListBySeller(seller, moderator)
+	if err != nil || viewer == "" || moderator || s.blocks == nil {
+		return reviews, err
+	}
+
+	blocked, err := s.blocks.ListBlocked(viewer)
+	if err != nil {
+		return nil, err
+	}
+	if len(blocked) == 0 {
+		return reviews, nil
+	}
+	visible := make([]*Review, 0, len(reviews))
+	for _, rv := range reviews {
+		if !slices.Contains(blocked, rv.Author) {
+			visible = append(visible, rv)
+		}
+	}
+	return visible, nil
+}
+
+func (s *Service) GetProfile(login string) (*Profile, error) {
+	return s.repository.GetProfile(login)
+}
+
+// Reply отвечает на отзыв. Ответить может только продавец и только один раз.
+func (s *Service) Reply(id uint, seller, text string) (*Review, error) {
+	text, err := normalizeText(text)
+	if err != nil {
+		return nil, err
+	}
+	rv, err := s.repository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if rv.Seller != seller {
+		return nil, ErrNotAllowed
+	}
+	if rv.Reply != nil {
+		return nil, ErrAlreadyReplied
+	}
+	if err := s.checkBlocked(seller, rv.Author); err != nil {
+		return nil, err
+	}
+
+	replied, err := s.repository.Reply(id, text)
+	if err != nil {
+		return nil, err
+	}
+	s.notify(replied.Author, replied, fmt.Sprintf("%s replied to your review", seller))
+	return replied, nil
+}
+
+// SetHidden скрывает отзыв или возвращает его; доступно только модераторам.
+// Скрытый отзыв не показывается и не учитывается в рейтинге.
+func (s *Service) SetHidden(id uint, moderator string, hidden bool) (*Review, error) {
+	ok, err := s.isModerator(moderator)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrNotModerator
+	}
+	return s.repository.SetHidden(id, moderator, hidden)
+}
+
+func (s *Service) isModerator(login string) (bool, error) {
+	if login == "" {
+		return false, nil
+	}
+	role, err := s.users.GetRole(login)
+	if err != nil {
+		return false, err
+	}
+	return role == auth.RoleModerator, nil
+}
+
+func (s *Service) checkBlocked(a, b string) error {
+	if s.blocks == nil {
+		return nil
+	}
+	return s.blocks.Check(a, b)
+}
+
+func (s *Service) notify(recipient string, rv *Review, title string) {
+	if s.notifier == nil {
+		return
+	}
+	payload, err := json.Marshal(rv)
+	if err != nil {
+		return
+	}
+	err = s.notifier.Notify(notify.Message{
+		Recipient: recipient,
+		Kind:      KindReview,
+		Title:     title,
+		Payload:   payload,
+		Channel:   notify.ChannelInbox,
+	})
+	if err != nil {
+		s.logger.Warn(
+			"Failed to queue review notification",
+			zap.Uint("review_id", rv.ID),
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,283 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/auth"
+	"github.com/TemirB/rest-api-marketplace/internal/block"
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+)
+
+func TestService_CreateReview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		author     string
+		rating     int
+		text       string
+		setupMocks func(repo *Mockstorage, n *Mocknotifier)
+
+		expectedErr error
+	}{
+		{
+			name:   "1. Created",
+			author: "alice",
+			rating: 5,
+			text:   "  Fast shipping  ",
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().Eligible("alice", "bob").Return(true, nil)
+				repo.EXPECT().Create(&Review{Seller: "bob", Author: "alice", Rating: 5, Text: "Fast shipping"}).Return(nil)
+				n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+					assert.Equal(t, "bob", msg.Recipient)
+					assert.Equal(t, KindReview, msg.Kind)
+					return nil
+				})
+			},
+		},
+		{
+			name:   "2. Not_Eligible",
+			author: "alice",
+			rating: 1,
+			text:   "Never answered",
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().Eligible("alice", "bob").Return(false, nil)
+			},
+			expectedErr: ErrNotEligible,
+		},
+		{
+			name:   "3. Already_Reviewed",
+			author: "alice",
+			rating: 4,
+			text:   "Again",
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().Eligible("alice", "bob").Return(true, nil)
+				repo.EXPECT().Create(gomock.Any()).Return(ErrAlreadyReviewed)
+			},
+			expectedErr: ErrAlreadyReviewed,
+		},
+		{
+			name:        "4. Own_Review",
+			author:      "bob",
+			rating:      5,
+			text:        "I am great",
+			setupMocks:  func(repo *Mockstorage, n *Mocknotifier) {},
+			expectedErr: ErrOwnReview,
+		},
+		{
+			name:        "5. Rating_Out_Of_Range",
+			author:      "alice",
+			rating:      6,
+			text:        "Great",
+			setupMocks:  func(repo *Mockstorage, n *Mocknotifier) {},
+			expectedErr: ErrInvalidRating,
+		},
+		{
+			name:        "6. Empty_Text",
+			author:      "alice",
+			rating:      3,
+			text:        "   ",
+			setupMocks:  func(repo *Mockstorage, n *Mocknotifier) {},
+			expectedErr: ErrInvalidText,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			n := NewMocknotifier(ctrl)
+			tc.setupMocks(repo, n)
+			service := NewService(repo, NewMockusers(ctrl), zap.NewNop(), WithNotifier(n))
+
+			rv, err := service.CreateReview(tc.author, "bob", tc.rating, tc.text)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "Fast shipping", rv.Text)
+		})
+	}
+}
+
+func TestService_Reply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	review := func() *Review {
+		return &Review{ID: 3, Seller: "bob", Author: "alice", Rating: 2, Text: "Slow"}
+	}
+
+	testCases := []struct {
+		name string
+
+		seller     string
+		setupMocks func(repo *Mockstorage, n *Mocknotifier)
+
+		expectedErr error
+	}{
+		{
+			name:   "1. Replied",
+			seller: "bob",
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				replied := review()
+				replied.Reply = &Reply{Text: "Sorry"}
+				repo.EXPECT().GetByID(uint(3)).Return(review(), nil)
+				repo.EXPECT().Reply(uint(3), "Sorry").Return(replied, nil)
+				n.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+					assert.Equal(t, "alice", msg.Recipient)
+					return nil
+				})
+			},
+		},
+		{
+			name:   "2. Not_The_Seller",
+			seller: "eve",
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(3)).Return(review(), nil)
+			},
+			expectedErr: ErrNotAllowed,
+		},
+		{
+			name:   "3. Already_Replied",
+			seller: "bob",
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				replied := review()
+				replied.Reply = &Reply{Text: "Sorry"}
+				repo.EXPECT().GetByID(uint(3)).Return(replied, nil)
+			},
+			expectedErr: ErrAlreadyReplied,
+		},
+		{
+			name:   "4. Replied_Concurrently",
+			seller: "bob",
+			setupMocks: func(repo *Mockstorage, n *Mocknotifier) {
+				repo.EXPECT().GetByID(uint(3)).Return(review(), nil)
+				repo.EXPECT().Reply(uint(3), "Sorry").Return(nil, ErrAlreadyReplied)
+			},
+			expectedErr: ErrAlreadyReplied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			n := NewMocknotifier(ctrl)
+			tc.setupMocks(repo, n)
+			service := NewService(repo, NewMockusers(ctrl), zap.NewNop(), WithNotifier(n))
+
+			rv, err := service.Reply(3, tc.seller, "Sorry")
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "Sorry", rv.Reply.Text)
+		})
+	}
+}
+
+func TestService_SetHidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		role       string
+		setupMocks func(repo *Mockstorage)
+
+		expectedErr error
+	}{
+		{
+			name: "1. Moderator_Hides",
+			role: auth.RoleModerator,
+			setupMocks: func(repo *Mockstorage) {
+				repo.EXPECT().SetHidden(uint(3), "mod", true).Return(&Review{ID: 3, Hidden: true}, nil)
+			},
+		},
+		{
+			name:        "2. User_Cannot_Hide",
+			role:        auth.RoleUser,
+			setupMocks:  func(repo *Mockstorage) {},
+			expectedErr: ErrNotModerator,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := NewMockstorage(ctrl)
+			users := NewMockusers(ctrl)
+			users.EXPECT().GetRole("mod").Return(tc.role, nil)
+			tc.setupMocks(repo)
+			service := NewService(repo, users, zap.NewNop())
+
+			rv, err := service.SetHidden(3, "mod", true)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, rv.Hidden)
+		})
+	}
+}
+
+func TestService_ListReviews(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	users := NewMockusers(ctrl)
+	service := NewService(repo, users, zap.NewNop())
+
+	// Анонимный зритель не видит скрытые отзывы, модератор — видит
+	repo.EXPECT().ListBySeller("bob", false).Return([]*Review{}, nil)
+	_, err := service.ListReviews("bob", "")
+	assert.NoError(t, err)
+
+	users.EXPECT().GetRole("mod").Return(auth.RoleModerator, nil)
+	repo.EXPECT().ListBySeller("bob", true).Return([]*Review{{ID: 3, Hidden: true}}, nil)
+	reviews, err := service.ListReviews("bob", "mod")
+	assert.NoError(t, err)
+	assert.Len(t, reviews, 1)
+}
+
+func TestService_Blocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	users := NewMockusers(ctrl)
+	blocks := NewMockblocks(ctrl)
+	service := NewService(repo, users, zap.NewNop(), WithBlocks(blocks))
+
+	// Заблокированный не может оставить отзыв
+	blocks.EXPECT().Check("spammer", "bob").Return(block.ErrBlocked)
+	_, err := service.CreateReview("spammer", "bob", 1, "Terrible")
+	assert.ErrorIs(t, err, block.ErrBlocked)
+
+	// Отзывы заблокированных зрителем авторов скрыты
+	users.EXPECT().GetRole("alice").Return(auth.RoleUser, nil)
+	repo.EXPECT().ListBySeller("bob", false).Return([]*Review{
+		{ID: 1, Author: "spammer"},
+		{ID: 2, Author: "carol"},
+	}, nil)
+	blocks.EXPECT().ListBlocked("alice").Return([]string{"spammer"}, nil)
+	reviews, err := service.ListReviews("bob", "alice")
+	assert.NoError(t, err)
+	assert.Len(t, reviews, 1)
+	assert.Equal(t, "carol", reviews[0].Author)
+
+	// Продавец не отвечает тому, с кем есть блокировка
+	repo.EXPECT().GetByID(uint(1)).Return(&Review{ID: 1, Seller: "bob", Author: "spammer"}, nil)
+	blocks.EXPECT().Check("bob", "spammer").Return(block.ErrBlocked)
+	_, err = service.Reply(1, "bob", "No")
+	assert.ErrorIs(t, err, block.ErrBlocked)
+}
@@ -0,0 +1,251 @@
+package review
+
+// mockgen  -source=storage.go -destination=storage_mock_test.go -package=review
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	pgForeignKeyViolation = "23503"
+	pgUniqueViolation     = "23505"
+)
+
+const reviewColumns = "id, seller, author, rating, body, reply, replied_at, hidden, created_at"
+
+type Repository interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+	Query(query string, args ...any) (*sql.Rows, error)
+	Begin() (*sql.Tx, error)
+}
+
+type Storage struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+func NewStorage(repository Repository, logger *zap.Logger) *Storage {
+	return &Storage{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanReview читает строку в порядке reviewColumns.
+func scanReview(row rowScanner, rv *Review) error {
+	var (
+		reply     sql.NullString
+		repliedAt sql.NullTime
+	)
+	err := row.Scan(
+		&rv.ID, &rv.Seller, &rv.Author, &rv.Rating, &rv.Text,
+		&reply, &repliedAt, &rv.Hidden, &rv.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	rv.Reply = nil
+	if reply.Valid {
+		rv.Reply = &Reply{Text: reply.String, CreatedAt: repliedAt.Time}
+	}
+	return nil
+}
+
+// Eligible сообщает, может ли author оставить отзыв seller: у него есть завершённый заказ
+// у продавца или переписка, в которой писали оба.
+func (r *Storage) Eligible(author, seller string) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM orders WHERE buyer = $1 AND seller = $2 AND status = 'completed'
+		) OR EXISTS (
+			SELECT 1 FROM conversations c
+			WHERE c.buyer = $1 AND c.seller = $2
+				AND EXISTS (SELECT 1 FROM messages m WHERE m.conversation_id = c.id AND m.sender = $1)
+				AND EXISTS (SELECT 1 FROM messages m WHERE m.conversation_id = c.id AND m.sender = $2)
+		)
+	`
+	var ok bool
+	if err := r.repository.QueryRow(query, author, seller).Scan(&ok); err != nil {
+		r.logger.Error(
+			"Failed to check review eligibility",
+			zap.String("author", author),
+			zap.String("seller", seller),
+			zap.Error(err),
+		)
+		return false, errors.Errorf("failed to check review eligibility: %v", err)
+	}
+	return ok, nil
+}
+
+// refreshRating пересчитывает рейтинг продавца в users по видимым отзывам. Вызывается в той же
+// транзакции, что и изменение отзыва.
+func refreshRating(tx *sql.Tx, seller string) error {
+	query := `
+		UPDATE users SET
+			seller_rating = (SELECT ROUND(AVG(r.rating), 2) FROM reviews r WHERE r.seller = $1 AND NOT r.hidden),
+			seller_reviews = (SELECT COUNT(*) FROM reviews r WHERE r.seller = $1 AND NOT r.hidden)
+		WHERE login = $1
+	`
+	if _, err := tx.Exec(query, seller); err != nil {
+		return errors.Errorf("failed to refresh seller rating: %v", err)
+	}
+	return nil
+}
+
+func (r *Storage) Create(rv *Review) error {
+	tx, err := r.repository.Begin()
+	if err != nil {
+		return errors.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO reviews (seller, author, rating, body)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+	err = tx.QueryRow(query, rv.Seller, rv.Author, rv.Rating, rv.Text).Scan(&rv.ID, &rv.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) {
+			switch pqErr.Code {
+			case pgUniqueViolation:
+				return ErrAlreadyReviewed
+			case pgForeignKeyViolation:
+				return ErrUserNotFound
+			}
+		}
+		r.logger.Error(
+			"Failed to create review",
+			zap.String("seller", rv.Seller),
+			zap.String("author", rv.Author),
+			zap.Error(err),
+		)
+		return errors.Errorf("failed to create review: %v", err)
+	}
+	if err := refreshRating(tx, rv.Seller); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Errorf("failed to commit review: %v", err)
+	}
+	return nil
+}
+
+func (r *Storage) GetByID(id uint) (*Review, error) {
+	query := `SELECT ` + reviewColumns + ` FROM reviews WHERE id = $1`
+
+	var rv Review
+	if err := scanReview(r.repository.QueryRow(query, id), &rv); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrReviewNotFound
+		}
+		r.logger.Error("Failed to get review", zap.Uint("id", id), zap.Error(err))
+		return nil, errors.Errorf("failed to get review: %v", err)
+	}
+	return &rv, nil
+}
+
+// ListBySeller возвращает отзывы о продавце, новые первыми. Скрытые отзывы видят только модераторы.
+func (r *Storage) ListBySeller(seller string, withHidden bool) ([]*Review, error) {
+	query := `SELECT ` + reviewColumns + ` FROM reviews WHERE seller = $1 AND ($2 OR NOT hidden) ORDER BY id DESC`
+
+	rows, err := r.repository.Query(query, seller, withHidden)
+	if err != nil {
+		r.logger.Error("Failed to list reviews", zap.String("seller", seller), zap.Error(err))
+		return nil, errors.Errorf("failed to list reviews: %v", err)
+	}
+	defer rows.Close()
+
+	reviews := []*Review{}
+	for rows.Next() {
+		var rv Review
+		if err := scanReview(rows, &rv); err != nil {
+			return nil, errors.Errorf("failed to scan review: %v", err)
+		}
+		reviews = append(reviews, &rv)
+	}
+	return reviews, rows.Err()
+}
+
+// Reply сохраняет ответ продавца. Если ответ уже есть, возвращает ErrAlreadyReplied.
+func (r *Storage) Reply(id uint, text string) (*Review, error) {
+	query := `
+		UPDATE reviews SET reply = $2, replied_at = NOW()
+		WHERE id = $1 AND reply IS NULL
+		RETURNING ` + reviewColumns
+
+	var rv Review
+	if err := scanReview(r.repository.QueryRow(query, id, text), &rv); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAlreadyReplied
+		}
+		r.logger.Error("Failed to reply to review", zap.Uint("id", id), zap.Error(err))
+		return nil, errors.Errorf("failed to reply to review: %v", err)
+	}
+	return &rv, nil
+}
+
+// SetHidden скрывает отзыв (или возвращает его) и запоминает модератора.
+func (r *Storage) SetHidden(id uint, moderator string, hidden bool) (*Review, error) {
+	tx, err := r.repository.Begin()
+	if err != nil {
+		return nil, errors.Errorf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE reviews SET hidden = $3, hidden_by = CASE WHEN $3 THEN $2 END
+		WHERE id = $1
+		RETURNING ` + reviewColumns
+
+	var rv Review
+	if err := scanReview(tx.QueryRow(query, id, moderator, hidden), &rv); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrReviewNotFound
+		}
+		r.logger.Error("Failed to hide review", zap.Uint("id", id), zap.Error(err))
+		return nil, errors.Errorf("failed to hide review: %v", err)
+	}
+	if err := refreshRating(tx, rv.Seller); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Errorf("failed to commit review: %v", err)
+	}
+	return &rv, nil
+}
+
+// GetProfile возвращает сводный рейтинг продавца по видимым отзывам.
+func (r *Storage) GetProfile(login string) (*Profile, error) {
+	query := `
+		SELECT login, seller_rating::float8, seller_reviews
+		FROM users WHERE login = $1
+	`
+	var (
+		p      Profile
+		rating sql.NullFloat64
+	)
+	if err := r.repository.QueryRow(query, login).Scan(&p.Login, &rating, &p.ReviewsCount); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		r.logger.Error("Failed to get profile", zap.String("login", login), zap.Error(err))
+		return nil, errors.Errorf("failed to get profile: %v", err)
+	}
+	if rating.Valid {
+		p.Rating = &rating.Float64
+	}
+	return &p, nil
+}
@@ -0,0 +1,167 @@
+package search
+
+// mockgen  -source=handler.go -destination=handler_mock_test.go -package=search
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+)
+
+type service interface {
+	CreateSearch(search *SavedSearch) (*SavedSearch, error)
+	ListSearches(owner string) ([]*SavedSearch, error)
+	DeleteSearch(id uint, owner string) error
+	RunSearch(id uint, owner string) ([]*post.Post, error)
+}
+
+type Handler struct {
+	service service
+	logger  *zap.Logger
+}
+
+func NewHandler(service service, logger *zap.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Searches обрабатывает /me/searches: GET — список, POST — сохранить поиск.
+func (h *Handler) Searches(w http.ResponseWriter, r *http.Request) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		searches, err := h.service.ListSearches(login)
+		if err != nil {
+			h.logger.Error(
+				"Failed to list saved searches",
+				zap.String("login", login),
+				zap.Error(err),
+			)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(searches)
+
+	case http.MethodPost:
+		var req CreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Bad Request: invalid JSON", http.StatusBadRequest)
+			return
+		}
+		q, err := url.ParseQuery(strings.TrimPrefix(req.Query, "?"))
+		if err != nil {
+			http.Error(w, "Bad Request: invalid query", http.StatusBadRequest)
+			return
+		}
+		sort, filter := post.ParseFeedQuery(q, "")
+
+		search, err := h.service.CreateSearch(&SavedSearch{
+			Owner:      login,
+			Name:       req.Name,
+			Filter:     *filter,
+			Sort:       *sort,
+			Channel:    req.Channel,
+			WebhookURL: req.WebhookURL,
+			Digest:     req.Digest,
+		})
+		if err != nil {
+			if errors.Is(err, ErrInvalidName) || errors.Is(err, ErrInvalidChannel) || errors.Is(err, ErrInvalidWebhookURL) {
+				http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			h.logger.Error(
+				"Failed to create saved search",
+				zap.String("login", login),
+				zap.Error(err),
+			)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(search)
+
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Search обрабатывает DELETE /me/searches/{id} и GET /me/searches/{id}/posts.
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	parts := strings.Split(r.URL.Path, "/")
+	if len(parts) < 4 || len(parts) > 5 || parts[1] != "me" || parts[2] != "searches" {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+	id64, err := strconv.ParseUint(parts[3], 10, 64)
+	if err != nil {
+		http.Error(w, "Bad Request: invalid id", http.StatusBadRequest)
+		return
+	}
+	id := uint(id64)
+
+	switch {
+	case len(parts) == 4 && r.Method == http.MethodDelete:
+		err = h.service.DeleteSearch(id, login)
+		if err == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+	case len(parts) == 5 && parts[4] == "posts" && r.Method == http.MethodGet:
+		var posts []*post.Post
+		posts, err = h.service.RunSearch(id, login)
+		if err == nil {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(posts)
+			return
+		}
+
+	case len(parts) == 5 && parts[4] != "posts":
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	switch {
+	case errors.Is(err, ErrSearchNotFound):
+		http.Error(w, "Not Found", http.StatusNotFound)
+	case errors.Is(err, post.ErrNoRateForCurrency):
+		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+	case errors.Is(err, post.ErrRatesUnavailable):
+		http.Error(w, "Service Unavailable: "+err.Error(), http.StatusServiceUnavailable)
+	default:
+		h.logger.Error(
+			"Failed to handle saved search",
+			zap.Uint("id", id),
+			zap.String("method", r.Method),
+			zap.Error(err),
+		)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
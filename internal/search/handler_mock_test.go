@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+
+// Package search is a generated GoMock package.
+package search
+
+import (
+	reflect "reflect"
+
+	post "github.com/TemirB/rest-api-marketplace/internal/post"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockservice is a mock of service interface.
+type Mockservice struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceMockRecorder
+}
+
+// MockserviceMockRecorder is the mock recorder for Mockservice.
+type MockserviceMockRecorder struct {
+	mock *Mockservice
+}
+
+// NewMockservice creates a new mock instance.
+func NewMockservice(ctrl *gomock.Controller) *Mockservice {
+	mock := &Mockservice{ctrl: ctrl}
+	mock.recorder = &MockserviceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockservice) EXPECT() *MockserviceMockRecorder {
+	return m.recorder
+}
+
+// CreateSearch mocks base method.
+func (m *Mockservice) CreateSearch(search *SavedSearch) (*SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSearch", search)
+	ret0, _ := ret[0].(*SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSearch indicates an expected call of CreateSearch.
+func (mr *MockserviceMockRecorder) CreateSearch(search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSearch", reflect.TypeOf((*Mockservice)(nil).CreateSearch), search)
+}
+
+// DeleteSearch mocks base method.
+func (m *Mockservice) DeleteSearch(id uint, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSearch", id, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSearch indicates an expected call of DeleteSearch.
+func (mr *MockserviceMockRecorder) DeleteSearch(id, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSearch", reflect.TypeOf((*Mockservice)(nil).DeleteSearch), id, owner)
+}
+
+// ListSearches mocks base method.
+func (m *Mockservice) ListSearches(owner string) ([]*SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSearches", owner)
+	ret0, _ := ret[0].([]*SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSearches indicates an expected call of ListSearches.
+func (mr *MockserviceMockRecorder) ListSearches(owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSearches", reflect.TypeOf((*Mockservice)(nil).ListSearches), owner)
+}
+
+// RunSearch mocks base method.
+func (m *Mockservice) RunSearch(id uint, owner string) ([]*post.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunSearch", id, owner)
+	ret0, _ := ret[0].([]*post.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunSearch indicates an expected call of RunSearch.
+func (mr *MockserviceMockRecorder) RunSearch(id, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunSearch", reflect.TypeOf((*Mockservice)(nil).RunSearch), id, owner)
+}
@@ -0,0 +1,118 @@
+package search
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+func TestHandler_Searches_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := NewMockservice(ctrl)
+	handler := NewHandler(mockService, zap.NewNop())
+
+	mockService.EXPECT().CreateSearch(&SavedSearch{
+		Owner:  "alice",
+		Name:   "bikes",
+		Filter: post.FilterParams{MinPrice: 100, MaxPrice: 500, Currency: money.USD},
+		Sort:   post.SortParams{Field: "price", Direction: "ASC"},
+		Digest: true,
+	}).DoAndReturn(func(s *SavedSearch) (*SavedSearch, error) {
+		s.ID = 1
+		return s, nil
+	})
+
+	body := `{"name":"bikes","query":"?min_price=100&max_price=500&currency=usd&sort_by=price&order=asc","digest":true}`
+	req := httptest.NewRequest(http.MethodPost, "/me/searches", strings.NewReader(body))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, "alice"))
+	rr := httptest.NewRecorder()
+
+	handler.Searches(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"min_price":100`)
+}
+
+func TestHandler_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name string
+
+		method     string
+		path       string
+		setupMocks func(s *Mockservice)
+
+		expectedCode int
+	}{
+		{
+			name:   "1. Delete",
+			method: http.MethodDelete,
+			path:   "/me/searches/1",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().DeleteSearch(uint(1), "alice").Return(nil)
+			},
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			name:   "2. Delete_Not_Found",
+			method: http.MethodDelete,
+			path:   "/me/searches/2",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().DeleteSearch(uint(2), "alice").Return(ErrSearchNotFound)
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "3. Run",
+			method: http.MethodGet,
+			path:   "/me/searches/1/posts",
+			setupMocks: func(s *Mockservice) {
+				s.EXPECT().RunSearch(uint(1), "alice").Return([]*post.Post{{ID: 3}}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "4. Invalid_ID",
+			method:       http.MethodDelete,
+			path:         "/me/searches/abc",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "5. Wrong_Method",
+			method:       http.MethodPut,
+			path:         "/me/searches/1",
+			setupMocks:   func(s *Mockservice) {},
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := NewMockservice(ctrl)
+			tc.setupMocks(mockService)
+			handler := NewHandler(mockService, zap.NewNop())
+
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			req = req.WithContext(context.WithValue(req.Context(), middleware.CtxUser, "alice"))
+			rr := httptest.NewRecorder()
+
+			handler.Search(rr, req)
+
+			assert.Equal(t, tc.expectedCode, rr.Code)
+		})
+	}
+}
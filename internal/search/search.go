@@ -0,0 +1,69 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+// KindSavedSearchMatch — уведомление о новом посте, подходящем под сохранённый поиск.
+const KindSavedSearchMatch = "saved_search_match"
+
+var (
+	ErrInvalidName       = errors.New("name must be between 1 and 100 characters")
+	ErrInvalidChannel    = errors.New("channel must be inbox or webhook")
+	ErrInvalidWebhookURL = errors.New("webhook_url must be an absolute http(s) URL with a public address")
+)
+
+// SavedSearch — сохранённый запрос к ленте. Фильтр и сортировка хранятся
+// в том же виде, в каком их строит /posts/feed.
+type SavedSearch struct {
+	ID     uint              `json:"id"`
+	Owner  string            `json:"-"`
+	Name   string            `json:"name"`
+	Filter post.FilterParams `json:"filter"`
+	Sort   post.SortParams   `json:"sort"`
+
+	Channel    string `json:"channel"`
+	WebhookURL string `json:"webhook_url,omitempty"`
+	Digest     bool   `json:"digest"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateRequest — тело POST /me/searches. Query — строка запроса ленты,
+// например "min_price=100&max_price=500&currency=USD".
+type CreateRequest struct {
+	Name       string `json:"name"`
+	Query      string `json:"query"`
+	Channel    string `json:"channel"`
+	WebhookURL string `json:"webhook_url"`
+	Digest     bool   `json:"digest"`
+}
+
+func validateSearch(s *SavedSearch) error {
+	s.Name = strings.TrimSpace(s.Name)
+	if s.Name == "" || utf8.RuneCountInString(s.Name) > 100 {
+		return ErrInvalidName
+	}
+
+	switch s.Channel {
+	case "":
+		s.Channel = notify.ChannelInbox
+	case notify.ChannelInbox:
+	case notify.ChannelWebhook:
+		if err := notify.ValidateWebhookURL(s.WebhookURL); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidWebhookURL, err)
+		}
+		return nil
+	default:
+		return ErrInvalidChannel
+	}
+	s.WebhookURL = ""
+	return nil
+}
@@ -0,0 +1,167 @@
+package search
+
+// mockgen  -source=service.go -destination=service_mock_test.go -package=search
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+type storage interface {
+	Create(s *SavedSearch) error
+	GetByID(id uint) (*SavedSearch, error)
+	ListByOwner(owner string) ([]*SavedSearch, error)
+	ListExcept(owner string) ([]*SavedSearch, error)
+	Delete(id uint, owner string) error
+}
+
+type feed interface {
+	GetPosts(sort *post.SortParams, filter *post.FilterParams) ([]*post.Post, error)
+}
+
+type notifier interface {
+	Notify(msg notify.Message) error
+}
+
+type ratesProvider interface {
+	Rates() (*money.Rates, error)
+}
+
+type Service struct {
+	repository storage
+	posts      feed
+	notifier   notifier
+	rates      ratesProvider
+	logger     *zap.Logger
+}
+
+type Option func(*Service)
+
+// WithRates нужен для сопоставления поисков, у которых цена задана в другой валюте.
+func WithRates(rates ratesProvider) Option {
+	return func(s *Service) {
+		s.rates = rates
+	}
+}
+
+func NewService(repository storage, posts feed, notifier notifier, logger *zap.Logger, opts ...Option) *Service {
+	s := &Service{
+		repository: repository,
+		posts:      posts,
+		notifier:   notifier,
+		logger:     logger,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *Service) CreateSearch(search *SavedSearch) (*SavedSearch, error) {
+	if err := validateSearch(search); err != nil {
+		return nil, err
+	}
+	if err := s.repository.Create(search); err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+func (s *Service) ListSearches(owner string) ([]*SavedSearch, error) {
+	return s.repository.ListByOwner(owner)
+}
+
+func (s *Service) DeleteSearch(id uint, owner string) error {
+	return s.repository.Delete(id, owner)
+}
+
+// RunSearch выполняет сохранённый поиск как обычный запрос к ленте.
+func (s *Service) RunSearch(id uint, owner string) ([]*post.Post, error) {
+	search, err := s.repository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if search.Owner != owner {
+		return nil, ErrSearchNotFound
+	}
+	filter := search.Filter
+	filter.Owner = owner
+	sort := search.Sort
+	return s.posts.GetPosts(&sort, &filter)
+}
+
+// OnPostCreated — обработчик для post.WithOnCreate. Сопоставление идёт
+// в отдельной горутине, чтобы не задерживать ответ на создание поста.
+func (s *Service) OnPostCreated(p *post.Post) {
+	created := *p
+	go func() {
+		if err := s.Match(&created); err != nil {
+			s.logger.Error(
+				"Failed to match saved searches",
+				zap.Uint("post_id", created.ID),
+				zap.Error(err),
+			)
+		}
+	}()
+}
+
+type matchPayload struct {
+	SearchID   uint        `json:"search_id"`
+	SearchName string      `json:"search_name"`
+	PostID     uint        `json:"post_id"`
+	PostTitle  string      `json:"post_title"`
+	Price      money.Money `json:"price"`
+}
+
+// Match отправляет уведомления владельцам поисков, под которые подходит новый пост.
+func (s *Service) Match(p *post.Post) error {
+	searches, err := s.repository.ListExcept(p.Owner)
+	if err != nil {
+		return err
+	}
+
+	var rates *money.Rates
+	if s.rates != nil {
+		// Без курсов поиски с валютой просто не совпадут
+		rates, _ = s.rates.Rates()
+	}
+
+	for _, search := range searches {
+		if !search.Filter.Match(p, rates) {
+			continue
+		}
+		payload, err := json.Marshal(matchPayload{
+			SearchID:   search.ID,
+			SearchName: search.Name,
+			PostID:     p.ID,
+			PostTitle:  p.Title,
+			Price:      p.Price,
+		})
+		if err != nil {
+			return err
+		}
+		err = s.notifier.Notify(notify.Message{
+			Recipient: search.Owner,
+			Kind:      KindSavedSearchMatch,
+			Title:     fmt.Sprintf("New match for %q: %s", search.Name, p.Title),
+			Payload:   payload,
+			Channel:   search.Channel,
+			Target:    search.WebhookURL,
+			Digest:    search.Digest,
+		})
+		if err != nil {
+			s.logger.Warn(
+				"Failed to queue saved search notification",
+				zap.Uint("search_id", search.ID),
+				zap.Error(err),
+			)
+		}
+	}
+	return nil
+}
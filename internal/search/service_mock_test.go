@@ -0,0 +1,223 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+
+// Package search is a generated GoMock package.
+package search
+
+import (
+	reflect "reflect"
+
+	notify "github.com/TemirB/rest-api-marketplace/internal/notify"
+	post "github.com/TemirB/rest-api-marketplace/internal/post"
+	money "github.com/TemirB/rest-api-marketplace/pkg/money"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockstorage is a mock of storage interface.
+type Mockstorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockstorageMockRecorder
+}
+
+// MockstorageMockRecorder is the mock recorder for Mockstorage.
+type MockstorageMockRecorder struct {
+	mock *Mockstorage
+}
+
+// NewMockstorage creates a new mock instance.
+func NewMockstorage(ctrl *gomock.Controller) *Mockstorage {
+	mock := &Mockstorage{ctrl: ctrl}
+	mock.recorder = &MockstorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstorage) EXPECT() *MockstorageMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *Mockstorage) Create(s *SavedSearch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockstorageMockRecorder) Create(s interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*Mockstorage)(nil).Create), s)
+}
+
+// Delete mocks base method.
+func (m *Mockstorage) Delete(id uint, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockstorageMockRecorder) Delete(id, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*Mockstorage)(nil).Delete), id, owner)
+}
+
+// GetByID mocks base method.
+func (m *Mockstorage) GetByID(id uint) (*SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockstorageMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*Mockstorage)(nil).GetByID), id)
+}
+
+// ListByOwner mocks base method.
+func (m *Mockstorage) ListByOwner(owner string) ([]*SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByOwner", owner)
+	ret0, _ := ret[0].([]*SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByOwner indicates an expected call of ListByOwner.
+func (mr *MockstorageMockRecorder) ListByOwner(owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByOwner", reflect.TypeOf((*Mockstorage)(nil).ListByOwner), owner)
+}
+
+// ListExcept mocks base method.
+func (m *Mockstorage) ListExcept(owner string) ([]*SavedSearch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExcept", owner)
+	ret0, _ := ret[0].([]*SavedSearch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExcept indicates an expected call of ListExcept.
+func (mr *MockstorageMockRecorder) ListExcept(owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExcept", reflect.TypeOf((*Mockstorage)(nil).ListExcept), owner)
+}
+
+// Mockfeed is a mock of feed interface.
+type Mockfeed struct {
+	ctrl     *gomock.Controller
+	recorder *MockfeedMockRecorder
+}
+
+// MockfeedMockRecorder is the mock recorder for Mockfeed.
+type MockfeedMockRecorder struct {
+	mock *Mockfeed
+}
+
+// NewMockfeed creates a new mock instance.
+func NewMockfeed(ctrl *gomock.Controller) *Mockfeed {
+	mock := &Mockfeed{ctrl: ctrl}
+	mock.recorder = &MockfeedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockfeed) EXPECT() *MockfeedMockRecorder {
+	return m.recorder
+}
+
+// GetPosts mocks base method.
+func (m *Mockfeed) GetPosts(sort *post.SortParams, filter *post.FilterParams) ([]*post.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPosts", sort, filter)
+	ret0, _ := ret[0].([]*post.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPosts indicates an expected call of GetPosts.
+func (mr *MockfeedMockRecorder) GetPosts(sort, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPosts", reflect.TypeOf((*Mockfeed)(nil).GetPosts), sort, filter)
+}
+
+// Mocknotifier is a mock of notifier interface.
+type Mocknotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MocknotifierMockRecorder
+}
+
+// MocknotifierMockRecorder is the mock recorder for Mocknotifier.
+type MocknotifierMockRecorder struct {
+	mock *Mocknotifier
+}
+
+// NewMocknotifier creates a new mock instance.
+func NewMocknotifier(ctrl *gomock.Controller) *Mocknotifier {
+	mock := &Mocknotifier{ctrl: ctrl}
+	mock.recorder = &MocknotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocknotifier) EXPECT() *MocknotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *Mocknotifier) Notify(msg notify.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", msg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MocknotifierMockRecorder) Notify(msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*Mocknotifier)(nil).Notify), msg)
+}
+
+// MockratesProvider is a mock of ratesProvider interface.
+type MockratesProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockratesProviderMockRecorder
+}
+
+// MockratesProviderMockRecorder is the mock recorder for MockratesProvider.
+type MockratesProviderMockRecorder struct {
+	mock *MockratesProvider
+}
+
+// NewMockratesProvider creates a new mock instance.
+func NewMockratesProvider(ctrl *gomock.Controller) *MockratesProvider {
+	mock := &MockratesProvider{ctrl: ctrl}
+	mock.recorder = &MockratesProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockratesProvider) EXPECT() *MockratesProviderMockRecorder {
+	return m.recorder
+}
+
+// Rates mocks base method.
+func (m *MockratesProvider) Rates() (*money.Rates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rates")
+	ret0, _ := ret[0].(*money.Rates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rates indicates an expected call of Rates.
+func (mr *MockratesProviderMockRecorder) Rates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rates", reflect.TypeOf((*MockratesProvider)(nil).Rates))
+}
@@ -0,0 +1,106 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/notify"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+type stubRates struct {
+	rates *money.Rates
+}
+
+func (s stubRates) Rates() (*money.Rates, error) {
+	return s.rates, nil
+}
+
+func TestService_Match(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	notifier := NewMocknotifier(ctrl)
+	rates := stubRates{rates: &money.Rates{
+		Base:  money.RUB,
+		Rates: map[money.Currency]money.Decimal{money.USD: money.NewDecimal(125, 4)},
+	}}
+	service := NewService(repo, nil, notifier, zap.NewNop(), WithRates(rates))
+
+	created := &post.Post{ID: 10, Title: "Bike", Price: money.MustParse("8000", "RUB"), Owner: "seller"}
+
+	repo.EXPECT().ListExcept("seller").Return([]*SavedSearch{
+		{ID: 1, Owner: "alice", Name: "cheap", Filter: post.FilterParams{MaxPrice: 10000}, Channel: notify.ChannelInbox},
+		{ID: 2, Owner: "bob", Name: "expensive", Filter: post.FilterParams{MinPrice: 50000, MaxPrice: -1}, Channel: notify.ChannelInbox},
+		{ID: 3, Owner: "carol", Name: "usd", Filter: post.FilterParams{MinPrice: 90, MaxPrice: 110, Currency: money.USD},
+			Channel: notify.ChannelWebhook, WebhookURL: "https://example.com/hook", Digest: true},
+	}, nil)
+
+	var sent []notify.Message
+	notifier.EXPECT().Notify(gomock.Any()).DoAndReturn(func(msg notify.Message) error {
+		sent = append(sent, msg)
+		return nil
+	}).Times(2)
+
+	assert.NoError(t, service.Match(created))
+
+	assert.Len(t, sent, 2)
+	assert.Equal(t, "alice", sent[0].Recipient)
+	assert.Equal(t, KindSavedSearchMatch, sent[0].Kind)
+	assert.JSONEq(t,
+		`{"search_id":1,"search_name":"cheap","post_id":10,"post_title":"Bike","price":{"amount":"8000.00","currency":"RUB"}}`,
+		string(sent[0].Payload),
+	)
+	assert.Equal(t, "carol", sent[1].Recipient)
+	assert.Equal(t, "https://example.com/hook", sent[1].Target)
+	assert.True(t, sent[1].Digest)
+}
+
+func TestService_RunSearch_OtherOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := NewMockstorage(ctrl)
+	service := NewService(repo, nil, nil, zap.NewNop())
+
+	repo.EXPECT().GetByID(uint(1)).Return(&SavedSearch{ID: 1, Owner: "alice"}, nil)
+
+	_, err := service.RunSearch(1, "bob")
+	assert.ErrorIs(t, err, ErrSearchNotFound)
+}
+
+func Test_validateSearch(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		search SavedSearch
+
+		expectedErr error
+	}{
+		{name: "1. Defaults_To_Inbox", search: SavedSearch{Name: "bikes"}},
+		{name: "2. Empty_Name", search: SavedSearch{Name: "  "}, expectedErr: ErrInvalidName},
+		{name: "3. Unknown_Channel", search: SavedSearch{Name: "bikes", Channel: "sms"}, expectedErr: ErrInvalidChannel},
+		{name: "4. Webhook_Without_URL", search: SavedSearch{Name: "bikes", Channel: notify.ChannelWebhook}, expectedErr: ErrInvalidWebhookURL},
+		{name: "5. Webhook_Bad_Scheme", search: SavedSearch{Name: "bikes", Channel: notify.ChannelWebhook, WebhookURL: "ftp://host/x"}, expectedErr: ErrInvalidWebhookURL},
+		{name: "6. Webhook", search: SavedSearch{Name: "bikes", Channel: notify.ChannelWebhook, WebhookURL: "https://93.184.216.34/x"}},
+		{name: "7. Webhook_Loopback", search: SavedSearch{Name: "bikes", Channel: notify.ChannelWebhook, WebhookURL: "http://127.0.0.1:8080/x"}, expectedErr: ErrInvalidWebhookURL},
+		{name: "8. Webhook_Metadata", search: SavedSearch{Name: "bikes", Channel: notify.ChannelWebhook, WebhookURL: "http://169.254.169.254/latest/meta-data/"}, expectedErr: ErrInvalidWebhookURL},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSearch(&tc.search)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, tc.search.Channel)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package search
+
+// mockgen  -source=storage.go -destination=storage_mock_test.go -package=search
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+var ErrSearchNotFound = errors.New("saved search not found")
+
+const searchColumns = "id, owner, name, filter, sort, channel, webhook_url, digest, created_at"
+
+type Repository interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+type Storage struct {
+	repository Repository
+	logger     *zap.Logger
+}
+
+func NewStorage(repository Repository, logger *zap.Logger) *Storage {
+	return &Storage{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSearch(row rowScanner, s *SavedSearch) error {
+	var (
+		filter, sort []byte
+		webhookURL   sql.NullString
+	)
+	if err := row.Scan(&s.ID, &s.Owner, &s.Name, &filter, &sort, &s.Channel, &webhookURL, &s.Digest, &s.CreatedAt); err != nil {
+		return err
+	}
+	s.WebhookURL = webhookURL.String
+	if err := json.Unmarshal(filter, &s.Filter); err != nil {
+		return errors.Wrap(err, "failed to decode filter")
+	}
+	if err := json.Unmarshal(sort, &s.Sort); err != nil {
+		return errors.Wrap(err, "failed to decode sort")
+	}
+	return nil
+}
+
+func (r *Storage) Create(s *SavedSearch) error {
+	filter, err := json.Marshal(s.Filter)
+	if err != nil {
+		return err
+	}
+	sort, err := json.Marshal(s.Sort)
+	if err != nil {
+		return err
+	}
+	var webhookURL any
+	if s.WebhookURL != "" {
+		webhookURL = s.WebhookURL
+	}
+
+	query := `
+		INSERT INTO saved_searches (owner, name, filter, sort, channel, webhook_url, digest)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	err = r.repository.QueryRow(
+		query,
+		s.Owner,
+		s.Name,
+		string(filter),
+		string(sort),
+		s.Channel,
+		webhookURL,
+		s.Digest,
+	).Scan(&s.ID, &s.CreatedAt)
+	if err != nil {
+		r.logger.Error(
+			"Failed to create saved search",
+			zap.String("owner", s.Owner),
+			zap.Error(err),
+		)
+		return errors.Errorf("failed to create saved search: %v", err)
+	}
+	return nil
+}
+
+func (r *Storage) GetByID(id uint) (*SavedSearch, error) {
+	query := `SELECT ` + searchColumns + ` FROM saved_searches WHERE id = $1`
+
+	var s SavedSearch
+	if err := scanSearch(r.repository.QueryRow(query, id), &s); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrSearchNotFound
+		}
+		r.logger.Error(
+			"Failed to get saved search",
+			zap.Uint("id", id),
+			zap.Error(err),
+		)
+		return nil, errors.Errorf("failed to get saved search: %v", err)
+	}
+	return &s, nil
+}
+
+// ListByOwner возвращает поиски пользователя.
+func (r *Storage) ListByOwner(owner string) ([]*SavedSearch, error) {
+	return r.list(`SELECT `+searchColumns+` FROM saved_searches WHERE owner = $1 ORDER BY id`, owner)
+}
+
+// ListExcept возвращает поиски всех пользователей, кроме owner: свои посты
+// пользователю в уведомления не попадают, как и посты тех, кого он заблокировал.
+func (r *Storage) ListExcept(owner string) ([]*SavedSearch, error) {
+	return r.list(`
+		SELECT `+searchColumns+` FROM saved_searches
+		WHERE owner <> $1
+		  AND owner NOT IN (SELECT blocker FROM user_blocks WHERE blocked = $1)`, owner)
+}
+
+func (r *Storage) list(query string, args ...any) ([]*SavedSearch, error) {
+	rows, err := r.repository.Query(query, args...)
+	if err != nil {
+		r.logger.Error("Failed to list saved searches", zap.Error(err))
+		return nil, errors.Wrap(err, "failed to list saved searches")
+	}
+	defer rows.Close()
+
+	searches := []*SavedSearch{}
+	for rows.Next() {
+		var s SavedSearch
+		if err := scanSearch(rows, &s); err != nil {
+			return nil, errors.Wrap(err, "failed to scan saved search")
+		}
+		searches = append(searches, &s)
+	}
+	return searches, rows.Err()
+}
+
+func (r *Storage) Delete(id uint, owner string) error {
+	res, err := r.repository.Exec(`DELETE FROM saved_searches WHERE id = $1 AND owner = $2`, id, owner)
+	if err != nil {
+		r.logger.Error(
+			"Failed to delete saved search",
+			zap.Uint("id", id),
+			zap.Error(err),
+		)
+		return errors.Errorf("failed to delete saved search: %v", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrSearchNotFound
+	}
+	return nil
+}
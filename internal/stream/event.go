@@ -0,0 +1,65 @@
+package stream
+
+import (
+	"encoding/json"
+
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+// Типы событий потока.
+const (
+	TypePostCreated    = "post.created"
+	TypeMessageCreated = "message.created"
+	TypeNotification   = "notification"
+)
+
+// Event — событие для подписчиков. Recipients ограничивает получателей;
+// пустой список означает событие для всех (например, новый пост).
+// Событие сериализуемо целиком, чтобы его можно было передать между
+// инстансами через Postgres NOTIFY.
+type Event struct {
+	ID         uint64          `json:"id,omitempty"`
+	Type       string          `json:"type"`
+	Recipients []string        `json:"recipients,omitempty"`
+	Data       json.RawMessage `json:"data"`
+
+	// post — Data события post.created, разобранный один раз в Hub.Publish для фильтров всех подписчиков
+	post *post.Post
+}
+
+// Post возвращает пост события post.created или nil, если это другое событие или данные не разбираются.
+func (e *Event) Post() *post.Post {
+	return e.post
+}
+
+// decode разбирает пост события post.created, если это ещё не сделано.
+func (e *Event) decode() {
+	if e.Type != TypePostCreated || e.post != nil {
+		return
+	}
+	var p post.Post
+	if err := json.Unmarshal(e.Data, &p); err == nil {
+		e.post = &p
+	}
+}
+
+// NewEvent кодирует data в событие типа typ.
+func NewEvent(typ string, data any, recipients ...string) (Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{Type: typ, Recipients: recipients, Data: raw}, nil
+}
+
+func (e *Event) addressedTo(login string) bool {
+	if len(e.Recipients) == 0 {
+		return true
+	}
+	for _, r := range e.Recipients {
+		if r == login {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,259 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/jwt"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+const (
+	writeTimeout = 10 * time.Second
+	wsReadLimit  = 512
+)
+
+type hub interface {
+	Subscribe(login string, accept func(Event) bool) (*Subscriber, error)
+	Unsubscribe(s *Subscriber)
+}
+
+type ratesProvider interface {
+	Rates() (*money.Rates, error)
+}
+
+type blocks interface {
+	ListBlocked(blocker string) ([]string, error)
+}
+
+type Handler struct {
+	hub       hub
+	rates     ratesProvider
+	blocks    blocks
+	heartbeat time.Duration
+	upgrader  websocket.Upgrader
+	logger    *zap.Logger
+}
+
+type Option func(*Handler)
+
+// WithRates нужен для фильтров новых постов с currency.
+func WithRates(rates ratesProvider) Option {
+	return func(h *Handler) {
+		h.rates = rates
+	}
+}
+
+// WithBlocks убирает из потока новые посты пользователей, заблокированных подписчиком.
+// Список берётся при подключении: новая блокировка действует после переподключения.
+func WithBlocks(blocks blocks) Option {
+	return func(h *Handler) {
+		h.blocks = blocks
+	}
+}
+
+func NewHandler(hub hub, heartbeat time.Duration, logger *zap.Logger, opts ...Option) *Handler {
+	h := &Handler{
+		hub:       hub,
+		heartbeat: heartbeat,
+		logger:    logger,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// subscribe разбирает параметры подписки и регистрирует подключение.
+// types — список типов событий через запятую (по умолчанию все), остальные
+// параметры — фильтр новых постов в формате /posts/feed.
+func (h *Handler) subscribe(w http.ResponseWriter, r *http.Request) (*Subscriber, bool) {
+	login, err := jwt.GetLogin(r)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	q := r.URL.Query()
+	types := map[string]bool{}
+	for _, t := range strings.Split(q.Get("types"), ",") {
+		switch t = strings.TrimSpace(t); t {
+		case "":
+		case TypePostCreated, TypeMessageCreated, TypeNotification:
+			types[t] = true
+		default:
+			http.Error(w, "Bad Request: unknown event type "+t, http.StatusBadRequest)
+			return nil, false
+		}
+	}
+	_, filter := post.ParseFeedQuery(q, login)
+
+	var blocked []string
+	if h.blocks != nil {
+		if blocked, err = h.blocks.ListBlocked(login); err != nil {
+			h.logger.Error("Failed to list blocked users", zap.String("login", login), zap.Error(err))
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return nil, false
+		}
+	}
+
+	accept := func(e Event) bool {
+		if len(types) > 0 && !types[e.Type] {
+			return false
+		}
+		if e.Type != TypePostCreated {
+			return true
+		}
+		p := e.Post()
+		if p == nil {
+			return false
+		}
+		// Свои посты пользователю не нужны
+		if p.Owner == login || slices.Contains(blocked, p.Owner) {
+			return false
+		}
+		var rates *money.Rates
+		if filter.Currency != "" && h.rates != nil {
+			rates, _ = h.rates.Rates()
+		}
+		return filter.Match(p, rates)
+	}
+
+	s, err := h.hub.Subscribe(login, accept)
+	if err != nil {
+		if errors.Is(err, ErrTooManyConnections) {
+			http.Error(w, "Too Many Requests: "+err.Error(), http.StatusTooManyRequests)
+			return nil, false
+		}
+		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+		return nil, false
+	}
+	return s, true
+}
+
+// SSE — GET /stream, поток Server-Sent Events. Heartbeat отправляется комментарием ": ping".
+func (h *Handler) SSE(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s, ok := h.subscribe(w, r)
+	if !ok {
+		return
+	}
+	defer h.hub.Unsubscribe(s)
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	write := func(format string, args ...any) error {
+		rc.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+	if err := write("retry: 3000\n\n"); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.Done():
+			return
+		case <-ticker.C:
+			if err := write(": ping\n\n"); err != nil {
+				return
+			}
+		case e := <-s.Events():
+			if err := write("id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, e.Data); err != nil {
+				h.logger.Info("Stream client disconnected", zap.String("login", s.Login), zap.Error(err))
+				return
+			}
+		}
+	}
+}
+
+type wsEvent struct {
+	ID   uint64          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// WebSocket — GET /stream/ws, те же события, что и в SSE, в виде JSON-сообщений
+// {"id", "type", "data"}. Heartbeat — ping-кадры; клиент, не ответивший pong
+// за два интервала, отключается. Сообщения от клиента не ожидаются.
+func (h *Handler) WebSocket(w http.ResponseWriter, r *http.Request) {
+	s, ok := h.subscribe(w, r)
+	if !ok {
+		return
+	}
+	defer h.hub.Unsubscribe(s)
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade уже ответил клиенту ошибкой
+		return
+	}
+	defer conn.Close()
+
+	conn.SetReadLimit(wsReadLimit)
+	conn.SetReadDeadline(time.Now().Add(2 * h.heartbeat))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * h.heartbeat))
+	})
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-s.Done():
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too slow"),
+				time.Now().Add(writeTimeout))
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return
+			}
+		case e := <-s.Events():
+			conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if err := conn.WriteJSON(wsEvent{ID: e.ID, Type: e.Type, Data: e.Data}); err != nil {
+				h.logger.Info("Stream client disconnected", zap.String("login", s.Login), zap.Error(err))
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package stream
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/middleware"
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+	"github.com/TemirB/rest-api-marketplace/pkg/money"
+)
+
+// withUser подставляет пользователя так же, как это делает JWT-мидлварь.
+func withUser(login string, next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next(w, r.WithContext(context.WithValue(r.Context(), middleware.CtxUser, login)))
+	})
+}
+
+func waitSubscribers(t *testing.T, hub *Hub, n int) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		hub.mu.RLock()
+		count := len(hub.subs)
+		hub.mu.RUnlock()
+		if count == n {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("expected %d subscribers", n)
+}
+
+func TestHandler_SSE(t *testing.T) {
+	hub := NewHub(Limits{}, zap.NewNop())
+	handler := NewHandler(hub, time.Hour, zap.NewNop())
+	server := httptest.NewServer(withUser("alice", handler.SSE))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/stream?types=post.created&max_price=100")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	waitSubscribers(t, hub, 1)
+
+	expensive, _ := NewEvent(TypePostCreated, post.Post{ID: 1, Owner: "bob", Price: money.MustParse("500", "RUB")})
+	own, _ := NewEvent(TypePostCreated, post.Post{ID: 2, Owner: "alice", Price: money.MustParse("50", "RUB")})
+	message, _ := NewEvent(TypeMessageCreated, map[string]string{"body": "hi"}, "alice")
+	cheap, _ := NewEvent(TypePostCreated, post.Post{ID: 3, Owner: "bob", Price: money.MustParse("50", "RUB")})
+	for _, e := range []Event{expensive, own, message, cheap} {
+		assert.NoError(t, hub.Publish(e))
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for len(lines) < 4 {
+		line, err := reader.ReadString('\n')
+		assert.NoError(t, err)
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "retry:") {
+			lines = append(lines, line)
+		}
+		if strings.HasPrefix(line, "data:") {
+			break
+		}
+	}
+	assert.Equal(t, "id: 4", lines[0])
+	assert.Equal(t, "event: post.created", lines[1])
+	assert.Contains(t, lines[2], `"ID":3`)
+}
+
+type blockList map[string][]string
+
+func (b blockList) ListBlocked(blocker string) ([]string, error) {
+	return b[blocker], nil
+}
+
+func TestHandler_SSE_Blocked(t *testing.T) {
+	hub := NewHub(Limits{}, zap.NewNop())
+	handler := NewHandler(hub, time.Hour, zap.NewNop(), WithBlocks(blockList{"alice": {"bob"}}))
+	server := httptest.NewServer(withUser("alice", handler.SSE))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/stream?types=post.created")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	waitSubscribers(t, hub, 1)
+
+	blocked, _ := NewEvent(TypePostCreated, post.Post{ID: 1, Owner: "bob", Price: money.MustParse("50", "RUB")})
+	other, _ := NewEvent(TypePostCreated, post.Post{ID: 2, Owner: "carol", Price: money.MustParse("50", "RUB")})
+	for _, e := range []Event{blocked, other} {
+		assert.NoError(t, hub.Publish(e))
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	for {
+		line, err := reader.ReadString('\n')
+		assert.NoError(t, err)
+		if strings.HasPrefix(line, "data:") {
+			assert.Contains(t, line, `"ID":2`)
+			break
+		}
+	}
+}
+
+func TestHandler_SSE_UnknownType(t *testing.T) {
+	handler := NewHandler(NewHub(Limits{}, zap.NewNop()), time.Hour, zap.NewNop())
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/stream?types=bids", nil)
+
+	withUser("alice", handler.SSE).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestHandler_SSE_TooManyConnections(t *testing.T) {
+	hub := NewHub(Limits{MaxPerUser: 1}, zap.NewNop())
+	_, err := hub.Subscribe("alice", nil)
+	assert.NoError(t, err)
+
+	handler := NewHandler(hub, time.Hour, zap.NewNop())
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+
+	withUser("alice", handler.SSE).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+}
+
+func TestHandler_WebSocket(t *testing.T) {
+	hub := NewHub(Limits{}, zap.NewNop())
+	handler := NewHandler(hub, time.Hour, zap.NewNop())
+	server := httptest.NewServer(withUser("bob", handler.WebSocket))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/stream/ws", nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+	waitSubscribers(t, hub, 1)
+
+	other, _ := NewEvent(TypeNotification, map[string]string{"kind": "digest"}, "alice")
+	mine, _ := NewEvent(TypeNotification, map[string]string{"kind": "digest"}, "bob")
+	assert.NoError(t, hub.Publish(other))
+	assert.NoError(t, hub.Publish(mine))
+
+	var e wsEvent
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	assert.NoError(t, conn.ReadJSON(&e))
+	assert.Equal(t, uint64(2), e.ID)
+	assert.Equal(t, TypeNotification, e.Type)
+	assert.JSONEq(t, `{"kind":"digest"}`, string(e.Data))
+
+	conn.Close()
+	waitSubscribers(t, hub, 0)
+}
@@ -0,0 +1,160 @@
+package stream
+
+import (
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrTooManyConnections = errors.New("too many stream connections")
+	ErrHubClosed          = errors.New("stream hub is closed")
+)
+
+// Publisher публикует события. Его реализуют Hub (в пределах процесса)
+// и PGBridge (рассылка по всем инстансам через LISTEN/NOTIFY).
+type Publisher interface {
+	Publish(e Event) error
+}
+
+// Limits — ограничения на подключения к потоку.
+type Limits struct {
+	MaxPerUser int // одновременных подключений одного пользователя
+	Buffer     int // событий в очереди подключения, сверх этого подписчик отключается
+}
+
+// Subscriber — одно подключение к потоку.
+type Subscriber struct {
+	Login  string
+	accept func(Event) bool
+
+	events chan Event
+	done   chan struct{}
+	once   sync.Once
+}
+
+// Events — события для отправки клиенту.
+func (s *Subscriber) Events() <-chan Event {
+	return s.events
+}
+
+// Done закрывается, когда хаб отключил подписчика: он не успевал читать события
+// или хаб остановлен. Клиенту стоит переподключиться.
+func (s *Subscriber) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Subscriber) close() {
+	s.once.Do(func() { close(s.done) })
+}
+
+// Hub раздаёт события подписчикам текущего процесса. Медленные подписчики
+// не тормозят остальных: при переполнении очереди подключение закрывается.
+type Hub struct {
+	mu      sync.RWMutex
+	subs    map[*Subscriber]struct{}
+	perUser map[string]int
+	lastID  uint64
+	closed  bool
+
+	limits Limits
+	logger *zap.Logger
+}
+
+func NewHub(limits Limits, logger *zap.Logger) *Hub {
+	if limits.Buffer <= 0 {
+		limits.Buffer = 64
+	}
+	return &Hub{
+		subs:    make(map[*Subscriber]struct{}),
+		perUser: make(map[string]int),
+		limits:  limits,
+		logger:  logger,
+	}
+}
+
+// Subscribe регистрирует подключение. accept дополнительно фильтрует события,
+// адресованные пользователю; nil принимает все.
+func (h *Hub) Subscribe(login string, accept func(Event) bool) (*Subscriber, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrHubClosed
+	}
+	if h.limits.MaxPerUser > 0 && h.perUser[login] >= h.limits.MaxPerUser {
+		return nil, ErrTooManyConnections
+	}
+
+	s := &Subscriber{
+		Login:  login,
+		accept: accept,
+		events: make(chan Event, h.limits.Buffer),
+		done:   make(chan struct{}),
+	}
+	h.subs[s] = struct{}{}
+	h.perUser[login]++
+	return s, nil
+}
+
+// Unsubscribe удаляет подключение. Повторный вызов безопасен.
+func (h *Hub) Unsubscribe(s *Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(s)
+}
+
+func (h *Hub) remove(s *Subscriber) {
+	if _, ok := h.subs[s]; !ok {
+		return
+	}
+	delete(h.subs, s)
+	if h.perUser[s.Login]--; h.perUser[s.Login] <= 0 {
+		delete(h.perUser, s.Login)
+	}
+	s.close()
+}
+
+// Publish раздаёт событие подходящим подписчикам, не блокируясь на медленных.
+// Данные события разбираются один раз и до блокировки, а не в фильтре каждого подписчика.
+func (h *Hub) Publish(e Event) error {
+	e.decode()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return ErrHubClosed
+	}
+	h.lastID++
+	e.ID = h.lastID
+
+	for s := range h.subs {
+		if !e.addressedTo(s.Login) || (s.accept != nil && !s.accept(e)) {
+			continue
+		}
+		select {
+		case s.events <- e:
+		default:
+			h.logger.Warn(
+				"Stream subscriber is too slow, disconnecting",
+				zap.String("login", s.Login),
+				zap.String("event", e.Type),
+			)
+			h.remove(s)
+		}
+	}
+	return nil
+}
+
+// Close отключает всех подписчиков.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for s := range h.subs {
+		h.remove(s)
+	}
+}
@@ -0,0 +1,105 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/TemirB/rest-api-marketplace/internal/post"
+)
+
+func TestHub_Publish(t *testing.T) {
+	hub := NewHub(Limits{Buffer: 4}, zap.NewNop())
+
+	alice, err := hub.Subscribe("alice", nil)
+	assert.NoError(t, err)
+	bob, err := hub.Subscribe("bob", func(e Event) bool { return e.Type == TypeMessageCreated })
+	assert.NoError(t, err)
+
+	assert.NoError(t, hub.Publish(Event{Type: TypePostCreated}))
+	assert.NoError(t, hub.Publish(Event{Type: TypeMessageCreated, Recipients: []string{"bob"}}))
+
+	assert.Len(t, alice.Events(), 1)
+	assert.Equal(t, TypePostCreated, (<-alice.Events()).Type)
+
+	assert.Len(t, bob.Events(), 1)
+	e := <-bob.Events()
+	assert.Equal(t, TypeMessageCreated, e.Type)
+	assert.Equal(t, uint64(2), e.ID)
+}
+
+func TestHub_Publish_DecodesPostOnce(t *testing.T) {
+	hub := NewHub(Limits{Buffer: 4}, zap.NewNop())
+
+	var seen []*post.Post
+	accept := func(e Event) bool {
+		seen = append(seen, e.Post())
+		return true
+	}
+	for _, login := range []string{"alice", "bob"} {
+		_, err := hub.Subscribe(login, accept)
+		assert.NoError(t, err)
+	}
+
+	e, _ := NewEvent(TypePostCreated, post.Post{ID: 7, Owner: "carol"})
+	assert.NoError(t, hub.Publish(e))
+
+	// Оба подписчика получили один и тот же разобранный пост
+	assert.Len(t, seen, 2)
+	assert.NotNil(t, seen[0])
+	assert.Equal(t, uint(7), seen[0].ID)
+	assert.Same(t, seen[0], seen[1])
+}
+
+func TestHub_PerUserLimit(t *testing.T) {
+	hub := NewHub(Limits{MaxPerUser: 2}, zap.NewNop())
+
+	first, err := hub.Subscribe("alice", nil)
+	assert.NoError(t, err)
+	_, err = hub.Subscribe("alice", nil)
+	assert.NoError(t, err)
+
+	_, err = hub.Subscribe("alice", nil)
+	assert.ErrorIs(t, err, ErrTooManyConnections)
+
+	_, err = hub.Subscribe("bob", nil)
+	assert.NoError(t, err)
+
+	hub.Unsubscribe(first)
+	hub.Unsubscribe(first)
+	_, err = hub.Subscribe("alice", nil)
+	assert.NoError(t, err)
+}
+
+func TestHub_SlowSubscriberIsDisconnected(t *testing.T) {
+	hub := NewHub(Limits{Buffer: 1}, zap.NewNop())
+
+	slow, err := hub.Subscribe("alice", nil)
+	assert.NoError(t, err)
+
+	assert.NoError(t, hub.Publish(Event{Type: TypePostCreated}))
+	assert.NoError(t, hub.Publish(Event{Type: TypePostCreated}))
+
+	select {
+	case <-slow.Done():
+	default:
+		t.Fatal("slow subscriber should be disconnected")
+	}
+
+	// Место в лимите освобождается, подписчик может переподключиться
+	_, err = hub.Subscribe("alice", nil)
+	assert.NoError(t, err)
+}
+
+func TestHub_Close(t *testing.T) {
+	hub := NewHub(Limits{}, zap.NewNop())
+	s, _ := hub.Subscribe("alice", nil)
+
+	hub.Close()
+
+	<-s.Done()
+	assert.ErrorIs(t, hub.Publish(Event{}), ErrHubClosed)
+	_, err := hub.Subscribe("alice", nil)
+	assert.ErrorIs(t, err, ErrHubClosed)
+}
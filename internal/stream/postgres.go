@@ -0,0 +1,89 @@
+package stream
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// maxNotifyPayload — ограничение Postgres на размер payload в NOTIFY (8000 байт).
+const maxNotifyPayload = 7999
+
+var ErrEventTooLarge = errors.New("event is too large for NOTIFY")
+
+type Repository interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// PGBridge рассылает события через Postgres LISTEN/NOTIFY: Publish отправляет
+// событие в канал, а Run на каждом инстансе слушает канал и передаёт
+// события в локальный Hub. Заменяет Hub в роли Publisher, если инстансов несколько.
+type PGBridge struct {
+	repository Repository
+	dsn        string
+	channel    string
+	hub        *Hub
+	logger     *zap.Logger
+}
+
+func NewPGBridge(repository Repository, dsn, channel string, hub *Hub, logger *zap.Logger) *PGBridge {
+	return &PGBridge{
+		repository: repository,
+		dsn:        dsn,
+		channel:    channel,
+		hub:        hub,
+		logger:     logger,
+	}
+}
+
+func (b *PGBridge) Publish(e Event) error {
+	e.ID = 0
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if len(payload) > maxNotifyPayload {
+		return ErrEventTooLarge
+	}
+	_, err = b.repository.Exec(`SELECT pg_notify($1, $2)`, b.channel, string(payload))
+	return err
+}
+
+// Run слушает канал до отмены ctx. pq.Listener сам переподключается при обрыве.
+func (b *PGBridge) Run(ctx context.Context) error {
+	listener := pq.NewListener(b.dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			b.logger.Warn("Stream listener connection event", zap.Int("event", int(ev)), zap.Error(err))
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(b.channel); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-listener.Notify:
+			// nil приходит после переподключения: пропущенные события не восстанавливаются
+			if n == nil {
+				continue
+			}
+			var e Event
+			if err := json.Unmarshal([]byte(n.Extra), &e); err != nil {
+				b.logger.Error("Invalid stream event payload", zap.Error(err))
+				continue
+			}
+			b.hub.Publish(e)
+		case <-time.After(90 * time.Second):
+			go listener.Ping()
+		}
+	}
+}
@@ -1,20 +1,406 @@
 CREATE TABLE IF NOT EXISTS users (
     login    VARCHAR(50) PRIMARY KEY,
-    password VARCHAR(60) NOT NULL
+    password VARCHAR(60) NOT NULL,
+    -- модераторов назначают вручную: UPDATE users SET role = 'moderator' WHERE login = ...
+    role     VARCHAR(16) NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'moderator')),
+    -- заблокированный модератором пользователь не может войти, его токены отклоняются
+    banned_at TIMESTAMP,
+    -- по возрасту аккаунта выбирается тариф квот на объявления
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    -- рейтинг продавца по видимым отзывам; пересчитывается при добавлении и скрытии отзыва,
+    -- чтобы ленте не агрегировать отзывы на каждую строку
+    seller_rating  NUMERIC(3,2),
+    seller_reviews INTEGER NOT NULL DEFAULT 0
 );
 
+-- Текст без регистра, пунктуации и «ё» — для поиска дословно совпадающих описаний
+CREATE OR REPLACE FUNCTION normalize_text(s TEXT) RETURNS TEXT
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$ SELECT btrim(regexp_replace(translate(lower(s), 'ё', 'е'), '[^[:alnum:]]+', ' ', 'g')) $$;
+
 CREATE TABLE IF NOT EXISTS posts (
     id          SERIAL PRIMARY KEY,
     title       VARCHAR(200) NOT NULL,
     description TEXT            NOT NULL,
-    price       NUMERIC(10,2)   NOT NULL CHECK (price > 0),
+    price       NUMERIC(15,3)   NOT NULL CHECK (price > 0),
+    currency    CHAR(3)         NOT NULL DEFAULT 'RUB',
     image_url   VARCHAR(500)    NOT NULL,
+    lat         DOUBLE PRECISION CHECK (lat BETWEEN -90 AND 90),
+    lon         DOUBLE PRECISION CHECK (lon BETWEEN -180 AND 180),
+    place       VARCHAR(200),
     owner       VARCHAR(50)     NOT NULL
         REFERENCES users(login)
         ON DELETE CASCADE,
-    created_at  TIMESTAMP       NOT NULL DEFAULT NOW()
+    created_at  TIMESTAMP       NOT NULL DEFAULT NOW(),
+    status      VARCHAR(16)     NOT NULL DEFAULT 'active'
+        CHECK (status IN ('active', 'reserved', 'sold')),
+    listing_type VARCHAR(16)    NOT NULL DEFAULT 'fixed'
+        CHECK (listing_type IN ('fixed', 'auction')),
+    -- quantity_sold учитывает и проданные, и зарезервированные под принятые предложения единицы;
+    -- ограничение не даст продать больше, чем выставлено
+    quantity    INTEGER         NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    quantity_sold INTEGER       NOT NULL DEFAULT 0 CHECK (quantity_sold >= 0),
+    -- скрытый модерацией пост виден только владельцу и недоступен для покупки
+    hidden      BOOLEAN         NOT NULL DEFAULT FALSE,
+    -- похожий пост того же продавца, если пост сохранён как возможный дубль
+    duplicate_of INTEGER        REFERENCES posts(id) ON DELETE SET NULL,
+    -- слова заголовка и описания для поиска похожих постов; Postgres пересчитывает их сам
+    terms       TSVECTOR        GENERATED ALWAYS AS (to_tsvector('simple', title || ' ' || description)) STORED,
+    -- хеш нормализованного описания для проверки дословных дублей у других продавцов
+    description_hash TEXT       GENERATED ALWAYS AS (md5(normalize_text(description))) STORED,
+    CHECK (quantity_sold <= quantity),
+    CHECK ((lat IS NULL) = (lon IS NULL))
 );
 
 CREATE INDEX IF NOT EXISTS idx_posts_owner      ON posts(owner);
 CREATE INDEX IF NOT EXISTS idx_posts_created_at ON posts(created_at);
 CREATE INDEX IF NOT EXISTS idx_posts_price      ON posts(price);
+CREATE INDEX IF NOT EXISTS idx_posts_terms      ON posts USING GIN (terms);
+CREATE INDEX IF NOT EXISTS idx_posts_description_hash ON posts(description_hash, created_at);
+
+-- Курсы валют относительно базовой (EXCHANGE_BASE_CURRENCY): сколько единиц currency за 1 единицу базовой
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    currency    CHAR(3)         PRIMARY KEY,
+    rate        NUMERIC(20,10)  NOT NULL CHECK (rate > 0),
+    updated_at  TIMESTAMP       NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_posts_currency   ON posts(currency);
+-- Поиск по радиусу без PostGIS: индекс для предфильтра по прямоугольнику, точная проверка — гаверсинусом
+CREATE INDEX IF NOT EXISTS idx_posts_lat_lon    ON posts(lat, lon) WHERE lat IS NOT NULL;
+
+-- Избранное: пользователь может добавить пост в избранное один раз
+CREATE TABLE IF NOT EXISTS favorites (
+    login       VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    post_id     INTEGER         NOT NULL REFERENCES posts(id)    ON DELETE CASCADE,
+    created_at  TIMESTAMP       NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (login, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_favorites_post_id ON favorites(post_id);
+
+-- Сохранённые поиски: filter и sort — сериализованные FilterParams/SortParams ленты
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id          SERIAL PRIMARY KEY,
+    owner       VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    name        VARCHAR(100)    NOT NULL,
+    filter      JSONB           NOT NULL,
+    sort        JSONB           NOT NULL,
+    channel     VARCHAR(20)     NOT NULL DEFAULT 'inbox' CHECK (channel IN ('inbox', 'webhook')),
+    webhook_url VARCHAR(500),
+    digest      BOOLEAN         NOT NULL DEFAULT FALSE,
+    created_at  TIMESTAMP       NOT NULL DEFAULT NOW(),
+    CHECK (channel <> 'webhook' OR webhook_url IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_searches_owner ON saved_searches(owner);
+
+-- Входящие уведомления пользователя
+CREATE TABLE IF NOT EXISTS notifications (
+    id          SERIAL PRIMARY KEY,
+    recipient   VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    kind        VARCHAR(50)     NOT NULL,
+    title       VARCHAR(300)    NOT NULL,
+    payload     JSONB,
+    created_at  TIMESTAMP       NOT NULL DEFAULT NOW(),
+    read_at     TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_recipient ON notifications(recipient, created_at DESC);
+
+-- История цен: запись появляется при каждом изменении цены или валюты поста
+CREATE TABLE IF NOT EXISTS price_history (
+    id           SERIAL PRIMARY KEY,
+    post_id      INTEGER         NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    old_price    NUMERIC(15,3)   NOT NULL,
+    old_currency CHAR(3)         NOT NULL,
+    new_price    NUMERIC(15,3)   NOT NULL,
+    new_currency CHAR(3)         NOT NULL,
+    changed_at   TIMESTAMP       NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_history_post_id ON price_history(post_id, id);
+
+-- Переписка покупателя с владельцем поста: одна на пару (пост, покупатель)
+CREATE TABLE IF NOT EXISTS conversations (
+    id              SERIAL PRIMARY KEY,
+    post_id         INTEGER         NOT NULL REFERENCES posts(id)    ON DELETE CASCADE,
+    buyer           VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    seller          VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    created_at      TIMESTAMP       NOT NULL DEFAULT NOW(),
+    last_message_at TIMESTAMP       NOT NULL DEFAULT NOW(),
+    UNIQUE (post_id, buyer),
+    CHECK (buyer <> seller)
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversations_buyer  ON conversations(buyer, last_message_at DESC);
+CREATE INDEX IF NOT EXISTS idx_conversations_seller ON conversations(seller, last_message_at DESC);
+
+CREATE TABLE IF NOT EXISTS messages (
+    id              SERIAL PRIMARY KEY,
+    conversation_id INTEGER         NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    sender          VARCHAR(50)     NOT NULL REFERENCES users(login)      ON DELETE CASCADE,
+    body            VARCHAR(2000)   NOT NULL,
+    created_at      TIMESTAMP       NOT NULL DEFAULT NOW(),
+    read_at         TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_messages_conversation ON messages(conversation_id, id DESC);
+
+-- Блокировки: blocker больше не получает сообщений от blocked и не может писать ему сам
+CREATE TABLE IF NOT EXISTS user_blocks (
+    blocker     VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    blocked     VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    created_at  TIMESTAMP       NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (blocker, blocked),
+    CHECK (blocker <> blocked)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_blocks_blocked ON user_blocks(blocked);
+
+-- Предложения цены: открытыми считаются pending и countered, у пары (пост, покупатель)
+-- одновременно может быть только одно открытое предложение
+CREATE TABLE IF NOT EXISTS offers (
+    id              SERIAL PRIMARY KEY,
+    post_id         INTEGER         NOT NULL REFERENCES posts(id)    ON DELETE CASCADE,
+    buyer           VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    seller          VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    price           NUMERIC(15,3)   NOT NULL CHECK (price > 0),
+    counter_price   NUMERIC(15,3)   CHECK (counter_price > 0),
+    currency        CHAR(3)         NOT NULL,
+    message         VARCHAR(500),
+    status          VARCHAR(16)     NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'accepted', 'rejected', 'countered', 'expired', 'withdrawn')),
+    created_at      TIMESTAMP       NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP       NOT NULL DEFAULT NOW(),
+    expires_at      TIMESTAMP       NOT NULL,
+    CHECK (buyer <> seller)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_offers_open    ON offers(post_id, buyer) WHERE status IN ('pending', 'countered');
+CREATE INDEX IF NOT EXISTS idx_offers_post           ON offers(post_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_offers_buyer          ON offers(buyer, id DESC);
+CREATE INDEX IF NOT EXISTS idx_offers_seller         ON offers(seller, id DESC);
+CREATE INDEX IF NOT EXISTS idx_offers_expires_at     ON offers(expires_at) WHERE status IN ('pending', 'countered');
+
+-- Аукционы: пост с listing_type = 'auction'. Текущая цена и лидер обновляются вместе со ставкой
+-- под блокировкой строки аукциона
+CREATE TABLE IF NOT EXISTS auctions (
+    post_id         INTEGER         PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    seller          VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    currency        CHAR(3)         NOT NULL,
+    start_price     NUMERIC(15,3)   NOT NULL CHECK (start_price > 0),
+    reserve_price   NUMERIC(15,3)   CHECK (reserve_price >= start_price),
+    min_increment   NUMERIC(15,3)   NOT NULL CHECK (min_increment > 0),
+    current_price   NUMERIC(15,3),
+    leader          VARCHAR(50)     REFERENCES users(login) ON DELETE SET NULL,
+    bid_count       INTEGER         NOT NULL DEFAULT 0,
+    ends_at         TIMESTAMP       NOT NULL,
+    status          VARCHAR(16)     NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'closed')),
+    winner          VARCHAR(50)     REFERENCES users(login) ON DELETE SET NULL,
+    created_at      TIMESTAMP       NOT NULL DEFAULT NOW(),
+    closed_at       TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_auctions_open_ends_at ON auctions(ends_at) WHERE status = 'open';
+
+CREATE TABLE IF NOT EXISTS bids (
+    id              SERIAL PRIMARY KEY,
+    post_id         INTEGER         NOT NULL REFERENCES auctions(post_id) ON DELETE CASCADE,
+    bidder          VARCHAR(50)     NOT NULL REFERENCES users(login)      ON DELETE CASCADE,
+    amount          NUMERIC(15,3)   NOT NULL CHECK (amount > 0),
+    created_at      TIMESTAMP       NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bids_post_id ON bids(post_id, id DESC);
+
+-- Заказы: цена фиксируется в момент покупки, total = price * quantity.
+-- payment_id — идентификатор платежа у провайдера, по нему сверяются вебхуки
+CREATE TABLE IF NOT EXISTS orders (
+    id              SERIAL PRIMARY KEY,
+    buyer           VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE RESTRICT,
+    seller          VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE RESTRICT,
+    -- сумма всех позиций; позиции заказа в одной валюте
+    total           NUMERIC(15,3)   NOT NULL CHECK (total > 0),
+    currency        CHAR(3)         NOT NULL,
+    status          VARCHAR(16)     NOT NULL DEFAULT 'created'
+        CHECK (status IN ('created', 'paid', 'shipped', 'delivered', 'completed', 'cancelled', 'refunded')),
+    payment_id      VARCHAR(100),
+    created_at      TIMESTAMP       NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP       NOT NULL DEFAULT NOW(),
+    CHECK (buyer <> seller)
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_buyer  ON orders(buyer, id DESC);
+CREATE INDEX IF NOT EXISTS idx_orders_seller ON orders(seller, id DESC);
+-- неоплаченные заказы для отмены по PAYMENT_TTL
+CREATE INDEX IF NOT EXISTS idx_orders_unpaid ON orders(created_at) WHERE status = 'created';
+
+-- Возвраты оплат, которыми заказ не оплачивается (пришли после отмены или заменены новой попыткой
+-- оплаты): не больше одного на платёж, чтобы повторная
+-- доставка вебхука не вернула деньги дважды
+CREATE TABLE IF NOT EXISTS payment_refunds (
+    payment_id      VARCHAR(100)    PRIMARY KEY,
+    order_id        INTEGER         NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    amount          NUMERIC(15,3)   NOT NULL,
+    currency        CHAR(3)         NOT NULL,
+    created_at      TIMESTAMP       NOT NULL DEFAULT NOW()
+);
+
+-- Позиции заказа: цена фиксируется на момент покупки
+CREATE TABLE IF NOT EXISTS order_items (
+    order_id        INTEGER         NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    post_id         INTEGER         NOT NULL REFERENCES posts(id)  ON DELETE RESTRICT,
+    price           NUMERIC(15,3)   NOT NULL CHECK (price > 0),
+    quantity        INTEGER         NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    -- принятое предложение, по цене которого куплена позиция; по одному предложению — одна позиция
+    offer_id        INTEGER         UNIQUE REFERENCES offers(id) ON DELETE SET NULL,
+    PRIMARY KEY (order_id, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_items_post ON order_items(post_id);
+
+-- Корзина покупателя: цена запоминается при добавлении, чтобы при оформлении предупредить об изменении
+CREATE TABLE IF NOT EXISTS cart_items (
+    login           VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    post_id         INTEGER         NOT NULL REFERENCES posts(id)    ON DELETE CASCADE,
+    quantity        INTEGER         NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    price           NUMERIC(15,3)   NOT NULL,
+    currency        CHAR(3)         NOT NULL,
+    added_at        TIMESTAMP       NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (login, post_id)
+);
+
+-- Отзывы покупателей о продавцах: один отзыв от покупателя продавцу, один ответ продавца.
+-- Скрытые модератором отзывы не показываются и не входят в рейтинг
+CREATE TABLE IF NOT EXISTS reviews (
+    id              SERIAL PRIMARY KEY,
+    seller          VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    author          VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    rating          SMALLINT        NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    body            VARCHAR(2000)   NOT NULL,
+    reply           VARCHAR(2000),
+    replied_at      TIMESTAMP,
+    hidden          BOOLEAN         NOT NULL DEFAULT FALSE,
+    hidden_by       VARCHAR(50)     REFERENCES users(login) ON DELETE SET NULL,
+    created_at      TIMESTAMP       NOT NULL DEFAULT NOW(),
+    UNIQUE (seller, author),
+    CHECK (seller <> author)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_seller ON reviews(seller, id DESC);
+
+-- Очередь модерации: жалобы на пост и замечания фильтра контента копятся в одном деле до решения модератора.
+-- На пост одновременно открыто не больше одного дела
+CREATE TABLE IF NOT EXISTS moderation_cases (
+    id              SERIAL PRIMARY KEY,
+    post_id         INTEGER         NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    owner           VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    status          VARCHAR(16)     NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'claimed', 'resolved')),
+    reports_count   INTEGER         NOT NULL DEFAULT 1,
+    auto_hidden     BOOLEAN         NOT NULL DEFAULT FALSE,
+    assignee        VARCHAR(50)     REFERENCES users(login) ON DELETE SET NULL,
+    resolution      VARCHAR(16)     CHECK (resolution IN ('dismiss', 'hide', 'warn', 'ban')),
+    note            VARCHAR(1000),
+    -- замечания фильтра контента (check/code), с которыми пост попал в очередь
+    flags           TEXT[]          NOT NULL DEFAULT '{}',
+    created_at      TIMESTAMP       NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP       NOT NULL DEFAULT NOW(),
+    resolved_at     TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_moderation_cases_active ON moderation_cases(post_id) WHERE status <> 'resolved';
+CREATE INDEX IF NOT EXISTS idx_moderation_cases_status ON moderation_cases(status, reports_count DESC);
+
+-- Жалобы пользователей: на один пост пользователь жалуется один раз
+CREATE TABLE IF NOT EXISTS reports (
+    id              SERIAL PRIMARY KEY,
+    post_id         INTEGER         NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    case_id         INTEGER         NOT NULL REFERENCES moderation_cases(id) ON DELETE CASCADE,
+    reporter        VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    reason          VARCHAR(16)     NOT NULL
+        CHECK (reason IN ('prohibited', 'fraud', 'counterfeit', 'spam', 'offensive', 'other')),
+    comment         VARCHAR(1000),
+    created_at      TIMESTAMP       NOT NULL DEFAULT NOW(),
+    UNIQUE (post_id, reporter)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reports_case ON reports(case_id);
+
+-- Журнал модерации. Ссылок на посты и пользователей нет намеренно: записи переживают удаление
+CREATE TABLE IF NOT EXISTS moderation_audit (
+    id              SERIAL PRIMARY KEY,
+    actor           VARCHAR(50)     NOT NULL,
+    action          VARCHAR(16)     NOT NULL,
+    case_id         INTEGER,
+    post_id         INTEGER,
+    target          VARCHAR(50),
+    note            VARCHAR(1000),
+    created_at      TIMESTAMP       NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_audit_actor  ON moderation_audit(actor, id DESC);
+CREATE INDEX IF NOT EXISTS idx_moderation_audit_target ON moderation_audit(target, id DESC);
+
+-- Журнал созданий объявлений для квот. Удаление поста не удаляет запись, поэтому квоту
+-- нельзя обойти, удаляя и создавая посты заново; записи старше суток удаляются
+CREATE TABLE IF NOT EXISTS quota_usage (
+    id              SERIAL PRIMARY KEY,
+    login           VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    created_at      TIMESTAMP       NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_quota_usage_login ON quota_usage(login, created_at);
+
+-- Корзины токенов ограничения частоты запросов (RATE_LIMIT_BACKEND=postgres).
+-- full_at — когда корзина наполнится; после этого строку можно удалить
+CREATE TABLE IF NOT EXISTS rate_limits (
+    key             VARCHAR(200)        PRIMARY KEY,
+    tokens          DOUBLE PRECISION    NOT NULL,
+    updated_at      TIMESTAMP           NOT NULL,
+    full_at         TIMESTAMP           NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limits_full_at ON rate_limits(full_at);
+
+-- Продвижение постов. Действующее продвижение — active с ends_at в будущем; закончившиеся
+-- помечает expired фоновая задача, отправляя владельцу отчёт с показами и переходами
+CREATE TABLE IF NOT EXISTS promotions (
+    id              SERIAL PRIMARY KEY,
+    post_id         INTEGER         NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    owner           VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    kind            VARCHAR(16)     NOT NULL CHECK (kind IN ('featured', 'highlighted')),
+    status          VARCHAR(16)     NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'expired')),
+    starts_at       TIMESTAMP       NOT NULL DEFAULT NOW(),
+    ends_at         TIMESTAMP       NOT NULL,
+    impressions     INTEGER         NOT NULL DEFAULT 0,
+    clicks          INTEGER         NOT NULL DEFAULT 0
+);
+
+-- Одно действующее продвижение каждого вида на пост
+CREATE UNIQUE INDEX IF NOT EXISTS idx_promotions_active ON promotions(post_id, kind) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_promotions_owner ON promotions(owner, id DESC);
+
+-- Дневные счётчики аналитики объявлений. Пишутся пачками из очереди в памяти;
+-- день — по UTC
+CREATE TABLE IF NOT EXISTS post_stats (
+    post_id         INTEGER         NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    day             DATE            NOT NULL,
+    views           INTEGER         NOT NULL DEFAULT 0,
+    impressions     INTEGER         NOT NULL DEFAULT 0,
+    favorites       INTEGER         NOT NULL DEFAULT 0,
+    messages        INTEGER         NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, day)
+);
+
+-- Подписки на продавцов: лента ?scope=following показывает только их посты
+CREATE TABLE IF NOT EXISTS follows (
+    follower        VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    seller          VARCHAR(50)     NOT NULL REFERENCES users(login) ON DELETE CASCADE,
+    created_at      TIMESTAMP       NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (follower, seller),
+    CHECK (follower <> seller)
+);
+
+CREATE INDEX IF NOT EXISTS idx_follows_seller ON follows(seller);
@@ -0,0 +1,87 @@
+package geo
+
+import (
+	"errors"
+	"math"
+)
+
+// EarthRadiusKm — средний радиус Земли, который используется и в SQL-выражении расстояния.
+const EarthRadiusKm = 6371.0
+
+var ErrInvalidPoint = errors.New("latitude must be in [-90, 90] and longitude in [-180, 180]")
+
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+func (p Point) Validate() error {
+	if math.IsNaN(p.Lat) || math.IsNaN(p.Lon) ||
+		p.Lat < -90 || p.Lat > 90 || p.Lon < -180 || p.Lon > 180 {
+		return ErrInvalidPoint
+	}
+	return nil
+}
+
+// Distance возвращает расстояние между точками по формуле гаверсинусов, в километрах.
+func Distance(a, b Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat := lat2 - lat1
+	dLon := radians(b.Lon - a.Lon)
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Box — прямоугольник в градусах, гарантированно содержащий круг заданного радиуса.
+// Если MinLon > MaxLon, прямоугольник пересекает 180-й меридиан.
+type Box struct {
+	MinLat, MaxLat float64
+	MinLon, MaxLon float64
+}
+
+// CrossesAntimeridian сообщает, что диапазон долгот нужно проверять как два отрезка.
+func (b Box) CrossesAntimeridian() bool {
+	return b.MinLon > b.MaxLon
+}
+
+// BoundingBox строит прямоугольник вокруг center для предварительной фильтрации по индексу.
+// См. J. P. Matuschek, "Finding Points Within a Distance of a Latitude/Longitude Using Bounding Coordinates".
+func BoundingBox(center Point, radiusKm float64) Box {
+	angular := radiusKm / EarthRadiusKm
+	lat := radians(center.Lat)
+	lon := radians(center.Lon)
+
+	minLat, maxLat := lat-angular, lat+angular
+	if minLat <= -math.Pi/2 || maxLat >= math.Pi/2 {
+		// Круг накрывает полюс — подходят любые долготы
+		return Box{
+			MinLat: degrees(math.Max(minLat, -math.Pi/2)),
+			MaxLat: degrees(math.Min(maxLat, math.Pi/2)),
+			MinLon: -180,
+			MaxLon: 180,
+		}
+	}
+
+	dLon := math.Asin(math.Min(1, math.Sin(angular)/math.Cos(lat)))
+	minLon, maxLon := lon-dLon, lon+dLon
+	if minLon < -math.Pi {
+		minLon += 2 * math.Pi
+	}
+	if maxLon > math.Pi {
+		maxLon -= 2 * math.Pi
+	}
+	if dLon >= math.Pi/2 {
+		minLon, maxLon = -math.Pi, math.Pi
+	}
+
+	return Box{
+		MinLat: degrees(minLat),
+		MaxLat: degrees(maxLat),
+		MinLon: degrees(minLon),
+		MaxLon: degrees(maxLon),
+	}
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
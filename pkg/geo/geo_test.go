@@ -0,0 +1,71 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	moscow := Point{Lat: 55.7558, Lon: 37.6173}
+	spb := Point{Lat: 59.9343, Lon: 30.3351}
+
+	assert.InDelta(t, 634, Distance(moscow, spb), 2)
+	assert.InDelta(t, 0, Distance(moscow, moscow), 1e-9)
+	assert.InDelta(t, Distance(moscow, spb), Distance(spb, moscow), 1e-9)
+}
+
+func TestBoundingBox(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		center   Point
+		radiusKm float64
+
+		crosses bool
+		inside  []Point
+	}{
+		{
+			name:     "1. Regular_Box",
+			center:   Point{Lat: 55.7558, Lon: 37.6173},
+			radiusKm: 50,
+			inside:   []Point{{Lat: 55.9, Lon: 37.9}, {Lat: 55.5, Lon: 37.2}},
+		},
+		{
+			name:     "2. Crosses_Antimeridian",
+			center:   Point{Lat: 64.7, Lon: 179.9},
+			radiusKm: 100,
+			crosses:  true,
+			inside:   []Point{{Lat: 64.8, Lon: -179.5}, {Lat: 64.6, Lon: 179.1}},
+		},
+		{
+			name:     "3. Covers_Pole",
+			center:   Point{Lat: 89.5, Lon: 0},
+			radiusKm: 100,
+			inside:   []Point{{Lat: 89.9, Lon: 180}, {Lat: 89.4, Lon: -90}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			box := BoundingBox(tc.center, tc.radiusKm)
+			assert.Equal(t, tc.crosses, box.CrossesAntimeridian())
+
+			for _, p := range tc.inside {
+				assert.LessOrEqual(t, Distance(tc.center, p), tc.radiusKm)
+				assert.True(t, p.Lat >= box.MinLat && p.Lat <= box.MaxLat, "latitude %v outside box", p)
+				if box.CrossesAntimeridian() {
+					assert.True(t, p.Lon >= box.MinLon || p.Lon <= box.MaxLon, "longitude %v outside box", p)
+				} else {
+					assert.True(t, p.Lon >= box.MinLon && p.Lon <= box.MaxLon, "longitude %v outside box", p)
+				}
+			}
+		})
+	}
+}
+
+func TestPoint_Validate(t *testing.T) {
+	assert.NoError(t, Point{Lat: -90, Lon: 180}.Validate())
+	assert.ErrorIs(t, Point{Lat: 91, Lon: 0}.Validate(), ErrInvalidPoint)
+	assert.ErrorIs(t, Point{Lat: 0, Lon: -181}.Validate(), ErrInvalidPoint)
+}
@@ -15,10 +15,10 @@ var (
 
 type Manager struct {
 	secret     string
-	expiration time.Time
+	expiration time.Duration
 }
 
-func New(secret string, expiration time.Time) *Manager {
+func New(secret string, expiration time.Duration) *Manager {
 	return &Manager{
 		secret:     secret,
 		expiration: expiration,
@@ -28,7 +28,7 @@ func New(secret string, expiration time.Time) *Manager {
 func (m *Manager) GenerateToken(login string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"login": login,
-		"exp":   m.expiration,
+		"exp":   time.Now().Add(m.expiration).Unix(),
 	})
 	return token.SignedString([]byte(m.secret))
 }
@@ -0,0 +1,52 @@
+package money
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Currency — трёхбуквенный код валюты по ISO 4217.
+type Currency string
+
+const (
+	RUB Currency = "RUB"
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+)
+
+// DefaultCurrency используется, если клиент прислал цену без валюты.
+const DefaultCurrency = RUB
+
+// minorUnits — количество знаков после запятой у каждой поддерживаемой валюты (ISO 4217).
+var minorUnits = map[Currency]int32{
+	"AED": 2, "AMD": 2, "AUD": 2, "AZN": 2, "BHD": 3, "BRL": 2, "BYN": 2,
+	"CAD": 2, "CHF": 2, "CLP": 0, "CNY": 2, "CZK": 2, "DKK": 2, "EUR": 2,
+	"GBP": 2, "GEL": 2, "HKD": 2, "HUF": 2, "IDR": 2, "ILS": 2, "INR": 2,
+	"ISK": 0, "JOD": 3, "JPY": 0, "KGS": 2, "KRW": 0, "KWD": 3, "KZT": 2,
+	"MDL": 2, "MXN": 2, "NOK": 2, "NZD": 2, "OMR": 3, "PLN": 2, "RON": 2,
+	"RSD": 2, "RUB": 2, "SEK": 2, "SGD": 2, "THB": 2, "TJS": 2, "TND": 3,
+	"TRY": 2, "UAH": 2, "USD": 2, "UZS": 2, "VND": 0, "ZAR": 2,
+}
+
+// ParseCurrency приводит код к верхнему регистру и проверяет, что валюта поддерживается.
+func ParseCurrency(code string) (Currency, error) {
+	c := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if !c.Valid() {
+		return "", fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+	}
+	return c, nil
+}
+
+func (c Currency) Valid() bool {
+	_, ok := minorUnits[c]
+	return ok
+}
+
+// MinorUnits возвращает число знаков после запятой для валюты.
+func (c Currency) MinorUnits() int32 {
+	return minorUnits[c]
+}
+
+func (c Currency) String() string {
+	return string(c)
+}
@@ -0,0 +1,194 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidDecimal = errors.New("invalid decimal")
+	ErrOverflow       = errors.New("decimal overflow")
+)
+
+const maxScale = 18
+
+// Decimal — точное десятичное число вида coef * 10^-scale.
+type Decimal struct {
+	coef  int64
+	scale int32
+}
+
+func NewDecimal(coef int64, scale int32) Decimal {
+	return Decimal{coef: coef, scale: scale}
+}
+
+// ParseDecimal разбирает строку вида "-123.45" без потери точности.
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Decimal{}, ErrInvalidDecimal
+	}
+
+	neg := false
+	switch s[0] {
+	case '-':
+		neg = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasDot := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" || hasDot && fracPart == "" {
+		return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+	digits := intPart + fracPart
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return Decimal{}, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+		}
+	}
+	if len(fracPart) > maxScale {
+		return Decimal{}, fmt.Errorf("%w: too many decimal places", ErrInvalidDecimal)
+	}
+
+	var coef int64
+	if digits = strings.TrimLeft(digits, "0"); digits != "" {
+		var err error
+		if coef, err = strconv.ParseInt(digits, 10, 64); err != nil {
+			return Decimal{}, ErrOverflow
+		}
+	}
+	if neg {
+		coef = -coef
+	}
+	return Decimal{coef: coef, scale: int32(len(fracPart))}, nil
+}
+
+func (d Decimal) Coefficient() int64 { return d.coef }
+func (d Decimal) Scale() int32       { return d.scale }
+
+func (d Decimal) Sign() int {
+	switch {
+	case d.coef > 0:
+		return 1
+	case d.coef < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func (d Decimal) IsZero() bool { return d.coef == 0 }
+
+// Normalize убирает незначащие нули в дробной части.
+func (d Decimal) Normalize() Decimal {
+	for d.scale > 0 && d.coef%10 == 0 {
+		d.coef /= 10
+		d.scale--
+	}
+	return d
+}
+
+// Rescale приводит число к заданному количеству знаков после запятой.
+// Возвращает ошибку, если при этом теряется точность.
+func (d Decimal) Rescale(scale int32) (Decimal, error) {
+	r := d.Round(scale)
+	if r.Cmp(d) != 0 {
+		return Decimal{}, fmt.Errorf("%w: %s has more than %d decimal places", ErrInvalidDecimal, d, scale)
+	}
+	return r, nil
+}
+
+// Round округляет число до scale знаков по правилу банковского округления.
+func (d Decimal) Round(scale int32) Decimal {
+	r, _ := fromRat(d.Rat(), scale)
+	return r
+}
+
+func (d Decimal) Cmp(o Decimal) int {
+	return d.Rat().Cmp(o.Rat())
+}
+
+func (d Decimal) Add(o Decimal) (Decimal, error) {
+	return fromRat(new(big.Rat).Add(d.Rat(), o.Rat()), max(d.scale, o.scale))
+}
+
+func (d Decimal) Sub(o Decimal) (Decimal, error) {
+	return fromRat(new(big.Rat).Sub(d.Rat(), o.Rat()), max(d.scale, o.scale))
+}
+
+// Mul умножает число на o и округляет результат до scale знаков.
+func (d Decimal) Mul(o Decimal, scale int32) (Decimal, error) {
+	return fromRat(new(big.Rat).Mul(d.Rat(), o.Rat()), scale)
+}
+
+// Quo делит число на o и округляет результат до scale знаков.
+func (d Decimal) Quo(o Decimal, scale int32) (Decimal, error) {
+	if o.IsZero() {
+		return Decimal{}, errors.New("division by zero")
+	}
+	return fromRat(new(big.Rat).Quo(d.Rat(), o.Rat()), scale)
+}
+
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(d.coef), pow10(d.scale))
+}
+
+// Float64 нужен только там, где точность не важна (например, для сортировки в логах).
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+func (d Decimal) String() string {
+	s := strconv.FormatInt(d.coef, 10)
+	if d.scale <= 0 {
+		return s
+	}
+
+	neg := d.coef < 0
+	if neg {
+		s = s[1:]
+	}
+	if pad := int(d.scale) + 1 - len(s); pad > 0 {
+		s = strings.Repeat("0", pad) + s
+	}
+	s = s[:len(s)-int(d.scale)] + "." + s[len(s)-int(d.scale):]
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// fromRat переводит рациональное число в Decimal с заданной точностью,
+// округляя половины к ближайшему чётному.
+func fromRat(r *big.Rat, scale int32) (Decimal, error) {
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10(scale)))
+	num, den := scaled.Num(), scaled.Denom()
+
+	q, m := new(big.Int).QuoRem(num, den, new(big.Int))
+	if m.Sign() != 0 {
+		twice := new(big.Int).Abs(m)
+		twice.Lsh(twice, 1)
+		switch c := twice.Cmp(den); {
+		case c > 0, c == 0 && q.Bit(0) == 1:
+			if num.Sign() < 0 {
+				q.Sub(q, big.NewInt(1))
+			} else {
+				q.Add(q, big.NewInt(1))
+			}
+		}
+	}
+	if !q.IsInt64() {
+		return Decimal{}, ErrOverflow
+	}
+	return Decimal{coef: q.Int64(), scale: scale}, nil
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
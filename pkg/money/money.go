@@ -0,0 +1,177 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnknownCurrency  = errors.New("unknown currency")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+)
+
+// Money — точная сумма в конкретной валюте.
+// Сумма валидной Money всегда приведена к числу знаков валюты: 100 RUB хранится как 100.00.
+type Money struct {
+	amount   Decimal
+	currency Currency
+}
+
+// New создаёт сумму в валюте c. Если валюта известна и сумма укладывается
+// в её минорные единицы, сумма приводится к ним.
+func New(amount Decimal, c Currency) Money {
+	if c.Valid() {
+		if r, err := amount.Rescale(c.MinorUnits()); err == nil {
+			amount = r
+		}
+	}
+	return Money{amount: amount, currency: c}
+}
+
+// Parse разбирает сумму и проверяет её по правилам валюты.
+func Parse(amount, currency string) (Money, error) {
+	c, err := ParseCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	d, err := ParseDecimal(amount)
+	if err != nil {
+		return Money{}, err
+	}
+	m := New(d, c)
+	if err := m.Validate(); err != nil {
+		return Money{}, err
+	}
+	return m, nil
+}
+
+func MustParse(amount, currency string) Money {
+	m, err := Parse(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func (m Money) Amount() Decimal    { return m.amount }
+func (m Money) Currency() Currency { return m.currency }
+func (m Money) Sign() int          { return m.amount.Sign() }
+func (m Money) IsPositive() bool   { return m.amount.Sign() > 0 }
+func (m Money) HasCurrency() bool  { return m.currency != "" }
+func (m Money) Equal(o Money) bool { return m.currency == o.currency && m.amount.Cmp(o.amount) == 0 }
+func (m Money) IsZeroValue() bool  { return m == Money{} }
+func (m Money) String() string     { return m.amount.String() + " " + string(m.currency) }
+
+// WithCurrency возвращает ту же сумму в валюте c (без конвертации).
+func (m Money) WithCurrency(c Currency) Money {
+	return New(m.amount, c)
+}
+
+// Validate проверяет валюту и что у суммы не больше знаков после запятой, чем допускает валюта.
+func (m Money) Validate() error {
+	if !m.currency.Valid() {
+		return fmt.Errorf("%w: %q", ErrUnknownCurrency, m.currency)
+	}
+	if _, err := m.amount.Rescale(m.currency.MinorUnits()); err != nil {
+		return fmt.Errorf("%s allows %d decimal places: %w", m.currency, m.currency.MinorUnits(), err)
+	}
+	return nil
+}
+
+func (m Money) Cmp(o Money) (int, error) {
+	if m.currency != o.currency {
+		return 0, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, o.currency)
+	}
+	return m.amount.Cmp(o.amount), nil
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	if m.currency != o.currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, o.currency)
+	}
+	sum, err := m.amount.Add(o.amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return New(sum, m.currency), nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	if m.currency != o.currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, o.currency)
+	}
+	diff, err := m.amount.Sub(o.amount)
+	if err != nil {
+		return Money{}, err
+	}
+	return New(diff, m.currency), nil
+}
+
+// Times умножает сумму на целое число (например, цена за штуку на количество).
+func (m Money) Times(n int) (Money, error) {
+	d, err := m.amount.Mul(NewDecimal(int64(n), 0), m.amount.Scale())
+	if err != nil {
+		return Money{}, err
+	}
+	return New(d, m.currency), nil
+}
+
+type moneyJSON struct {
+	Amount   json.Number `json:"amount"`
+	Currency Currency    `json:"currency,omitempty"`
+}
+
+// MarshalJSON кодирует сумму строкой, чтобы клиенты не теряли точность:
+// {"amount": "100.50", "currency": "RUB"}.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string   `json:"amount"`
+		Currency Currency `json:"currency"`
+	}{
+		Amount:   m.amount.String(),
+		Currency: m.currency,
+	})
+}
+
+// UnmarshalJSON принимает объект {"amount": "100.50" | 100.50, "currency": "RUB"}
+// или просто число. Во втором случае валюта остаётся пустой и задаётся вызывающим кодом.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*m = Money{}
+		return nil
+	}
+
+	var v moneyJSON
+	if len(data) > 0 && data[0] == '{' {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&v); err != nil {
+			return fmt.Errorf("invalid money: %w", err)
+		}
+		if v.Amount == "" {
+			return errors.New("invalid money: amount is required")
+		}
+	} else {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&v.Amount); err != nil {
+			return fmt.Errorf("invalid money: %w", err)
+		}
+	}
+
+	d, err := ParseDecimal(v.Amount.String())
+	if err != nil {
+		return fmt.Errorf("invalid money amount: %w", err)
+	}
+	c := v.Currency
+	if c != "" {
+		if c, err = ParseCurrency(string(c)); err != nil {
+			return err
+		}
+	}
+	*m = New(d, c)
+	return nil
+}
@@ -0,0 +1,184 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDecimal(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		input string
+
+		expected string
+		wantErr  bool
+	}{
+		{name: "1. Integer", input: "100", expected: "100"},
+		{name: "2. Fraction", input: "100.50", expected: "100.50"},
+		{name: "3. Negative", input: "-0.05", expected: "-0.05"},
+		{name: "4. Leading_Zeros", input: "007.1", expected: "7.1"},
+		{name: "5. Only_Fraction", input: ".5", expected: "0.5"},
+		{name: "6. Empty", input: "", wantErr: true},
+		{name: "7. Trailing_Dot", input: "1.", wantErr: true},
+		{name: "8. Letters", input: "1e3", wantErr: true},
+		{name: "9. Overflow", input: "99999999999999999999", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := ParseDecimal(tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, d.String())
+		})
+	}
+}
+
+func TestDecimal_Round(t *testing.T) {
+	testCases := []struct {
+		input    string
+		scale    int32
+		expected string
+	}{
+		{input: "1.005", scale: 2, expected: "1.00"},
+		{input: "1.015", scale: 2, expected: "1.02"},
+		{input: "1.0151", scale: 2, expected: "1.02"},
+		{input: "-2.5", scale: 0, expected: "-2"},
+		{input: "-3.5", scale: 0, expected: "-4"},
+		{input: "7", scale: 3, expected: "7.000"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			d, err := ParseDecimal(tc.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, d.Round(tc.scale).String())
+		})
+	}
+}
+
+func TestParse_MinorUnits(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		amount   string
+		currency string
+
+		expected string
+		wantErr  bool
+	}{
+		{name: "1. RUB_Is_Padded", amount: "100", currency: "RUB", expected: "100.00 RUB"},
+		{name: "2. Lowercase_Currency", amount: "1.5", currency: "usd", expected: "1.50 USD"},
+		{name: "3. JPY_Has_No_Minor_Units", amount: "100.5", currency: "JPY", wantErr: true},
+		{name: "4. KWD_Has_Three_Minor_Units", amount: "1.125", currency: "KWD", expected: "1.125 KWD"},
+		{name: "5. Too_Precise_For_EUR", amount: "1.001", currency: "EUR", wantErr: true},
+		{name: "6. Trailing_Zeros_Are_Fine", amount: "1.500", currency: "EUR", expected: "1.50 EUR"},
+		{name: "7. Unknown_Currency", amount: "1", currency: "XXX", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := Parse(tc.amount, tc.currency)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, m.String())
+		})
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	t.Run("1. Marshal_Object", func(t *testing.T) {
+		data, err := json.Marshal(MustParse("100.5", "USD"))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"amount":"100.50","currency":"USD"}`, string(data))
+	})
+
+	t.Run("2. Unmarshal_Object_With_String_Amount", func(t *testing.T) {
+		var m Money
+		assert.NoError(t, json.Unmarshal([]byte(`{"amount":"0.10","currency":"eur"}`), &m))
+		assert.Equal(t, MustParse("0.1", "EUR"), m)
+	})
+
+	t.Run("3. Unmarshal_Plain_Number_Without_Currency", func(t *testing.T) {
+		var m Money
+		assert.NoError(t, json.Unmarshal([]byte(`123.45`), &m))
+		assert.False(t, m.HasCurrency())
+		assert.Equal(t, MustParse("123.45", "RUB"), m.WithCurrency(RUB))
+	})
+
+	t.Run("4. Unmarshal_Exact_Amount", func(t *testing.T) {
+		var m Money
+		assert.NoError(t, json.Unmarshal([]byte(`{"amount":0.1,"currency":"USD"}`), &m))
+		assert.Equal(t, "0.10", m.Amount().String())
+	})
+
+	t.Run("5. Unmarshal_Unknown_Currency", func(t *testing.T) {
+		var m Money
+		assert.Error(t, json.Unmarshal([]byte(`{"amount":"1","currency":"ABC"}`), &m))
+	})
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	a := MustParse("10.10", "USD")
+	b := MustParse("0.20", "USD")
+
+	sum, err := a.Add(b)
+	assert.NoError(t, err)
+	assert.Equal(t, MustParse("10.30", "USD"), sum)
+
+	times, err := a.Times(3)
+	assert.NoError(t, err)
+	assert.Equal(t, MustParse("30.30", "USD"), times)
+
+	_, err = a.Add(MustParse("1", "EUR"))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
+
+func TestRates_Convert(t *testing.T) {
+	rates := &Rates{
+		Base: RUB,
+		Rates: map[Currency]Decimal{
+			USD:             NewDecimal(125, 4), // 1 RUB = 0.0125 USD
+			EUR:             NewDecimal(1, 2),   // 1 RUB = 0.01 EUR
+			Currency("JPY"): NewDecimal(18, 1),  // 1 RUB = 1.8 JPY
+		},
+	}
+
+	testCases := []struct {
+		name string
+
+		from Money
+		to   Currency
+
+		expected Money
+		wantErr  bool
+	}{
+		{name: "1. Base_To_Quote", from: MustParse("100", "RUB"), to: USD, expected: MustParse("1.25", "USD")},
+		{name: "2. Quote_To_Base", from: MustParse("1", "USD"), to: RUB, expected: MustParse("80", "RUB")},
+		{name: "3. Cross_Rate", from: MustParse("10", "EUR"), to: USD, expected: MustParse("12.50", "USD")},
+		{name: "4. Rounded_To_Minor_Units", from: MustParse("0.99", "RUB"), to: "JPY", expected: MustParse("2", "JPY")},
+		{name: "5. Same_Currency", from: MustParse("5", "GBP"), to: "GBP", expected: MustParse("5", "GBP")},
+		{name: "6. Missing_Rate", from: MustParse("5", "GBP"), to: USD, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := rates.Convert(tc.from, tc.to)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrNoRate)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
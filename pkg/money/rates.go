@@ -0,0 +1,74 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+var ErrNoRate = errors.New("no exchange rate")
+
+// factorScale — точность множителя, который передаётся в SQL для нормализации цен.
+const factorScale = 12
+
+// Rates — курсы валют относительно базовой: сколько единиц валюты дают за 1 единицу Base.
+type Rates struct {
+	Base      Currency
+	Rates     map[Currency]Decimal
+	UpdatedAt time.Time
+}
+
+func (r *Rates) rate(c Currency) (*big.Rat, error) {
+	if c == r.Base {
+		return big.NewRat(1, 1), nil
+	}
+	d, ok := r.Rates[c]
+	if !ok || d.Sign() <= 0 {
+		return nil, fmt.Errorf("%w for %s", ErrNoRate, c)
+	}
+	return d.Rat(), nil
+}
+
+// Has сообщает, можно ли конвертировать из этой валюты и в неё.
+func (r *Rates) Has(c Currency) bool {
+	_, err := r.rate(c)
+	return err == nil
+}
+
+func (r *Rates) factor(from, to Currency) (*big.Rat, error) {
+	f, err := r.rate(from)
+	if err != nil {
+		return nil, err
+	}
+	t, err := r.rate(to)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Rat).Quo(t, f), nil
+}
+
+// Factor возвращает множитель для перевода сумм из from в to.
+func (r *Rates) Factor(from, to Currency) (Decimal, error) {
+	f, err := r.factor(from, to)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return fromRat(f, factorScale)
+}
+
+// Convert переводит сумму в валюту to с банковским округлением до её минорных единиц.
+func (r *Rates) Convert(m Money, to Currency) (Money, error) {
+	if m.currency == to {
+		return m, nil
+	}
+	f, err := r.factor(m.currency, to)
+	if err != nil {
+		return Money{}, err
+	}
+	d, err := fromRat(new(big.Rat).Mul(m.amount.Rat(), f), to.MinorUnits())
+	if err != nil {
+		return Money{}, err
+	}
+	return New(d, to), nil
+}